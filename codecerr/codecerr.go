@@ -0,0 +1,59 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+// Package codecerr defines the errors returned by methods generated with the
+// -typed-errors flag of gencodec. Callers can match them with errors.As to report
+// the offending key, for example in the response of an API.
+//
+//	var missing *codecerr.MissingFieldError
+//	if errors.As(err, &missing) {
+//		// missing.Field is the key which should have been present.
+//	}
+package codecerr
+
+import "fmt"
+
+// MissingFieldError is returned when a required field is absent from the input.
+type MissingFieldError struct {
+	Type  string // name of the Go type
+	Field string // key of the field in the input format
+	Line  int    // line of the value in YAML decoded with -yaml v3, zero otherwise
+}
+
+func (e *MissingFieldError) Error() string {
+	return linePrefix(e.Line) + fmt.Sprintf("missing required field '%s' for %s", e.Field, e.Type)
+}
+
+// UnknownFieldError is returned in strict mode when the input holds a key which
+// doesn't belong to a field.
+type UnknownFieldError struct {
+	Type  string // name of the Go type
+	Field string // the unknown key
+	Line  int    // line of the key in YAML decoded with -yaml v3, zero otherwise
+}
+
+func (e *UnknownFieldError) Error() string {
+	return linePrefix(e.Line) + fmt.Sprintf("unknown field '%s' for %s", e.Field, e.Type)
+}
+
+// ChecksumError is returned by UnmarshalBinary when the checksum in the trailer of
+// the input doesn't match the bytes before it.
+type ChecksumError struct {
+	Type      string // name of the Go type
+	Algorithm string // value of the checksum struct tag
+	Sum       uint64 // checksum in the input
+	Computed  uint64 // checksum of the input bytes
+}
+
+func (e *ChecksumError) Error() string {
+	return fmt.Sprintf("%s checksum mismatch for %s: input has %#x, computed %#x", e.Algorithm, e.Type, e.Sum, e.Computed)
+}
+
+// linePrefix returns the prefix of messages naming a line of the input.
+func linePrefix(line int) string {
+	if line == 0 {
+		return ""
+	}
+	return fmt.Sprintf("line %d: ", line)
+}
@@ -0,0 +1,142 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/fjl/gencodec/gencodec"
+)
+
+// runCompat implements the compat subcommand, which reports breaking wire
+// changes of a type between two git revisions or package directories.
+func runCompat(args []string) int {
+	var (
+		fs        = flag.NewFlagSet("compat", flag.ExitOnError)
+		pkgdir    = fs.String("dir", ".", "input package")
+		oldRev    = fs.String("old", "", "old git revision or package directory")
+		newRev    = fs.String("new", "", "new git revision or package directory (default is the working tree)")
+		typename  = fs.String("type", "", "type to check")
+		overrides = fs.String("field-override", "", "type to take field type replacements from")
+		format    = fs.String("format", "json", "marshaling format of the keys")
+	)
+	fs.Parse(args)
+	if *oldRev == "" || *typename == "" {
+		fmt.Fprintln(os.Stderr, "usage: gencodec compat -old rev [-new rev] -type T")
+		return 2
+	}
+
+	oldDir, cleanupOld, err := checkoutPackage(*pkgdir, *oldRev)
+	if err != nil {
+		fatal(err)
+	}
+	defer cleanupOld()
+	newDir, cleanupNew, err := checkoutPackage(*pkgdir, *newRev)
+	if err != nil {
+		fatal(err)
+	}
+	defer cleanupNew()
+	var (
+		oldCfg = gencodec.Config{Dir: oldDir, Type: *typename, FieldOverride: *overrides}
+		newCfg = gencodec.Config{Dir: newDir, Type: *typename, FieldOverride: *overrides}
+	)
+	problems, err := gencodec.Compat(&oldCfg, &newCfg, *format)
+	if err != nil {
+		fatal(err)
+	}
+	for _, p := range problems {
+		fmt.Printf("%s: %s\n", *typename, p)
+	}
+	if len(problems) > 0 {
+		return 1
+	}
+	return 0
+}
+
+// checkoutPackage returns the directory of the package in dir at the given
+// revision. If rev is a directory, it is used as is. An empty revision means
+// the working tree. Other revisions are extracted from git into a temporary
+// directory, which is removed by the cleanup function.
+func checkoutPackage(dir, rev string) (string, func(), error) {
+	nop := func() {}
+	if rev == "" {
+		return dir, nop, nil
+	}
+	if info, err := os.Stat(rev); err == nil && info.IsDir() {
+		return rev, nop, nil
+	}
+	root, err := git(dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", nop, err
+	}
+	prefix, err := git(dir, "rev-parse", "--show-prefix")
+	if err != nil {
+		return "", nop, err
+	}
+	archive, err := git(strings.TrimSpace(string(root)), "archive", "--format=tar", rev)
+	if err != nil {
+		return "", nop, err
+	}
+	tmp, err := ioutil.TempDir("", "gencodec-compat-")
+	if err != nil {
+		return "", nop, err
+	}
+	cleanup := func() { os.RemoveAll(tmp) }
+	if err := extractTar(tmp, bytes.NewReader(archive)); err != nil {
+		cleanup()
+		return "", nop, err
+	}
+	return filepath.Join(tmp, strings.TrimSpace(string(prefix))), cleanup, nil
+}
+
+func git(dir string, args ...string) ([]byte, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Stderr = new(bytes.Buffer)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git %s: %v: %s", args[0], err, cmd.Stderr)
+	}
+	return out, nil
+}
+
+// extractTar writes the regular files of a tar archive to dir.
+func extractTar(dir string, r io.Reader) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		path := filepath.Join(dir, filepath.FromSlash(hdr.Name))
+		if !strings.HasPrefix(path, filepath.Clean(dir)+string(filepath.Separator)) {
+			return fmt.Errorf("invalid path %q in archive", hdr.Name)
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		data, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(path, data, 0644); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,31 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/fjl/gencodec/gencodec"
+)
+
+// runCoverage implements the coverage subcommand, which lists the struct types
+// encoded by encoding/json without generated methods.
+func runCoverage(args []string) int {
+	var (
+		fs      = flag.NewFlagSet("coverage", flag.ExitOnError)
+		pattern = fs.String("dir", "./...", "packages to scan")
+	)
+	fs.Parse(args)
+
+	candidates, err := gencodec.Coverage(*pattern)
+	if err != nil {
+		fatal(err)
+	}
+	for _, c := range candidates {
+		fmt.Println(c)
+	}
+	return 0
+}
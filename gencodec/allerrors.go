@@ -0,0 +1,141 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"go/ast"
+
+	. "github.com/garslo/gogen"
+)
+
+// With -all-errors, the unmarshaling methods don't stop at the first missing or
+// invalid field. The conversion of each field which can fail runs in a function
+// literal, so the errors it returns are collected, and the collected errors are
+// returned together by errors.Join. UnmarshalJSON also decodes the keys of the
+// input separately, collecting the errors of invalid values and unknown keys.
+
+// unmarshalAllErrors is unmarshalConversions collecting the errors of all fields.
+func (m *marshalMethod) unmarshalAllErrors(from, to Var, format string) []Statement {
+	var (
+		errors = Name(m.scope.parent.packageName("errors"))
+		err    = Name("err")
+		s      []Statement
+	)
+	if m.errs == "" {
+		m.errs = m.scope.newIdent("errs")
+		s = append(s, Declare{Name: m.errs, TypeName: "[]error"})
+	}
+	errs := Name(m.errs)
+	for _, f := range m.mtyp.Fields {
+		if f.function != nil || !f.inFormat(format) {
+			continue // fields generated from functions cannot be assigned
+		}
+		conv := m.unmarshalField(f, from, to, format)
+		if !hasReturn(conv) {
+			s = append(s, conv...)
+			continue
+		}
+		s = append(s, If{
+			Init:      DeclareAndAssign{Lhs: err, Rhs: CallFunction{Func: errorFunc(append(conv, Return{Values: []Expression{NIL}}))}},
+			Condition: NotEqual{Lhs: err, Rhs: NIL},
+			Body:      []Statement{Assign{Lhs: errs, Rhs: CallFunction{Func: Name("append"), Params: []Expression{errs, err}}}},
+		})
+	}
+	join := &ast.CallExpr{
+		Fun:      Dotted{Receiver: errors, Name: "Join"}.Expression(),
+		Args:     []ast.Expr{errs.Expression()},
+		Ellipsis: 1,
+	}
+	return append(s, If{
+		Init:      DeclareAndAssign{Lhs: err, Rhs: Thunk{Expr: join}},
+		Condition: NotEqual{Lhs: err, Rhs: NIL},
+		Body:      []Statement{Return{Values: []Expression{err}}},
+	})
+}
+
+// decodeJSONFields decodes the keys of the JSON object input one at a time, so that
+// the errors of all invalid values are collected. Each key is decoded as an object
+// holding only that key, which keeps the field matching and the type errors of
+// encoding/json. The keys are decoded in sorted order. In strict mode, all unknown
+// keys are collected after decoding.
+func (m *marshalMethod) decodeJSONFields(input, dec Var) []Statement {
+	var (
+		json    = Name(m.scope.parent.packageName("encoding/json"))
+		sort    = Name(m.scope.parent.packageName("sort"))
+		raw     = Name(m.scope.newIdent("raw"))
+		keys    = Name(m.scope.newIdent("keys"))
+		key     = Name(m.scope.newIdent("key"))
+		field   = Name(m.scope.newIdent("field"))
+		err     = Name("err")
+		errs    = Name(m.errs)
+		rawType = "map[string]" + json.Name + ".RawMessage"
+	)
+	collect := Range{Key: key, RangeValue: raw, Body: []Statement{
+		Assign{Lhs: keys, Rhs: CallFunction{Func: Name("append"), Params: []Expression{keys, key}}},
+	}}.Statement().(*ast.RangeStmt)
+	collect.Value = nil // range over the keys only
+	object := &ast.CompositeLit{
+		Type: ast.NewIdent(rawType),
+		Elts: []ast.Expr{&ast.KeyValueExpr{Key: key.Expression(), Value: Index{Value: raw, Index: key}.Expression()}},
+	}
+	decode := []Statement{
+		declareMulti{
+			Lhs: []Expression{field, err},
+			Rhs: CallFunction{Func: Dotted{Receiver: json, Name: "Marshal"}, Params: []Expression{Thunk{Expr: object}}},
+		},
+		If{Condition: NotEqual{Lhs: err, Rhs: NIL}, Body: []Statement{Return{Values: []Expression{err}}}},
+		If{
+			Init:      DeclareAndAssign{Lhs: err, Rhs: CallFunction{Func: Dotted{Receiver: json, Name: "Unmarshal"}, Params: []Expression{field, AddressOf{Value: dec}}}},
+			Condition: NotEqual{Lhs: err, Rhs: NIL},
+			Body:      []Statement{Assign{Lhs: errs, Rhs: CallFunction{Func: Name("append"), Params: []Expression{errs, err}}}},
+		},
+	}
+	s := []Statement{
+		Declare{Name: raw.Name, TypeName: rawType},
+		errCheck(CallFunction{Func: Dotted{Receiver: json, Name: "Unmarshal"}, Params: []Expression{input, AddressOf{Value: raw}}}),
+		DeclareAndAssign{Lhs: keys, Rhs: CallFunction{Func: Name("make"), Params: []Expression{Name("[]string"), Int(0), CallFunction{Func: Name("len"), Params: []Expression{raw}}}}},
+		Thunk{Stmt: collect},
+		CallFunction{Func: Dotted{Receiver: sort, Name: "Strings"}, Params: []Expression{keys}},
+		Range{Value: key, RangeValue: keys, Body: decode},
+	}
+	if m.mtyp.strict {
+		unknown := append(m.skipKnown(key, "json"), Assign{
+			Lhs: errs,
+			Rhs: CallFunction{Func: Name("append"), Params: []Expression{errs, m.unknownFieldError(key)}},
+		})
+		s = append(s, Range{Value: key, RangeValue: keys, Body: unknown})
+	}
+	return s
+}
+
+// errorFunc is a function literal returning an error.
+func errorFunc(body []Statement) Expression {
+	lit := &ast.FuncLit{
+		Type: &ast.FuncType{
+			Params:  &ast.FieldList{},
+			Results: &ast.FieldList{List: []*ast.Field{{Type: ast.NewIdent("error")}}},
+		},
+		Body: &ast.BlockStmt{},
+	}
+	for _, st := range body {
+		lit.Body.List = append(lit.Body.List, st.Statement())
+	}
+	return Thunk{Expr: lit}
+}
+
+// hasReturn reports whether the statements contain a return statement.
+func hasReturn(s []Statement) bool {
+	found := false
+	for _, st := range s {
+		ast.Inspect(st.Statement(), func(n ast.Node) bool {
+			if _, ok := n.(*ast.ReturnStmt); ok {
+				found = true
+			}
+			_, lit := n.(*ast.FuncLit)
+			return !found && !lit
+		})
+	}
+	return found
+}
@@ -0,0 +1,425 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/types"
+	"io"
+	"strings"
+
+	. "github.com/garslo/gogen"
+)
+
+// appenderPackages are the packages used by MarshalJSONTo.
+var appenderPackages = []string{"encoding/base64", "math", "strconv", "unicode/utf8"}
+
+// jsonAppender generates the MarshalJSONTo method of a type. Fields are converted
+// like in MarshalJSON, but instead of calling json.Marshal on the intermediate
+// value, their encoding is appended to the buffer by generated code. Types which
+// can't be encoded that way, like maps and types with a MarshalJSON method, fall
+// back to json.Marshal.
+type jsonAppender struct {
+	mtyp      *marshalerType
+	scope     *funcScope
+	buf       bytes.Buffer
+	err, b    string // variable names
+	appendStr string // name of the string encoding closure
+	appendFlt string // name of the float encoding closure
+	usesStr   bool
+	usesFlt   bool
+	usesErr   bool
+}
+
+// genMarshalJSONTo writes the MarshalJSONTo method of mtyp and the MarshalJSON
+// method calling it.
+func genMarshalJSONTo(w io.Writer, mtyp *marshalerType) error {
+	m := newMarshalMethod(mtyp, false)
+	m.appendHooks = true
+	var (
+		recv     = m.receiver().Name
+		intertyp = m.intermediateType(m.intermediateName("json"), "json")
+		enc      = m.scope.newIdent("enc")
+		buf      = m.scope.newIdent("buf")
+		start    = m.scope.newIdent("start")
+	)
+	m.scope.used[m.iterKey.Name] = true
+	m.scope.used[m.iterVal.Name] = true
+	a := &jsonAppender{
+		mtyp:      mtyp,
+		scope:     m.scope,
+		err:       m.scope.newIdent("err"),
+		b:         m.scope.newIdent("b"),
+		appendStr: m.scope.newIdent("appendString"),
+		appendFlt: m.scope.newIdent("appendFloat"),
+	}
+	for _, f := range mtyp.Fields {
+		key, ok := f.key("json")
+		if !ok {
+			continue
+		}
+		if f.hook != nil {
+			a.hookField(f, key, buf, printExpr(mtyp, f.access(Name(recv))))
+			continue
+		}
+		if err := a.field(f, key, buf, enc+"."+f.name); err != nil {
+			return fmt.Errorf("%v: field %s: %v", mtyp.fs.Position(f.pos), f.name, err)
+		}
+	}
+
+	fmt.Fprintf(w, "// MarshalJSONTo appends the JSON encoding of %s to %s.\n", recv, buf)
+	fmt.Fprintf(w, "func (%s %s) MarshalJSONTo(%s []byte) ([]byte, error) {\n", recv, mtyp.recvType(), buf)
+	fmt.Fprintln(w, printStatements(mtyp, []Statement{declStmt{intertyp}, Declare{Name: enc, TypeName: intertyp.Name}}))
+	fmt.Fprintln(w, printStatements(mtyp, m.marshalConversions(Name(recv), Name(enc), "json")))
+	if a.usesStr {
+		a.writeStringFunc(w)
+	}
+	if a.usesFlt {
+		a.writeFloatFunc(w)
+	}
+	if a.usesErr {
+		fmt.Fprintf(w, "var %s error\n", a.err)
+	}
+	// Every member is written with a leading comma, which is replaced by the opening
+	// brace of the object at the end.
+	fmt.Fprintf(w, "%s := len(%s)\n", start, buf)
+	w.Write(a.buf.Bytes())
+	fmt.Fprintf(w, "if len(%s) == %s {\n%s = append(%s, '{')\n} else {\n%s[%s] = '{'\n}\n", buf, start, buf, buf, buf, start)
+	fmt.Fprintf(w, "return append(%s, '}'), nil\n}\n\n", buf)
+
+	fmt.Fprintf(w, "// MarshalJSON marshals as JSON.\n")
+	fmt.Fprintf(w, "func (%s %s) MarshalJSON() ([]byte, error) {\n", recv, mtyp.recvType())
+	fmt.Fprintf(w, "return %s.MarshalJSONTo(nil)\n}\n", recv)
+	return nil
+}
+
+// field writes the code appending the member of a field.
+func (a *jsonAppender) field(f *marshalerField, key, buf, v string) error {
+	member, _ := json.Marshal(key)
+	prefix := "," + string(member) + ":"
+	quoted := f.hasTagOption("json", "string")
+	if quoted && !isNumber(derefType(f.typ)) && !isBool(derefType(f.typ)) {
+		return fmt.Errorf("the string option is only supported for numbers and booleans")
+	}
+	var cond string
+	switch {
+	case f.hasTagOption("json", "omitempty"):
+		cond = a.notEmpty(f.typ, v)
+	case f.hasTagOption("json", "omitzero"):
+		c, err := a.notZero(f.typ, v)
+		if err != nil {
+			return err
+		}
+		cond = c
+	}
+	if cond != "" {
+		a.printf("if %s {\n", cond)
+	}
+	a.printf("%s = append(%s, %s...)\n", buf, buf, "`"+prefix+"`")
+	if quoted {
+		if err := a.quotedValue(f.typ, buf, v); err != nil {
+			return err
+		}
+	} else if err := a.value(f.typ, buf, v); err != nil {
+		return err
+	}
+	if cond != "" {
+		a.printf("}\n")
+	}
+	return nil
+}
+
+// hookField writes the code appending the member of a field with the hook option.
+// The append function is called with the field of the receiver.
+func (a *jsonAppender) hookField(f *marshalerField, key, buf, v string) {
+	member, _ := json.Marshal(key)
+	a.printf("%s = append(%s, %s...)\n", buf, buf, "`,"+string(member)+":`")
+	a.printf("%s = %s(%s, %s)\n", buf, printExpr(a.mtyp, a.mtyp.funcName(f.hook.append)), buf, v)
+}
+
+// value writes the code appending the encoding of v, an addressable
+// expression of type typ.
+func (a *jsonAppender) value(typ types.Type, buf, v string) error {
+	typ = types.Unalias(typ)
+	if a.hasAppender(typ) {
+		a.usesErr = true
+		a.printf("%s, %s = %s.MarshalJSONTo(%s)\n", buf, a.err, parens(v), buf)
+		a.printf("if %s != nil {\nreturn nil, %s\n}\n", a.err, a.err)
+		return nil
+	}
+	if a.needsMarshal(typ) {
+		a.marshal(buf, v)
+		return nil
+	}
+	switch u := typ.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case u.Info()&types.IsBoolean != 0:
+			a.printf("%s = %s.AppendBool(%s, %s)\n", buf, a.pkg("strconv"), buf, convertString(v, typ, "bool"))
+		case u.Info()&types.IsUnsigned != 0:
+			a.printf("%s = %s.AppendUint(%s, %s, 10)\n", buf, a.pkg("strconv"), buf, convertString(v, typ, "uint64"))
+		case u.Info()&types.IsInteger != 0:
+			a.printf("%s = %s.AppendInt(%s, %s, 10)\n", buf, a.pkg("strconv"), buf, convertString(v, typ, "int64"))
+		case u.Info()&types.IsFloat != 0:
+			a.usesFlt = true
+			a.usesErr = true
+			a.printf("%s, %s = %s(%s, %s, %d)\n", buf, a.err, a.appendFlt, buf, convertString(v, typ, "float64"), floatBits(u))
+			a.printf("if %s != nil {\nreturn nil, %s\n}\n", a.err, a.err)
+		case u.Info()&types.IsString != 0:
+			a.usesStr = true
+			a.printf("%s = %s(%s, %s)\n", buf, a.appendStr, buf, convertString(v, typ, "string"))
+		default:
+			a.marshal(buf, v)
+		}
+	case *types.Pointer:
+		a.printf("if %s == nil {\n%s = append(%s, \"null\"...)\n} else {\n", v, buf, buf)
+		if err := a.value(u.Elem(), buf, "*"+parens(v)); err != nil {
+			return err
+		}
+		a.printf("}\n")
+	case *types.Slice:
+		a.printf("if %s == nil {\n%s = append(%s, \"null\"...)\n} else {\n", v, buf, buf)
+		if isByte(u.Elem()) {
+			a.printf("%s = append(%s, '\"')\n", buf, buf)
+			a.printf("%s = %s.StdEncoding.AppendEncode(%s, %s)\n", buf, a.pkg("encoding/base64"), buf, v)
+			a.printf("%s = append(%s, '\"')\n", buf, buf)
+		} else if err := a.list(u.Elem(), buf, v); err != nil {
+			return err
+		}
+		a.printf("}\n")
+	case *types.Array:
+		return a.list(u.Elem(), buf, v)
+	default:
+		a.marshal(buf, v)
+	}
+	return nil
+}
+
+// quotedValue writes the code appending a number or boolean as a JSON string,
+// as done by encoding/json for fields with the string option.
+func (a *jsonAppender) quotedValue(typ types.Type, buf, v string) error {
+	if ptr, ok := typ.Underlying().(*types.Pointer); ok {
+		a.printf("if %s == nil {\n%s = append(%s, \"null\"...)\n} else {\n", v, buf, buf)
+		if err := a.quotedValue(ptr.Elem(), buf, "*"+parens(v)); err != nil {
+			return err
+		}
+		a.printf("}\n")
+		return nil
+	}
+	a.printf("%s = append(%s, '\"')\n", buf, buf)
+	if err := a.value(typ, buf, v); err != nil {
+		return err
+	}
+	a.printf("%s = append(%s, '\"')\n", buf, buf)
+	return nil
+}
+
+// list writes the code appending the elements of a slice or array.
+func (a *jsonAppender) list(elem types.Type, buf, v string) error {
+	i := a.scope.newIdent("i")
+	a.printf("%s = append(%s, '[')\n", buf, buf)
+	a.printf("for %s := range %s {\n", i, v)
+	a.printf("if %s > 0 {\n%s = append(%s, ',')\n}\n", i, buf, buf)
+	if err := a.value(elem, buf, parens(v)+"["+i+"]"); err != nil {
+		return err
+	}
+	a.printf("}\n%s = append(%s, ']')\n", buf, buf)
+	return nil
+}
+
+// marshal writes the code appending the result of json.Marshal.
+func (a *jsonAppender) marshal(buf, v string) {
+	a.printf("if %s, %s := %s.Marshal(%s); %s != nil {\n", a.b, a.err, a.pkg("encoding/json"), address(v), a.err)
+	a.printf("return nil, %s\n} else {\n%s = append(%s, %s...)\n}\n", a.err, buf, buf, a.b)
+}
+
+// notEmpty returns the condition under which a field with the omitempty option
+// is written. Like in encoding/json, struct values are never empty.
+func (a *jsonAppender) notEmpty(typ types.Type, v string) string {
+	switch u := typ.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case u.Info()&types.IsBoolean != 0:
+			return v
+		case u.Info()&types.IsString != 0:
+			return v + ` != ""`
+		default:
+			return v + " != 0"
+		}
+	case *types.Slice, *types.Map, *types.Array:
+		return "len(" + v + ") > 0"
+	case *types.Pointer, *types.Interface:
+		return v + " != nil"
+	}
+	return "true"
+}
+
+// notZero returns the condition under which a field with the omitzero option is
+// written. Types with an IsZero method decide themselves.
+func (a *jsonAppender) notZero(typ types.Type, v string) (string, error) {
+	isZero := types.NewSignature(nil, nil, types.NewTuple(types.NewParam(0, nil, "", types.Typ[types.Bool])), false)
+	if hasMethod(typ, "IsZero", isZero) {
+		return "!" + v + ".IsZero()", nil
+	}
+	switch typ.Underlying().(type) {
+	case *types.Basic:
+		return a.notEmpty(typ, v), nil
+	case *types.Slice, *types.Map, *types.Pointer, *types.Interface:
+		return v + " != nil", nil
+	case *types.Struct, *types.Array:
+		if types.Comparable(typ) {
+			return fmt.Sprintf("%s != (%s{})", v, types.TypeString(typ, a.mtyp.scope.qualify)), nil
+		}
+	}
+	return "", fmt.Errorf("the omitzero option is not supported for type %s", typ)
+}
+
+// hasAppender reports whether typ has a MarshalJSONTo method.
+func (a *jsonAppender) hasAppender(typ types.Type) bool {
+	named, ok := types.Unalias(typ).(*types.Named)
+	if !ok {
+		return false
+	}
+	if a.mtyp.appender[named.Obj()] {
+		return true
+	}
+	obj, _, _ := types.LookupFieldOrMethod(types.NewPointer(typ), true, a.mtyp.orig.Obj().Pkg(), "MarshalJSONTo")
+	_, ok = obj.(*types.Func)
+	return ok
+}
+
+// needsMarshal reports whether the encoding of typ is defined by its methods.
+func (a *jsonAppender) needsMarshal(typ types.Type) bool {
+	if _, ok := types.Unalias(typ).(*types.Named); !ok {
+		return false
+	}
+	for _, name := range []string{"MarshalJSON", "MarshalText"} {
+		obj, _, _ := types.LookupFieldOrMethod(types.NewPointer(typ), true, a.mtyp.orig.Obj().Pkg(), name)
+		if _, ok := obj.(*types.Func); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *jsonAppender) pkg(path string) string {
+	return a.mtyp.scope.packageName(path)
+}
+
+func (a *jsonAppender) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&a.buf, format, args...)
+}
+
+// writeStringFunc writes a closure which appends a string like encoding/json,
+// including the escaping of HTML characters and the replacement of invalid UTF-8.
+func (a *jsonAppender) writeStringFunc(w io.Writer) {
+	utf8 := a.pkg("unicode/utf8")
+	code := `NAME := func(buf []byte, s string) []byte {
+	const hex = "0123456789abcdef"
+	buf = append(buf, '"')
+	start := 0
+	for i := 0; i < len(s); {
+		if c := s[i]; c < UTF8.RuneSelf {
+			if c >= ' ' && c != '"' && c != '\\' && c != '<' && c != '>' && c != '&' {
+				i++
+				continue
+			}
+			buf = append(buf, s[start:i]...)
+			switch c {
+			case '"', '\\':
+				buf = append(buf, '\\', c)
+			case '\b':
+				buf = append(buf, '\\', 'b')
+			case '\f':
+				buf = append(buf, '\\', 'f')
+			case '\n':
+				buf = append(buf, '\\', 'n')
+			case '\r':
+				buf = append(buf, '\\', 'r')
+			case '\t':
+				buf = append(buf, '\\', 't')
+			default:
+				buf = append(buf, '\\', 'u', '0', '0', hex[c>>4], hex[c&0xF])
+			}
+			i++
+			start = i
+			continue
+		}
+		r, size := UTF8.DecodeRuneInString(s[i:])
+		if r == UTF8.RuneError && size == 1 {
+			buf = append(buf, s[start:i]...)
+			buf = UTF8.AppendRune(buf, UTF8.RuneError)
+			i += size
+			start = i
+			continue
+		}
+		if r == '\u2028' || r == '\u2029' {
+			buf = append(buf, s[start:i]...)
+			buf = append(buf, '\\', 'u', '2', '0', '2', hex[r&0xF])
+			i += size
+			start = i
+			continue
+		}
+		i += size
+	}
+	buf = append(buf, s[start:]...)
+	return append(buf, '"')
+}
+`
+	code = strings.NewReplacer("NAME", a.appendStr, "UTF8", utf8).Replace(code)
+	io.WriteString(w, code)
+}
+
+// writeFloatFunc writes a closure which appends a float like encoding/json.
+func (a *jsonAppender) writeFloatFunc(w io.Writer) {
+	code := `NAME := func(buf []byte, f float64, bits int) ([]byte, error) {
+	if MATH.IsInf(f, 0) || MATH.IsNaN(f) {
+		return nil, FMT.Errorf("json: unsupported value: %s", STRCONV.FormatFloat(f, 'g', -1, bits))
+	}
+	format := byte('f')
+	if abs := MATH.Abs(f); abs != 0 {
+		if bits == 64 && (abs < 1e-6 || abs >= 1e21) || bits == 32 && (float32(abs) < 1e-6 || float32(abs) >= 1e21) {
+			format = 'e'
+		}
+	}
+	buf = STRCONV.AppendFloat(buf, f, format, -1, bits)
+	if format == 'e' {
+		// Clean up e-09 to e-9.
+		if n := len(buf); n >= 4 && buf[n-4] == 'e' && buf[n-3] == '-' && buf[n-2] == '0' {
+			buf[n-2] = buf[n-1]
+			buf = buf[:n-1]
+		}
+	}
+	return buf, nil
+}
+`
+	r := strings.NewReplacer("NAME", a.appendFlt, "MATH", a.pkg("math"), "FMT", a.pkg("fmt"), "STRCONV", a.pkg("strconv"))
+	io.WriteString(w, r.Replace(code))
+}
+
+// convertString returns the expression converting v to the basic type
+// named basic, unless typ is that type.
+func convertString(v string, typ types.Type, basic string) string {
+	if b, ok := typ.(*types.Basic); ok && b.Name() == basic {
+		return v
+	}
+	return basic + "(" + v + ")"
+}
+
+func floatBits(typ *types.Basic) int {
+	if typ.Kind() == types.Float32 {
+		return 32
+	}
+	return 64
+}
+
+func derefType(typ types.Type) types.Type {
+	if ptr, ok := typ.Underlying().(*types.Pointer); ok {
+		return ptr.Elem()
+	}
+	return typ
+}
@@ -0,0 +1,611 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"fmt"
+	"go/types"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	. "github.com/garslo/gogen"
+)
+
+// wireForm is the encoding of a field in the fixed binary layout, selected by
+// the wire struct tag.
+type wireForm struct {
+	kind   string // "int", "float", "bool" or "bytes"
+	size   int    // in bytes
+	signed bool
+	order  string // "BigEndian" or "LittleEndian", empty for single bytes
+}
+
+// wireForms are the values of the wire tag, except for byte arrays.
+var wireForms = map[string]wireForm{
+	"u8":    {kind: "int", size: 1},
+	"i8":    {kind: "int", size: 1, signed: true},
+	"bool":  {kind: "bool", size: 1},
+	"bytes": {kind: "bytes"},
+}
+
+func init() {
+	for _, size := range []int{2, 4, 8} {
+		for suffix, order := range map[string]string{"be": "BigEndian", "le": "LittleEndian"} {
+			bits := fmt.Sprint(size * 8)
+			wireForms["u"+bits+suffix] = wireForm{kind: "int", size: size, order: order}
+			wireForms["i"+bits+suffix] = wireForm{kind: "int", size: size, signed: true, order: order}
+			if size > 2 {
+				wireForms["f"+bits+suffix] = wireForm{kind: "float", size: size, order: order}
+			}
+		}
+	}
+}
+
+// checksum is an algorithm of the checksum tag, which computes the trailer of the
+// binary layout from the bytes before it.
+type checksum struct {
+	size int    // in bytes
+	path string // package of the hash function
+	// sum creates the expression computing the checksum of data,
+	// using the package name pkg.
+	sum func(pkg, data string) string
+}
+
+var checksums = map[string]checksum{
+	"crc32": {4, "hash/crc32", func(pkg, data string) string {
+		return fmt.Sprintf("%s.ChecksumIEEE(%s)", pkg, data)
+	}},
+	"crc32c": {4, "hash/crc32", func(pkg, data string) string {
+		return fmt.Sprintf("%s.Checksum(%s, %s.MakeTable(%s.Castagnoli))", pkg, data, pkg, pkg)
+	}},
+	"xxhash": {8, "github.com/cespare/xxhash/v2", func(pkg, data string) string {
+		return fmt.Sprintf("%s.Sum64(%s)", pkg, data)
+	}},
+}
+
+// intLimits describes the values of an integer type. Types of platform-dependent
+// size are assumed to be 64 bits wide when converting from them, and 32 bits wide
+// when converting to them, so that checks are generated for either size.
+type intLimits struct {
+	signed   bool
+	bits     int
+	min, max string // constants of package math
+	platform bool
+}
+
+// valueBits returns the number of bits of the largest value.
+func (r intLimits) valueBits(to bool) int {
+	bits := r.bits
+	if r.platform && to {
+		bits = 32
+	}
+	if r.signed {
+		bits--
+	}
+	return bits
+}
+
+func basicIntLimits(basic *types.Basic) (intLimits, bool) {
+	switch basic.Kind() {
+	case types.Int:
+		return intLimits{true, 64, "MinInt", "MaxInt", true}, true
+	case types.Int8:
+		return intLimits{true, 8, "MinInt8", "MaxInt8", false}, true
+	case types.Int16:
+		return intLimits{true, 16, "MinInt16", "MaxInt16", false}, true
+	case types.Int32:
+		return intLimits{true, 32, "MinInt32", "MaxInt32", false}, true
+	case types.Int64:
+		return intLimits{true, 64, "MinInt64", "MaxInt64", false}, true
+	case types.Uint:
+		return intLimits{false, 64, "", "MaxUint", true}, true
+	case types.Uint8:
+		return intLimits{false, 8, "", "MaxUint8", false}, true
+	case types.Uint16:
+		return intLimits{false, 16, "", "MaxUint16", false}, true
+	case types.Uint32:
+		return intLimits{false, 32, "", "MaxUint32", false}, true
+	case types.Uint64:
+		return intLimits{false, 64, "", "MaxUint64", false}, true
+	}
+	return intLimits{}, false
+}
+
+func (w wireForm) limits() intLimits {
+	r, _ := basicIntLimits(types.Typ[w.basicKind()])
+	return r
+}
+
+// basicKind returns the Go type of the encoded value.
+func (w wireForm) basicKind() types.BasicKind {
+	switch {
+	case w.kind == "float" && w.size == 4:
+		return types.Float32
+	case w.kind == "float":
+		return types.Float64
+	case w.kind == "bool":
+		return types.Bool
+	}
+	kinds := map[int]types.BasicKind{1: types.Uint8, 2: types.Uint16, 4: types.Uint32, 8: types.Uint64}
+	if w.signed {
+		kinds = map[int]types.BasicKind{1: types.Int8, 2: types.Int16, 4: types.Int32, 8: types.Int64}
+	}
+	return kinds[w.size]
+}
+
+// binaryField is a field of the fixed binary layout, or a group of bit fields
+// sharing its bytes.
+type binaryField struct {
+	f      *marshalerField
+	wire   wireForm
+	name   string // value of the wire tag
+	offset int
+	rng    intLimits  // of integer fields
+	packed []bitField // of bit field groups
+	v      string     // variable holding the bits of a group
+	sum    string     // checksum algorithm of the trailer
+}
+
+// bitField is a field with a bits tag, packed into the bits of a group.
+type bitField struct {
+	f     *marshalerField
+	bits  int
+	shift int       // position of the lowest bit in the group
+	rng   intLimits // of integer fields
+}
+
+// binaryLayout returns the fields with a wire or bits tag in the order of the type.
+// Adjacent bit fields are packed into groups of whole bytes.
+func (mtyp *marshalerType) binaryLayout() (fields []binaryField, size int, err error) {
+	var group []bitField // bit fields of the open group
+	groupBits := 0
+	for _, f := range mtyp.Fields {
+		if f.function != nil {
+			continue
+		}
+		tag := reflect.StructTag(f.tag)
+		if bits, ok := tag.Lookup("bits"); ok {
+			if _, ok := tag.Lookup("wire"); ok {
+				return nil, 0, fmt.Errorf("%v: field %s can't have both a wire and a bits tag", mtyp.fs.Position(f.pos), f.name)
+			}
+			bf, err := newBitField(f, bits)
+			if err != nil {
+				return nil, 0, fmt.Errorf("%v: field %s: %v", mtyp.fs.Position(f.pos), f.name, err)
+			}
+			group = append(group, bf)
+			if groupBits += bf.bits; groupBits > 64 {
+				return nil, 0, fmt.Errorf("%v: bit fields %s exceed 64 bits", mtyp.fs.Position(f.pos), bitFieldNames(group))
+			}
+			if groupBits%8 == 0 {
+				fields = append(fields, packBitFields(group, size))
+				size += groupBits / 8
+				group, groupBits = nil, 0
+			}
+			continue
+		}
+		name, ok := tag.Lookup("wire")
+		if !ok || name == "-" {
+			continue
+		}
+		if group != nil {
+			return nil, 0, fmt.Errorf("%v: bit fields %s fill %d bits, not whole bytes", mtyp.fs.Position(f.pos), bitFieldNames(group), groupBits)
+		}
+		wire, ok := wireForms[name]
+		if !ok {
+			return nil, 0, fmt.Errorf("%v: field %s: unknown wire form %q (available: %s)", mtyp.fs.Position(f.pos), f.name, name, strings.Join(wireFormNames(), ", "))
+		}
+		if len(fields) > 0 && fields[len(fields)-1].sum != "" {
+			return nil, 0, fmt.Errorf("%v: field %s follows the checksum of %s", mtyp.fs.Position(f.pos), f.name, mtyp.name)
+		}
+		bf := binaryField{f: f, wire: wire, name: name, offset: size, sum: tag.Get("checksum")}
+		if err := bf.check(); err != nil {
+			return nil, 0, fmt.Errorf("%v: field %s: %v", mtyp.fs.Position(f.pos), f.name, err)
+		}
+		size += bf.wire.size
+		fields = append(fields, bf)
+	}
+	if group != nil {
+		return nil, 0, fmt.Errorf("bit fields %s of %s fill %d bits, not whole bytes", bitFieldNames(group), mtyp.name, groupBits)
+	}
+	if len(fields) == 0 {
+		return nil, 0, fmt.Errorf("type %s has no field with a wire or bits tag", mtyp.name)
+	}
+	return fields, size, nil
+}
+
+// newBitField checks the width given by the bits tag against the field type, which
+// must be an unsigned integer or a boolean of one bit.
+func newBitField(f *marshalerField, tag string) (bitField, error) {
+	bits, err := strconv.Atoi(tag)
+	if err != nil || bits < 1 || bits > 64 {
+		return bitField{}, fmt.Errorf("invalid bit width %q", tag)
+	}
+	bf := bitField{f: f, bits: bits}
+	if isBool(f.origTyp) {
+		if bits != 1 {
+			return bitField{}, fmt.Errorf("boolean bit field must have 1 bit, not %d", bits)
+		}
+		return bf, nil
+	}
+	basic, ok := f.origTyp.Underlying().(*types.Basic)
+	if ok {
+		bf.rng, ok = basicIntLimits(basic)
+	}
+	if !ok || bf.rng.signed {
+		return bitField{}, fmt.Errorf("bit field needs an unsigned integer or boolean type, not %s", f.origTyp)
+	}
+	return bf, nil
+}
+
+// packBitFields creates the group of the bit fields, which fill whole bytes. The
+// first field takes the most significant bits.
+func packBitFields(group []bitField, offset int) binaryField {
+	total := 0
+	for _, bf := range group {
+		total += bf.bits
+	}
+	shift := total
+	for i := range group {
+		shift -= group[i].bits
+		group[i].shift = shift
+	}
+	return binaryField{wire: wireForm{kind: "bits", size: total / 8}, name: "bits", offset: offset, packed: group}
+}
+
+func bitFieldNames(group []bitField) string {
+	names := make([]string, len(group))
+	for i, bf := range group {
+		names[i] = bf.f.name
+	}
+	return strings.Join(names, ", ")
+}
+
+// max returns the largest value of the bit field, which is below 64 bits wide.
+func (bf bitField) max() string {
+	return strconv.FormatUint(1<<uint(bf.bits)-1, 10)
+}
+
+// check verifies that the wire form can encode the field type, and that it has
+// the size of the checksum if the field holds one.
+func (bf *binaryField) check() error {
+	if bf.sum != "" {
+		sum, ok := checksums[bf.sum]
+		if !ok {
+			return fmt.Errorf("unknown checksum %q (available: %s)", bf.sum, strings.Join(checksumNames(), ", "))
+		}
+		if bf.wire.kind != "int" || bf.wire.signed || bf.wire.size != sum.size {
+			return fmt.Errorf("checksum %s needs wire form u%dbe or u%dle, not %s", bf.sum, sum.size*8, sum.size*8, bf.name)
+		}
+	}
+	typ := bf.f.origTyp
+	if bf.wire.kind == "bytes" {
+		arr, ok := typ.Underlying().(*types.Array)
+		if !ok || !types.Identical(arr.Elem().Underlying(), types.Typ[types.Byte]) {
+			return fmt.Errorf("wire form bytes needs a byte array, not %s", typ)
+		}
+		bf.wire.size = int(arr.Len())
+		return nil
+	}
+	basic, ok := typ.Underlying().(*types.Basic)
+	if !ok {
+		return fmt.Errorf("type %s has no binary encoding", typ)
+	}
+	switch bf.wire.kind {
+	case "int":
+		if bf.rng, ok = basicIntLimits(basic); !ok {
+			return fmt.Errorf("wire form %s needs an integer type, not %s", bf.name, typ)
+		}
+	case "float":
+		if basic.Info()&types.IsFloat == 0 {
+			return fmt.Errorf("wire form %s needs a float type, not %s", bf.name, typ)
+		}
+	case "bool":
+		if !isBool(typ) {
+			return fmt.Errorf("wire form bool needs a boolean type, not %s", typ)
+		}
+	}
+	return nil
+}
+
+func checksumNames() []string {
+	var names []string
+	for name := range checksums {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func wireFormNames() []string {
+	var names []string
+	for name := range wireForms {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// rangeCheck returns the condition under which the value v of an integer type
+// described by from doesn't fit the integer type described by to.
+func rangeCheck(math, v string, from, to intLimits) string {
+	conv := "uint64(" + v + ")"
+	if from.signed {
+		conv = "int64(" + v + ")"
+	}
+	if from.bits == 64 && !from.platform {
+		conv = v
+	}
+	var conds []string
+	if from.signed && (!to.signed || from.bits > to.bits || (to.platform && from.bits > 32)) {
+		if to.signed {
+			conds = append(conds, fmt.Sprintf("%s < %s.%s", conv, math, to.min))
+		} else {
+			conds = append(conds, fmt.Sprintf("%s < 0", conv))
+		}
+	}
+	if from.valueBits(false) > to.valueBits(true) {
+		conds = append(conds, fmt.Sprintf("%s > %s.%s", conv, math, to.max))
+	}
+	return strings.Join(conds, " || ")
+}
+
+// binaryWriter generates MarshalBinary and UnmarshalBinary.
+type binaryWriter struct {
+	mtyp              *marshalerType
+	scope             *funcScope
+	binary, fmt, math string // package names
+	recv, b, data     string // variable names
+}
+
+// loadBinary checks the binary layout of mtyp and adds the packages of its checksum.
+func loadBinary(mtyp *marshalerType) error {
+	fields, _, err := mtyp.binaryLayout()
+	if err != nil {
+		return err
+	}
+	if sum := fields[len(fields)-1].sum; sum != "" {
+		if err := mtyp.scope.requireImport(checksums[sum].path); err != nil {
+			return fmt.Errorf("checksum %s: %v", sum, err)
+		}
+		if err := mtyp.scope.requireImport(codecerrPath); err != nil {
+			return fmt.Errorf("checksum %s: %v", sum, err)
+		}
+	}
+	return nil
+}
+
+// genBinary writes the MarshalBinary and UnmarshalBinary methods of mtyp, which
+// encode the fields with a wire or bits tag in a fixed layout.
+func genBinary(w io.Writer, mtyp *marshalerType) error {
+	fields, size, err := mtyp.binaryLayout()
+	if err != nil {
+		return err
+	}
+	m := newMarshalMethod(mtyp, false)
+	bw := &binaryWriter{
+		mtyp:   mtyp,
+		binary: mtyp.scope.packageName("encoding/binary"),
+		fmt:    mtyp.scope.packageName("fmt"),
+		math:   mtyp.scope.packageName("math"),
+		scope:  m.scope,
+		recv:   m.receiver().Name,
+		b:      m.scope.newIdent("b"),
+		data:   m.scope.newIdent("data"),
+	}
+	for i := range fields {
+		if fields[i].packed != nil {
+			fields[i].v = m.scope.newIdent("bits")
+		}
+	}
+
+	fmt.Fprintf(w, "// MarshalBinary encodes the fields of %s with a wire or bits tag in their fixed binary layout.\n", bw.recv)
+	fmt.Fprintf(w, "func (%s %s) MarshalBinary() ([]byte, error) {\n", bw.recv, mtyp.recvType())
+	fmt.Fprintf(w, "%s := make([]byte, %d)\n", bw.b, size)
+	for _, bf := range fields {
+		bw.encodeField(w, bf)
+	}
+	fmt.Fprintf(w, "return %s, nil\n}\n\n", bw.b)
+
+	fmt.Fprintf(w, "// UnmarshalBinary decodes the fields of %s with a wire or bits tag from their fixed binary layout.\n", bw.recv)
+	fmt.Fprintf(w, "func (%s *%s) UnmarshalBinary(%s []byte) error {\n", bw.recv, mtyp.recvType(), bw.data)
+	fmt.Fprintf(w, "if len(%s) != %d {\n", bw.data, size)
+	fmt.Fprintf(w, "return %s.Errorf(\"wrong length %%d of %s data, want %d\", len(%s))\n}\n", bw.fmt, mtyp.name, size, bw.data)
+	if trailer := fields[len(fields)-1]; trailer.sum != "" {
+		bw.verifyChecksum(w, trailer)
+	}
+	for _, bf := range fields {
+		bw.decodeField(w, bf)
+	}
+	fmt.Fprintf(w, "return nil\n}\n")
+	return nil
+}
+
+// encodeField writes the code encoding a field into the buffer.
+func (bw *binaryWriter) encodeField(w io.Writer, bf binaryField) {
+	if bf.packed != nil {
+		bw.encodeBits(w, bf)
+		return
+	}
+	v := printExpr(bw.mtyp, bf.f.access(Name(bw.recv)))
+	at := fmt.Sprintf("%s[%d:]", bw.b, bf.offset)
+	if bf.sum != "" {
+		v = bw.checksum(bf, bw.b)
+	}
+	switch bf.wire.kind {
+	case "bytes":
+		fmt.Fprintf(w, "copy(%s, %s[:])\n", at, v)
+	case "bool":
+		fmt.Fprintf(w, "if %s {\n%s[%d] = 1\n}\n", v, bw.b, bf.offset)
+	case "float":
+		fn := "Float64bits"
+		if bf.wire.size == 4 {
+			fn = "Float32bits"
+		}
+		fmt.Fprintf(w, "%s.%s.PutUint%d(%s, %s.%s(%s(%s)))\n", bw.binary, bf.wire.order, bf.wire.size*8, at, bw.math, fn, types.Typ[bf.wire.basicKind()], v)
+	case "int":
+		if cond := rangeCheck(bw.math, v, bf.rng, bf.wire.limits()); cond != "" && bf.sum == "" {
+			fmt.Fprintf(w, "if %s {\n", cond)
+			fmt.Fprintf(w, "return nil, %s.Errorf(\"field %s of %s out of range for %s: %%d\", %s)\n}\n", bw.fmt, bf.f.name, bw.mtyp.name, bf.name, v)
+		}
+		if bf.wire.size == 1 {
+			fmt.Fprintf(w, "%s[%d] = byte(%s)\n", bw.b, bf.offset, v)
+		} else {
+			fmt.Fprintf(w, "%s.%s.PutUint%d(%s, uint%d(%s))\n", bw.binary, bf.wire.order, bf.wire.size*8, at, bf.wire.size*8, v)
+		}
+	}
+}
+
+// decodeField writes the code decoding a field from the input.
+func (bw *binaryWriter) decodeField(w io.Writer, bf binaryField) {
+	if bf.packed != nil {
+		bw.decodeBits(w, bf)
+		return
+	}
+	var (
+		v    = printExpr(bw.mtyp, bf.f.access(Name(bw.recv)))
+		at   = fmt.Sprintf("%s[%d:%d]", bw.data, bf.offset, bf.offset+bf.wire.size)
+		typ  = typeString(bf.f.origTyp, bw.mtyp.scope.qualify)
+		read = fmt.Sprintf("%s.%s.Uint%d(%s)", bw.binary, bf.wire.order, bf.wire.size*8, at)
+	)
+	if bf.wire.size == 1 {
+		read = fmt.Sprintf("%s[%d]", bw.data, bf.offset)
+	}
+	switch bf.wire.kind {
+	case "bytes":
+		fmt.Fprintf(w, "copy(%s[:], %s)\n", v, at)
+	case "bool":
+		fmt.Fprintf(w, "switch %s {\ncase 0:\n%s = false\ncase 1:\n%s = true\n", read, v, v)
+		fmt.Fprintf(w, "default:\nreturn %s.Errorf(\"invalid value %%d of field %s of %s for bool\", %s)\n}\n", bw.fmt, bf.f.name, bw.mtyp.name, read)
+	case "float":
+		fn := "Float64frombits"
+		if bf.wire.size == 4 {
+			fn = "Float32frombits"
+		}
+		fmt.Fprintf(w, "%s = %s(%s.%s(%s))\n", v, typ, bw.math, fn, read)
+	case "int":
+		if bf.wire.signed {
+			read = fmt.Sprintf("%s(%s)", types.Typ[bf.wire.basicKind()], read)
+		}
+		if rangeCheck(bw.math, "", bf.wire.limits(), bf.rng) == "" {
+			fmt.Fprintf(w, "%s = %s(%s)\n", v, typ, read)
+			return
+		}
+		value := bw.scope.newIdent("v")
+		cond := rangeCheck(bw.math, value, bf.wire.limits(), bf.rng)
+		fmt.Fprintf(w, "%s := %s\n", value, read)
+		fmt.Fprintf(w, "if %s {\n", cond)
+		fmt.Fprintf(w, "return %s.Errorf(\"value %%d of field %s of %s out of range\", %s)\n}\n", bw.fmt, bf.f.name, bw.mtyp.name, value)
+		fmt.Fprintf(w, "%s = %s(%s)\n", v, typ, value)
+	}
+}
+
+// encodeBits writes the code packing a group of bit fields into the buffer.
+// The group is stored in big-endian byte order.
+func (bw *binaryWriter) encodeBits(w io.Writer, group binaryField) {
+	fmt.Fprintf(w, "var %s uint64\n", group.v)
+	for _, bf := range group.packed {
+		v := printExpr(bw.mtyp, bf.f.access(Name(bw.recv)))
+		shift := ""
+		if bf.shift > 0 {
+			shift = fmt.Sprintf(" << %d", bf.shift)
+		}
+		if bf.rng == (intLimits{}) {
+			fmt.Fprintf(w, "if %s {\n%s |= 1%s\n}\n", v, group.v, shift)
+			continue
+		}
+		if bf.rng.valueBits(false) > bf.bits {
+			fmt.Fprintf(w, "if %s > %s {\n", v, bf.max())
+			fmt.Fprintf(w, "return nil, %s.Errorf(\"field %s of %s out of range for %d bits: %%d\", %s)\n}\n", bw.fmt, bf.f.name, bw.mtyp.name, bf.bits, v)
+		}
+		fmt.Fprintf(w, "%s |= uint64(%s)%s\n", group.v, v, shift)
+	}
+	size := group.wire.size
+	switch size {
+	case 1:
+		fmt.Fprintf(w, "%s[%d] = byte(%s)\n", bw.b, group.offset, group.v)
+	case 2, 4, 8:
+		fmt.Fprintf(w, "%s.BigEndian.PutUint%d(%s[%d:], uint%d(%s))\n", bw.binary, size*8, bw.b, group.offset, size*8, group.v)
+	default:
+		for i := 0; i < size-1; i++ {
+			fmt.Fprintf(w, "%s[%d] = byte(%s >> %d)\n", bw.b, group.offset+i, group.v, 8*(size-1-i))
+		}
+		fmt.Fprintf(w, "%s[%d] = byte(%s)\n", bw.b, group.offset+size-1, group.v)
+	}
+}
+
+// decodeBits writes the code unpacking a group of bit fields from the input.
+func (bw *binaryWriter) decodeBits(w io.Writer, group binaryField) {
+	size := group.wire.size
+	var read string
+	switch size {
+	case 1:
+		read = fmt.Sprintf("uint64(%s[%d])", bw.data, group.offset)
+	case 2, 4:
+		read = fmt.Sprintf("uint64(%s.BigEndian.Uint%d(%s[%d:%d]))", bw.binary, size*8, bw.data, group.offset, group.offset+size)
+	case 8:
+		read = fmt.Sprintf("%s.BigEndian.Uint64(%s[%d:%d])", bw.binary, bw.data, group.offset, group.offset+size)
+	default:
+		bytes := make([]string, size)
+		for i := range bytes {
+			bytes[i] = fmt.Sprintf("uint64(%s[%d])<<%d", bw.data, group.offset+i, 8*(size-1-i))
+		}
+		read = strings.TrimSuffix(strings.Join(bytes, " | "), "<<0")
+	}
+	fmt.Fprintf(w, "%s := %s\n", group.v, read)
+	for _, bf := range group.packed {
+		var (
+			v     = printExpr(bw.mtyp, bf.f.access(Name(bw.recv)))
+			value = group.v
+		)
+		if bf.shift > 0 {
+			value = fmt.Sprintf("%s>>%d", value, bf.shift)
+		}
+		// The most significant field needs no mask.
+		if bf.shift+bf.bits < size*8 {
+			value = fmt.Sprintf("%s&%s", value, bf.max())
+		}
+		if bf.rng == (intLimits{}) {
+			fmt.Fprintf(w, "%s = %s == 1\n", v, value)
+			continue
+		}
+		typ := typeString(bf.f.origTyp, bw.mtyp.scope.qualify)
+		if bf.bits <= bf.rng.valueBits(true) {
+			fmt.Fprintf(w, "%s = %s(%s)\n", v, typ, value)
+			continue
+		}
+		tmp := bw.scope.newIdent("v")
+		fmt.Fprintf(w, "%s := %s\n", tmp, value)
+		fmt.Fprintf(w, "if %s > %s.%s {\n", tmp, bw.math, bf.rng.max)
+		fmt.Fprintf(w, "return %s.Errorf(\"value %%d of field %s of %s out of range\", %s)\n}\n", bw.fmt, bf.f.name, bw.mtyp.name, tmp)
+		fmt.Fprintf(w, "%s = %s(%s)\n", v, typ, tmp)
+	}
+}
+
+// checksum returns the expression computing the checksum of the bytes of buf
+// before the trailer bf.
+func (bw *binaryWriter) checksum(bf binaryField, buf string) string {
+	sum := checksums[bf.sum]
+	return sum.sum(bw.mtyp.scope.packageName(sum.path), fmt.Sprintf("%s[:%d]", buf, bf.offset))
+}
+
+// verifyChecksum writes the code comparing the checksum of the input with its
+// trailer bf. A mismatch is reported by codecerr.ChecksumError.
+func (bw *binaryWriter) verifyChecksum(w io.Writer, bf binaryField) {
+	var (
+		bits   = bf.wire.size * 8
+		stored = fmt.Sprintf("%s.%s.Uint%d(%s[%d:%d])", bw.binary, bf.wire.order, bits, bw.data, bf.offset, bf.offset+bf.wire.size)
+		sum    = bw.scope.newIdent("sum")
+		conv   = func(v string) string { return v }
+	)
+	if bits != 64 {
+		conv = func(v string) string { return "uint64(" + v + ")" }
+	}
+	fmt.Fprintf(w, "if %s := %s; %s != %s {\n", sum, bw.checksum(bf, bw.data), sum, stored)
+	fmt.Fprintf(w, "return &%s.ChecksumError{Type: %q, Algorithm: %q, Sum: %s, Computed: %s}\n}\n",
+		bw.mtyp.scope.packageName(codecerrPath), bw.mtyp.name, bf.sum, conv(stored), conv(sum))
+}
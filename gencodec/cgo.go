@@ -0,0 +1,91 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+
+	"golang.org/x/tools/imports"
+)
+
+// cgoTemplate generates the main package of a C library exporting the decoders.
+// Buffers returned to C are allocated by malloc.
+var cgoTemplate = template.Must(template.New("cgo").Parse(`// Code generated by github.com/fjl/gencodec. DO NOT EDIT.
+
+// This package is built as a C library with
+//
+//	go build -buildmode=c-shared
+//
+// or -buildmode=c-archive. It exports the JSON decoders of the types of package
+// {{.Pkg}} to C. Each function has the signature
+//
+//	char* DecodeT(void* input, size_t size, void** output, size_t* output_size);
+//
+// It decodes the JSON input as type T and stores its canonical encoding in output.
+// On failure, it returns the error message and output is not set. The message and
+// the output must be released with free.
+package main
+
+// #include <stdlib.h>
+import "C"
+
+import (
+	"unsafe"
+
+	{{.Pkg}} {{printf "%q" .Path}}
+)
+{{range .Types}}
+// Decode{{.}} decodes the JSON input as {{.}}.
+//
+//export Decode{{.}}
+func Decode{{.}}(input unsafe.Pointer, size C.size_t, output *unsafe.Pointer, outputSize *C.size_t) *C.char {
+	var v {{$.Pkg}}.{{.}}
+	if err := v.UnmarshalJSON(C.GoBytes(input, C.int(size))); err != nil {
+		return C.CString(err.Error())
+	}
+	enc, err := v.MarshalJSON()
+	if err != nil {
+		return C.CString(err.Error())
+	}
+	*output = C.CBytes(enc)
+	*outputSize = C.size_t(len(enc))
+	return nil
+}
+{{end}}
+// main is never called. It makes the package build as a program.
+func main() {}
+`))
+
+// genCgo returns the main package of a C library exporting the JSON decoders of mtyps.
+func genCgo(mtyps []*marshalerType) ([]byte, error) {
+	pkg := mtyps[0].orig.Obj().Pkg()
+	data := pluginData{Pkg: pkg.Name(), Path: pkg.Path()}
+	if data.Pkg == "C" || data.Pkg == "unsafe" || data.Pkg == "main" {
+		return nil, fmt.Errorf("package %s can't be imported by the cgo exports", pkg.Name())
+	}
+	for _, mtyp := range mtyps {
+		switch {
+		case mtyp.orig.TypeParams().Len() > 0:
+			return nil, fmt.Errorf("generic type %s can't be exported to C", mtyp.name)
+		case !mtyp.orig.Obj().Exported():
+			return nil, fmt.Errorf("unexported type %s can't be exported to C", mtyp.name)
+		case !mtyp.hasFormat("json"):
+			return nil, fmt.Errorf("cgo exports of %s require the json format", mtyp.name)
+		}
+		data.Types = append(data.Types, mtyp.name)
+	}
+	w := new(bytes.Buffer)
+	if err := cgoTemplate.Execute(w, data); err != nil {
+		return nil, err
+	}
+	opt := &imports.Options{Comments: true, TabIndent: true, TabWidth: 8, FormatOnly: true}
+	code, err := imports.Process("", w.Bytes(), opt)
+	if err != nil {
+		return nil, fmt.Errorf("can't gofmt generated cgo exports: %v", err)
+	}
+	return code, nil
+}
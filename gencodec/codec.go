@@ -0,0 +1,222 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"errors"
+	"io"
+	"text/template"
+
+	. "github.com/garslo/gogen"
+)
+
+// codecTemplate generates the codec object of a type. The codec marshals
+// through the generated JSON methods and rewrites the object as a token
+// stream when options are set.
+var codecTemplate = template.Must(template.New("codec").Parse(`
+// {{.Metrics}} is notified of every call to UnmarshalJSON of {{.Type}}, including the calls
+// by json.Unmarshal and by the codec, with the name of the type, the size of the input,
+// the time spent decoding and the result. It must be set before decoding starts.
+var {{.Metrics}} interface {
+	OnDecode(typ string, size int, elapsed {{.Time}}.Duration, err error)
+}
+
+// {{.Var}} is the default JSON codec of {{.Type}}. Copy it to change the options.
+var {{.Var}} = {{.Options}}{}
+
+// {{.Options}} configures the JSON encoding of {{.Type}} at runtime.
+type {{.Options}} struct {
+	// NameMapping translates the keys of {{.Type}} objects. Marshal applies it
+	// to the keys of the output, Unmarshal translates known keys of the input
+	// back to the original ones. Keys of nested objects are not translated.
+	NameMapping func(key string) string
+	// Strict makes Unmarshal reject unknown keys. Keys must match exactly.
+	Strict bool
+	// Redact is the set of keys whose values are replaced by "[REDACTED]" on Marshal.
+	// It contains the original keys of {{.Type}}, before name mapping.
+	Redact map[string]bool
+}
+
+// Marshal encodes {{.Recv}} as JSON.
+func ({{.Opt}} {{.Options}}) Marshal({{.Recv}} *{{.Type}}) ([]byte, error) {
+	{{.Output}}, {{.Err}} := {{.Recv}}.MarshalJSON()
+	if {{.Err}} != nil || ({{.Opt}}.NameMapping == nil && len({{.Opt}}.Redact) == 0) {
+		return {{.Output}}, {{.Err}}
+	}
+	return {{.Opt}}.rewrite({{.Output}}, func({{.Key}} string, {{.Value}} {{.JSON}}.RawMessage) (string, {{.JSON}}.RawMessage, error) {
+		if {{.Opt}}.Redact[{{.Key}}] {
+			{{.Value}} = {{.JSON}}.RawMessage(` + "`" + `"[REDACTED]"` + "`" + `)
+		}
+		if {{.Opt}}.NameMapping != nil {
+			{{.Key}} = {{.Opt}}.NameMapping({{.Key}})
+		}
+		return {{.Key}}, {{.Value}}, nil
+	})
+}
+
+// Unmarshal decodes {{.Recv}} from JSON.
+func ({{.Opt}} {{.Options}}) Unmarshal({{.Input}} []byte, {{.Recv}} *{{.Type}}) error {
+	if {{.Opt}}.NameMapping != nil || {{.Opt}}.Strict {
+		{{.Names}} := make(map[string]string)
+		for _, {{.Key}} := range {{.Opt}}.keys() {
+			{{.Name}} := {{.Key}}
+			if {{.Opt}}.NameMapping != nil {
+				{{.Name}} = {{.Opt}}.NameMapping({{.Key}})
+			}
+			{{.Names}}[{{.Name}}] = {{.Key}}
+		}
+		var {{.Err}} error
+		{{.Input}}, {{.Err}} = {{.Opt}}.rewrite({{.Input}}, func({{.Key}} string, {{.Value}} {{.JSON}}.RawMessage) (string, {{.JSON}}.RawMessage, error) {
+			if {{.Name}}, {{.Ok}} := {{.Names}}[{{.Key}}]; {{.Ok}} {
+				return {{.Name}}, {{.Value}}, nil
+			}
+			if {{.Opt}}.Strict {
+				return "", nil, {{.Fmt}}.Errorf("unknown field '%s' for {{.Type}}", {{.Key}})
+			}
+			return {{.Key}}, {{.Value}}, nil
+		})
+		if {{.Err}} != nil {
+			return {{.Err}}
+		}
+	}
+	return {{.Recv}}.UnmarshalJSON({{.Input}})
+}
+
+// keys returns the JSON keys of {{.Type}}.
+func ({{.Options}}) keys() []string {
+	return []string{ {{- range $i, $k := .Keys}}{{if $i}}, {{end}}{{printf "%q" $k}}{{end -}} }
+}
+
+// rewrite rebuilds the JSON object in {{.Input}}, replacing each key and value
+// by the result of {{.Fn}}. Other JSON values are returned unchanged.
+func ({{.Options}}) rewrite({{.Input}} []byte, {{.Fn}} func(string, {{.JSON}}.RawMessage) (string, {{.JSON}}.RawMessage, error)) ([]byte, error) {
+	{{.Dec}} := {{.JSON}}.NewDecoder({{.Bytes}}.NewReader({{.Input}}))
+	if {{.Tok}}, {{.Err}} := {{.Dec}}.Token(); {{.Err}} != nil {
+		return nil, {{.Err}}
+	} else if {{.Tok}} != {{.JSON}}.Delim('{') {
+		return {{.Input}}, nil
+	}
+	{{.Output}} := []byte{'{'}
+	for {{.Dec}}.More() {
+		{{.Tok}}, {{.Err}} := {{.Dec}}.Token()
+		if {{.Err}} != nil {
+			return nil, {{.Err}}
+		}
+		var {{.Value}} {{.JSON}}.RawMessage
+		if {{.Err}} := {{.Dec}}.Decode(&{{.Value}}); {{.Err}} != nil {
+			return nil, {{.Err}}
+		}
+		{{.Name}}, {{.Value}}, {{.Err}} := {{.Fn}}({{.Tok}}.(string), {{.Value}})
+		if {{.Err}} != nil {
+			return nil, {{.Err}}
+		}
+		{{.Key}}, _ := {{.JSON}}.Marshal({{.Name}})
+		if len({{.Output}}) > 1 {
+			{{.Output}} = append({{.Output}}, ',')
+		}
+		{{.Output}} = append({{.Output}}, {{.Key}}...)
+		{{.Output}} = append({{.Output}}, ':')
+		{{.Output}} = append({{.Output}}, {{.Value}}...)
+	}
+	if _, {{.Err}} := {{.Dec}}.Token(); {{.Err}} != nil {
+		return nil, {{.Err}}
+	}
+	return append({{.Output}}, '}'), nil
+}
+`))
+
+// codecData is the input of codecTemplate.
+type codecData struct {
+	Type, Var, Options, Metrics string
+	Keys                        []string
+
+	// package and variable names
+	JSON, Bytes, Fmt, Time                               string
+	Opt, Recv, Input, Output, Err, Dec, Key, Names, Name string
+	Tok, Value, Ok, Fn                                   string
+}
+
+// loadCodec prepares generation of the codec object. The codec requires the JSON methods.
+func (mtyp *marshalerType) loadCodec(formats []string) error {
+	if mtyp.orig.TypeParams().Len() > 0 {
+		return errors.New("codec object is not supported for generic types")
+	}
+	for _, format := range formats {
+		if format == "json" {
+			mtyp.scope.addImport("bytes")
+			mtyp.scope.addImport("fmt")
+			mtyp.scope.addImport("time")
+			mtyp.metrics = mtyp.name + "Metrics"
+			return nil
+		}
+	}
+	return errors.New("codec object requires the json format")
+}
+
+// genCodec writes the codec object of mtyp.
+func genCodec(w io.Writer, mtyp *marshalerType) error {
+	var (
+		scope = newFuncScope(mtyp.scope)
+		recv  = newMarshalMethod(mtyp, false).receiver().Name
+	)
+	scope.used[recv] = true
+	data := codecData{
+		Type:    mtyp.name,
+		Var:     mtyp.name + "Codec",
+		Options: mtyp.name + "CodecOptions",
+		Metrics: mtyp.metrics,
+		JSON:    mtyp.scope.packageName("encoding/json"),
+		Bytes:   mtyp.scope.packageName("bytes"),
+		Fmt:     mtyp.scope.packageName("fmt"),
+		Time:    mtyp.scope.packageName("time"),
+		Recv:    recv,
+		Opt:     scope.newIdent("o"),
+		Input:   scope.newIdent("input"),
+		Output:  scope.newIdent("output"),
+		Err:     scope.newIdent("err"),
+		Dec:     scope.newIdent("dec"),
+		Key:     scope.newIdent("key"),
+		Names:   scope.newIdent("names"),
+		Name:    scope.newIdent("name"),
+		Tok:     scope.newIdent("tok"),
+		Value:   scope.newIdent("value"),
+		Ok:      scope.newIdent("ok"),
+		Fn:      scope.newIdent("fn"),
+	}
+	for _, f := range mtyp.Fields {
+		if key, ok := f.key("json"); ok {
+			data.Keys = append(data.Keys, key)
+		}
+	}
+	return mtyp.template(codecTemplate).Execute(w, data)
+}
+
+// reportMetrics wraps the body of UnmarshalJSON, which notifies the metrics hook
+// of the codec if it is set. The body runs in a function literal.
+func (m *marshalMethod) reportMetrics(body []Statement, input Var) []Statement {
+	var (
+		hook   = Name(m.mtyp.metrics)
+		decode = Name(m.scope.newIdent("decode"))
+		start  = Name(m.scope.newIdent("start"))
+		err    = Name(m.scope.newIdent("err"))
+		time   = Name(m.scope.parent.packageName("time"))
+		typ    = m.mtyp.orig.Obj().Pkg().Name() + "." + m.mtyp.name
+	)
+	since := CallFunction{Func: Dotted{Receiver: time, Name: "Since"}, Params: []Expression{start}}
+	return []Statement{
+		DeclareAndAssign{Lhs: decode, Rhs: errorFunc(body)},
+		If{
+			Condition: Equals{Lhs: hook, Rhs: NIL},
+			Body:      []Statement{Return{Values: []Expression{CallFunction{Func: decode}}}},
+		},
+		DeclareAndAssign{Lhs: start, Rhs: CallFunction{Func: Dotted{Receiver: time, Name: "Now"}}},
+		DeclareAndAssign{Lhs: err, Rhs: CallFunction{Func: decode}},
+		CallFunction{
+			Func:   Dotted{Receiver: hook, Name: "OnDecode"},
+			Params: []Expression{stringLit{typ}, CallFunction{Func: Name("len"), Params: []Expression{input}}, since, err},
+		},
+		Return{Values: []Expression{err}},
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"fmt"
+	"go/types"
+)
+
+// Compat compares the encoding of the type configured by old and new in the
+// given format. It returns the changes which break decoding of values encoded
+// by either side.
+func Compat(old, new *Config, format string) ([]string, error) {
+	load := func(cfg *Config) ([]schemaField, error) {
+		cfg.Formats = []string{format}
+		mtyp, err := cfg.loadMarshalerType()
+		if err != nil {
+			return nil, err
+		}
+		return mtyp.schema(format), nil
+	}
+	oldSchema, err := load(old)
+	if err != nil {
+		return nil, err
+	}
+	newSchema, err := load(new)
+	if err != nil {
+		return nil, err
+	}
+	return compareSchemas(oldSchema, newSchema), nil
+}
+
+// schemaField describes the encoding of a field.
+type schemaField struct {
+	name     string // Go field name
+	key      string
+	typ      string
+	required bool
+}
+
+// schema returns the encoded fields of the marshaling type. Types of the
+// package itself are unqualified, other types are qualified by package path.
+func (mtyp *marshalerType) schema(format string) (fields []schemaField) {
+	qualify := func(pkg *types.Package) string {
+		if pkg == mtyp.scope.pkg {
+			return ""
+		}
+		return pkg.Path()
+	}
+	for _, f := range mtyp.Fields {
+		key, ok := f.key(format)
+		if !ok {
+			continue
+		}
+		fields = append(fields, schemaField{
+			name:     f.name,
+			key:      key,
+			typ:      types.TypeString(f.typ, qualify),
+			required: f.isRequired(format),
+		})
+	}
+	return fields
+}
+
+// compareSchemas returns the changes from old to new which break decoding
+// of values encoded by either side.
+func compareSchemas(old, new []schemaField) (problems []string) {
+	var (
+		oldByKey  = make(map[string]schemaField)
+		newByKey  = make(map[string]schemaField)
+		newByName = make(map[string]schemaField)
+	)
+	for _, f := range old {
+		oldByKey[f.key] = f
+	}
+	for _, f := range new {
+		newByKey[f.key] = f
+		newByName[f.name] = f
+	}
+	renamed := make(map[string]bool)
+	for _, of := range old {
+		nf, ok := newByKey[of.key]
+		switch {
+		case !ok && newByName[of.name].key != "":
+			nf = newByName[of.name]
+			renamed[nf.key] = true
+			problems = append(problems, fmt.Sprintf("field %s renamed from %q to %q", of.name, of.key, nf.key))
+		case !ok && of.required:
+			problems = append(problems, fmt.Sprintf("required field %q removed", of.key))
+		case ok && of.typ != nf.typ:
+			problems = append(problems, fmt.Sprintf("field %q changed type from %s to %s", of.key, of.typ, nf.typ))
+		case ok && !of.required && nf.required:
+			problems = append(problems, fmt.Sprintf("field %q became required", of.key))
+		}
+	}
+	for _, nf := range new {
+		if _, ok := oldByKey[nf.key]; !ok && !renamed[nf.key] && nf.required {
+			problems = append(problems, fmt.Sprintf("required field %q added", nf.key))
+		}
+	}
+	return problems
+}
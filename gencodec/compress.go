@@ -0,0 +1,95 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"fmt"
+	"io"
+	"text/template"
+)
+
+// gzipTemplate generates the gzip variants of the marshaling methods of the formats
+// which encode to a byte slice.
+var gzipTemplate = template.Must(template.New("gzip").Parse(`
+// {{.MaxSize}} is the largest decompressed input accepted by the gzip unmarshaling
+// methods of {{.Type}}. Larger input is rejected without being decompressed any
+// further, which protects against zip bombs.
+var {{.MaxSize}} int64 = 64 << 20
+{{range .Formats}}
+// Marshal{{.}}Gzip returns the gzip-compressed {{.}} encoding of {{$.Recv}}.
+func ({{$.Recv}} {{$.RecvType}}) Marshal{{.}}Gzip() ([]byte, error) {
+	{{$.Data}}, {{$.Err}} := {{$.Recv}}.Marshal{{.}}()
+	if {{$.Err}} != nil {
+		return nil, {{$.Err}}
+	}
+	var {{$.Buf}} {{$.Bytes}}.Buffer
+	{{$.ZW}} := {{$.Gzip}}.NewWriter(&{{$.Buf}})
+	if _, {{$.Err}} := {{$.ZW}}.Write({{$.Data}}); {{$.Err}} != nil {
+		return nil, {{$.Err}}
+	}
+	if {{$.Err}} := {{$.ZW}}.Close(); {{$.Err}} != nil {
+		return nil, {{$.Err}}
+	}
+	return {{$.Buf}}.Bytes(), nil
+}
+
+// Unmarshal{{.}}Gzip decompresses {{$.Input}} and decodes it from {{.}}. The decompressed
+// size is limited by {{$.MaxSize}}.
+func ({{$.Recv}} *{{$.RecvType}}) Unmarshal{{.}}Gzip({{$.Input}} []byte) error {
+	{{$.ZR}}, {{$.Err}} := {{$.Gzip}}.NewReader({{$.Bytes}}.NewReader({{$.Input}}))
+	if {{$.Err}} != nil {
+		return {{$.Err}}
+	}
+	defer {{$.ZR}}.Close()
+	{{$.Data}}, {{$.Err}} := {{$.IO}}.ReadAll({{$.IO}}.LimitReader({{$.ZR}}, {{$.MaxSize}}+1))
+	if {{$.Err}} != nil {
+		return {{$.Err}}
+	}
+	if int64(len({{$.Data}})) > {{$.MaxSize}} {
+		return {{$.Fmt}}.Errorf("decompressed {{$.Type}} input exceeds %d bytes", {{$.MaxSize}})
+	}
+	return {{$.Recv}}.Unmarshal{{.}}({{$.Data}})
+}
+{{end}}`))
+
+type gzipData struct {
+	Type, RecvType, MaxSize string
+	Formats                 []string // method name suffixes, e.g. JSON
+
+	// package and variable names
+	Bytes, Fmt, Gzip, IO                string
+	Recv, Input, Data, Err, Buf, ZW, ZR string
+}
+
+// genGzip writes the gzip variants of the marshaling methods of mtyp for each of
+// its formats which encode to a byte slice.
+func genGzip(w io.Writer, mtyp *marshalerType) error {
+	m := newMarshalMethod(mtyp, false)
+	data := gzipData{
+		Type:     mtyp.name,
+		RecvType: mtyp.recvType(),
+		MaxSize:  mtyp.name + "MaxDecompressedSize",
+		Bytes:    mtyp.scope.packageName("bytes"),
+		Fmt:      mtyp.scope.packageName("fmt"),
+		Gzip:     mtyp.scope.packageName("compress/gzip"),
+		IO:       mtyp.scope.packageName("io"),
+		Recv:     m.receiver().Name,
+		Input:    m.scope.newIdent("input"),
+		Data:     m.scope.newIdent("data"),
+		Err:      m.scope.newIdent("err"),
+		Buf:      m.scope.newIdent("buf"),
+		ZW:       m.scope.newIdent("zw"),
+		ZR:       m.scope.newIdent("zr"),
+	}
+	for _, f := range kafkaFormats {
+		if mtyp.hasFormat(f) {
+			data.Formats = append(data.Formats, formatNames[f])
+		}
+	}
+	if len(data.Formats) == 0 {
+		return fmt.Errorf("gzip helper requires one of the formats %v", kafkaFormats)
+	}
+	return mtyp.template(gzipTemplate).Execute(w, data)
+}
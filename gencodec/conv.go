@@ -0,0 +1,177 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"errors"
+	"fmt"
+	"go/types"
+	"reflect"
+	"strings"
+
+	. "github.com/garslo/gogen"
+)
+
+// convFuncs are the functions of the gencodec:"conv=to,from" option, which
+// convert a field to its marshaling type and back.
+type convFuncs struct {
+	to, from *types.Func
+	fromErr  bool // from returns an error as the second result
+}
+
+// parseConv returns the function names of the conv option in a struct tag. The
+// option is written as conv=to,from, so it takes two elements of the option list.
+func parseConv(tag string) (to, from string, ok bool, err error) {
+	opts := strings.Split(reflect.StructTag(tag).Get("gencodec"), ",")
+	for i, o := range opts {
+		k, v, _ := strings.Cut(strings.TrimSpace(o), "=")
+		if k != "conv" {
+			continue
+		}
+		if v == "" || i+1 == len(opts) || strings.Contains(opts[i+1], "=") {
+			return "", "", false, errors.New("conv option needs two functions, like conv=toWire,fromWire")
+		}
+		return v, strings.TrimSpace(opts[i+1]), true, nil
+	}
+	return "", "", false, nil
+}
+
+// loadConvFields resolves the conversion functions of fields with the conv option,
+// which is read from the field override type if it has the field, and from the
+// original type otherwise. Without an override, the marshaling type is the result
+// type of the function converting to it.
+func (mtyp *marshalerType) loadConvFields(imp types.Importer, pkg *types.Package) error {
+	for _, f := range mtyp.Fields {
+		if f.function != nil {
+			continue
+		}
+		// With the option on the override field, the marshaling type is already set.
+		tag, wire := f.tag, types.Type(nil)
+		if _, _, ok, _ := parseConv(f.overrideTag); ok {
+			tag, wire = f.overrideTag, f.typ
+		}
+		pos := mtyp.fs.Position(f.pos)
+		toName, fromName, ok, err := parseConv(tag)
+		if err != nil {
+			return fmt.Errorf("%v: field %s: %v", pos, f.name, err)
+		}
+		if !ok {
+			continue
+		}
+		conv := new(convFuncs)
+		if conv.to, err = lookupConvFunc(imp, pkg, toName); err != nil {
+			return fmt.Errorf("%v: field %s: %v", pos, f.name, err)
+		}
+		if conv.from, err = lookupConvFunc(imp, pkg, fromName); err != nil {
+			return fmt.Errorf("%v: field %s: %v", pos, f.name, err)
+		}
+		if wire, err = checkConvTo(conv.to, f.origTyp, wire); err != nil {
+			return fmt.Errorf("%v: field %s: %v", pos, f.name, err)
+		}
+		if conv.fromErr, err = checkConvFrom(conv.from, wire, f.origTyp); err != nil {
+			return fmt.Errorf("%v: field %s: %v", pos, f.name, err)
+		}
+		for _, fn := range []*types.Func{conv.to, conv.from} {
+			if fn.Pkg() != pkg {
+				mtyp.scope.addImport(fn.Pkg().Path())
+			}
+		}
+		f.typ = wire
+		f.conv = conv
+		f.wellKnown = nil
+	}
+	return nil
+}
+
+// lookupConvFunc finds a conversion function. The name is either a function of pkg,
+// or qualified by the name or path of a package, like strconv.Itoa.
+func lookupConvFunc(imp types.Importer, pkg *types.Package, name string) (*types.Func, error) {
+	scope := pkg.Scope()
+	if dot := strings.LastIndexByte(name, '.'); dot != -1 {
+		qual := name[:dot]
+		var opkg *types.Package
+		for _, p := range pkg.Imports() {
+			if p.Path() == qual || p.Name() == qual {
+				opkg = p
+				break
+			}
+		}
+		if opkg == nil {
+			var err error
+			if opkg, err = imp.Import(qual); err != nil {
+				return nil, fmt.Errorf("can't import %q: %v", qual, err)
+			}
+		}
+		scope, name = opkg.Scope(), name[dot+1:]
+	}
+	fn, ok := scope.Lookup(name).(*types.Func)
+	switch {
+	case !ok:
+		return nil, fmt.Errorf("conversion function %s not found", name)
+	case fn.Pkg() != pkg && !fn.Exported():
+		return nil, fmt.Errorf("conversion function %s of package %s is not exported", name, fn.Pkg().Path())
+	case fn.Type().(*types.Signature).TypeParams().Len() > 0:
+		return nil, fmt.Errorf("conversion function %s can't be generic", name)
+	}
+	return fn, nil
+}
+
+// checkConvTo checks the function converting values of type orig to the marshaling
+// type. If wire is nil, the marshaling type is the result type of the function.
+func checkConvTo(fn *types.Func, orig, wire types.Type) (types.Type, error) {
+	sig := fn.Type().(*types.Signature)
+	if sig.Params().Len() != 1 || sig.Results().Len() != 1 || !types.AssignableTo(orig, sig.Params().At(0).Type()) {
+		return nil, fmt.Errorf("conversion function %s must have signature func(%s) T", fn.Name(), orig)
+	}
+	result := sig.Results().At(0).Type()
+	if wire == nil {
+		return result, nil
+	}
+	if !types.AssignableTo(result, wire) {
+		return nil, fmt.Errorf("conversion function %s returns %s, not %s", fn.Name(), result, wire)
+	}
+	return wire, nil
+}
+
+// checkConvFrom checks the function converting the marshaling type back to the
+// field type. It may return an error as the second result.
+func checkConvFrom(fn *types.Func, wire, orig types.Type) (fromErr bool, err error) {
+	sig := fn.Type().(*types.Signature)
+	res := sig.Results()
+	fromErr = res.Len() == 2 && types.Identical(res.At(1).Type(), types.Universe.Lookup("error").Type())
+	if sig.Params().Len() != 1 || !types.AssignableTo(wire, sig.Params().At(0).Type()) ||
+		(res.Len() != 1 && !fromErr) || !types.AssignableTo(res.At(0).Type(), orig) {
+		return false, fmt.Errorf("conversion function %s must have signature func(%s) %s or func(%s) (%s, error)", fn.Name(), wire, orig, wire, orig)
+	}
+	return fromErr, nil
+}
+
+// funcName returns the name of a conversion function in the generated code.
+func (mtyp *marshalerType) funcName(fn *types.Func) Expression {
+	if qual := mtyp.scope.qualify(fn.Pkg()); qual != "" {
+		return Dotted{Receiver: Name(qual), Name: fn.Name()}
+	}
+	return Name(fn.Name())
+}
+
+// marshalConv converts a field by its conversion function.
+func (m *marshalMethod) marshalConv(f *marshalerField, from, to Expression) []Statement {
+	call := CallFunction{Func: m.mtyp.funcName(f.conv.to), Params: []Expression{from}}
+	return []Statement{Assign{Lhs: to, Rhs: call}}
+}
+
+// unmarshalConv converts the decoded value of a field back by its conversion
+// function. The error of the function is returned.
+func (m *marshalMethod) unmarshalConv(f *marshalerField, from, to Expression) []Statement {
+	if isPointer(m.decodedType(f)) && !isPointer(f.typ) {
+		from = Star{Value: from}
+	}
+	call := CallFunction{Func: m.mtyp.funcName(f.conv.from), Params: []Expression{from}}
+	if !f.conv.fromErr {
+		return []Statement{Assign{Lhs: to, Rhs: call}}
+	}
+	v := Name(m.scope.newIdent("v"))
+	return append(m.parseChecked(v, call), Assign{Lhs: to, Rhs: v})
+}
@@ -0,0 +1,161 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"os"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// CoverageAnalyzer reports struct types of the module which are passed to
+// encoding/json but have no MarshalJSON or UnmarshalJSON method. These types are
+// candidates for generating the methods with gencodec.
+var CoverageAnalyzer = &analysis.Analyzer{
+	Name: "gencodeccoverage",
+	Doc:  "report struct types encoded by encoding/json without generated methods",
+	Run:  runCoverage,
+}
+
+// jsonCalls are the functions of encoding/json whose arguments are checked,
+// mapped to the index of the value argument and the method used for it.
+var jsonCalls = map[string]struct {
+	arg    int
+	method string
+}{
+	"encoding/json.Marshal":           {0, "MarshalJSON"},
+	"encoding/json.MarshalIndent":     {0, "MarshalJSON"},
+	"encoding/json.Unmarshal":         {1, "UnmarshalJSON"},
+	"(*encoding/json.Encoder).Encode": {0, "MarshalJSON"},
+	"(*encoding/json.Decoder).Decode": {0, "UnmarshalJSON"},
+}
+
+// Coverage runs CoverageAnalyzer on the packages matched by pattern, e.g. ./...
+// It returns the reported types, prefixed by the position of the call.
+func Coverage(pattern string) ([]string, error) {
+	pcfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedSyntax | packages.NeedTypes |
+			packages.NeedTypesInfo | packages.NeedTypesSizes | packages.NeedImports | packages.NeedDeps | packages.NeedModule,
+	}
+	ps, err := packages.Load(pcfg, pattern)
+	if err != nil {
+		return nil, err
+	}
+	var problems []string
+	for _, p := range ps {
+		if len(p.Errors) > 0 {
+			return nil, p.Errors[0]
+		}
+		pass := &analysis.Pass{
+			Analyzer:   CoverageAnalyzer,
+			Fset:       p.Fset,
+			Files:      p.Syntax,
+			Pkg:        p.Types,
+			TypesInfo:  p.TypesInfo,
+			TypesSizes: p.TypesSizes,
+			ResultOf:   map[*analysis.Analyzer]interface{}{},
+			ReadFile:   os.ReadFile,
+			Report: func(d analysis.Diagnostic) {
+				problems = append(problems, fmt.Sprintf("%v: %s", p.Fset.Position(d.Pos), d.Message))
+			},
+		}
+		if p.Module != nil {
+			pass.Module = &analysis.Module{Path: p.Module.Path, Version: p.Module.Version, GoVersion: p.Module.GoVersion}
+		}
+		if _, err := CoverageAnalyzer.Run(pass); err != nil {
+			return nil, err
+		}
+	}
+	return problems, nil
+}
+
+func runCoverage(pass *analysis.Pass) (interface{}, error) {
+	for _, file := range pass.Files {
+		if ast.IsGenerated(file) {
+			continue
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+			if !ok {
+				return true
+			}
+			c, ok := jsonCalls[fn.FullName()]
+			if !ok || c.arg >= len(call.Args) {
+				return true
+			}
+			named := encodedStruct(pass.TypesInfo.TypeOf(call.Args[c.arg]))
+			if named == nil || !inModule(pass, named.Obj().Pkg()) || hasCodecMethod(named, c.method) {
+				return true
+			}
+			pass.Reportf(call.Pos(), "%s is encoded by %s but has no %s method",
+				types.TypeString(named, types.RelativeTo(pass.Pkg)), jsonCallName(fn), c.method)
+			return true
+		})
+	}
+	return nil, nil
+}
+
+// jsonCallName returns the name of fn as written in calls, like json.Marshal or
+// json.Encoder.Encode.
+func jsonCallName(fn *types.Func) string {
+	name := fn.Pkg().Name() + "."
+	if recv := fn.Type().(*types.Signature).Recv(); recv != nil {
+		name += recv.Type().(*types.Pointer).Elem().(*types.Named).Obj().Name() + "."
+	}
+	return name + fn.Name()
+}
+
+// encodedStruct returns the named struct type whose values are encoded in values
+// of typ. Pointers and the elements of slices, arrays and maps are followed.
+func encodedStruct(typ types.Type) *types.Named {
+	for {
+		switch t := types.Unalias(typ).(type) {
+		case *types.Pointer:
+			typ = t.Elem()
+		case *types.Slice:
+			typ = t.Elem()
+		case *types.Array:
+			typ = t.Elem()
+		case *types.Map:
+			typ = t.Elem()
+		case *types.Named:
+			if _, ok := t.Underlying().(*types.Struct); !ok || t.Obj().Pkg() == nil {
+				return nil
+			}
+			return t
+		default:
+			return nil
+		}
+	}
+}
+
+// inModule reports whether pkg belongs to the module of the analyzed package.
+// Without module information, only the analyzed package itself is considered.
+func inModule(pass *analysis.Pass, pkg *types.Package) bool {
+	if pass.Module == nil || pass.Module.Path == "" {
+		return pkg == pass.Pkg
+	}
+	mod := pass.Module.Path
+	return pkg.Path() == mod || strings.HasPrefix(pkg.Path(), mod+"/")
+}
+
+// hasCodecMethod reports whether *named has the method, either generated or written
+// by hand. Types with hand-written methods already control their encoding and
+// aren't reported.
+func hasCodecMethod(named *types.Named, name string) bool {
+	obj, _, _ := types.LookupFieldOrMethod(types.NewPointer(named), true, named.Obj().Pkg(), name)
+	_, ok := obj.(*types.Func)
+	return ok
+}
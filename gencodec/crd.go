@@ -0,0 +1,332 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"bytes"
+	"fmt"
+	"go/types"
+	"io"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// crdSchema is an OpenAPI v3 schema which satisfies the structural schema rules
+// of Kubernetes CustomResourceDefinitions.
+type crdSchema struct {
+	Type, Format string
+	Properties   []crdProperty
+	Required     []string
+	Items        *crdSchema
+	Additional   *crdSchema
+	Extensions   []crdExtension
+}
+
+type crdProperty struct {
+	Name   string
+	Schema *crdSchema
+}
+
+// crdExtension is an x-kubernetes-* vendor extension of a schema.
+type crdExtension struct {
+	Name  string
+	Value string // YAML value
+	List  []string
+}
+
+// crdMarkers maps the options of the crd struct tag to vendor extensions.
+// Options with a value are written as name=value.
+var crdMarkers = map[string]string{
+	"listType":              "x-kubernetes-list-type",
+	"listMapKey":            "x-kubernetes-list-map-keys",
+	"mapType":               "x-kubernetes-map-type",
+	"preserveUnknownFields": "x-kubernetes-preserve-unknown-fields",
+	"intOrString":           "x-kubernetes-int-or-string",
+	"embeddedResource":      "x-kubernetes-embedded-resource",
+}
+
+var crdPlainKey = regexp.MustCompile(`^[A-Za-z_][-0-9A-Za-z_.]*$`)
+
+// genCRDSchema creates the structural schemas of the marshaling types.
+func genCRDSchema(mtyps []*marshalerType) ([]byte, error) {
+	w := new(bytes.Buffer)
+	fmt.Fprint(w, "# Code generated by github.com/fjl/gencodec. DO NOT EDIT.\n")
+	for _, mtyp := range mtyps {
+		if mtyp.orig.TypeParams().Len() > 0 {
+			return nil, fmt.Errorf("generic type %s has no CRD schema", mtyp.name)
+		}
+		schema, err := newCRDTypeSchema(mtyp)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(w, "---\n# %s\nopenAPIV3Schema:\n", mtyp.name)
+		schema.write(w, "  ")
+	}
+	return w.Bytes(), nil
+}
+
+// newCRDTypeSchema creates the object schema of mtyp. Like the GraphQL schema,
+// properties are the JSON keys and their types follow the intermediate type.
+func newCRDTypeSchema(mtyp *marshalerType) (*crdSchema, error) {
+	c := crdConverter{visiting: map[*types.TypeName]bool{mtyp.orig.Obj(): true}}
+	schema := &crdSchema{Type: "object"}
+	for _, f := range mtyp.Fields {
+		key, ok := f.key("json")
+		if !ok {
+			continue
+		}
+		prop, err := c.fieldSchema(f.typ, f.tag)
+		if err != nil {
+			return nil, fmt.Errorf("%v: field %s: %v", mtyp.fs.Position(f.pos), f.name, err)
+		}
+		schema.Properties = append(schema.Properties, crdProperty{key, prop})
+		if f.isRequired("json") {
+			schema.Required = append(schema.Required, key)
+		}
+	}
+	return schema, nil
+}
+
+// crdConverter creates the schemas of Go types. Recursive types are rejected
+// because structural schemas can't refer to other schemas.
+type crdConverter struct {
+	visiting map[*types.TypeName]bool
+}
+
+// fieldSchema creates the schema of a struct field and adds the extensions
+// given by its crd tag.
+func (c *crdConverter) fieldSchema(typ types.Type, tag string) (*crdSchema, error) {
+	schema, err := c.schema(typ)
+	if err != nil {
+		return nil, err
+	}
+	opts, ok := reflect.StructTag(tag).Lookup("crd")
+	if !ok {
+		return schema, nil
+	}
+	for _, opt := range strings.Split(opts, ",") {
+		name, value, hasValue := strings.Cut(strings.TrimSpace(opt), "=")
+		ext, ok := crdMarkers[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown crd option %q", name)
+		}
+		valid := !hasValue
+		switch name {
+		case "listType", "listMapKey":
+			if schema.Type != "array" {
+				return nil, fmt.Errorf("crd option %s requires a slice or array type", name)
+			}
+			valid = value == "atomic" || value == "set" || value == "map"
+			if name == "listMapKey" {
+				valid = value != ""
+			}
+		case "mapType":
+			if schema.Type != "object" {
+				return nil, fmt.Errorf("crd option %s requires a map or struct type", name)
+			}
+			valid = value == "atomic" || value == "granular"
+		case "intOrString":
+			schema.Type, schema.Format = "", ""
+		}
+		switch {
+		case !valid:
+			return nil, fmt.Errorf("invalid crd option %q", opt)
+		case name == "listMapKey":
+			schema.addListExtension(ext, value)
+		case hasValue:
+			schema.Extensions = append(schema.Extensions, crdExtension{Name: ext, Value: value})
+		default:
+			schema.Extensions = append(schema.Extensions, crdExtension{Name: ext, Value: "true"})
+		}
+	}
+	return schema, nil
+}
+
+func (s *crdSchema) addListExtension(name, value string) {
+	for i := range s.Extensions {
+		if s.Extensions[i].Name == name {
+			s.Extensions[i].List = append(s.Extensions[i].List, value)
+			return
+		}
+	}
+	s.Extensions = append(s.Extensions, crdExtension{Name: name, List: []string{value}})
+}
+
+// schema creates the schema of the JSON encoding of typ.
+func (c *crdConverter) schema(typ types.Type) (*crdSchema, error) {
+	if named, ok := typ.(*types.Named); ok {
+		obj := named.Obj()
+		switch {
+		case obj.Pkg() != nil && obj.Pkg().Path() == "time" && obj.Name() == "Time":
+			return &crdSchema{Type: "string", Format: "date-time"}, nil
+		case obj.Pkg() != nil && obj.Pkg().Path() == "encoding/json" && obj.Name() == "RawMessage":
+			return crdUnknownFields(), nil
+		}
+	}
+	if isTextMarshaler(typ) {
+		return &crdSchema{Type: "string"}, nil
+	}
+	switch u := typ.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case u.Info()&types.IsBoolean != 0:
+			return &crdSchema{Type: "boolean"}, nil
+		case u.Info()&types.IsString != 0:
+			return &crdSchema{Type: "string"}, nil
+		case u.Info()&types.IsInteger != 0:
+			s := &crdSchema{Type: "integer"}
+			switch bitSize(u) {
+			case 32:
+				s.Format = "int32"
+			case 64:
+				s.Format = "int64"
+			}
+			return s, nil
+		case u.Info()&types.IsFloat != 0:
+			return &crdSchema{Type: "number"}, nil
+		}
+	case *types.Pointer:
+		return c.schema(u.Elem())
+	case *types.Slice:
+		if isByte(u.Elem()) {
+			return &crdSchema{Type: "string", Format: "byte"}, nil
+		}
+		return c.arraySchema(u.Elem())
+	case *types.Array:
+		return c.arraySchema(u.Elem())
+	case *types.Map:
+		if !isString(u.Key()) && !isTextMarshaler(u.Key()) {
+			return nil, fmt.Errorf("map key type %s is not a string", u.Key())
+		}
+		elem, err := c.schema(u.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &crdSchema{Type: "object", Additional: elem}, nil
+	case *types.Interface:
+		return crdUnknownFields(), nil
+	case *types.Struct:
+		return c.structSchema(typ, u)
+	}
+	return nil, fmt.Errorf("type %s has no CRD schema", typ)
+}
+
+func (c *crdConverter) arraySchema(elem types.Type) (*crdSchema, error) {
+	items, err := c.schema(elem)
+	if err != nil {
+		return nil, err
+	}
+	return &crdSchema{Type: "array", Items: items}, nil
+}
+
+// crdUnknownFields is the schema of arbitrary JSON values.
+func crdUnknownFields() *crdSchema {
+	return &crdSchema{Extensions: []crdExtension{{Name: "x-kubernetes-preserve-unknown-fields", Value: "true"}}}
+}
+
+// structSchema creates the object schema of a nested struct type. Its fields
+// are encoded by encoding/json, embedded structs without a key are inlined.
+func (c *crdConverter) structSchema(typ types.Type, styp *types.Struct) (*crdSchema, error) {
+	if named, ok := typ.(*types.Named); ok {
+		if c.visiting[named.Obj()] {
+			return nil, fmt.Errorf("recursive type %s has no CRD schema", typ)
+		}
+		c.visiting[named.Obj()] = true
+		defer delete(c.visiting, named.Obj())
+	}
+	schema := &crdSchema{Type: "object"}
+	for i := 0; i < styp.NumFields(); i++ {
+		f, tag := styp.Field(i), styp.Tag(i)
+		key, opts, _ := strings.Cut(reflect.StructTag(tag).Get("json"), ",")
+		if key == "-" && opts == "" {
+			continue
+		}
+		if f.Embedded() && key == "" {
+			ftyp := f.Type()
+			if ptr, ok := ftyp.(*types.Pointer); ok {
+				ftyp = ptr.Elem()
+			}
+			if _, ok := ftyp.Underlying().(*types.Struct); ok {
+				inner, err := c.schema(ftyp)
+				if err != nil {
+					return nil, err
+				}
+				schema.Properties = append(schema.Properties, inner.Properties...)
+				schema.Required = append(schema.Required, inner.Required...)
+				continue
+			}
+		}
+		if !f.Exported() {
+			continue
+		}
+		if key == "" {
+			key = f.Name()
+		}
+		prop, err := c.fieldSchema(f.Type(), tag)
+		if err != nil {
+			return nil, fmt.Errorf("field %s.%s: %v", typ, f.Name(), err)
+		}
+		schema.Properties = append(schema.Properties, crdProperty{key, prop})
+		gencodecOpts := "," + reflect.StructTag(tag).Get("gencodec") + ","
+		if strings.Contains(gencodecOpts, ",required,") && !hasTagOption(opts, "omitempty") {
+			schema.Required = append(schema.Required, key)
+		}
+	}
+	return schema, nil
+}
+
+// write writes the schema as YAML with the given indentation.
+func (s *crdSchema) write(w io.Writer, indent string) {
+	if s.Type != "" {
+		fmt.Fprintf(w, "%stype: %s\n", indent, s.Type)
+	}
+	if s.Format != "" {
+		fmt.Fprintf(w, "%sformat: %s\n", indent, s.Format)
+	}
+	for _, ext := range s.Extensions {
+		if ext.List != nil {
+			fmt.Fprintf(w, "%s%s:\n", indent, ext.Name)
+			for _, v := range ext.List {
+				fmt.Fprintf(w, "%s- %s\n", indent, crdYAMLKey(v))
+			}
+		} else {
+			fmt.Fprintf(w, "%s%s: %s\n", indent, ext.Name, ext.Value)
+		}
+	}
+	if len(s.Required) > 0 {
+		fmt.Fprintf(w, "%srequired:\n", indent)
+		for _, key := range s.Required {
+			fmt.Fprintf(w, "%s- %s\n", indent, crdYAMLKey(key))
+		}
+	}
+	if s.Type == "object" && s.Properties != nil {
+		fmt.Fprintf(w, "%sproperties:\n", indent)
+		for _, p := range s.Properties {
+			fmt.Fprintf(w, "%s  %s:\n", indent, crdYAMLKey(p.Name))
+			p.Schema.write(w, indent+"    ")
+		}
+	}
+	if s.Items != nil {
+		fmt.Fprintf(w, "%sitems:\n", indent)
+		s.Items.write(w, indent+"  ")
+	}
+	if s.Additional != nil {
+		fmt.Fprintf(w, "%sadditionalProperties:\n", indent)
+		s.Additional.write(w, indent+"  ")
+	}
+}
+
+// crdYAMLKey quotes a key unless it is a plain YAML scalar.
+func crdYAMLKey(key string) string {
+	switch strings.ToLower(key) {
+	case "true", "false", "yes", "no", "on", "off", "null", "y", "n":
+		return fmt.Sprintf("%q", key)
+	}
+	if crdPlainKey.MatchString(key) {
+		return key
+	}
+	return fmt.Sprintf("%q", key)
+}
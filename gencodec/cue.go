@@ -0,0 +1,303 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"text/scanner"
+)
+
+// cueParser reads constraints from CUE definitions. It supports the subset of CUE
+// which has an equivalent in JSON Schema: definitions of structs whose fields are
+// constrained by types, bounds, regular expressions, literals and the length
+// functions of the strings and list packages. The result has the form of the
+// JSON Schema read by readSchema.
+//
+//	#Server: {
+//		host:  string & =~"^[a-z.]+$"
+//		port?: int & >0 & <65536
+//		mode:  "dev" | "prod"
+//		tags?: [...string] & list.MaxItems(10)
+//	}
+type cueParser struct {
+	s   scanner.Scanner
+	tok rune
+	err error
+}
+
+// cueTypes are the JSON Schema types of CUE type identifiers.
+var cueTypes = map[string]string{
+	"string": "string",
+	"bytes":  "string",
+	"bool":   "boolean",
+	"int":    "integer",
+	"uint":   "integer",
+	"float":  "number",
+	"number": "number",
+}
+
+// cueFuncs are the JSON Schema keywords of CUE builtin functions.
+var cueFuncs = map[string]string{
+	"strings.MinRunes": "minLength",
+	"strings.MaxRunes": "maxLength",
+	"list.MinItems":    "minItems",
+	"list.MaxItems":    "maxItems",
+}
+
+func parseCUE(file string, data []byte) (defs map[string]map[string]interface{}, err error) {
+	p := new(cueParser)
+	p.s.Init(bytes.NewReader(data))
+	p.s.Filename = file
+	p.s.Mode = scanner.ScanIdents | scanner.ScanInts | scanner.ScanFloats | scanner.ScanStrings | scanner.ScanRawStrings | scanner.ScanComments | scanner.SkipComments
+	p.s.Error = func(s *scanner.Scanner, msg string) { p.fail("%s", msg) }
+	defer func() {
+		if r := recover(); r != nil {
+			if r != p {
+				panic(r)
+			}
+			defs, err = nil, p.err
+		}
+	}()
+
+	p.next()
+	defs = make(map[string]map[string]interface{})
+	for p.tok != scanner.EOF {
+		switch {
+		case p.tok == scanner.Ident && (p.s.TokenText() == "package" || p.s.TokenText() == "import"):
+			p.skipLine()
+		case p.tok == '#':
+			p.next()
+			name := p.expectIdent()
+			p.expect(':')
+			defs[name] = p.parseStruct()
+		default:
+			p.fail("expected definition")
+		}
+	}
+	return defs, nil
+}
+
+func (p *cueParser) next() {
+	p.tok = p.s.Scan()
+}
+
+func (p *cueParser) fail(format string, args ...interface{}) {
+	if p.err == nil {
+		p.err = fmt.Errorf("%s: %s", p.s.Position, fmt.Sprintf(format, args...))
+	}
+	panic(p)
+}
+
+func (p *cueParser) expect(tok rune) {
+	if p.tok != tok {
+		p.fail("expected %s, found %q", scanner.TokenString(tok), p.s.TokenText())
+	}
+	p.next()
+}
+
+func (p *cueParser) expectIdent() string {
+	if p.tok != scanner.Ident {
+		p.fail("expected identifier, found %q", p.s.TokenText())
+	}
+	name := p.s.TokenText()
+	p.next()
+	return name
+}
+
+// skipLine skips package and import clauses.
+func (p *cueParser) skipLine() {
+	line := p.s.Position.Line
+	for p.tok != scanner.EOF && p.s.Position.Line == line {
+		if p.tok == '(' { // import block
+			for p.tok != ')' && p.tok != scanner.EOF {
+				p.next()
+			}
+		}
+		p.next()
+	}
+}
+
+// parseStruct parses the fields of a struct. Fields without ? are required.
+func (p *cueParser) parseStruct() map[string]interface{} {
+	var (
+		props    = make(map[string]interface{})
+		required []interface{}
+	)
+	p.expect('{')
+	for p.tok != '}' {
+		var name string
+		switch p.tok {
+		case scanner.Ident:
+			name = p.s.TokenText()
+		case scanner.String:
+			name, _ = strconv.Unquote(p.s.TokenText())
+		default:
+			p.fail("expected field name, found %q", p.s.TokenText())
+		}
+		p.next()
+		optional := p.tok == '?'
+		if optional || p.tok == '!' {
+			p.next()
+		}
+		p.expect(':')
+		if !optional {
+			required = append(required, name)
+		}
+		props[name] = p.parseConstraints()
+		if p.tok == ',' {
+			p.next()
+		}
+	}
+	p.next()
+	def := map[string]interface{}{"type": "object", "properties": props}
+	if len(required) > 0 {
+		def["required"] = required
+	}
+	return def
+}
+
+// parseConstraints parses a field value, which is a conjunction of constraints
+// or a disjunction of literals.
+func (p *cueParser) parseConstraints() map[string]interface{} {
+	schema := make(map[string]interface{})
+	var enum []interface{}
+	for {
+		if p.tok == '*' {
+			p.next() // default marker
+		}
+		if lit, ok := p.parseLiteral(); ok {
+			enum = append(enum, lit)
+		} else {
+			p.parseConstraint(schema)
+		}
+		switch p.tok {
+		case '&':
+			if enum != nil {
+				p.fail("literal in conjunction")
+			}
+			p.next()
+			continue
+		case '|':
+			if enum == nil {
+				p.fail("disjunctions are only supported for literals")
+			}
+			p.next()
+			continue
+		}
+		break
+	}
+	if enum != nil {
+		schema["enum"] = enum
+	}
+	return schema
+}
+
+// parseLiteral parses a string, number, boolean or null literal.
+func (p *cueParser) parseLiteral() (interface{}, bool) {
+	var v interface{}
+	switch p.tok {
+	case scanner.String, scanner.RawString:
+		s, err := strconv.Unquote(p.s.TokenText())
+		if err != nil {
+			p.fail("invalid string %s", p.s.TokenText())
+		}
+		v = s
+	case scanner.Int, scanner.Float:
+		v = json.Number(p.s.TokenText())
+	case '-':
+		p.next()
+		if p.tok != scanner.Int && p.tok != scanner.Float {
+			p.fail("expected number after -")
+		}
+		v = json.Number("-" + p.s.TokenText())
+	case scanner.Ident:
+		switch p.s.TokenText() {
+		case "true", "false":
+			v = p.s.TokenText() == "true"
+		case "null":
+			v = nil
+		default:
+			return nil, false
+		}
+	default:
+		return nil, false
+	}
+	p.next()
+	return v, true
+}
+
+func (p *cueParser) parseConstraint(schema map[string]interface{}) {
+	switch p.tok {
+	case scanner.Ident:
+		name := p.expectIdent()
+		if p.tok == '.' {
+			p.next()
+			name += "." + p.expectIdent()
+			keyword, ok := cueFuncs[name]
+			if !ok {
+				p.fail("unsupported function %s", name)
+			}
+			p.expect('(')
+			if p.tok != scanner.Int {
+				p.fail("expected integer argument of %s", name)
+			}
+			schema[keyword] = json.Number(p.s.TokenText())
+			p.next()
+			p.expect(')')
+			return
+		}
+		t, ok := cueTypes[name]
+		if !ok {
+			p.fail("unsupported type %s", name)
+		}
+		schema["type"] = t
+		if name == "uint" {
+			schema["minimum"] = json.Number("0")
+		}
+	case '[':
+		// Lists are written [...T]. The element type isn't checked.
+		p.next()
+		for i := 0; i < 3; i++ {
+			p.expect('.')
+		}
+		p.parseConstraints()
+		p.expect(']')
+		schema["type"] = "array"
+	case '>', '<':
+		op := p.tok
+		p.next()
+		keyword := map[rune]string{'>': "exclusiveMinimum", '<': "exclusiveMaximum"}[op]
+		if p.tok == '=' {
+			keyword = map[rune]string{'>': "minimum", '<': "maximum"}[op]
+			p.next()
+		}
+		lit, ok := p.parseLiteral()
+		if n, isNum := lit.(json.Number); !ok || !isNum {
+			p.fail("expected number after %c", op)
+		} else {
+			schema[keyword] = n
+		}
+	case '=':
+		p.next()
+		p.expect('~')
+		lit, ok := p.parseLiteral()
+		if s, isStr := lit.(string); !ok || !isStr {
+			p.fail("expected regular expression after =~")
+		} else {
+			schema["pattern"] = s
+		}
+	case '(':
+		p.next()
+		for k, v := range p.parseConstraints() {
+			schema[k] = v
+		}
+		p.expect(')')
+	default:
+		p.fail("unsupported constraint %q", p.s.TokenText())
+	}
+}
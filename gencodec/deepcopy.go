@@ -0,0 +1,200 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"bytes"
+	"fmt"
+	"go/token"
+	"go/types"
+	"io"
+	"strings"
+)
+
+// deepCopier generates the DeepCopyInto methods of the marshaling types. The code
+// follows the style of Kubernetes deepcopy-gen: after the shallow copy *out = *in,
+// shared pointers, slices and maps are replaced by copies.
+type deepCopier struct {
+	mtyp      *marshalerType
+	generated map[*types.TypeName]bool // types whose DeepCopyInto is generated
+	expanding map[*types.TypeName]bool // struct types being copied field by field
+	buf       bytes.Buffer
+}
+
+// genDeepCopy writes the DeepCopyInto and DeepCopy methods of all types.
+func genDeepCopy(w io.Writer, mtyps []*marshalerType) error {
+	generated := make(map[*types.TypeName]bool)
+	for _, mtyp := range mtyps {
+		generated[mtyp.orig.Obj()] = true
+	}
+	for i, mtyp := range mtyps {
+		c := &deepCopier{mtyp: mtyp, generated: generated, expanding: make(map[*types.TypeName]bool)}
+		if err := c.fixupFields(mtyp.orig.Underlying().(*types.Struct), "in", "out"); err != nil {
+			return fmt.Errorf("DeepCopyInto of %s: %v", mtyp.name, err)
+		}
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		typ := mtyp.recvType()
+		fmt.Fprintf(w, "// DeepCopyInto copies in into out. Pointers, slices and maps are copied recursively.\n")
+		fmt.Fprintf(w, "func (in *%s) DeepCopyInto(out *%s) {\n*out = *in\n%s}\n\n", typ, typ, c.buf.Bytes())
+		fmt.Fprintf(w, "// DeepCopy returns a deep copy of in.\n")
+		fmt.Fprintf(w, "func (in *%s) DeepCopy() *%s {\n", typ, typ)
+		fmt.Fprintf(w, "if in == nil {\nreturn nil\n}\nout := new(%s)\nin.DeepCopyInto(out)\nreturn out\n}\n", typ)
+	}
+	return nil
+}
+
+// fixupFields copies the fields of a struct which share memory after a shallow copy.
+func (c *deepCopier) fixupFields(styp *types.Struct, in, out string) error {
+	for i := 0; i < styp.NumFields(); i++ {
+		f := styp.Field(i)
+		if err := c.fixup(f.Type(), selector(in, f.Name()), selector(out, f.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fixup writes statements that turn out, a shallow copy of in, into a deep copy.
+// Both expressions must be addressable.
+func (c *deepCopier) fixup(typ types.Type, in, out string) error {
+	if !c.needsCopy(typ, nil) {
+		return nil
+	}
+	if c.hasDeepCopyInto(typ) {
+		c.printf("%s.DeepCopyInto(%s)\n", parens(in), address(out))
+		return nil
+	}
+	switch u := typ.Underlying().(type) {
+	case *types.Pointer:
+		c.printf("if %s != nil {\nin, out := %s, %s\n*out = new(%s)\n", in, address(in), address(out), c.typeString(u.Elem()))
+		if c.hasDeepCopyInto(u.Elem()) {
+			c.printf("(*in).DeepCopyInto(*out)\n")
+		} else {
+			c.printf("**out = **in\n")
+			if err := c.fixup(u.Elem(), "**in", "**out"); err != nil {
+				return err
+			}
+		}
+		c.printf("}\n")
+	case *types.Slice:
+		c.printf("if %s != nil {\nin, out := %s, %s\n", in, address(in), address(out))
+		c.printf("*out = make(%s, len(*in))\ncopy(*out, *in)\n", c.typeString(typ))
+		if c.needsCopy(u.Elem(), nil) {
+			c.printf("for i := range *in {\n")
+			if err := c.fixup(u.Elem(), "(*in)[i]", "(*out)[i]"); err != nil {
+				return err
+			}
+			c.printf("}\n")
+		}
+		c.printf("}\n")
+	case *types.Array:
+		c.printf("for i := range %s {\n", in)
+		if err := c.fixup(u.Elem(), parens(in)+"[i]", parens(out)+"[i]"); err != nil {
+			return err
+		}
+		c.printf("}\n")
+	case *types.Map:
+		c.printf("if %s != nil {\nin, out := %s, %s\n", in, address(in), address(out))
+		c.printf("*out = make(%s, len(*in))\nfor key, val := range *in {\n", c.typeString(typ))
+		if c.needsCopy(u.Elem(), nil) {
+			c.printf("outVal := val\n")
+			if err := c.fixup(u.Elem(), "val", "outVal"); err != nil {
+				return err
+			}
+			c.printf("(*out)[key] = outVal\n")
+		} else {
+			c.printf("(*out)[key] = val\n")
+		}
+		c.printf("}\n}\n")
+	case *types.Struct:
+		if named, ok := typ.(*types.Named); ok {
+			if c.expanding[named.Obj()] {
+				return fmt.Errorf("recursive type %s needs a DeepCopyInto method", typ)
+			}
+			c.expanding[named.Obj()] = true
+			defer delete(c.expanding, named.Obj())
+		}
+		return c.fixupFields(u, in, out)
+	}
+	return nil
+}
+
+// needsCopy reports whether values of typ share memory after assignment. Interfaces,
+// functions and channels are copied by assignment, and so are struct types of other
+// packages which have unexported fields and no DeepCopyInto method.
+func (c *deepCopier) needsCopy(typ types.Type, seen map[types.Type]bool) bool {
+	if c.hasDeepCopyInto(typ) {
+		return true
+	}
+	switch u := typ.Underlying().(type) {
+	case *types.Pointer, *types.Slice, *types.Map:
+		return true
+	case *types.Array:
+		return c.needsCopy(u.Elem(), seen)
+	case *types.Struct:
+		if seen[typ] {
+			return false
+		}
+		if seen == nil {
+			seen = make(map[types.Type]bool)
+		}
+		seen[typ] = true
+		for i := 0; i < u.NumFields(); i++ {
+			if f := u.Field(i); f.Pkg() != c.mtyp.orig.Obj().Pkg() && !f.Exported() {
+				return false
+			}
+		}
+		for i := 0; i < u.NumFields(); i++ {
+			if c.needsCopy(u.Field(i).Type(), seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasDeepCopyInto reports whether typ is a named type with a DeepCopyInto method.
+func (c *deepCopier) hasDeepCopyInto(typ types.Type) bool {
+	named, ok := typ.(*types.Named)
+	if !ok {
+		return false
+	}
+	if c.generated[named.Obj()] {
+		return true
+	}
+	ptr := types.NewPointer(typ)
+	arg := types.NewVar(token.NoPos, nil, "", ptr)
+	return hasMethod(ptr, "DeepCopyInto", types.NewSignature(nil, types.NewTuple(arg), nil, false))
+}
+
+func (c *deepCopier) typeString(typ types.Type) string {
+	return types.TypeString(typ, c.mtyp.scope.qualify)
+}
+
+func (c *deepCopier) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&c.buf, format, args...)
+}
+
+// parens wraps expressions starting with the dereference operator.
+func parens(x string) string {
+	if strings.HasPrefix(x, "*") {
+		return "(" + x + ")"
+	}
+	return x
+}
+
+func selector(x, name string) string {
+	return parens(x) + "." + name
+}
+
+// address returns an expression for the address of x.
+func address(x string) string {
+	if strings.HasPrefix(x, "*") {
+		return x[1:]
+	}
+	return "&" + x
+}
@@ -0,0 +1,51 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"fmt"
+	"go/types"
+	"io"
+	"text/template"
+)
+
+const k8sRuntimePath = "k8s.io/apimachinery/pkg/runtime"
+
+var deepCopyObjectTemplate = template.Must(template.New("deepcopyobject").Parse(`
+// DeepCopyObject returns a deep copy of in. It implements runtime.Object.
+func (in *{{.Type}}) DeepCopyObject() {{.Runtime}}.Object {
+	if in == nil {
+		return nil
+	}
+	out := new({{.Type}})
+	in.DeepCopyInto(out)
+	return out
+}
+`))
+
+type deepCopyObjectData struct {
+	Type string
+
+	// package names
+	Runtime string
+}
+
+// genDeepCopyObject writes the DeepCopyObject method of mtyp, which completes the
+// runtime.Object interface of Kubernetes API types. The other method of the interface,
+// GetObjectKind, is usually promoted from an embedded metav1.TypeMeta.
+func genDeepCopyObject(w io.Writer, mtyp *marshalerType) error {
+	if mtyp.orig.TypeParams().Len() > 0 {
+		return fmt.Errorf("generic type %s can't be a runtime.Object", mtyp.name)
+	}
+	obj, _, _ := types.LookupFieldOrMethod(types.NewPointer(mtyp.orig), true, mtyp.orig.Obj().Pkg(), "GetObjectKind")
+	if _, ok := obj.(*types.Func); !ok {
+		return fmt.Errorf("type %s has no GetObjectKind method, it should embed metav1.TypeMeta", mtyp.name)
+	}
+	data := deepCopyObjectData{
+		Type:    mtyp.name,
+		Runtime: mtyp.scope.packageName(k8sRuntimePath),
+	}
+	return mtyp.template(deepCopyObjectTemplate).Execute(w, data)
+}
@@ -0,0 +1,101 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"math"
+	"reflect"
+	"strconv"
+
+	. "github.com/garslo/gogen"
+)
+
+// loadDefaults parses the default struct tags of the fields. The default value
+// is assigned by the unmarshal methods when the field is absent from the input.
+func (mtyp *marshalerType) loadDefaults() error {
+	for _, f := range mtyp.Fields {
+		if !f.hasDefault() {
+			continue
+		}
+		pos := mtyp.fs.Position(f.pos)
+		if f.function != nil {
+			return fmt.Errorf("%v: function field %s can't have a default value", pos, f.name)
+		}
+		for _, format := range mtyp.formats {
+			if f.isRequired(format) {
+				return fmt.Errorf("%v: required field %s can't have a default value", pos, f.name)
+			}
+		}
+		v, err := defaultLiteral(f.origTyp, reflect.StructTag(f.tag).Get("default"))
+		if err != nil {
+			return fmt.Errorf("%v: invalid default value of field %s: %v", pos, f.name, err)
+		}
+		f.defValue = v
+	}
+	return nil
+}
+
+// hasDefault reports whether the field has a default struct tag.
+func (mf *marshalerField) hasDefault() bool {
+	_, ok := reflect.StructTag(mf.tag).Lookup("default")
+	return ok
+}
+
+// defaultLiteral returns the constant expression of a default value. Only
+// fields of string, numeric and boolean types can have a default value.
+func defaultLiteral(typ types.Type, val string) (Expression, error) {
+	basic, ok := typ.Underlying().(*types.Basic)
+	if !ok {
+		return nil, fmt.Errorf("type %s can't have a default value", typ)
+	}
+	info := basic.Info()
+	switch {
+	case info&types.IsString != 0:
+		return stringLit{val}, nil
+	case info&types.IsBoolean != 0:
+		b, err := strconv.ParseBool(val)
+		if err != nil {
+			return nil, err
+		}
+		return Name(strconv.FormatBool(b)), nil
+	case info&types.IsUnsigned != 0 && basic.Kind() != types.Uintptr:
+		n, err := strconv.ParseUint(val, 0, bitSize(basic))
+		if err != nil {
+			return nil, err
+		}
+		return basicLit{token.INT, strconv.FormatUint(n, 10)}, nil
+	case info&types.IsInteger != 0:
+		n, err := strconv.ParseInt(val, 0, bitSize(basic))
+		if err != nil {
+			return nil, err
+		}
+		return basicLit{token.INT, strconv.FormatInt(n, 10)}, nil
+	case info&types.IsFloat != 0:
+		n, err := strconv.ParseFloat(val, bitSize(basic))
+		if err != nil {
+			return nil, err
+		}
+		if math.IsInf(n, 0) || math.IsNaN(n) {
+			return nil, fmt.Errorf("%s is not a constant", val)
+		}
+		return basicLit{token.FLOAT, strconv.FormatFloat(n, 'g', -1, bitSize(basic))}, nil
+	default:
+		return nil, fmt.Errorf("type %s can't have a default value", typ)
+	}
+}
+
+// basicLit is a literal of a basic type.
+type basicLit struct {
+	Kind  token.Token
+	Value string
+}
+
+func (l basicLit) Expression() ast.Expr {
+	return &ast.BasicLit{Kind: l.Kind, Value: l.Value}
+}
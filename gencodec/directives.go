@@ -0,0 +1,229 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Directive is an invocation of gencodec found in the source code of a package.
+type Directive struct {
+	Dir  string   // package directory
+	Args []string // command line arguments
+	Pos  token.Position
+}
+
+// directivePrefix marks type declarations which are generated by the -all mode.
+// The rest of the comment holds additional command line flags.
+const directivePrefix = "//gencodec:generate"
+
+// goGenerateCommands are the commands of go:generate comments running gencodec.
+var goGenerateCommands = [][]string{
+	{"gencodec"},
+	{"go", "run", "github.com/fjl/gencodec"},
+}
+
+// ScanDirectives finds the invocations of gencodec in the packages matched by
+// pattern, which is a directory or a directory followed by /... to include all
+// packages below it. Directories named testdata or vendor, directories starting
+// with . or _ and nested modules are skipped.
+//
+// There are two kinds of directives. A //gencodec:generate comment on a type
+// declaration generates the type, writing the code to gen_<type>_json.go unless
+// the comment has the -out flag. A go:generate comment running gencodec yields
+// its arguments as they are.
+func ScanDirectives(pattern string) ([]Directive, error) {
+	root, recursive := strings.TrimSuffix(pattern, "/..."), strings.HasSuffix(pattern, "/...")
+	if root == "" {
+		root = "."
+	}
+	if !recursive {
+		return scanPackageDirectives(root)
+	}
+	var ds []Directive
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return err
+		}
+		if path != root {
+			name := d.Name()
+			if name == "testdata" || name == "vendor" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") {
+				return filepath.SkipDir
+			}
+			if _, err := os.Stat(filepath.Join(path, "go.mod")); err == nil {
+				return filepath.SkipDir
+			}
+		}
+		pkgds, err := scanPackageDirectives(path)
+		ds = append(ds, pkgds...)
+		return err
+	})
+	return ds, err
+}
+
+func scanPackageDirectives(dir string) ([]Directive, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	var (
+		fset = token.NewFileSet()
+		ds   []Directive
+	)
+	for _, file := range files {
+		f, err := parser.ParseFile(fset, file, nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		if ast.IsGenerated(f) {
+			continue
+		}
+		env := map[string]string{"GOFILE": filepath.Base(file), "GOPACKAGE": f.Name.Name}
+		for _, cg := range f.Comments {
+			for _, c := range cg.List {
+				args, err := goGenerateArgs(c.Text, env)
+				if err != nil {
+					return nil, fmt.Errorf("%v: %v", fset.Position(c.Pos()), err)
+				}
+				if args != nil {
+					ds = append(ds, Directive{Dir: dir, Args: args, Pos: fset.Position(c.Pos())})
+				}
+			}
+		}
+		for _, decl := range f.Decls {
+			gd, ok := decl.(*ast.GenDecl)
+			if !ok || gd.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gd.Specs {
+				ts := spec.(*ast.TypeSpec)
+				doc := ts.Doc
+				if doc == nil && len(gd.Specs) == 1 {
+					doc = gd.Doc
+				}
+				d, err := typeDirective(fset, doc, ts.Name.Name)
+				if err != nil {
+					return nil, err
+				}
+				if d != nil {
+					d.Dir = dir
+					ds = append(ds, *d)
+				}
+			}
+		}
+	}
+	return ds, nil
+}
+
+// goGenerateArgs returns the arguments of a go:generate comment if it runs gencodec.
+func goGenerateArgs(comment string, env map[string]string) ([]string, error) {
+	line, ok := strings.CutPrefix(comment, "//go:generate ")
+	if !ok {
+		return nil, nil
+	}
+	words, err := splitGenerateLine(line)
+	if err != nil {
+		return nil, err
+	}
+	for _, cmd := range goGenerateCommands {
+		if len(words) >= len(cmd) && slices.Equal(words[:len(cmd)], cmd) {
+			args := words[len(cmd):]
+			for i := range args {
+				args[i] = os.Expand(args[i], func(k string) string { return env[k] })
+			}
+			return args, nil
+		}
+	}
+	return nil, nil
+}
+
+// typeDirective returns the directive in the doc comment of a type.
+func typeDirective(fset *token.FileSet, doc *ast.CommentGroup, typeName string) (*Directive, error) {
+	if doc == nil {
+		return nil, nil
+	}
+	for _, c := range doc.List {
+		rest, ok := strings.CutPrefix(c.Text, directivePrefix)
+		if !ok || (rest != "" && !unicode.IsSpace(rune(rest[0]))) {
+			continue
+		}
+		flags, err := splitGenerateLine(rest)
+		if err != nil {
+			return nil, fmt.Errorf("%v: %v", fset.Position(c.Pos()), err)
+		}
+		if hasFlag(flags, "type") {
+			return nil, fmt.Errorf("%v: %s comment can't have the -type flag", fset.Position(c.Pos()), directivePrefix)
+		}
+		args := append([]string{"-type", typeName}, flags...)
+		if !hasFlag(flags, "out") {
+			args = append(args, "-out", "gen_"+strings.ToLower(typeName)+"_json.go")
+		}
+		return &Directive{Args: args, Pos: fset.Position(c.Pos())}, nil
+	}
+	return nil, nil
+}
+
+// splitGenerateLine splits the arguments of a go:generate line. Like the go command,
+// it accepts double-quoted strings as single arguments.
+func splitGenerateLine(line string) (words []string, err error) {
+	line = strings.TrimSpace(line)
+	for line != "" {
+		if line[0] == '"' {
+			end := 1
+			for ; end < len(line) && line[end] != '"'; end++ {
+				if line[end] == '\\' {
+					end++
+				}
+			}
+			if end >= len(line) {
+				return nil, errors.New("unterminated quoted string")
+			}
+			word, err := strconv.Unquote(line[:end+1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid quoted string %s", line[:end+1])
+			}
+			words = append(words, word)
+			line = line[end+1:]
+		} else {
+			end := strings.IndexFunc(line, unicode.IsSpace)
+			if end < 0 {
+				end = len(line)
+			}
+			words = append(words, line[:end])
+			line = line[end:]
+		}
+		line = strings.TrimLeftFunc(line, unicode.IsSpace)
+	}
+	return words, nil
+}
+
+func hasFlag(args []string, name string) bool {
+	for _, arg := range args {
+		if isFlag(arg, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// isFlag reports whether arg sets the flag with the given name.
+func isFlag(arg, name string) bool {
+	if !strings.HasPrefix(arg, "-") {
+		return false
+	}
+	arg = strings.TrimPrefix(arg[1:], "-")
+	return arg == name || strings.HasPrefix(arg, name+"=")
+}
@@ -0,0 +1,180 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// irDump is the model of the loaded types, written by TypeSet.IR.
+type irDump struct {
+	Comment string     `json:"$comment"` // marks the file as generated
+	Package string     `json:"package"`
+	Imports []irImport `json:"imports"`
+	Types   []irType   `json:"types"`
+}
+
+// irImport is an import of the generated file. Types in the dump are qualified
+// by the name of the import.
+type irImport struct {
+	Path string `json:"path"`
+	Name string `json:"name"`
+}
+
+type irType struct {
+	Name        string            `json:"name"`
+	Kind        string            `json:"kind"` // struct or enum
+	Override    string            `json:"override,omitempty"`
+	Strict      bool              `json:"strict,omitempty"`
+	Presence    bool              `json:"presence,omitempty"`
+	AllErrors   bool              `json:"allErrors,omitempty"`
+	TypedErrors bool              `json:"typedErrors,omitempty"`
+	Validate    string            `json:"validate,omitempty"` // validation method
+	YAMLNode    bool              `json:"yamlNode,omitempty"`
+	TOMLValue   bool              `json:"tomlValue,omitempty"`
+	Appender    bool              `json:"appender,omitempty"`
+	Unknown     string            `json:"unknown,omitempty"` // field of unknown keys
+	InterSuffix map[string]string `json:"intermediateSuffix,omitempty"`
+	Templates   []string          `json:"templates,omitempty"` // replaced built-in templates
+	Fields      []irField         `json:"fields,omitempty"`
+	Enum        []irEnumConst     `json:"enum,omitempty"`
+	// Formats holds the input of the method template of each format. The keys
+	// of its values are the names used in templates.
+	Formats map[string]methodTemplateData `json:"formats,omitempty"`
+}
+
+type irField struct {
+	Name     string   `json:"name"`
+	Path     []string `json:"path,omitempty"`   // selector path of promoted fields
+	Prefix   string   `json:"prefix,omitempty"` // key prefix of flattened fields
+	Type     string   `json:"type"`             // type in the intermediate types
+	OrigType string   `json:"origType"`
+	Tag      string   `json:"tag,omitempty"`
+	Kind     string   `json:"kind"` // how the field is converted
+	Function string   `json:"function,omitempty"`
+	Conv     []string `json:"conv,omitempty"` // functions of the conv option
+	Hook     []string `json:"hook,omitempty"` // functions of the hook option
+	Default  string   `json:"default,omitempty"`
+	Pos      string   `json:"pos,omitempty"`
+}
+
+type irEnumConst struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// IR returns the model of the types computed by the generator as JSON. It holds
+// the fields with their resolved types, tags and conversions, the imports of the
+// generated file, and the input of the method templates of each format. It is
+// meant for debugging templates and the generator.
+func (t *TypeSet) IR() ([]byte, error) {
+	dump := irDump{Comment: "Code generated by github.com/fjl/gencodec. DO NOT EDIT."}
+	for _, mtyp := range t.mtyps {
+		if dump.Package == "" {
+			dump.Package = mtyp.scope.pkg.Path()
+			for _, pkg := range mtyp.scope.imports {
+				dump.Imports = append(dump.Imports, irImport{pkg.Path(), mtyp.scope.importNames[pkg.Path()]})
+			}
+		}
+		dump.Types = append(dump.Types, mtyp.ir())
+	}
+	out, err := json.MarshalIndent(&dump, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(out, '\n'), nil
+}
+
+func (mtyp *marshalerType) ir() irType {
+	qf := mtyp.scope.qualify
+	it := irType{
+		Name:        mtyp.name,
+		Kind:        "struct",
+		Strict:      mtyp.strict,
+		Presence:    mtyp.presence,
+		AllErrors:   mtyp.allErrors,
+		TypedErrors: mtyp.typedErrors,
+		Validate:    mtyp.validate,
+		YAMLNode:    mtyp.yamlNode,
+		TOMLValue:   mtyp.tomlValue,
+		Appender:    mtyp.appender != nil,
+		InterSuffix: mtyp.interSuffix,
+	}
+	if mtyp.unknown != nil {
+		it.Unknown = mtyp.unknown.name
+	}
+	if mtyp.override != nil {
+		it.Override = typeString(mtyp.override, qf)
+	}
+	for name := range mtyp.templates {
+		it.Templates = append(it.Templates, name)
+	}
+	sort.Strings(it.Templates)
+	if mtyp.enum != nil {
+		it.Kind = "enum"
+		for _, c := range mtyp.enum {
+			it.Enum = append(it.Enum, irEnumConst{c.name, c.value.ExactString()})
+		}
+		return it
+	}
+
+	for _, f := range mtyp.Fields {
+		fi := irField{
+			Name:     f.name,
+			Prefix:   f.prefix,
+			Type:     typeString(f.typ, qf),
+			OrigType: typeString(f.origTyp, qf),
+			Tag:      f.tag,
+			Kind:     f.kind(),
+		}
+		if len(f.path) > 1 {
+			fi.Path = f.path
+		}
+		if f.function != nil {
+			fi.Function = f.function.Name()
+		}
+		if f.conv != nil {
+			fi.Conv = []string{printExpr(mtyp, mtyp.funcName(f.conv.to)), printExpr(mtyp, mtyp.funcName(f.conv.from))}
+		}
+		if f.hook != nil {
+			fi.Hook = []string{printExpr(mtyp, mtyp.funcName(f.hook.append)), printExpr(mtyp, mtyp.funcName(f.hook.parse))}
+		}
+		if f.defValue != nil {
+			fi.Default = printExpr(mtyp, f.defValue)
+		}
+		if f.pos.IsValid() {
+			fi.Pos = mtyp.fs.Position(f.pos).String()
+		}
+		it.Fields = append(it.Fields, fi)
+	}
+	it.Formats = make(map[string]methodTemplateData)
+	for _, format := range mtyp.formats {
+		it.Formats[format] = newMethodTemplateData(mtyp, format)
+	}
+	return it
+}
+
+// kind names the conversion of the field.
+func (mf *marshalerField) kind() string {
+	switch {
+	case mf.function != nil:
+		return "function"
+	case mf.money != nil:
+		return "money"
+	case mf.dynamic:
+		return "dynamic"
+	case mf.union != nil:
+		return "union"
+	case mf.hook != nil:
+		return "hook"
+	case mf.conv != nil:
+		return "conv"
+	case mf.wellKnown != nil:
+		return "wellknown"
+	default:
+		return "convert"
+	}
+}
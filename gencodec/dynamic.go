@@ -0,0 +1,239 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"io"
+	"text/template"
+
+	. "github.com/garslo/gogen"
+)
+
+// dynamicTemplate generates the codec lookup table of a type with dynamic fields,
+// and the type holding dynamic values in the intermediate type.
+var dynamicTemplate = template.Must(template.New("dynamic").Parse(`
+// {{.Codec}} is a codec registered by {{.Register}}.
+type {{.Codec}} struct {
+	name      string
+	marshal   func(any) ([]byte, error)
+	unmarshal func([]byte) (any, error)
+}
+
+// {{.Codecs}} is the lookup table of the codecs of dynamic fields of {{.Type}}.
+var {{.Codecs}} struct {
+	mu     {{.Sync}}.RWMutex
+	byType map[{{.Reflect}}.Type]*{{.Codec}}
+	byName map[string]*{{.Codec}}
+}
+
+// {{.Register}} registers the codec of values with the dynamic type of {{.V}}
+// in the dynamic fields of {{.Type}}. Such values are encoded as {"type": {{.Name}}, "value": ...},
+// where the value is created by {{.Marshal}} and decoded by {{.Unmarshal}}. {{.Register}} panics
+// if the type or the name already has a codec.
+func {{.Register}}({{.Name}} string, {{.V}} any, {{.Marshal}} func(any) ([]byte, error), {{.Unmarshal}} func([]byte) (any, error)) {
+	{{.Typ}} := {{.Reflect}}.TypeOf({{.V}})
+	{{.Codecs}}.mu.Lock()
+	defer {{.Codecs}}.mu.Unlock()
+	if _, {{.Ok}} := {{.Codecs}}.byType[{{.Typ}}]; {{.Ok}} {
+		panic({{.Fmt}}.Sprintf("duplicate codec for type %v in {{.Type}}", {{.Typ}}))
+	}
+	if _, {{.Ok}} := {{.Codecs}}.byName[{{.Name}}]; {{.Ok}} {
+		panic({{.Fmt}}.Sprintf("duplicate codec name %q in {{.Type}}", {{.Name}}))
+	}
+	if {{.Codecs}}.byType == nil {
+		{{.Codecs}}.byType = make(map[{{.Reflect}}.Type]*{{.Codec}})
+		{{.Codecs}}.byName = make(map[string]*{{.Codec}})
+	}
+	{{.C}} := &{{.Codec}}{{"{"}}{{.Name}}, {{.Marshal}}, {{.Unmarshal}}}
+	{{.Codecs}}.byType[{{.Typ}}] = {{.C}}
+	{{.Codecs}}.byName[{{.Name}}] = {{.C}}
+}
+
+// {{.Dynamic}} holds the value of a dynamic field of {{.Type}}.
+type {{.Dynamic}} struct{ v any }
+
+// {{.Enc}} is the encoding of {{.Dynamic}}.
+type {{.Enc}} struct {
+	Type  string          ` + "`json:\"type\"`" + `
+	Value {{.JSON}}.RawMessage ` + "`json:\"value\"`" + `
+}
+
+// MarshalJSON encodes the value by its registered codec.
+func ({{.D}} {{.Dynamic}}) MarshalJSON() ([]byte, error) {
+	{{.Typ}} := {{.Reflect}}.TypeOf({{.D}}.v)
+	{{.Codecs}}.mu.RLock()
+	{{.C}} := {{.Codecs}}.byType[{{.Typ}}]
+	{{.Codecs}}.mu.RUnlock()
+	if {{.C}} == nil {
+		return nil, {{.Fmt}}.Errorf("no codec for dynamic type %v in {{.Type}}", {{.Typ}})
+	}
+	{{.Value}}, {{.Err}} := {{.C}}.marshal({{.D}}.v)
+	if {{.Err}} != nil {
+		return nil, {{.Err}}
+	}
+	return {{.JSON}}.Marshal({{.Enc}}{ {{- .C}}.name, {{.Value -}} })
+}
+
+// UnmarshalJSON decodes the value by the codec registered for its type name.
+func ({{.D}} *{{.Dynamic}}) UnmarshalJSON({{.Input}} []byte) error {
+	var {{.Dec}} {{.Enc}}
+	if {{.Err}} := {{.JSON}}.Unmarshal({{.Input}}, &{{.Dec}}); {{.Err}} != nil {
+		return {{.Err}}
+	}
+	{{.Codecs}}.mu.RLock()
+	{{.C}} := {{.Codecs}}.byName[{{.Dec}}.Type]
+	{{.Codecs}}.mu.RUnlock()
+	if {{.C}} == nil {
+		return {{.Fmt}}.Errorf("no codec for dynamic type name %q in {{.Type}}", {{.Dec}}.Type)
+	}
+	{{.V}}, {{.Err}} := {{.C}}.unmarshal({{.Dec}}.Value)
+	if {{.Err}} != nil {
+		return {{.Err}}
+	}
+	{{.D}}.v = {{.V}}
+	return nil
+}
+`))
+
+// dynamicData is the input of dynamicTemplate.
+type dynamicData struct {
+	Type                                  string
+	Register, Codec, Codecs, Dynamic, Enc string
+
+	// package and variable names
+	Fmt, JSON, Reflect, Sync                string
+	Name, V, Marshal, Unmarshal, Typ, Ok, C string
+	D, Value, Err, Input, Dec               string
+}
+
+// loadDynamicFields prepares the fields with the gencodec:"dynamic" option. Their
+// values have an interface type and are encoded by codecs registered at runtime,
+// which are looked up by the dynamic type of the value.
+func (mtyp *marshalerType) loadDynamicFields() error {
+	var dynamic *types.Named
+	for _, f := range mtyp.Fields {
+		if f.function != nil || !f.hasOption("dynamic") {
+			continue
+		}
+		pos := mtyp.fs.Position(f.pos)
+		if !types.IsInterface(f.origTyp) {
+			return fmt.Errorf("%v: dynamic field %s must have interface type", pos, f.name)
+		}
+		for _, format := range mtyp.formats {
+			if format != "json" {
+				return fmt.Errorf("%v: dynamic field %s is not supported in format %s", pos, f.name, format)
+			}
+		}
+		if dynamic == nil {
+			name := types.NewTypeName(token.NoPos, mtyp.orig.Obj().Pkg(), dynamicTypeName(mtyp), nil)
+			dynamic = types.NewNamed(name, types.NewStruct(nil, nil), nil)
+			for _, path := range []string{"fmt", "reflect", "sync"} {
+				mtyp.scope.addImport(path)
+			}
+		}
+		f.typ = types.NewPointer(dynamic)
+		f.dynamic = true
+		f.wellKnown = nil
+	}
+	return nil
+}
+
+func dynamicTypeName(mtyp *marshalerType) string {
+	return uncapitalize(mtyp.name) + "Dynamic"
+}
+
+func (mtyp *marshalerType) hasDynamicFields() bool {
+	for _, f := range mtyp.Fields {
+		if f.dynamic {
+			return true
+		}
+	}
+	return false
+}
+
+// genDynamic writes the codec registry of the dynamic fields of mtyp.
+func genDynamic(w io.Writer, mtyp *marshalerType) error {
+	if !mtyp.hasDynamicFields() {
+		return nil
+	}
+	scope := newFuncScope(mtyp.scope)
+	data := dynamicData{
+		Type:      mtyp.name,
+		Register:  "Register" + mtyp.name + "Codec",
+		Codec:     uncapitalize(mtyp.name) + "Codec",
+		Codecs:    uncapitalize(mtyp.name) + "Codecs",
+		Dynamic:   dynamicTypeName(mtyp),
+		Enc:       dynamicTypeName(mtyp) + "Enc",
+		Fmt:       mtyp.scope.packageName("fmt"),
+		JSON:      mtyp.scope.packageName("encoding/json"),
+		Reflect:   mtyp.scope.packageName("reflect"),
+		Sync:      mtyp.scope.packageName("sync"),
+		Name:      scope.newIdent("name"),
+		V:         scope.newIdent("v"),
+		Marshal:   scope.newIdent("marshal"),
+		Unmarshal: scope.newIdent("unmarshal"),
+		Typ:       scope.newIdent("typ"),
+		Ok:        scope.newIdent("ok"),
+		C:         scope.newIdent("c"),
+		D:         scope.newIdent("d"),
+		Value:     scope.newIdent("value"),
+		Err:       scope.newIdent("err"),
+		Input:     scope.newIdent("input"),
+		Dec:       scope.newIdent("dec"),
+	}
+	if err := mtyp.template(dynamicTemplate).Execute(w, data); err != nil {
+		return err
+	}
+	fmt.Fprintln(w)
+	return nil
+}
+
+// dynamicJSONSchema returns the JSON Schema of dynamic values. The schema of the
+// value depends on the registered codec and is unknown.
+func dynamicJSONSchema() *jsonSchema {
+	return &jsonSchema{
+		Type:       "object",
+		Properties: jsonSchemaProps{{"type", &jsonSchema{Type: "string"}}, {"value", &jsonSchema{}}},
+		Required:   []string{"type", "value"},
+	}
+}
+
+// marshalDynamic stores the value of a dynamic field in the intermediate value.
+// Nil values are left out.
+func (m *marshalMethod) marshalDynamic(f *marshalerField, from, to Expression) []Statement {
+	wrap := Name(fmt.Sprintf("&%s{%s}", dynamicTypeName(m.mtyp), printExpr(m.mtyp, from)))
+	return []Statement{If{
+		Condition: NotEqual{Lhs: from, Rhs: NIL},
+		Body:      []Statement{Assign{Lhs: to, Rhs: wrap}},
+	}}
+}
+
+// unmarshalDynamic assigns the decoded value of a dynamic field, checking that it
+// implements the interface type of the field.
+func (m *marshalMethod) unmarshalDynamic(f *marshalerField, from, to Expression, format string) []Statement {
+	var (
+		v      = Name(m.scope.newIdent("v"))
+		ok     = Name(m.scope.newIdent("ok"))
+		fmtpkg = Name(m.scope.parent.packageName("fmt"))
+		iface  = types.TypeString(f.origTyp, m.mtyp.scope.qualify)
+		value  = Dotted{Receiver: from, Name: "v"}
+		errMsg = fmt.Sprintf("dynamic type %%T of field '%s' of %s does not implement %s", f.encodedName(format), m.mtyp.name, iface)
+	)
+	assert := Name(fmt.Sprintf("%s.(%s)", printExpr(m.mtyp, value), iface))
+	return []Statement{
+		declareMulti{Lhs: []Expression{v, ok}, Rhs: assert},
+		If{
+			Condition: Not{Value: ok},
+			Body: []Statement{Return{Values: []Expression{CallFunction{
+				Func:   Dotted{Receiver: fmtpkg, Name: "Errorf"},
+				Params: []Expression{stringLit{errMsg}, value},
+			}}}},
+		},
+		Assign{Lhs: to, Rhs: v},
+	}
+}
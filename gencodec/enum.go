@@ -0,0 +1,189 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"errors"
+	"fmt"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"io"
+	"sort"
+	"text/template"
+)
+
+// enumTemplate generates the methods of an integer type with a set of constants,
+// which encode the values as the names of the constants.
+var enumTemplate = template.Must(template.New("enum").Parse(`
+// {{.Names}} are the names of the values of {{.Type}}.
+var {{.Names}} = map[{{.Type}}]string{
+	{{- range .Consts}}{{if .Primary}}
+	{{.Name}}: {{printf "%q" .Name}},
+	{{- end}}{{end}}
+}
+
+// {{.Values}} are the values of {{.Type}} by name.
+var {{.Values}} = map[string]{{.Type}}{
+	{{- range .Consts}}
+	{{printf "%q" .Name}}: {{.Name}},
+	{{- end}}
+}
+
+// MarshalText encodes the name of the constant holding the value.
+func ({{.Recv}} {{.Type}}) MarshalText() ([]byte, error) {
+	{{.Name}}, {{.Ok}} := {{.Names}}[{{.Recv}}]
+	if !{{.Ok}} {
+		return nil, {{.Fmt}}.Errorf("invalid {{.Type}} value %d", {{.Int}}({{.Recv}}))
+	}
+	return []byte({{.Name}}), nil
+}
+
+// UnmarshalText decodes the name of a constant.
+func ({{.Recv}} *{{.Type}}) UnmarshalText({{.Input}} []byte) error {
+	{{.V}}, {{.Ok}} := {{.Values}}[string({{.Input}})]
+	if !{{.Ok}} {
+		return {{.Fmt}}.Errorf("unknown {{.Type}} name %q", {{.Input}})
+	}
+	*{{.Recv}} = {{.V}}
+	return nil
+}
+
+// MarshalJSON encodes the name of the constant holding the value as a JSON string.
+func ({{.Recv}} {{.Type}}) MarshalJSON() ([]byte, error) {
+	{{.Name}}, {{.Err}} := {{.Recv}}.MarshalText()
+	if {{.Err}} != nil {
+		return nil, {{.Err}}
+	}
+	return {{.JSON}}.Marshal(string({{.Name}}))
+}
+
+// UnmarshalJSON decodes a JSON string holding the name of a constant.
+func ({{.Recv}} *{{.Type}}) UnmarshalJSON({{.Input}} []byte) error {
+	var {{.Name}} string
+	if {{.Err}} := {{.JSON}}.Unmarshal({{.Input}}, &{{.Name}}); {{.Err}} != nil {
+		return {{.Err}}
+	}
+	return {{.Recv}}.UnmarshalText([]byte({{.Name}}))
+}
+`))
+
+// enumData is the input of enumTemplate.
+type enumData struct {
+	Type, Names, Values string
+	Int                 string // conversion of values in error messages
+	Consts              []enumConstData
+
+	// package and variable names
+	Fmt, JSON                     string
+	Recv, Name, Ok, Input, V, Err string
+}
+
+type enumConstData struct {
+	Name    string
+	Primary bool // first constant with the value, whose name is encoded
+}
+
+// enumConst is a constant of an enum type.
+type enumConst struct {
+	name  string
+	value constant.Value
+}
+
+// isEnumType reports whether the methods of typ are generated for its constants,
+// which is the case for named integer types.
+func isEnumType(typ *types.Named) bool {
+	basic, ok := typ.Underlying().(*types.Basic)
+	return ok && basic.Info()&types.IsInteger != 0
+}
+
+// newEnumType creates the marshaling type of an integer type. The constants of the
+// type are collected from the package scope, in the order of declaration.
+func newEnumType(fs *token.FileSet, scope *fileScope, typ *types.Named) (*marshalerType, error) {
+	if typ.TypeParams().Len() > 0 {
+		return nil, fmt.Errorf("enum type %s can't be generic", typ.Obj().Name())
+	}
+	mtyp := &marshalerType{name: typ.Obj().Name(), fs: fs, orig: typ, scope: scope}
+	pkgScope := typ.Obj().Pkg().Scope()
+	var consts []*types.Const
+	for _, name := range pkgScope.Names() {
+		c, ok := pkgScope.Lookup(name).(*types.Const)
+		if ok && c.Name() != "_" && types.Identical(c.Type(), typ) {
+			consts = append(consts, c)
+		}
+	}
+	if len(consts) == 0 {
+		return nil, fmt.Errorf("enum type %s has no constants", mtyp.name)
+	}
+	sort.Slice(consts, func(i, j int) bool { return consts[i].Pos() < consts[j].Pos() })
+	for _, c := range consts {
+		mtyp.enum = append(mtyp.enum, enumConst{c.Name(), c.Val()})
+	}
+	mtyp.scope.addImport("encoding/json")
+	mtyp.scope.addImport("fmt")
+	return mtyp, nil
+}
+
+// genEnum writes the methods of an enum type.
+func genEnum(w io.Writer, mtyp *marshalerType) error {
+	var (
+		scope = newFuncScope(mtyp.scope)
+		recv  = newMarshalMethod(mtyp, false).receiver().Name
+	)
+	scope.used[recv] = true
+	data := enumData{
+		Type:   mtyp.name,
+		Names:  uncapitalize(mtyp.name) + "Names",
+		Values: uncapitalize(mtyp.name) + "Values",
+		Int:    "int64",
+		Fmt:    mtyp.scope.packageName("fmt"),
+		JSON:   mtyp.scope.packageName("encoding/json"),
+		Recv:   recv,
+		Name:   scope.newIdent("name"),
+		Ok:     scope.newIdent("ok"),
+		Input:  scope.newIdent("input"),
+		V:      scope.newIdent("v"),
+		Err:    scope.newIdent("err"),
+	}
+	if basic := mtyp.orig.Underlying().(*types.Basic); basic.Info()&types.IsUnsigned != 0 {
+		data.Int = "uint64"
+	}
+	var seen []constant.Value
+	for _, c := range mtyp.enum {
+		primary := true
+		for _, v := range seen {
+			if constant.Compare(v, token.EQL, c.value) {
+				primary = false
+			}
+		}
+		seen = append(seen, c.value)
+		data.Consts = append(data.Consts, enumConstData{Name: c.name, Primary: primary})
+	}
+	return mtyp.template(enumTemplate).Execute(w, data)
+}
+
+// enumJSONSchema returns the JSON Schema of an enum type, whose values are
+// the names of its constants.
+func enumJSONSchema(mtyp *marshalerType) *jsonSchema {
+	schema := &jsonSchema{Type: "string"}
+	for _, c := range mtyp.enum {
+		schema.Enum = append(schema.Enum, c.name)
+	}
+	return schema
+}
+
+// structTypes returns the struct types of mtyps, leaving out enum types.
+func structTypes(mtyps []*marshalerType) ([]*marshalerType, error) {
+	var structs []*marshalerType
+	for _, mtyp := range mtyps {
+		if mtyp.enum == nil {
+			structs = append(structs, mtyp)
+		}
+	}
+	if len(structs) == 0 {
+		return nil, errors.New("no struct types")
+	}
+	return structs, nil
+}
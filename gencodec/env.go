@@ -0,0 +1,112 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"fmt"
+	"io"
+	"text/template"
+
+	. "github.com/garslo/gogen"
+)
+
+// The env format only generates UnmarshalEnv, which reads the fields with an env
+// struct tag from environment variables. Fields without a tag are not read.
+
+// envTemplate generates the UnmarshalEnv method. Each field is parsed by its own
+// function, which env calls if the variable is set.
+var envTemplate = template.Must(template.New("env").Parse(`
+// UnmarshalEnv sets the fields of {{.Recv}} with an env tag from the environment variables
+// returned by {{.Lookup}}, such as os.LookupEnv. Unset required variables are an error.
+// Fields of unset optional variables get their default value or are left unchanged.
+func ({{.Recv}} *{{.RecvType}}) UnmarshalEnv({{.Lookup}} func(string) (string, bool)) error {
+	{{.Env}} := func({{.Key}} string, {{.Parse}} func(string) error) (bool, error) {
+		{{.S}}, {{.Ok}} := {{.Lookup}}({{.Key}})
+		if !{{.Ok}} {
+			return false, nil
+		}
+		if {{.Err}} := {{.Parse}}({{.S}}); {{.Err}} != nil {
+			return true, {{.Fmt}}.Errorf("invalid environment variable %s for {{.Type}}: %v", {{.Key}}, {{.Err}})
+		}
+		return true, nil
+	}
+	{{- range .Fields}}
+	if {{if .Unset}}{{$.Ok}}{{else}}_{{end}}, {{$.Err}} := {{$.Env}}({{printf "%q" .Key}}, func({{$.S}} string) error {
+		{{.Parse}}
+		return nil
+	}); {{$.Err}} != nil {
+		return {{$.Err}}
+	}{{if .Unset}} else if !{{$.Ok}} {
+		{{.Unset}}
+	}{{end}}
+	{{- end}}
+	{{.Return}}
+}
+`))
+
+type envData struct {
+	Type, RecvType string
+	Fields         []envField
+	Return         string
+
+	// package and variable names
+	Fmt                    string
+	Recv, Lookup, Env, Key string
+	Parse, S, Ok, Err      string
+}
+
+type envField struct {
+	Key   string
+	Parse string // statements assigning the field from the variable
+	Unset string // statements run if the variable is unset, empty if none
+}
+
+// genUnmarshalEnv writes the UnmarshalEnv method of mtyp.
+func genUnmarshalEnv(w io.Writer, mtyp *marshalerType) error {
+	var (
+		m    = newMarshalMethod(mtyp, true)
+		recv = m.receiver().Name
+	)
+	data := envData{
+		Type:     mtyp.name,
+		RecvType: mtyp.recvType(),
+		Fmt:      mtyp.scope.packageName("fmt"),
+		Recv:     recv,
+		Lookup:   m.scope.newIdent("lookup"),
+		Env:      m.scope.newIdent("env"),
+		Key:      m.scope.newIdent("key"),
+		Parse:    m.scope.newIdent("parse"),
+		S:        m.scope.newIdent("s"),
+		Ok:       m.scope.newIdent("ok"),
+		Err:      m.scope.newIdent("err"),
+		Return:   printStatements(mtyp, []Statement{m.unmarshalReturn(Name(recv))}),
+	}
+	for _, f := range mtyp.Fields {
+		if f.function != nil || !f.hasKey("env") || !f.inFormat("env") {
+			continue
+		}
+		key, _ := f.key("env")
+		// Each field is parsed in its own function, which has a fresh scope.
+		fm := newMarshalMethod(mtyp, true)
+		fm.receiver()
+		fm.scope.used[data.S] = true
+		parse, err := parseString(fm, f.access(Name(recv)), f.origTyp, Name(data.S))
+		if err != nil {
+			return fmt.Errorf("%v: field %s: %v", mtyp.fs.Position(f.pos), f.name, err)
+		}
+		ef := envField{Key: key, Parse: printStatements(mtyp, parse)}
+		switch {
+		case f.isRequired("env"):
+			ef.Unset = printStatements(mtyp, []Statement{Return{Values: []Expression{m.missingFieldError(f, "env")}}})
+		case f.defValue != nil:
+			ef.Unset = printStatements(mtyp, []Statement{Assign{Lhs: f.access(Name(recv)), Rhs: f.defValue}})
+		}
+		data.Fields = append(data.Fields, ef)
+	}
+	if len(data.Fields) == 0 {
+		return fmt.Errorf("type %s has no fields with an env tag", mtyp.name)
+	}
+	return mtyp.template(envTemplate).Execute(w, data)
+}
@@ -0,0 +1,60 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"fmt"
+	"go/types"
+	"io"
+	"strconv"
+)
+
+// genFieldInfo writes the TFields function of mtyp, which describes the
+// marshaled fields. The element type is an alias of an unnamed struct type,
+// so the descriptions of all types can be handled by the same code.
+func genFieldInfo(w io.Writer, mtyp *marshalerType) error {
+	var (
+		info    = mtyp.name + "FieldInfo"
+		fn      = mtyp.name + "Fields"
+		qualify = func(pkg *types.Package) string {
+			if pkg == mtyp.scope.pkg {
+				return ""
+			}
+			return pkg.Name()
+		}
+	)
+	fmt.Fprintf(w, "// %s describes a marshaled field of %s.\n", info, mtyp.name)
+	fmt.Fprintf(w, "type %s = struct {\n", info)
+	fmt.Fprintln(w, "\tName     string // Go field name")
+	fmt.Fprintln(w, "\tKey      string // JSON key")
+	fmt.Fprintln(w, "\tType     string // Go type")
+	fmt.Fprintln(w, "\tRequired bool")
+	fmt.Fprintln(w, "\tTag      string")
+	fmt.Fprintln(w, "}")
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "// %s returns the marshaled fields of %s.\n", fn, mtyp.name)
+	fmt.Fprintf(w, "func %s() []%s {\n", fn, info)
+	fmt.Fprintf(w, "\treturn []%s{\n", info)
+	for _, f := range mtyp.Fields {
+		key, ok := f.key("json")
+		if !ok {
+			continue
+		}
+		fmt.Fprintf(w, "\t\t{Name: %q, Key: %q, Type: %q, Required: %t, Tag: %s},\n",
+			f.name, key, types.TypeString(f.origTyp, qualify), f.isRequired("json"), tagLiteral(f.tag))
+	}
+	fmt.Fprintln(w, "\t}")
+	fmt.Fprintln(w, "}")
+	return nil
+}
+
+// tagLiteral returns a Go string literal of a struct tag. Like in struct
+// declarations, raw string literals are used when possible.
+func tagLiteral(tag string) string {
+	if strconv.CanBackquote(tag) {
+		return "`" + tag + "`"
+	}
+	return strconv.Quote(tag)
+}
@@ -0,0 +1,24 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"fmt"
+	"io"
+)
+
+// genFieldNames writes constants holding the JSON keys of the fields of mtyp.
+// The constant of field F in type T is named TFieldF.
+func genFieldNames(w io.Writer, mtyp *marshalerType) error {
+	fmt.Fprintf(w, "// JSON keys of %s.\n", mtyp.name)
+	fmt.Fprintln(w, "const (")
+	for _, f := range mtyp.Fields {
+		if key, ok := f.key("json"); ok {
+			fmt.Fprintf(w, "\t%sField%s = %q\n", mtyp.name, f.name, key)
+		}
+	}
+	fmt.Fprintln(w, ")")
+	return nil
+}
@@ -0,0 +1,38 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"go/types"
+	"io"
+)
+
+// genFingerprint writes the schema fingerprint constant of mtyp. The fingerprint
+// is a hash of the marshaled fields, their types and requiredness. Types are
+// qualified by package path so that the hash doesn't depend on import names.
+func genFingerprint(w io.Writer, mtyp *marshalerType) error {
+	name := mtyp.name + "SchemaFingerprint"
+	fmt.Fprintf(w, "// %s identifies the schema of %s. It changes when fields\n", name, mtyp.name)
+	fmt.Fprintf(w, "// are added or removed, or when their type or requiredness changes.\n")
+	fmt.Fprintf(w, "const %s = %q\n", name, mtyp.fingerprint())
+	return nil
+}
+
+// fingerprint computes the schema fingerprint of mtyp.
+func (mtyp *marshalerType) fingerprint() string {
+	h := sha256.New()
+	qualify := func(pkg *types.Package) string { return pkg.Path() }
+	for _, f := range mtyp.Fields {
+		key, ok := f.key("json")
+		if !ok {
+			continue
+		}
+		fmt.Fprintf(h, "%s %q %s %t\n", f.name, key, types.TypeString(f.typ, qualify), f.isRequired("json"))
+	}
+	return hex.EncodeToString(h.Sum(nil)[:8])
+}
@@ -0,0 +1,114 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"reflect"
+
+	. "github.com/garslo/gogen"
+)
+
+// flagFuncs are the methods of flag.FlagSet defining flags of basic types.
+var flagFuncs = map[types.BasicKind]string{
+	types.String:  "StringVar",
+	types.Bool:    "BoolVar",
+	types.Int:     "IntVar",
+	types.Int64:   "Int64Var",
+	types.Uint:    "UintVar",
+	types.Uint64:  "Uint64Var",
+	types.Float64: "Float64Var",
+}
+
+// genRegisterFlags generates the RegisterFlags method, which defines a flag for each
+// field with a "flag" struct tag. The usage message is taken from the "usage" tag.
+func genRegisterFlags(mtyp *marshalerType) (Function, error) {
+	var (
+		m    = newMarshalMethod(mtyp, true)
+		recv = m.receiver()
+		fs   = Name(m.scope.newIdent("fs"))
+		flag = m.scope.parent.packageName("flag")
+	)
+	fn := Function{
+		Receiver:   recv,
+		Name:       "RegisterFlags",
+		Parameters: Types{{Name: fs.Name, TypeName: "*" + flag + ".FlagSet"}},
+	}
+	fields := mtyp.taggedFields("flag")
+	if len(fields) == 0 {
+		return fn, fmt.Errorf("type %s has no fields with a flag tag", mtyp.name)
+	}
+	for _, f := range fields {
+		var (
+			tag   = reflect.StructTag(f.tag)
+			name  = stringLit{tag.Get("flag")}
+			usage = stringLit{tag.Get("usage")}
+			value = f.access(Name(recv.Name))
+		)
+		// The flag starts with the default value of the field, or its current value.
+		var def Expression = value
+		if f.defValue != nil {
+			def = f.defValue
+		}
+		define := func(method string, params ...Expression) Statement {
+			return CallFunction{Func: Dotted{Receiver: fs, Name: method}, Params: params}
+		}
+		basic, _ := f.origTyp.Underlying().(*types.Basic)
+		switch {
+		case isDuration(f.origTyp):
+			fn.Body = append(fn.Body, define("DurationVar", AddressOf{Value: value}, name, def, usage))
+		case basic != nil && flagFuncs[basic.Kind()] != "":
+			var ptr Expression = AddressOf{Value: value}
+			if !types.Identical(f.origTyp, basic) {
+				conv := &ast.ParenExpr{X: &ast.StarExpr{X: ast.NewIdent(basic.Name())}}
+				ptr = Thunk{Expr: &ast.CallExpr{Fun: conv, Args: []ast.Expr{ptr.Expression()}}}
+				if f.defValue == nil {
+					def = CallFunction{Func: Name(basic.Name()), Params: []Expression{value}}
+				}
+			}
+			fn.Body = append(fn.Body, define(flagFuncs[basic.Kind()], ptr, name, def, usage))
+		case isTextMarshaler(f.origTyp) && !isPointer(f.origTyp):
+			fn.Body = append(fn.Body, define("TextVar", AddressOf{Value: value}, name, value, usage))
+		default:
+			// Other types are parsed by a function, and don't show a default value.
+			fm := newMarshalMethod(mtyp, true)
+			fm.receiver()
+			s := Name(fm.scope.newIdent("s"))
+			parse, err := parseString(fm, value, f.origTyp, s)
+			if err != nil {
+				return fn, fmt.Errorf("%v: field %s: %v", mtyp.fs.Position(f.pos), f.name, err)
+			}
+			if f.defValue != nil {
+				fn.Body = append(fn.Body, Assign{Lhs: value, Rhs: f.defValue})
+			}
+			parse = append(parse, Return{Values: []Expression{NIL}})
+			fn.Body = append(fn.Body, define("Func", name, usage, parseFunc(s, parse)))
+		}
+	}
+	return fn, nil
+}
+
+// isDuration reports whether typ is time.Duration.
+func isDuration(typ types.Type) bool {
+	named, ok := typ.(*types.Named)
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == "time" && named.Obj().Name() == "Duration"
+}
+
+// parseFunc is a function literal parsing the string s.
+func parseFunc(s Var, body []Statement) Expression {
+	lit := &ast.FuncLit{
+		Type: &ast.FuncType{
+			Params:  &ast.FieldList{List: []*ast.Field{{Names: []*ast.Ident{ast.NewIdent(s.Name)}, Type: ast.NewIdent("string")}}},
+			Results: &ast.FieldList{List: []*ast.Field{{Type: ast.NewIdent("error")}}},
+		},
+		Body: &ast.BlockStmt{},
+	}
+	for _, st := range body {
+		lit.Body.List = append(lit.Body.List, st.Statement())
+	}
+	return Thunk{Expr: lit}
+}
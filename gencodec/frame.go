@@ -0,0 +1,138 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"fmt"
+	"io"
+	"text/template"
+)
+
+// frameTemplate generates WriteFrame and ReadFrame, which add a length prefix to the
+// encoding of a type. The prefix is a uvarint, or a big-endian uint32 if Fixed is set.
+var frameTemplate = template.Must(template.New("frame").Parse(`
+// {{.MaxSize}} is the largest {{.Format}} encoding of {{.Type}} accepted by ReadFrame.
+var {{.MaxSize}} uint64 = 16 << 20
+
+// WriteFrame writes the {{.Format}} encoding of {{.Recv}} to {{.W}}, preceded by its length as
+{{- if .Fixed}} a big-endian uint32{{else}} a uvarint{{end}}.
+// The frame is written by a single call of Write.
+func ({{.Recv}} *{{.RecvType}}) WriteFrame({{.W}} {{.IO}}.Writer) error {
+	{{.Data}}, {{.Err}} := {{.Recv}}.Marshal{{.Format}}()
+	if {{.Err}} != nil {
+		return {{.Err}}
+	}
+	{{- if .Fixed}}
+	if uint64(len({{.Data}})) > {{.MaxSize}} || uint64(len({{.Data}})) > {{.Math}}.MaxUint32 {
+		return {{.Fmt}}.Errorf("{{.Type}} frame of %d bytes exceeds maximum size", len({{.Data}}))
+	}
+	{{.Frame}} := make([]byte, 4, 4+len({{.Data}}))
+	{{.Binary}}.BigEndian.PutUint32({{.Frame}}, uint32(len({{.Data}})))
+	{{- else}}
+	if uint64(len({{.Data}})) > {{.MaxSize}} {
+		return {{.Fmt}}.Errorf("{{.Type}} frame of %d bytes exceeds maximum size", len({{.Data}}))
+	}
+	{{.Frame}} := {{.Binary}}.AppendUvarint(make([]byte, 0, {{.Binary}}.MaxVarintLen64+len({{.Data}})), uint64(len({{.Data}})))
+	{{- end}}
+	_, {{.Err}} = {{.W}}.Write(append({{.Frame}}, {{.Data}}...))
+	return {{.Err}}
+}
+
+// ReadFrame reads a frame written by WriteFrame from {{.R}} and decodes it into {{.Recv}}.
+// It reads no further than the end of the frame. At the end of the input, it returns
+// io.EOF if no byte of the frame was read, and io.ErrUnexpectedEOF otherwise.
+func ({{.Recv}} *{{.RecvType}}) ReadFrame({{.R}} {{.IO}}.Reader) error {
+	{{- if .Fixed}}
+	var {{.Prefix}} [4]byte
+	if _, {{.Err}} := {{.IO}}.ReadFull({{.R}}, {{.Prefix}}[:]); {{.Err}} != nil {
+		return {{.Err}}
+	}
+	{{.Size}} := uint64({{.Binary}}.BigEndian.Uint32({{.Prefix}}[:]))
+	{{- else}}
+	var (
+		{{.Prefix}} [1]byte
+		{{.Size}}   uint64
+	)
+	for {{.I}} := 0; ; {{.I}}++ {
+		if _, {{.Err}} := {{.IO}}.ReadFull({{.R}}, {{.Prefix}}[:]); {{.Err}} != nil {
+			if {{.I}} > 0 && {{.Err}} == {{.IO}}.EOF {
+				return {{.IO}}.ErrUnexpectedEOF
+			}
+			return {{.Err}}
+		}
+		if {{.I}} == {{.Binary}}.MaxVarintLen64-1 && {{.Prefix}}[0] > 1 {
+			return {{.Fmt}}.Errorf("{{.Type}} frame length overflows uint64")
+		}
+		{{.Size}} |= uint64({{.Prefix}}[0]&0x7f) << (7 * {{.I}})
+		if {{.Prefix}}[0] < 0x80 {
+			break
+		}
+	}
+	{{- end}}
+	if {{.Size}} > {{.MaxSize}} {
+		return {{.Fmt}}.Errorf("{{.Type}} frame of %d bytes exceeds maximum size", {{.Size}})
+	}
+	{{.Data}} := make([]byte, {{.Size}})
+	if _, {{.Err}} := {{.IO}}.ReadFull({{.R}}, {{.Data}}); {{.Err}} != nil {
+		if {{.Err}} == {{.IO}}.EOF {
+			return {{.IO}}.ErrUnexpectedEOF
+		}
+		return {{.Err}}
+	}
+	return {{.Recv}}.Unmarshal{{.Format}}({{.Data}})
+}
+`))
+
+type frameData struct {
+	Type, RecvType, MaxSize string
+	Format                  string // method name suffix, e.g. JSON
+	Fixed                   bool   // the prefix is a uint32 instead of a uvarint
+
+	// package and variable names
+	Binary, Fmt, IO, Math        string
+	Recv, W, R, Data, Err, Frame string
+	Prefix, Size, I              string
+}
+
+// genFrame returns the generator of the framing methods of a type. The length
+// prefix is a uvarint, or a big-endian uint32 if fixed is set.
+func genFrame(fixed bool) func(w io.Writer, mtyp *marshalerType) error {
+	return func(w io.Writer, mtyp *marshalerType) error {
+		var format string
+		for _, f := range kafkaFormats {
+			if mtyp.hasFormat(f) {
+				format = f
+				break
+			}
+		}
+		if format == "" {
+			return fmt.Errorf("framing requires one of the formats %v", kafkaFormats)
+		}
+		m := newMarshalMethod(mtyp, true)
+		data := frameData{
+			Type:     mtyp.name,
+			RecvType: mtyp.recvType(),
+			MaxSize:  mtyp.name + "MaxFrameSize",
+			Format:   formatNames[format],
+			Fixed:    fixed,
+			Binary:   mtyp.scope.packageName("encoding/binary"),
+			Fmt:      mtyp.scope.packageName("fmt"),
+			IO:       mtyp.scope.packageName("io"),
+			Recv:     m.receiver().Name,
+			W:        m.scope.newIdent("w"),
+			R:        m.scope.newIdent("r"),
+			Data:     m.scope.newIdent("data"),
+			Err:      m.scope.newIdent("err"),
+			Frame:    m.scope.newIdent("frame"),
+			Prefix:   m.scope.newIdent("prefix"),
+			Size:     m.scope.newIdent("size"),
+			I:        m.scope.newIdent("i"),
+		}
+		if fixed {
+			data.Math = mtyp.scope.packageName("math")
+		}
+		return mtyp.template(frameTemplate).Execute(w, data)
+	}
+}
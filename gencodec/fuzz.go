@@ -0,0 +1,393 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/types"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+
+	. "github.com/garslo/gogen"
+)
+
+// fuzzFormats are the formats which have fuzz targets, with the
+// packages providing Marshal and Unmarshal functions for them.
+var fuzzFormats = []struct{ format, path string }{
+	{"json", "encoding/json"},
+	{"yaml", "gopkg.in/yaml.v3"},
+}
+
+// fuzzFileTemplate generates fuzz targets of the unmarshal methods. The seed corpus
+// holds the encoding of a sample value, which is built like in the round-trip tests.
+var fuzzFileTemplate = template.Must(template.New("fuzz").Parse(`{{range $typ := .Types}}{{range .Formats}}
+// Fuzz{{$typ.Name}}Unmarshal{{.Name}} checks that decoding arbitrary {{.Name}} input as {{$typ.Type}}
+// doesn't panic, and that decoded values can be encoded and decoded again.
+func Fuzz{{$typ.Name}}Unmarshal{{.Name}}(f *{{$.Testing}}.F) {
+	var {{$typ.V}} {{$typ.Type}}
+	{{- range $typ.Sample}}
+	{{.}}
+	{{- end}}
+	seed, err := {{.Pkg}}.Marshal(&{{$typ.V}})
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add(seed)
+	f.Add([]byte("{}"))
+	f.Fuzz(func(t *{{$.Testing}}.T, input []byte) {
+		var dec {{$typ.Type}}
+		if err := {{.Pkg}}.Unmarshal(input, &dec); err != nil {
+			return
+		}
+		enc, err := {{.Pkg}}.Marshal(&dec)
+		if err != nil {
+			t.Fatalf("can't encode decoded value %+v: %v", dec, err)
+		}
+		if err := {{.Pkg}}.Unmarshal(enc, new({{$typ.Type}})); err != nil {
+			t.Fatalf("can't decode %q: %v", enc, err)
+		}
+	})
+}
+{{end}}{{end}}`))
+
+type fuzzFileData struct {
+	Types   []fuzzTypeData
+	Testing string // package name
+}
+
+type fuzzTypeData struct {
+	Type, Name string
+	V          string   // variable holding the sample value
+	Sample     []string // statements setting the fields of the sample value
+	Formats    []fuzzFormat
+}
+
+type fuzzFormat struct {
+	Name string // format name used in the function name
+	Pkg  string // package providing Marshal and Unmarshal
+}
+
+// genFuzzTests returns a test file with fuzz targets of the unmarshal methods of mtyps.
+func genFuzzTests(mtyps []*marshalerType) ([]byte, error) {
+	scope := mtyps[0].scope
+	if err := scope.requireImport("testing"); err != nil {
+		return nil, err
+	}
+	data := fuzzFileData{Testing: scope.packageName("testing")}
+	for _, mtyp := range mtyps {
+		if mtyp.orig.TypeParams().Len() > 0 {
+			return nil, fmt.Errorf("fuzz targets can't be generated for generic type %s", mtyp.name)
+		}
+		td := fuzzTypeData{Type: mtyp.name, Name: testName(mtyp), V: "v"}
+		for _, ff := range fuzzFormats {
+			if !mtyp.hasFormat(ff.format) {
+				continue
+			}
+			if err := scope.requireImport(ff.path); err != nil {
+				return nil, err
+			}
+			td.Formats = append(td.Formats, fuzzFormat{Name: formatNames[ff.format], Pkg: scope.packageName(ff.path)})
+		}
+		if len(td.Formats) == 0 {
+			return nil, fmt.Errorf("fuzz targets of %s require the json or yaml format", mtyp.name)
+		}
+		for i, f := range mtyp.Fields {
+			if f.function != nil {
+				continue
+			}
+			if sample := sampleStatements(mtyp, f.access(Name(td.V)), f.origTyp, i, f.isRequired("json")); sample != nil {
+				td.Sample = append(td.Sample, printStatements(mtyp, sample))
+			}
+		}
+		data.Types = append(data.Types, td)
+	}
+	return writeTestFile(mtyps, fuzzFileTemplate, data)
+}
+
+// fuzzCorpusHeader is the first line of the files of the seed corpus of a fuzz target.
+const fuzzCorpusHeader = "go test fuzz v1\n"
+
+// genFuzzCorpus creates the seed corpus of the fuzz targets of mtyps. The files are keyed
+// by their path in testdata/fuzz. Each target gets an object holding sample values of the
+// required fields, objects which set one field to a boundary value of its type, and an
+// object with all optional fields set to null. The files matching the glob pattern
+// fixtures are added to the targets of their format, which is chosen by the extension.
+func genFuzzCorpus(mtyps []*marshalerType, fixtures string) (map[string][]byte, error) {
+	fixtureFiles, err := readFuzzFixtures(fixtures)
+	if err != nil {
+		return nil, err
+	}
+	corpus := make(map[string][]byte)
+	for _, mtyp := range mtyps {
+		if mtyp.enum != nil {
+			continue
+		}
+		c := newJSONSchemaConverter(mtyps, "")
+		var targets int
+		for _, ff := range fuzzFormats {
+			if !mtyp.hasFormat(ff.format) {
+				continue
+			}
+			targets++
+			dir := "Fuzz" + testName(mtyp) + "Unmarshal" + formatNames[ff.format] + "/"
+			seeds, err := fuzzBoundarySeeds(c, mtyp, ff.format)
+			if err != nil {
+				return nil, err
+			}
+			for _, seed := range seeds {
+				corpus[dir+seed.name] = fuzzCorpusFile(seed.input)
+			}
+			for _, file := range fixtureFiles[ff.format] {
+				corpus[dir+"fixture-"+file.name] = fuzzCorpusFile(file.input)
+			}
+		}
+		if targets == 0 {
+			return nil, fmt.Errorf("fuzz targets of %s require the json or yaml format", mtyp.name)
+		}
+	}
+	return corpus, nil
+}
+
+type fuzzSeed struct {
+	name  string
+	input []byte
+}
+
+// readFuzzFixtures reads the files matching pattern by format. Files ending in .json
+// are JSON, files ending in .yaml or .yml are YAML. Other files are ignored.
+func readFuzzFixtures(pattern string) (map[string][]fuzzSeed, error) {
+	if pattern == "" {
+		return nil, nil
+	}
+	files, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no fuzz fixtures match %s", pattern)
+	}
+	fixtures := make(map[string][]fuzzSeed)
+	for _, file := range files {
+		var format string
+		switch filepath.Ext(file) {
+		case ".json":
+			format = "json"
+		case ".yaml", ".yml":
+			format = "yaml"
+		default:
+			continue
+		}
+		input, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		fixtures[format] = append(fixtures[format], fuzzSeed{filepath.Base(file), input})
+	}
+	return fixtures, nil
+}
+
+// fuzzCorpusFile returns the content of a corpus file holding input. The value is
+// preceded by a comment marking the file as generated, which the go command skips
+// when it parses the value.
+func fuzzCorpusFile(input []byte) []byte {
+	return []byte(fuzzCorpusHeader + "/* Code generated by github.com/fjl/gencodec. DO NOT EDIT. */ []byte(" + strconv.Quote(string(input)) + ")\n")
+}
+
+// fuzzBoundarySeeds returns the objects of the boundary values of the fields of mtyp.
+// The objects are written in JSON syntax, which is also valid YAML.
+func fuzzBoundarySeeds(c *jsonSchemaConverter, mtyp *marshalerType, format string) ([]fuzzSeed, error) {
+	type fuzzField struct {
+		key      string
+		sample   string
+		bounds   []fuzzSeed // boundary values named by their kind
+		required bool
+	}
+	var fields []fuzzField
+	for _, f := range mtyp.Fields {
+		key, ok := f.key(format)
+		if !ok {
+			continue
+		}
+		typ := f.typ
+		if f.wellKnown != nil {
+			typ = f.wellKnown.repr(mtyp.scope)
+		}
+		var (
+			schema *jsonSchema
+			err    error
+		)
+		switch {
+		case f.dynamic:
+			schema = dynamicJSONSchema()
+		case f.union != nil:
+			schema, err = c.unionJSONSchema(f.union)
+		default:
+			schema, err = c.schema(typ)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%v: field %s: %v", mtyp.fs.Position(f.pos), f.name, err)
+		}
+		ff := fuzzField{key: key, sample: fuzzSample(schema), required: f.isRequired(format)}
+		if f.wellKnown == nil && !f.dynamic && f.union == nil {
+			ff.bounds = fuzzBounds(typ)
+		}
+		if !ff.required {
+			ff.bounds = append(ff.bounds, fuzzSeed{"null", []byte("null")})
+		}
+		fields = append(fields, ff)
+	}
+
+	// object returns the object of the required fields, with the values in set.
+	object := func(set map[string]string) []byte {
+		var props []string
+		for _, f := range fields {
+			v, ok := set[f.key]
+			if !ok && !f.required {
+				continue
+			}
+			if !ok {
+				v = f.sample
+			}
+			key, _ := json.Marshal(f.key)
+			props = append(props, string(key)+":"+v)
+		}
+		return []byte("{" + strings.Join(props, ",") + "}")
+	}
+	seeds := []fuzzSeed{{"required", object(nil)}}
+	nulls := make(map[string]string)
+	for _, f := range fields {
+		for _, b := range f.bounds {
+			name := "field-" + fuzzSeedName(f.key) + "-" + b.name
+			seeds = append(seeds, fuzzSeed{name, object(map[string]string{f.key: string(b.input)})})
+		}
+		if !f.required {
+			nulls[f.key] = "null"
+		}
+	}
+	if len(nulls) > 0 {
+		seeds = append(seeds, fuzzSeed{"nulls", object(nulls)})
+	}
+	return seeds, nil
+}
+
+// fuzzBounds returns the boundary values of typ: the limits of integers and floats,
+// and empty strings, slices and maps.
+func fuzzBounds(typ types.Type) []fuzzSeed {
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+	if isTextMarshaler(typ) {
+		return nil
+	}
+	seed := func(name string, value any) fuzzSeed {
+		enc, _ := json.Marshal(value)
+		return fuzzSeed{name, enc}
+	}
+	switch u := typ.Underlying().(type) {
+	case *types.Basic:
+		switch u.Kind() {
+		case types.Int8:
+			return []fuzzSeed{seed("min", math.MinInt8), seed("max", math.MaxInt8)}
+		case types.Int16:
+			return []fuzzSeed{seed("min", math.MinInt16), seed("max", math.MaxInt16)}
+		case types.Int32:
+			return []fuzzSeed{seed("min", math.MinInt32), seed("max", math.MaxInt32)}
+		case types.Int, types.Int64:
+			return []fuzzSeed{seed("min", int64(math.MinInt64)), seed("max", int64(math.MaxInt64))}
+		case types.Uint8:
+			return []fuzzSeed{seed("min", 0), seed("max", math.MaxUint8)}
+		case types.Uint16:
+			return []fuzzSeed{seed("min", 0), seed("max", math.MaxUint16)}
+		case types.Uint32:
+			return []fuzzSeed{seed("min", 0), seed("max", math.MaxUint32)}
+		case types.Uint, types.Uint64, types.Uintptr:
+			return []fuzzSeed{seed("min", 0), seed("max", uint64(math.MaxUint64))}
+		case types.Float32:
+			return []fuzzSeed{seed("max", math.MaxFloat32), seed("smallest", math.SmallestNonzeroFloat32)}
+		case types.Float64:
+			return []fuzzSeed{seed("max", math.MaxFloat64), seed("smallest", math.SmallestNonzeroFloat64)}
+		case types.String:
+			return []fuzzSeed{seed("empty", "")}
+		}
+	case *types.Slice:
+		if isByte(u.Elem()) {
+			return []fuzzSeed{seed("empty", "")}
+		}
+		return []fuzzSeed{seed("empty", []any{})}
+	case *types.Map:
+		return []fuzzSeed{seed("empty", map[string]any{})}
+	}
+	return nil
+}
+
+// fuzzSample returns a sample value accepted by schema, in JSON syntax.
+func fuzzSample(s *jsonSchema) string {
+	switch {
+	case s.Ref != "":
+		return "{}"
+	case s.Const != nil:
+		enc, _ := json.Marshal(s.Const)
+		return string(enc)
+	case len(s.AnyOf) > 0:
+		return fuzzSample(s.AnyOf[0])
+	case len(s.AllOf) > 0:
+		return fuzzSample(s.AllOf[0])
+	}
+	t, _ := s.Type.(string)
+	if list, ok := s.Type.([]string); ok {
+		t = list[0]
+	}
+	switch t {
+	case "string":
+		switch {
+		case s.Format == "date-time":
+			return `"2006-01-02T15:04:05Z"`
+		case s.ContentEncoding != "":
+			return `""`
+		}
+		return `"a"`
+	case "integer":
+		return "1"
+	case "number":
+		return "1.5"
+	case "boolean":
+		return "true"
+	case "array":
+		var items []string
+		if s.MinItems != nil {
+			for i := 0; i < *s.MinItems; i++ {
+				items = append(items, fuzzSample(s.Items))
+			}
+		}
+		return "[" + strings.Join(items, ",") + "]"
+	case "object":
+		var props []string
+		for _, p := range s.Properties {
+			if slices.Contains(s.Required, p.Name) {
+				key, _ := json.Marshal(p.Name)
+				props = append(props, string(key)+":"+fuzzSample(p.Schema))
+			}
+		}
+		return "{" + strings.Join(props, ",") + "}"
+	}
+	return "null"
+}
+
+// fuzzSeedName returns key without the characters which can't be used in file names.
+func fuzzSeedName(key string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_' || r == '.' {
+			return r
+		}
+		return '_'
+	}, key)
+}
@@ -0,0 +1,1216 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+// Package gencodec generates marshaling methods for struct types. It implements
+// the gencodec command, see its documentation for the features of the generated code.
+package gencodec
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/importer"
+	"go/token"
+	"go/types"
+	"io"
+	"reflect"
+	"strings"
+	"text/template"
+
+	"github.com/garslo/gogen"
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/imports"
+)
+
+// AllFormats are the supported marshaling formats.
+var AllFormats = []string{"json", "yaml", "toml", "msgpack", "cbor", "xml", "bson", "env", "hcl"}
+
+// formatNames are the names of formats used in doc comments.
+var formatNames = map[string]string{
+	"json":    "JSON",
+	"yaml":    "YAML",
+	"toml":    "TOML",
+	"msgpack": "MessagePack",
+	"cbor":    "CBOR",
+	"xml":     "XML",
+	"bson":    "BSON",
+	"env":     "environment variables",
+	"hcl":     "HCL",
+}
+
+// formatImports are the packages needed by the generated methods of a format.
+var formatImports = map[string][]string{
+	"msgpack": {"github.com/vmihailenco/msgpack/v5"},
+	"cbor":    {"github.com/fxamacker/cbor/v2"},
+	"xml":     {"encoding/xml"},
+	"bson":    {"go.mongodb.org/mongo-driver/bson"},
+	"env":     {"fmt", "strconv"},
+	"hcl":     {hclPath, gohclPath},
+}
+
+// decodeOnlyFormats are the formats which only have an unmarshaling method.
+var decodeOnlyFormats = map[string]bool{"env": true, "hcl": true}
+
+// Config is the configuration of the generator.
+type Config struct {
+	Dir           string   // input package directory
+	Type          string   // types to generate methods for, separated by commas
+	FieldOverride string   // names of struct types for field overrides, one for each type
+	Formats       []string // defaults to just "json", supported: "json", "yaml", "toml"
+	Codec         bool     // generate codec object
+	Helpers       []string // additional methods to generate
+	OmitEmpty     bool     // add omitempty to the struct tags of optional fields
+	RequireAll    bool     // make fields required unless they have gencodec:"optional"
+	Strict        bool     // reject unknown keys when unmarshaling JSON, YAML and TOML
+	Presence      bool     // track present JSON keys with flags instead of pointer fields
+	KeepUnknown   bool     // keep unknown JSON and YAML keys in a field with gencodec:"unknown"
+	AllErrors     bool     // report all missing and invalid fields when unmarshaling
+	TypedErrors   bool     // return the error types of package codecerr
+	Validate      string   // method called after unmarshaling, defaults to ValidateAfterDecode
+	YAML          string   // API of UnmarshalYAML: "v2" (default) or "v3", which takes a *yaml.Node
+	TOML          string   // API of UnmarshalTOML: "naoina" (default) or "burntsushi"
+	Appender      bool     // generate MarshalJSONTo and use it in MarshalJSON
+	Unexported    bool     // marshal unexported fields of the types
+	InterSuffix   string   // suffix of the intermediate type names, or format=suffix list
+	Unions        string   // union interfaces as Iface=T1,T2, separated by semicolons
+	TemplateDir   string   // directory of templates replacing built-in methods
+	Schema        string   // JSON Schema or CUE file with constraints checked when unmarshaling
+	BuildTags     []string // build tags used when loading the package
+	Header        string   // template of the first line of the Go file, see HeaderData
+	Args          []string // command line arguments, used by the header template
+	VersionConst  bool     // declare a constant holding FormatVersion
+	Importer      types.Importer
+	FileSet       *token.FileSet
+
+	// Warnf receives warnings about the input, such as ignored fields. Nil discards them.
+	// It is not part of the configuration hashed by State.
+	Warnf func(format string, args ...interface{}) `json:"-"`
+}
+
+// warnf reports a warning to the Warnf function of the configuration.
+func (cfg *Config) warnf(format string, args ...interface{}) {
+	if cfg.Warnf != nil {
+		cfg.Warnf(format, args...)
+	}
+}
+
+// Generate loads the configured types and returns the Go code of their
+// marshaling methods.
+func Generate(cfg *Config) ([]byte, error) {
+	return cfg.process()
+}
+
+// TypeSet is a set of loaded marshaling types. Enum types only have Go code and
+// JSON Schemas, the other outputs are created for the struct types.
+type TypeSet struct {
+	cfg   *Config
+	mtyps []*marshalerType
+}
+
+// Load loads the package and the configured types. The result can be used
+// to create several outputs without loading the package again.
+func Load(cfg *Config) (*TypeSet, error) {
+	mtyps, err := cfg.loadMarshalerTypes()
+	if err != nil {
+		return nil, err
+	}
+	return &TypeSet{cfg, mtyps}, nil
+}
+
+// Code returns the Go code of the marshaling methods.
+func (t *TypeSet) Code() ([]byte, error) {
+	return t.cfg.generateCode(t.mtyps)
+}
+
+// GraphQLSchema returns the GraphQL schema of the types.
+func (t *TypeSet) GraphQLSchema() ([]byte, error) {
+	structs, err := structTypes(t.mtyps)
+	if err != nil {
+		return nil, err
+	}
+	return genGraphQLSchema(structs)
+}
+
+// Tests returns a Go test file checking the JSON methods of the types. The tests
+// encode a sample value and decode it again, remove required fields and check
+// for the missing field error, and remove optional fields and check that they get their
+// zero or default value. If fixtures is set, the fixtures in its subdirectory of
+// each type are decoded with each required key removed, which must fail with the
+// missing field error.
+func (t *TypeSet) Tests(fixtures string) ([]byte, error) {
+	structs, err := structTypes(t.mtyps)
+	if err != nil {
+		return nil, err
+	}
+	return genTests(structs, fixtures)
+}
+
+// FuzzTests returns a test file with fuzz targets of the JSON and YAML
+// unmarshal methods of the types.
+func (t *TypeSet) FuzzTests() ([]byte, error) {
+	structs, err := structTypes(t.mtyps)
+	if err != nil {
+		return nil, err
+	}
+	return genFuzzTests(structs)
+}
+
+// FuzzCorpus returns the seed corpus of the fuzz targets, with files keyed by their
+// path in testdata/fuzz. The corpus holds boundary values of the fields, and the files
+// matching the glob pattern fixtures, which are added to the targets of their format.
+func (t *TypeSet) FuzzCorpus(fixtures string) (map[string][]byte, error) {
+	return genFuzzCorpus(t.mtyps, fixtures)
+}
+
+// JSONSchema returns a JSON Schema of the JSON encoding of the types,
+// using the 2020-12 dialect.
+func (t *TypeSet) JSONSchema() ([]byte, error) {
+	return genJSONSchema(t.mtyps)
+}
+
+// OpenAPISchemas returns the components section of an OpenAPI 3.1 document,
+// which holds the schemas of the JSON encoding of the types.
+func (t *TypeSet) OpenAPISchemas() ([]byte, error) {
+	return genOpenAPISchemas(t.mtyps)
+}
+
+// TypeScript returns TypeScript interface declarations of the JSON encoding of the types.
+func (t *TypeSet) TypeScript() ([]byte, error) {
+	return genTypeScript(t.mtyps)
+}
+
+// Plugin returns the main package of a Go plugin exposing the JSON codecs of the types.
+func (t *TypeSet) Plugin() ([]byte, error) {
+	structs, err := structTypes(t.mtyps)
+	if err != nil {
+		return nil, err
+	}
+	return genPlugin(structs)
+}
+
+// WASM returns the JavaScript bindings of the JSON methods of the types, for use in
+// programs compiled to WebAssembly.
+func (t *TypeSet) WASM() ([]byte, error) {
+	structs, err := structTypes(t.mtyps)
+	if err != nil {
+		return nil, err
+	}
+	return genWASM(structs)
+}
+
+// Cgo returns the main package of a C library exporting the JSON decoders of the types.
+func (t *TypeSet) Cgo() ([]byte, error) {
+	structs, err := structTypes(t.mtyps)
+	if err != nil {
+		return nil, err
+	}
+	return genCgo(structs)
+}
+
+// CRDSchema returns the structural schemas of the types for Kubernetes
+// CustomResourceDefinitions.
+func (t *TypeSet) CRDSchema() ([]byte, error) {
+	structs, err := structTypes(t.mtyps)
+	if err != nil {
+		return nil, err
+	}
+	return genCRDSchema(structs)
+}
+
+func (cfg *Config) process() (code []byte, err error) {
+	mtyps, err := cfg.loadMarshalerTypes()
+	if err != nil {
+		return nil, err
+	}
+	return cfg.generateCode(mtyps)
+}
+
+// generateCode generates the Go code for the loaded marshaling types.
+func (cfg *Config) generateCode(mtyps []*marshalerType) (code []byte, err error) {
+	for _, mtyp := range mtyps {
+		if mtyp.enum != nil {
+			continue
+		}
+		if cfg.Codec {
+			if err := mtyp.loadCodec(cfg.Formats); err != nil {
+				return nil, err
+			}
+		}
+		if err := mtyp.loadHelpers(cfg.Helpers); err != nil {
+			return nil, err
+		}
+	}
+
+	// Generate and format the output. Formatting uses goimports because it
+	// removes unused imports.
+	code, err = generate(mtyps, cfg)
+	if err != nil {
+		return nil, err
+	}
+	opt := &imports.Options{Comments: true, TabIndent: true, TabWidth: 8}
+	code, err = imports.Process("", code, opt)
+	if err != nil {
+		return nil, fmt.Errorf("can't gofmt generated code: %v", err)
+	}
+	return code, nil
+}
+
+// loadMarshalerType loads the package and constructs the marshaling type.
+func (cfg *Config) loadMarshalerType() (*marshalerType, error) {
+	mtyps, err := cfg.loadMarshalerTypes()
+	if err != nil {
+		return nil, err
+	}
+	if len(mtyps) != 1 {
+		return nil, errors.New("expected a single type")
+	}
+	return mtyps[0], nil
+}
+
+// loadMarshalerTypes loads the package and constructs the marshaling types.
+// The types share a file scope, so their methods can be written to one file.
+func (cfg *Config) loadMarshalerTypes() ([]*marshalerType, error) {
+	if cfg.FileSet == nil {
+		cfg.FileSet = token.NewFileSet()
+	}
+	if cfg.Importer == nil {
+		cfg.Importer = moduleImporter{importer.Default(), cfg.Dir}
+	}
+	if cfg.Formats == nil {
+		cfg.Formats = []string{"json"}
+	}
+	if err := checkFormats(cfg.Formats); err != nil {
+		return nil, err
+	}
+	typeNames := splitList(cfg.Type)
+	overrideNames := splitList(cfg.FieldOverride)
+	if len(overrideNames) > 0 && len(overrideNames) != len(typeNames) {
+		return nil, fmt.Errorf("got %d field override types for %d types", len(overrideNames), len(typeNames))
+	}
+	pkg, err := loadPackage(cfg)
+	if err != nil {
+		return nil, err
+	}
+	var schemas map[string]map[string]interface{}
+	if cfg.Schema != "" {
+		if schemas, err = readSchema(cfg.Schema, typeNames); err != nil {
+			return nil, err
+		}
+	}
+
+	interSuffix, err := parseInterSuffix(cfg.InterSuffix)
+	if err != nil {
+		return nil, err
+	}
+	unions, err := parseUnions(cfg.Unions, pkg)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.KeepUnknown && cfg.Strict {
+		return nil, errors.New("-keep-unknown can't be combined with -strict, which rejects unknown keys")
+	}
+	if cfg.KeepUnknown && cfg.Appender {
+		return nil, errors.New("-keep-unknown can't be combined with -appender")
+	}
+	if cfg.YAML != "" && cfg.YAML != "v2" && cfg.YAML != "v3" {
+		return nil, fmt.Errorf("invalid YAML API %q, want v2 or v3", cfg.YAML)
+	}
+	if cfg.TOML != "" && cfg.TOML != "naoina" && cfg.TOML != "burntsushi" {
+		return nil, fmt.Errorf("invalid TOML API %q, want naoina or burntsushi", cfg.TOML)
+	}
+
+	// Construct the marshaling types.
+	var (
+		scope = newFileScope(cfg.Importer, pkg)
+		mtyps []*marshalerType
+	)
+	for i, name := range typeNames {
+		typ, err := lookupType(pkg.Scope(), name)
+		if err == nil && isEnumType(typ) {
+			if len(overrideNames) > 0 && overrideNames[i] != "" {
+				return nil, fmt.Errorf("enum type %s can't have a field override type", name)
+			}
+			mtyp, err := newEnumType(cfg.FileSet, scope, typ)
+			if err != nil {
+				return nil, err
+			}
+			mtyps = append(mtyps, mtyp)
+			continue
+		}
+		if err == nil {
+			typ, err = lookupStructType(pkg.Scope(), name)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("can't find %s in %q: %v", name, pkg.Path(), err)
+		}
+		mtyp, err := newMarshalerType(cfg.FileSet, scope, typ, cfg.Formats, cfg.Unexported, cfg.warnf)
+		if err != nil {
+			return nil, err
+		}
+		if len(overrideNames) > 0 && overrideNames[i] != "" {
+			otyp, err := lookupOverrideType(cfg.Importer, pkg, overrideNames[i])
+			if err != nil {
+				return nil, fmt.Errorf("can't find field replacement type %s: %v", overrideNames[i], err)
+			}
+			if err := mtyp.loadOverrides(otyp); err != nil {
+				return nil, err
+			}
+		}
+		if err := mtyp.loadConvFields(cfg.Importer, pkg); err != nil {
+			return nil, err
+		}
+		if cfg.KeepUnknown {
+			if err := mtyp.loadUnknownField(); err != nil {
+				return nil, err
+			}
+		}
+		mtyp.interSuffix = interSuffix
+		if err := mtyp.loadUnionFields(unions); err != nil {
+			return nil, err
+		}
+		if err := mtyp.loadHookFields(cfg.Importer, pkg); err != nil {
+			return nil, err
+		}
+		if cfg.RequireAll {
+			mtyp.requireAll()
+		}
+		if cfg.OmitEmpty {
+			mtyp.addOmitEmpty()
+		}
+		mtyp.presence = cfg.Presence
+		if cfg.AllErrors {
+			mtyp.allErrors = true
+			mtyp.scope.addImport("sort")
+			mtyp.scope.addImport("strings")
+		}
+		if cfg.YAML == "v3" && mtyp.hasFormat("yaml") {
+			if err := mtyp.scope.requireImport(yamlPath); err != nil {
+				return nil, fmt.Errorf("-yaml v3: %v", err)
+			}
+			mtyp.yamlNode = true
+			mtyp.scope.addImport("fmt")
+		}
+		if cfg.TOML == "burntsushi" && mtyp.hasFormat("toml") {
+			if err := mtyp.scope.requireImport(tomlPath); err != nil {
+				return nil, fmt.Errorf("-toml burntsushi: %v", err)
+			}
+			mtyp.tomlValue = true
+			mtyp.scope.addImport("bytes")
+		}
+		if mtyp.enum == nil {
+			if err := mtyp.loadValidateMethod(cfg.Validate); err != nil {
+				return nil, err
+			}
+		}
+		if cfg.TypedErrors {
+			if err := mtyp.scope.requireImport(codecerrPath); err != nil {
+				return nil, fmt.Errorf("-typed-errors: %v", err)
+			}
+			mtyp.typedErrors = true
+			mtyp.scope.addImport("strings")
+		}
+		if cfg.Strict {
+			mtyp.setStrict()
+		}
+		if err := mtyp.loadDefaults(); err != nil {
+			return nil, err
+		}
+		if schema := schemas[name]; schema != nil {
+			if err := mtyp.loadSchema(schema); err != nil {
+				return nil, err
+			}
+		}
+		mtyps = append(mtyps, mtyp)
+	}
+	if schemas != nil && !hasSchema(schemas, typeNames) {
+		return nil, fmt.Errorf("%s has no schema of %s", cfg.Schema, strings.Join(typeNames, ", "))
+	}
+	if cfg.TemplateDir != "" {
+		tmpls, err := loadTemplates(cfg.TemplateDir, scope)
+		if err != nil {
+			return nil, err
+		}
+		for _, mtyp := range mtyps {
+			mtyp.templates = tmpls
+		}
+	}
+	if cfg.TOML == "burntsushi" {
+		docs := make(map[*types.TypeName]bool)
+		for _, mtyp := range mtyps {
+			if mtyp.tomlValue {
+				docs[mtyp.orig.Obj()] = true
+			}
+		}
+		for _, mtyp := range mtyps {
+			if mtyp.tomlValue {
+				mtyp.tomlDocs = docs
+			}
+		}
+	}
+	if cfg.Appender {
+		appender := make(map[*types.TypeName]bool)
+		for _, mtyp := range mtyps {
+			appender[mtyp.orig.Obj()] = true
+		}
+		for _, mtyp := range mtyps {
+			mtyp.setAppender(appender)
+		}
+	}
+	return mtyps, nil
+}
+
+// splitList splits a comma-separated list. Elements may be empty,
+// but the list of an empty string is empty.
+func splitList(s string) []string {
+	if s == "" {
+		return nil
+	}
+	list := strings.Split(s, ",")
+	for i := range list {
+		list[i] = strings.TrimSpace(list[i])
+	}
+	return list
+}
+
+// parseInterSuffix parses the suffixes of intermediate type names. A list element
+// format=suffix sets the suffix of a format, a plain suffix applies to the other
+// formats. The suffixes are stored by format, with the plain one under "".
+func parseInterSuffix(s string) (map[string]string, error) {
+	suffixes := make(map[string]string)
+	for _, elem := range splitList(s) {
+		format, suffix, ok := strings.Cut(elem, "=")
+		if !ok {
+			format, suffix = "", elem
+		} else if !isKnownFormat(format) {
+			return nil, fmt.Errorf("invalid intermediate suffix %q: unknown format %s", elem, format)
+		}
+		if _, dup := suffixes[format]; dup {
+			return nil, fmt.Errorf("duplicate intermediate suffix %q", elem)
+		}
+		if !token.IsIdentifier("T" + suffix) {
+			return nil, fmt.Errorf("invalid intermediate suffix %q", suffix)
+		}
+		suffixes[format] = suffix
+	}
+	return suffixes, nil
+}
+
+// checkFormats verifies that all formats are known and given only once.
+func checkFormats(formats []string) error {
+	seen := make(map[string]bool)
+	for _, format := range formats {
+		switch {
+		case seen[format]:
+			return fmt.Errorf("duplicate format: %q", format)
+		case !isKnownFormat(format):
+			return fmt.Errorf("unknown format: %q", format)
+		}
+		seen[format] = true
+	}
+	return nil
+}
+
+func isKnownFormat(format string) bool {
+	for _, f := range AllFormats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+// loadPackage loads the package in cfg.Dir using the go command, which resolves
+// imports through the enclosing module, workspace or vendor directory.
+func loadPackage(cfg *Config) (*types.Package, error) {
+	pcfg := &packages.Config{
+		Mode:  packages.NeedTypes | packages.NeedSyntax | packages.NeedDeps | packages.NeedImports,
+		Tests: true,
+		Dir:   cfg.Dir,
+		Fset:  cfg.FileSet,
+	}
+	if len(cfg.BuildTags) > 0 {
+		pcfg.BuildFlags = []string{"-tags=" + strings.Join(cfg.BuildTags, ",")}
+	}
+	ps, err := packages.Load(pcfg, ".")
+	if err != nil {
+		return nil, err
+	}
+	if len(ps) == 0 {
+		return nil, fmt.Errorf("can't find go package in %s", cfg.Dir)
+	}
+	// Type errors are tolerated because the generated file may be outdated,
+	// but packages that can't be listed or parsed are reported.
+	for _, e := range ps[0].Errors {
+		if e.Kind != packages.TypeError {
+			return nil, e
+		}
+	}
+	return ps[0].Types, nil
+}
+
+// moduleImporter imports packages from export data. Packages which can't be found
+// there, like dependencies of the module containing dir, are loaded from source.
+type moduleImporter struct {
+	types.Importer
+	dir string
+}
+
+func (imp moduleImporter) Import(path string) (*types.Package, error) {
+	pkg, err := imp.Importer.Import(path)
+	if err == nil {
+		return pkg, nil
+	}
+	pcfg := &packages.Config{Mode: packages.NeedTypes | packages.NeedDeps | packages.NeedImports, Dir: imp.dir}
+	ps, lerr := packages.Load(pcfg, path)
+	if lerr != nil || len(ps) != 1 || len(ps[0].Errors) > 0 {
+		return nil, err
+	}
+	return ps[0].Types, nil
+}
+
+func generate(mtyps []*marshalerType, cfg *Config) ([]byte, error) {
+	header, err := cfg.header()
+	if err != nil {
+		return nil, err
+	}
+	w := new(bytes.Buffer)
+	fmt.Fprint(w, header, "\n\n")
+	fmt.Fprintln(w, "package", mtyps[0].orig.Obj().Pkg().Name())
+	fmt.Fprintln(w)
+	mtyps[0].scope.writeImportDecl(w)
+	fmt.Fprintln(w)
+	if cfg.VersionConst {
+		fmt.Fprint(w, formatVersionConst(mtyps))
+	}
+	if err := genUnions(w, mtyps); err != nil {
+		return nil, err
+	}
+	for _, mtyp := range mtyps {
+		if err := generateType(w, mtyp, cfg); err != nil {
+			return nil, err
+		}
+	}
+	if structs, err := structTypes(mtyps); err == nil {
+		if err := genHelperSets(w, structs, cfg.Helpers); err != nil {
+			return nil, err
+		}
+	}
+	return w.Bytes(), nil
+}
+
+// generateType writes the methods of a marshaling type.
+func generateType(w io.Writer, mtyp *marshalerType, cfg *Config) error {
+	if mtyp.enum != nil {
+		if err := genEnum(w, mtyp); err != nil {
+			return err
+		}
+		fmt.Fprintln(w)
+		return nil
+	}
+	if mtyp.override != nil {
+		writeUseOfOverride(w, mtyp.override, mtyp.scope.qualify)
+	}
+	genSchemaPatterns(w, mtyp)
+	genPresence(w, mtyp)
+	if err := genDynamic(w, mtyp); err != nil {
+		return err
+	}
+	for _, format := range cfg.Formats {
+		if t := mtyp.templates[format]; t != nil {
+			if err := genMethodTemplate(w, mtyp, t, format); err != nil {
+				return fmt.Errorf("template %s: %v", format, err)
+			}
+			fmt.Fprintln(w)
+			continue
+		}
+		var genMarshal, genUnmarshal gogen.Function
+		switch format {
+		case "env", "hcl":
+			gen := genUnmarshalEnv
+			if format == "hcl" {
+				gen = genDecodeHCL
+			}
+			if err := gen(w, mtyp); err != nil {
+				return err
+			}
+			fmt.Fprintln(w)
+			continue
+		case "json":
+			if mtyp.appender != nil {
+				if err := genMarshalJSONTo(w, mtyp); err != nil {
+					return err
+				}
+				fmt.Fprintln(w)
+				genUnmarshal = genUnmarshalJSON(mtyp)
+				fmt.Fprintf(w, "// %s unmarshals from %s.\n", genUnmarshal.Name, formatNames[format])
+				writeFunction(w, mtyp.fs, genUnmarshal)
+				fmt.Fprintln(w)
+				continue
+			}
+			genMarshal = genMarshalJSON(mtyp)
+			genUnmarshal = genUnmarshalJSON(mtyp)
+		case "yaml":
+			genMarshal = genMarshalYAML(mtyp)
+			genUnmarshal = genUnmarshalYAML(mtyp)
+		case "toml":
+			genMarshal = genMarshalTOML(mtyp)
+			genUnmarshal = genUnmarshalTOML(mtyp)
+		case "msgpack":
+			genMarshal = genEncodeMsgpack(mtyp)
+			genUnmarshal = genDecodeMsgpack(mtyp)
+		case "cbor":
+			genMarshal = genMarshalCBOR(mtyp)
+			genUnmarshal = genUnmarshalCBOR(mtyp)
+		case "xml":
+			genMarshal = genMarshalXML(mtyp)
+			genUnmarshal = genUnmarshalXML(mtyp)
+		case "bson":
+			genMarshal = genMarshalBSON(mtyp)
+			genUnmarshal = genUnmarshalBSON(mtyp)
+		default:
+			return fmt.Errorf("unknown format: %q", format)
+		}
+		fmt.Fprintf(w, "// %s marshals as %s.", genMarshal.Name, formatNames[format])
+		fmt.Fprintln(w)
+		writeFunction(w, mtyp.fs, genMarshal)
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "// %s unmarshals from %s.", genUnmarshal.Name, formatNames[format])
+		fmt.Fprintln(w)
+		writeFunction(w, mtyp.fs, genUnmarshal)
+		fmt.Fprintln(w)
+	}
+	if cfg.Codec {
+		if err := genCodec(w, mtyp); err != nil {
+			return err
+		}
+	}
+	return genHelpers(w, mtyp, cfg.Helpers)
+}
+
+func writeUseOfOverride(w io.Writer, n *types.Named, qf types.Qualifier) {
+	name := types.TypeString(types.NewPointer(n), qf)
+	fmt.Fprintf(w, "var _ = (%s)(nil)\n", name)
+}
+
+// marshalerType represents the intermediate struct type used during marshaling.
+// This is the input data to all the Go code templates.
+type marshalerType struct {
+	name        string
+	Fields      []*marshalerField
+	fs          *token.FileSet
+	orig        *types.Named
+	override    *types.Named
+	scope       *fileScope
+	formats     []string                 // generated formats
+	strict      bool                     // reject unknown keys when unmarshaling
+	presence    bool                     // UnmarshalJSON tracks present keys with flags
+	allErrors   bool                     // unmarshaling methods collect the errors of all fields
+	metrics     string                   // variable of the decode metrics hook, empty if none
+	typedErrors bool                     // return the errors of package codecerr
+	validate    string                   // method called after unmarshaling, empty if none
+	yamlNode    bool                     // UnmarshalYAML takes a *yaml.Node
+	tomlValue   bool                     // UnmarshalTOML takes the value decoded by BurntSushi/toml
+	tomlDocs    map[*types.TypeName]bool // types whose MarshalTOML returns a document, nil unless tomlValue
+	unknown     *marshalerField          // field holding unknown keys, nil if disabled
+	appender    map[*types.TypeName]bool // types with generated MarshalJSONTo, nil if disabled
+	// interSuffix holds the suffixes of intermediate type names by format
+	interSuffix map[string]string
+	unexported  bool // include all unexported fields
+	warnf       func(format string, args ...interface{})
+	// enum holds the constants of integer types, whose values are encoded by name
+	enum []enumConst
+	// templates replace built-in methods and helper templates
+	templates map[string]*template.Template
+}
+
+// requireAll adds the required option to all fields which have neither
+// the optional option nor a default value.
+func (mtyp *marshalerType) requireAll() {
+	for _, f := range mtyp.Fields {
+		if f.function != nil || f.hasOption("optional") || f.hasOption("required") || f.hasDefault() {
+			continue
+		}
+		opts := reflect.StructTag(f.tag).Get("gencodec")
+		if opts != "" {
+			opts += ","
+		}
+		f.tag = setStructTag(f.tag, "gencodec", opts+"required")
+	}
+}
+
+// addOmitEmpty adds the omitempty option to the format struct tags of all
+// optional fields, so their zero values are left out when marshaling.
+func (mtyp *marshalerType) addOmitEmpty() {
+	for _, f := range mtyp.Fields {
+		for _, format := range mtyp.formats {
+			if decodeOnlyFormats[format] {
+				continue
+			}
+			key := format
+			if _, ok := reflect.StructTag(f.tag).Lookup(format); !ok && format == "cbor" {
+				key = "json" // cbor falls back to the json tag
+			}
+			val := f.formatTag(format)
+			if f.isRequired(format) || val == "-" || f.hasTagOption(format, "omitempty") {
+				continue
+			}
+			f.tag = setStructTag(f.tag, key, val+",omitempty")
+		}
+	}
+}
+
+// setStrict makes the unmarshaling methods reject unknown keys.
+func (mtyp *marshalerType) setStrict() {
+	mtyp.strict = true
+	for _, path := range []string{"bytes", "fmt", "io", "sort"} {
+		mtyp.scope.addImport(path)
+	}
+}
+
+// setAppender makes MarshalJSON append to a buffer instead of marshaling the
+// intermediate type. The set contains the types generated in the same file.
+func (mtyp *marshalerType) setAppender(set map[*types.TypeName]bool) {
+	mtyp.appender = set
+	for _, path := range append([]string{"encoding/json", "fmt"}, appenderPackages...) {
+		mtyp.scope.addImport(path)
+	}
+}
+
+// hasFormat reports whether methods for format are generated.
+func (mtyp *marshalerType) hasFormat(format string) bool {
+	for _, f := range mtyp.formats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+// recvType returns the receiver type of the generated methods. For generic
+// types, it is instantiated with the type parameters, e.g. Envelope[T].
+func (mtyp *marshalerType) recvType() string {
+	tparams := mtyp.orig.TypeParams()
+	if tparams.Len() == 0 {
+		return mtyp.name
+	}
+	names := make([]string, tparams.Len())
+	for i := range names {
+		names[i] = tparams.At(i).Obj().Name()
+	}
+	return mtyp.name + "[" + strings.Join(names, ", ") + "]"
+}
+
+// marshalerField represents a field of the intermediate marshaling type.
+type marshalerField struct {
+	name        string
+	path        []string // selector path in the original type
+	prefix      string   // key prefix of flattened fields
+	depth       int      // embedding depth of promoted fields
+	typ         types.Type
+	origTyp     types.Type
+	tag         string
+	overrideTag string           // tag of the field in the override type
+	function    *types.Func      // map to a function instead of a field
+	wellKnown   *wellKnownField  // built-in representation of the field type
+	money       *moneyField      // amount marshaled together with a currency
+	dynamic     bool             // encoded by a codec registered at runtime
+	union       *unionType       // interface with a known set of types
+	conv        *convFuncs       // functions converting to the marshaling type and back
+	hook        *hookFuncs       // functions encoding the field in JSON
+	schema      *fieldSchema     // constraints from the schema file
+	defValue    gogen.Expression // assigned when the field is absent
+	pos         token.Pos
+}
+
+func newMarshalerType(fs *token.FileSet, scope *fileScope, typ *types.Named, formats []string, unexported bool, warnf func(string, ...interface{})) (*marshalerType, error) {
+	mtyp := &marshalerType{name: typ.Obj().Name(), fs: fs, orig: typ, formats: formats, scope: scope, unexported: unexported, warnf: warnf}
+	styp := typ.Underlying().(*types.Struct)
+	mtyp.scope.addReferences(styp)
+	// The type parameters of generic types are in scope in the generated methods.
+	for i := 0; i < typ.TypeParams().Len(); i++ {
+		mtyp.scope.otherNames[typ.TypeParams().At(i).Obj().Name()] = true
+	}
+
+	// Add packages which are always needed.
+	mtyp.scope.addImport("encoding/json")
+	mtyp.scope.addImport("errors")
+	for _, format := range formats {
+		for _, path := range formatImports[format] {
+			if err := mtyp.scope.requireImport(path); err != nil {
+				return nil, fmt.Errorf("format %s: %v", format, err)
+			}
+		}
+	}
+
+	if err := mtyp.addFields(styp, nil, "", 0); err != nil {
+		return nil, err
+	}
+	mtyp.removeShadowedFields()
+	if err := mtyp.loadMoneyFields(); err != nil {
+		return nil, err
+	}
+	if err := mtyp.loadDynamicFields(); err != nil {
+		return nil, err
+	}
+	return mtyp, nil
+}
+
+// addFields adds the exported fields of styp to the intermediate type, and the
+// unexported ones which are included by the -include-unexported flag or the
+// gencodec:"export" option. The path
+// is the selector path of styp in the original type. Fields with the gencodec:"flatten"
+// option are replaced by their own fields, and their keys are prefixed with the
+// value of the "prefix" struct tag. The fields of embedded structs are promoted, depth
+// is the number of embedded structs containing styp.
+func (mtyp *marshalerType) addFields(styp *types.Struct, path []string, prefix string, depth int) error {
+	for i := 0; i < styp.NumFields(); i++ {
+		f := styp.Field(i)
+		mf := &marshalerField{
+			name:    f.Name(),
+			path:    append(path[:len(path):len(path)], f.Name()),
+			depth:   depth,
+			typ:     f.Type(),
+			origTyp: f.Type(),
+			tag:     styp.Tag(i),
+			pos:     f.Pos(),
+		}
+		if f.Anonymous() && !mf.hasKey("json") {
+			// Promote the fields of embedded structs like encoding/json.
+			if sub, ok := f.Type().Underlying().(*types.Struct); ok {
+				if err := mtyp.addFields(sub, mf.path, prefix, depth+1); err != nil {
+					return err
+				}
+				continue
+			}
+			if isPointer(f.Type()) {
+				mtyp.warnf("%v: ignoring embedded pointer field %s", mtyp.fs.Position(f.Pos()), f.Name())
+				continue
+			}
+		}
+		if !f.Exported() && !mtyp.includesUnexported(f, mf) {
+			continue
+		}
+		if mtyp.hasFormat("yaml") && mf.hasTagOption("yaml", "inline") {
+			if sub, ok := f.Type().Underlying().(*types.Struct); ok {
+				if err := mtyp.addYAMLInline(sub, mf, prefix, depth); err != nil {
+					return err
+				}
+			}
+		}
+		if mf.hasOption("flatten") {
+			sub, ok := f.Type().Underlying().(*types.Struct)
+			if !ok {
+				return fmt.Errorf("%v: flattened field %s must have struct type", mtyp.fs.Position(f.Pos()), f.Name())
+			}
+			subPrefix := prefix + reflect.StructTag(mf.tag).Get("prefix")
+			if err := mtyp.addFields(sub, mf.path, subPrefix, depth); err != nil {
+				return err
+			}
+			continue
+		}
+		if prefix != "" {
+			mf.setPrefix(prefix, mtyp.formats)
+		}
+		if !token.IsExported(mf.name) {
+			mf.export(mtyp.formats)
+		}
+		if other := mtyp.fieldByName(mf.name); other != nil && depth == 0 && other.depth == 0 {
+			return fmt.Errorf("%v: duplicate field %s in %s", mtyp.fs.Position(f.Pos()), f.Name(), mtyp.name)
+		}
+		wk := lookupWellKnown(f.Type())
+		if format, ok := reflect.StructTag(mf.tag).Lookup("timeformat"); ok {
+			var err error
+			if wk, err = lookupTimeFormat(mtyp.scope, f.Type(), format); err != nil {
+				return fmt.Errorf("%v: field %s: %v", mtyp.fs.Position(f.Pos()), f.Name(), err)
+			}
+		}
+		if wk != nil && !mf.hasOption("nobuiltin") {
+			if wk.validate != nil {
+				if err := wk.parseLimits(mf); err != nil {
+					return fmt.Errorf("%v: %v", mtyp.fs.Position(f.Pos()), err)
+				}
+			}
+			// Decoding errors of well-known types are created by fmt.Errorf.
+			mtyp.scope.addImport("fmt")
+			for _, path := range wk.imports {
+				mtyp.scope.addImport(path)
+			}
+			mf.typ = types.NewPointer(wk.repr(mtyp.scope))
+			mf.wellKnown = wk
+		}
+
+		mtyp.Fields = append(mtyp.Fields, mf)
+	}
+	return nil
+}
+
+// addYAMLInline adds the fields of a struct tagged yaml:",inline" to the type for
+// the YAML format, where the keys of the struct belong to the parent mapping. The
+// inline field itself is left out of YAML and kept in the other formats.
+func (mtyp *marshalerType) addYAMLInline(sub *types.Struct, mf *marshalerField, prefix string, depth int) error {
+	start := len(mtyp.Fields)
+	if err := mtyp.addFields(sub, mf.path, prefix, depth); err != nil {
+		return err
+	}
+	for _, f := range mtyp.Fields[start:] {
+		for _, format := range mtyp.formats {
+			if format != "yaml" {
+				f.tag = setStructTag(f.tag, format, "-")
+			}
+		}
+	}
+	mf.tag = setStructTag(mf.tag, "yaml", "-")
+	return nil
+}
+
+// removeShadowedFields removes promoted fields which conflict with other fields.
+// Like in encoding/json, the least nested field wins. Among fields of the same depth,
+// a field with a json tag wins. Otherwise, all conflicting fields are removed.
+func (mtyp *marshalerType) removeShadowedFields() {
+	var fields []*marshalerField
+	for _, f := range mtyp.Fields {
+		if !mtyp.isShadowed(f) {
+			fields = append(fields, f)
+		}
+	}
+	mtyp.Fields = fields
+}
+
+func (mtyp *marshalerType) isShadowed(f *marshalerField) bool {
+	fkey, fok := f.key("json")
+	for _, other := range mtyp.Fields {
+		if other == f || (f.depth == 0 && other.depth == 0) {
+			continue
+		}
+		// Fields skipped by JSON only conflict by name.
+		if okey, ok := other.key("json"); other.name != f.name && (!ok || !fok || okey != fkey) {
+			continue
+		}
+		if other.depth < f.depth || (other.depth == f.depth && (other.hasKey("json") || !f.hasKey("json"))) {
+			return true
+		}
+	}
+	return false
+}
+
+// findFunction returns a function with `name` that accepts no arguments
+// and returns a single value that is convertible to the given to type.
+func findFunction(typ *types.Named, name string, to types.Type) (*types.Func, types.Type) {
+	for i := 0; i < typ.NumMethods(); i++ {
+		fun := typ.Method(i)
+		if fun.Name() != name || !fun.Exported() {
+			continue
+		}
+		sign := fun.Type().(*types.Signature)
+		if sign.Params().Len() != 0 || sign.Results().Len() != 1 {
+			continue
+		}
+		if err := checkConvertible(sign.Results().At(0).Type(), to); err == nil {
+			return fun, sign.Results().At(0).Type()
+		}
+	}
+	return nil, nil
+}
+
+// loadOverrides sets field types of the intermediate marshaling type from
+// matching fields of otyp.
+func (mtyp *marshalerType) loadOverrides(otyp *types.Named) error {
+	if otyp.TypeParams().Len() > 0 {
+		return fmt.Errorf("field override type %s can't be generic", otyp.Obj().Name())
+	}
+	s := otyp.Underlying().(*types.Struct)
+	for i := 0; i < s.NumFields(); i++ {
+		of := s.Field(i)
+		if of.Anonymous() || !of.Exported() {
+			return fmt.Errorf("%v: field override type cannot have embedded or unexported fields", mtyp.fs.Position(of.Pos()))
+		}
+		f := mtyp.fieldByName(of.Name())
+		if f == nil {
+			// field not defined in original type, check if it maps to a suitable function and add it as an override
+			if fun, retType := findFunction(mtyp.orig, of.Name(), of.Type()); fun != nil {
+				f = &marshalerField{name: fun.Name(), origTyp: retType, typ: of.Type(), function: fun, tag: s.Tag(i)}
+				mtyp.Fields = append(mtyp.Fields, f)
+			} else {
+				return fmt.Errorf("%v: no matching field or function for %s in original type %s", mtyp.fs.Position(of.Pos()), of.Name(), mtyp.name)
+			}
+		}
+		f.overrideTag = s.Tag(i)
+		if _, _, ok, err := parseConv(f.overrideTag); err != nil {
+			return fmt.Errorf("%v: field %s: %v", mtyp.fs.Position(of.Pos()), of.Name(), err)
+		} else if ok {
+			// The conversion functions are checked by loadConvFields.
+			f.typ = of.Type()
+			f.wellKnown = nil
+			continue
+		}
+		// Marshaling converts the field to the override type, unmarshaling converts back.
+		err := checkConvertible(f.origTyp, of.Type())
+		if err == nil {
+			err = checkConversion(of.Type(), f.origTyp, true)
+		}
+		if err != nil {
+			return fmt.Errorf("%v: invalid field override: %v", mtyp.fs.Position(of.Pos()), err)
+		}
+		f.typ = of.Type()
+		f.wellKnown = nil
+	}
+	mtyp.scope.addReferences(s)
+	mtyp.scope.addReferences(otyp)
+	mtyp.override = otyp
+	return nil
+}
+
+func (mtyp *marshalerType) fieldByName(name string) *marshalerField {
+	for _, f := range mtyp.Fields {
+		if f.name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// isRequired returns whether the field is required when decoding the given format.
+func (mf *marshalerField) isRequired(format string) bool {
+	req := mf.hasOption("required")
+	// Fields with json:"-" must be treated as optional. This also works
+	// for the other supported formats. Fields with omitempty are optional
+	// as well because their zero value is left out when marshaling.
+	return req && !strings.HasPrefix(mf.formatTag(format), "-") && !mf.hasTagOption(format, "omitempty")
+}
+
+// hasOption reports whether the gencodec struct tag of the field contains
+// the given option. Options are separated by commas.
+func (mf *marshalerField) hasOption(opt string) bool {
+	_, ok := mf.option(opt)
+	return ok
+}
+
+// option returns the value of a gencodec tag option. Options with
+// a value are written as name=value.
+func (mf *marshalerField) option(name string) (string, bool) {
+	for _, o := range strings.Split(reflect.StructTag(mf.tag).Get("gencodec"), ",") {
+		k, v, _ := strings.Cut(strings.TrimSpace(o), "=")
+		if k == name {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// setPrefix prepends prefix to the keys of a flattened field in the given formats.
+// The field is renamed after its path to avoid clashes between fields of
+// structs which are flattened more than once.
+func (mf *marshalerField) setPrefix(prefix string, formats []string) {
+	mf.prefix = prefix
+	mf.name = strings.Join(mf.path, "")
+	for _, format := range formats {
+		name, opts, _ := strings.Cut(mf.formatTag(format), ",")
+		if name == "-" && opts == "" {
+			continue
+		}
+		if name == "" {
+			name = defaultKey(mf.path[len(mf.path)-1], format)
+		}
+		if opts != "" {
+			opts = "," + opts
+		}
+		mf.tag = setStructTag(mf.tag, format, prefix+name+opts)
+	}
+}
+
+// includesUnexported reports whether the unexported field f is marshaled. Fields of
+// other packages can't be accessed by the generated code, and fields like _ have no
+// exported name.
+func (mtyp *marshalerType) includesUnexported(f *types.Var, mf *marshalerField) bool {
+	if f.Pkg() != mtyp.orig.Obj().Pkg() || !token.IsExported(capitalize(f.Name())) {
+		return false
+	}
+	return mtyp.unexported || mf.hasOption("export")
+}
+
+// export renames an unexported field to its capitalized name, which is needed for
+// the field of the intermediate type to be marshaled. The key of the field stays the
+// same because it is set in the struct tags of the formats.
+func (mf *marshalerField) export(formats []string) {
+	for _, format := range formats {
+		name, opts, _ := strings.Cut(mf.formatTag(format), ",")
+		if name == "-" && opts == "" {
+			continue
+		}
+		if name == "" {
+			name = defaultKey(mf.name, format)
+		}
+		if opts != "" {
+			opts = "," + opts
+		}
+		mf.tag = setStructTag(mf.tag, format, name+opts)
+	}
+	mf.name = capitalize(mf.name)
+}
+
+// defaultKey returns the key used by the marshaling package of format for
+// a field without a struct tag.
+func defaultKey(name, format string) string {
+	if format == "yaml" || format == "bson" {
+		return strings.ToLower(name)
+	}
+	return name
+}
+
+// formatTag returns the struct tag value of the given format. Like the cbor
+// package, the json tag is used for CBOR if there is no cbor tag.
+func (mf *marshalerField) formatTag(format string) string {
+	tag := reflect.StructTag(mf.tag)
+	if val, ok := tag.Lookup(format); ok || format != "cbor" {
+		return val
+	}
+	return tag.Get("json")
+}
+
+// hasKey reports whether the struct tag of the given format sets a key.
+func (mf *marshalerField) hasKey(format string) bool {
+	name, _, _ := strings.Cut(mf.formatTag(format), ",")
+	return name != ""
+}
+
+// key returns the key of the field in the given format.
+// It returns false if the field is skipped by the format.
+func (mf *marshalerField) key(format string) (string, bool) {
+	name, _, hasOpts := strings.Cut(mf.formatTag(format), ",")
+	switch {
+	case name == "-" && !hasOpts:
+		return "", false
+	case name == "":
+		return defaultKey(mf.name, format), true
+	default:
+		return name, true
+	}
+}
+
+// inFormat reports whether the field is encoded by the format. Fields skipped by
+// a format are left out of its intermediate types.
+func (mf *marshalerField) inFormat(format string) bool {
+	_, ok := mf.key(format)
+	return ok
+}
+
+// encodedName returns the alternative field name assigned by the format's struct tag.
+func (mf *marshalerField) encodedName(format string) string {
+	val := mf.formatTag(format)
+	if comma := strings.Index(val, ","); comma != -1 {
+		val = val[:comma]
+	}
+	if val == "" || val == "-" {
+		return uncapitalize(mf.name)
+	}
+	return val
+}
+
+func uncapitalize(s string) string {
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+func capitalize(s string) string {
+	return strings.ToUpper(s[:1]) + s[1:]
+}
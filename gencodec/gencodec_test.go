@@ -0,0 +1,1071 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/kylelemons/godebug/diff"
+)
+
+// 'golden' tests. These tests compare the output code with the expected
+// code in internal/tests/*/output.go. The expected can be updated using
+//
+//    go generate ./internal/...
+
+// textFormats are the formats which don't need a marshaling package.
+var textFormats = []string{"json", "yaml", "toml"}
+
+func TestGolden(t *testing.T) {
+	tests := []Config{
+		Config{Dir: "mapconv", Type: "X", FieldOverride: "Xo", Formats: textFormats},
+		Config{Dir: "sliceconv", Type: "X", FieldOverride: "Xo", Formats: textFormats},
+		Config{Dir: "elemconv", Type: "X", FieldOverride: "Xo", Formats: []string{"json", "yaml"}},
+		Config{Dir: "conv", Type: "X", FieldOverride: "Xo", Formats: []string{"json", "yaml"}},
+		Config{Dir: "presence", Type: "X", FieldOverride: "Xo", Presence: true, Formats: []string{"json", "yaml"}},
+		Config{Dir: "keepunknown", Type: "X", KeepUnknown: true, Formats: []string{"json", "yaml"}},
+		Config{Dir: "allerrors", Type: "X", AllErrors: true, Strict: true, Formats: []string{"json", "yaml"}},
+		Config{Dir: "typederrors", Type: "X", TypedErrors: true, Strict: true, Formats: []string{"json", "yaml"}},
+		Config{Dir: "validate", Type: "X,Y", Formats: []string{"json", "yaml"}},
+		Config{Dir: "formatfields", Type: "X", Formats: []string{"json", "yaml"}},
+		Config{Dir: "hook", Type: "X", Formats: []string{"json", "yaml"}, Helpers: []string{"jsonstream"}},
+		Config{Dir: "binary", Type: "Header,Packet,Frame,Record", Helpers: []string{"binary"}},
+		Config{Dir: "yamlnode", Type: "X,Y", Formats: []string{"yaml"}, YAML: "v3", Strict: true},
+		Config{Dir: "tomlvalue", Type: "X,Server", Formats: []string{"toml"}, TOML: "burntsushi", Strict: true},
+		Config{Dir: "yamlinline", Type: "X", Formats: []string{"json", "yaml"}, Strict: true},
+		Config{Dir: filepath.Join("yamlnode", "typed"), Type: "X", Formats: []string{"yaml"}, YAML: "v3", Strict: true, TypedErrors: true},
+		Config{Dir: "nameclash", Type: "Y", FieldOverride: "yo", Formats: textFormats},
+		Config{Dir: "omitempty", Type: "X", FieldOverride: "Xo", Formats: textFormats},
+		Config{Dir: "reqfield", Type: "X", Formats: []string{"json"}},
+		Config{Dir: "ftypes", Type: "X", Formats: []string{"json"}},
+		Config{Dir: "funcoverride", Type: "Z", FieldOverride: "Zo", Formats: textFormats},
+		Config{Dir: "wellknown", Type: "X", Formats: []string{"json", "yaml"}},
+		Config{Dir: "timeformat", Type: "X", Formats: []string{"json", "yaml"}},
+		Config{Dir: "money", Type: "X", Formats: []string{"json", "yaml"}},
+		Config{Dir: "dynamic", Type: "Event"},
+		Config{Dir: "flatten", Type: "X", FieldOverride: "Xo", Formats: textFormats},
+		Config{Dir: "prefix", Type: "Y", Formats: []string{"json", "yaml"}},
+		Config{Dir: "embed", Type: "X", FieldOverride: "Xo", Formats: []string{"json", "yaml"}},
+		Config{Dir: "codec", Type: "X", Formats: []string{"json"}, Codec: true},
+		Config{Dir: "labels", Type: "X", Formats: []string{"json"}, Helpers: []string{"labels"}},
+		Config{Dir: "msgpack", Type: "X", FieldOverride: "Xo", Formats: []string{"json", "msgpack"}},
+		Config{Dir: "cbor", Type: "X", FieldOverride: "Xo", Formats: []string{"json", "cbor"}},
+		Config{Dir: "xml", Type: "X", FieldOverride: "Xo", Formats: []string{"json", "xml"}},
+		Config{Dir: "fingerprint", Type: "X", FieldOverride: "Xo", Formats: []string{"json"}, Helpers: []string{"fingerprint"}},
+		Config{Dir: "fieldnames", Type: "X", Formats: []string{"json"}, Helpers: []string{"fieldnames"}},
+		Config{Dir: "fieldinfo", Type: "X", FieldOverride: "Xo", Formats: []string{"json"}, Helpers: []string{"fieldinfo"}},
+		Config{Dir: "multitype", Type: "Header,Body", FieldOverride: ",bodyMarshaling", Formats: []string{"json", "yaml"}},
+		Config{Dir: "graphql", Type: "X", FieldOverride: "Xo", Formats: []string{"json"}},
+		Config{Dir: "text", Type: "X", Formats: []string{"json"}, Helpers: []string{"text"}},
+		Config{Dir: "jsonapi", Type: "Article", Formats: []string{"json"}, Helpers: []string{"jsonapi"}},
+		Config{Dir: "hal", Type: "X", Formats: []string{"json"}, Helpers: []string{"hal"}},
+		Config{Dir: "sse", Type: "X", Formats: []string{"json"}, Helpers: []string{"sse"}},
+		Config{Dir: "ws", Type: "Join,Chat", Formats: []string{"json"}, Helpers: []string{"ws"}},
+		Config{Dir: "kafka", Type: "Event", Formats: []string{"json"}, Helpers: []string{"kafka"}},
+		Config{Dir: "frame", Type: "Message", Helpers: []string{"frame"}},
+		Config{Dir: filepath.Join("frame", "fixed"), Type: "Message", Helpers: []string{"frame32"}},
+		Config{Dir: "gzip", Type: "Snapshot", Formats: []string{"json", "cbor"}, Helpers: []string{"gzip"}},
+		Config{Dir: "seal", Type: "Secret,Other", Helpers: []string{"seal"}},
+		Config{Dir: "autoomitempty", Type: "X", Formats: []string{"json", "yaml"}, OmitEmpty: true},
+		Config{Dir: "reqdefault", Type: "X", RequireAll: true},
+		Config{Dir: "defaults", Type: "Server", Formats: []string{"json", "yaml"}},
+		Config{Dir: "strict", Type: "X", Formats: []string{"json", "yaml"}, Strict: true},
+		Config{Dir: "jsonstream", Type: "Event", Helpers: []string{"jsonstream"}},
+		Config{Dir: "jsonfields", Type: "Transaction", Helpers: []string{"jsonfields"}},
+		Config{Dir: "peek", Type: "Envelope", Helpers: []string{"peek"}},
+		Config{Dir: "pretty", Type: "Block", Helpers: []string{"pretty"}},
+		Config{Dir: "table", Type: "Pod", Helpers: []string{"table"}},
+		Config{Dir: "prompt", Type: "Config", Helpers: []string{"prompt"}},
+		Config{Dir: "env", Type: "Config", Formats: []string{"json", "env"}},
+		Config{Dir: "hcl", Type: "Config,Listener,TLS", Formats: []string{"hcl"}},
+		Config{Dir: "values", Type: "Query", Helpers: []string{"values"}},
+		Config{Dir: "flags", Type: "Config", Helpers: []string{"flags"}},
+		Config{Dir: "jsonschema", Type: "Order,Item", FieldOverride: "orderMarshaling,itemMarshaling", Strict: true},
+		Config{Dir: "openapi", Type: "CreateUserRequest,User"},
+		Config{Dir: "typescript", Type: "Order,Item", FieldOverride: "orderMarshaling,itemMarshaling"},
+		Config{Dir: "plugin", Type: "Order,Item"},
+		Config{Dir: "wasm", Type: "Order,Item"},
+		Config{Dir: "cgo", Type: "Order,Item"},
+		Config{Dir: "intersuffix", Type: "X", Formats: []string{"json", "yaml"}, InterSuffix: "Wire,yaml=YAML"},
+		Config{Dir: "unexported", Type: "X,Y", Formats: []string{"json", "yaml"}, Unexported: true},
+		Config{Dir: "union", Type: "Drawing", Unions: "Shape=Circle,*Polygon,Empty"},
+		Config{Dir: "enum", Type: "Color,Size,Shirt", Formats: []string{"json", "yaml"}},
+		Config{Dir: "fuzz", Type: "Server", Formats: []string{"json", "yaml"}},
+		Config{Dir: "gentests", Type: "Order,item"},
+		Config{Dir: "crd", Type: "WidgetSpec,WidgetStatus", Helpers: []string{"deepcopy"}},
+		Config{Dir: "appender", Type: "Record,Item", Appender: true},
+		Config{Dir: "template", Type: "X", Helpers: []string{"sse"}, TemplateDir: filepath.Join("..", "internal", "tests", "template", "templates")},
+		Config{Dir: "schema", Type: "Server", Formats: []string{"json", "yaml"}, Schema: filepath.Join("..", "internal", "tests", "schema", "schema.json")},
+		Config{Dir: "schema", Type: "Server", Formats: []string{"json", "yaml"}, Schema: filepath.Join("..", "internal", "tests", "schema", "schema.cue")},
+		Config{Dir: "generic", Type: "Envelope", FieldOverride: "envelopeMarshaling", Formats: []string{"json", "yaml"}},
+		Config{Dir: "crossoverride", Type: "X", FieldOverride: "github.com/fjl/gencodec/internal/tests/crossoverride/marshalutil.XMarshaling"},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.Dir, func(t *testing.T) {
+			t.Parallel()
+			runGoldenTest(t, test)
+		})
+	}
+}
+
+// TestGoldenBSON checks the output of the bson format. The mongo driver isn't
+// a dependency of gencodec, so the input lives in testdata and the bson
+// package is replaced by an empty package of the same path.
+func TestGoldenBSON(t *testing.T) {
+	dir := filepath.Join("testdata", "bson")
+	imp := stubImporter{
+		Importer: moduleImporter{importer.Default(), filepath.Join("..", "internal", "tests", dir)},
+		stubs:    map[string]string{"go.mongodb.org/mongo-driver/bson": "bson"},
+	}
+	runGoldenTest(t, Config{Dir: dir, Type: "X", FieldOverride: "Xo", Formats: []string{"bson"}, Importer: imp})
+}
+
+// TestGoldenNATS checks the output of the nats helper. Like the bson test,
+// it replaces the NATS client with an empty package.
+func TestGoldenNATS(t *testing.T) {
+	dir := filepath.Join("testdata", "nats")
+	imp := stubImporter{
+		Importer: moduleImporter{importer.Default(), filepath.Join("..", "internal", "tests", dir)},
+		stubs:    map[string]string{natsPath: "nats"},
+	}
+	runGoldenTest(t, Config{Dir: dir, Type: "X", Helpers: []string{"nats"}, Importer: imp})
+}
+
+// TestGoldenLambda checks the output of the lambda helper
+// with an empty package in place of the Lambda events.
+func TestGoldenLambda(t *testing.T) {
+	dir := filepath.Join("testdata", "lambda")
+	imp := stubImporter{
+		Importer: moduleImporter{importer.Default(), filepath.Join("..", "internal", "tests", dir)},
+		stubs:    map[string]string{lambdaEventsPath: "events"},
+	}
+	runGoldenTest(t, Config{Dir: dir, Type: "X", Helpers: []string{"lambda"}, Importer: imp})
+}
+
+// TestGoldenK8s checks the output of the deepcopyobject helper
+// with an empty package in place of the Kubernetes runtime.
+func TestGoldenK8s(t *testing.T) {
+	dir := filepath.Join("testdata", "k8s")
+	imp := stubImporter{
+		Importer: moduleImporter{importer.Default(), filepath.Join("..", "internal", "tests", dir)},
+		stubs:    map[string]string{k8sRuntimePath: "runtime"},
+	}
+	runGoldenTest(t, Config{Dir: dir, Type: "Widget,WidgetList", Helpers: []string{"deepcopy", "deepcopyobject"}, Importer: imp})
+}
+
+// TestGoldenProtobuf checks the built-in representation of the protobuf well-known
+// types. The input is a module of its own, which replaces the protobuf module by a
+// stub declaring the types and functions used by the generated code.
+func TestGoldenProtobuf(t *testing.T) {
+	runGoldenTest(t, Config{Dir: filepath.Join("testdata", "protobuf"), Type: "X", Formats: []string{"json", "yaml"}})
+}
+
+// TestGoldenDecimal checks the built-in representation of shopspring/decimal values,
+// including the checks of the precision and scale options. The input module replaces
+// the decimal module by a stub.
+func TestGoldenDecimal(t *testing.T) {
+	runGoldenTest(t, Config{Dir: filepath.Join("testdata", "decimal"), Type: "X", Formats: []string{"json", "yaml"}})
+}
+
+// TestGoldenUUID checks the built-in representation of each supported UUID package.
+// The inputs share a module, which replaces the UUID modules by stubs.
+func TestGoldenUUID(t *testing.T) {
+	for _, dir := range []string{"google", "satori", "gofrs", "gofrsv5"} {
+		t.Run(dir, func(t *testing.T) {
+			runGoldenTest(t, Config{Dir: filepath.Join("testdata", "uuid", dir), Type: "X", Formats: []string{"json", "yaml"}})
+		})
+	}
+}
+
+// TestGoldenSpanAttrs checks the output of the spanattrs helper
+// with an empty package in place of the OpenTelemetry attributes.
+func TestGoldenSpanAttrs(t *testing.T) {
+	runGoldenTest(t, spanAttrsConfig())
+}
+
+func spanAttrsConfig() Config {
+	dir := filepath.Join("testdata", "spanattrs")
+	imp := stubImporter{
+		Importer: moduleImporter{importer.Default(), filepath.Join("..", "internal", "tests", dir)},
+		stubs:    map[string]string{"go.opentelemetry.io/otel/attribute": "attribute"},
+	}
+	return Config{Dir: dir, Type: "X", Helpers: []string{"spanattrs"}, Importer: imp}
+}
+
+// TestSpanAttributes checks the attribute constructor, key and value of each
+// field in the generated SpanAttributes method.
+func TestSpanAttributes(t *testing.T) {
+	cfg := spanAttrsConfig()
+	cfg.Dir = filepath.Join("..", "internal", "tests", cfg.Dir)
+	code, err := Generate(&cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file, err := parser.ParseFile(token.NewFileSet(), "output.go", code, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var attrs []string
+	ast.Inspect(file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		if sel, ok := call.Fun.(*ast.SelectorExpr); ok && isIdent(sel.X, "attribute") {
+			key, _ := strconv.Unquote(call.Args[0].(*ast.BasicLit).Value)
+			attrs = append(attrs, fmt.Sprintf("%s %s=%s", sel.Sel.Name, key, types.ExprString(call.Args[1])))
+		}
+		return true
+	})
+	want := []string{
+		"String user.id=x.UserID",
+		"String Method=x.Method",
+		"Int status=x.Status",
+		"Int64 size=int64(x.Size)",
+		"Float64 ratio=float64(x.Ratio)",
+		"Bool cached=x.Cached",
+		"String mode=string(x.Mode)",
+		"Int retries=*x.Retries",
+		"StringSlice tags=x.Tags",
+		"Stringer elapsed=x.Elapsed",
+	}
+	if !reflect.DeepEqual(attrs, want) {
+		t.Errorf("wrong attributes\n got %q\nwant %q", attrs, want)
+	}
+}
+
+func isIdent(e ast.Expr, name string) bool {
+	id, ok := e.(*ast.Ident)
+	return ok && id.Name == name
+}
+
+// stubImporter replaces the packages in stubs, which map import paths
+// to package names, by empty packages.
+type stubImporter struct {
+	types.Importer
+	stubs map[string]string
+}
+
+func (imp stubImporter) Import(path string) (*types.Package, error) {
+	if name, ok := imp.stubs[path]; ok {
+		pkg := types.NewPackage(path, name)
+		pkg.MarkComplete()
+		return pkg, nil
+	}
+	return imp.Importer.Import(path)
+}
+
+func runGoldenTest(t *testing.T, cfg Config) {
+	cfg.Dir = filepath.Join("..", "internal", "tests", cfg.Dir)
+	want, err := ioutil.ReadFile(filepath.Join(cfg.Dir, "output.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	code, err := Generate(&cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := diff.Diff(string(want), string(code)); d != "" {
+		t.Errorf("output mismatch\n\n%s", d)
+	}
+}
+
+func TestGraphQLSchema(t *testing.T) {
+	cfg := Config{Dir: filepath.Join("..", "internal", "tests", "graphql"), Type: "X", FieldOverride: "Xo"}
+	want, err := ioutil.ReadFile(filepath.Join(cfg.Dir, "schema.graphql"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mtyps, err := cfg.loadMarshalerTypes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	schema, err := genGraphQLSchema(mtyps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := diff.Diff(string(want), string(schema)); d != "" {
+		t.Errorf("output mismatch\n\n%s", d)
+	}
+}
+
+func TestCRDSchema(t *testing.T) {
+	cfg := Config{Dir: filepath.Join("..", "internal", "tests", "crd"), Type: "WidgetSpec,WidgetStatus"}
+	want, err := ioutil.ReadFile(filepath.Join(cfg.Dir, "schema.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mtyps, err := cfg.loadMarshalerTypes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	schema, err := genCRDSchema(mtyps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := diff.Diff(string(want), string(schema)); d != "" {
+		t.Errorf("output mismatch\n\n%s", d)
+	}
+}
+
+func TestJSONSchema(t *testing.T) {
+	cfg := Config{
+		Dir:           filepath.Join("..", "internal", "tests", "jsonschema"),
+		Type:          "Order,Item",
+		FieldOverride: "orderMarshaling,itemMarshaling",
+		Strict:        true,
+	}
+	want, err := ioutil.ReadFile(filepath.Join(cfg.Dir, "schema.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mtyps, err := cfg.loadMarshalerTypes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	schema, err := genJSONSchema(mtyps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := diff.Diff(string(want), string(schema)); d != "" {
+		t.Errorf("output mismatch\n\n%s", d)
+	}
+}
+
+func TestOpenAPISchemas(t *testing.T) {
+	cfg := Config{Dir: filepath.Join("..", "internal", "tests", "openapi"), Type: "CreateUserRequest,User"}
+	want, err := ioutil.ReadFile(filepath.Join(cfg.Dir, "schema.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mtyps, err := cfg.loadMarshalerTypes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	schemas, err := genOpenAPISchemas(mtyps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := diff.Diff(string(want), string(schemas)); d != "" {
+		t.Errorf("output mismatch\n\n%s", d)
+	}
+}
+
+func TestTypeScript(t *testing.T) {
+	cfg := Config{
+		Dir:           filepath.Join("..", "internal", "tests", "typescript"),
+		Type:          "Order,Item",
+		FieldOverride: "orderMarshaling,itemMarshaling",
+	}
+	want, err := ioutil.ReadFile(filepath.Join(cfg.Dir, "types.d.ts"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mtyps, err := cfg.loadMarshalerTypes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	decls, err := genTypeScript(mtyps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := diff.Diff(string(want), string(decls)); d != "" {
+		t.Errorf("output mismatch\n\n%s", d)
+	}
+}
+
+func TestPlugin(t *testing.T) {
+	cfg := Config{Dir: filepath.Join("..", "internal", "tests", "plugin"), Type: "Order,Item"}
+	want, err := ioutil.ReadFile(filepath.Join(cfg.Dir, "plugin", "main.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mtyps, err := cfg.loadMarshalerTypes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, err := genPlugin(mtyps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := diff.Diff(string(want), string(code)); d != "" {
+		t.Errorf("output mismatch\n\n%s", d)
+	}
+}
+
+func TestCgo(t *testing.T) {
+	cfg := Config{Dir: filepath.Join("..", "internal", "tests", "cgo"), Type: "Order,Item"}
+	want, err := ioutil.ReadFile(filepath.Join(cfg.Dir, "cexport", "main.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mtyps, err := cfg.loadMarshalerTypes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, err := genCgo(mtyps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := diff.Diff(string(want), string(code)); d != "" {
+		t.Errorf("output mismatch\n\n%s", d)
+	}
+}
+
+func TestWASM(t *testing.T) {
+	cfg := Config{Dir: filepath.Join("..", "internal", "tests", "wasm"), Type: "Order,Item"}
+	want, err := ioutil.ReadFile(filepath.Join(cfg.Dir, "wasm.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mtyps, err := cfg.loadMarshalerTypes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, err := genWASM(mtyps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := diff.Diff(string(want), string(code)); d != "" {
+		t.Errorf("output mismatch\n\n%s", d)
+	}
+}
+
+func TestGeneratedTests(t *testing.T) {
+	cfg := Config{Dir: filepath.Join("..", "internal", "tests", "gentests"), Type: "Order,item"}
+	want, err := ioutil.ReadFile(filepath.Join(cfg.Dir, "output_test.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mtyps, err := cfg.loadMarshalerTypes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests, err := genTests(mtyps, "testdata/fixtures")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := diff.Diff(string(want), string(tests)); d != "" {
+		t.Errorf("output mismatch\n\n%s", d)
+	}
+}
+
+func TestGeneratedFuzzTests(t *testing.T) {
+	cfg := Config{Dir: filepath.Join("..", "internal", "tests", "fuzz"), Type: "Server", Formats: []string{"json", "yaml"}}
+	want, err := ioutil.ReadFile(filepath.Join(cfg.Dir, "output_test.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mtyps, err := cfg.loadMarshalerTypes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fuzz, err := genFuzzTests(mtyps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := diff.Diff(string(want), string(fuzz)); d != "" {
+		t.Errorf("output mismatch\n\n%s", d)
+	}
+}
+
+func TestFuzzCorpus(t *testing.T) {
+	cfg := Config{Dir: filepath.Join("..", "internal", "tests", "fuzz"), Type: "Server", Formats: []string{"json", "yaml"}}
+	mtyps, err := cfg.loadMarshalerTypes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	corpus, err := genFuzzCorpus(mtyps, filepath.Join(cfg.Dir, "testdata", "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := filepath.Join(cfg.Dir, "testdata", "fuzz")
+	files, err := filepath.Glob(filepath.Join(dir, "*", "*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != len(corpus) {
+		t.Errorf("got %d corpus files, want %d", len(corpus), len(files))
+	}
+	for _, file := range files {
+		name, _ := filepath.Rel(dir, file)
+		want, err := ioutil.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d := diff.Diff(string(want), string(corpus[filepath.ToSlash(name)])); d != "" {
+			t.Errorf("%s mismatch\n\n%s", name, d)
+		}
+	}
+}
+
+func TestTemplateDirUnknown(t *testing.T) {
+	dir := t.TempDir()
+	if err := ioutil.WriteFile(filepath.Join(dir, "jsno.tmpl"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg := Config{Dir: filepath.Join("..", "internal", "tests", "template"), Type: "X", TemplateDir: dir}
+	_, err := Generate(&cfg)
+	if err == nil || !strings.Contains(err.Error(), `unknown template "jsno"`) {
+		t.Fatalf("wrong error %v", err)
+	}
+}
+
+// TestWarnings checks that ignored fields are reported to Warnf.
+func TestWarnings(t *testing.T) {
+	var warnings []string
+	cfg := Config{
+		Dir:   filepath.Join("..", "internal", "tests", "testdata", "embedptr"),
+		Type:  "X",
+		Warnf: func(format string, args ...interface{}) { warnings = append(warnings, fmt.Sprintf(format, args...)) },
+	}
+	if _, err := Generate(&cfg); err != nil {
+		t.Fatal(err)
+	}
+	if len(warnings) != 1 || !strings.HasSuffix(warnings[0], "ignoring embedded pointer field Inner") {
+		t.Fatalf("wrong warnings %q", warnings)
+	}
+}
+
+// TestTemplateInvalidCode checks that invalid code of a template is an error.
+func TestTemplateInvalidCode(t *testing.T) {
+	dir := t.TempDir()
+	if err := ioutil.WriteFile(filepath.Join(dir, "json.tmpl"), []byte("func {"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg := Config{Dir: filepath.Join("..", "internal", "tests", "template"), Type: "X", TemplateDir: dir}
+	_, err := Generate(&cfg)
+	if err == nil || !strings.Contains(err.Error(), "can't gofmt generated code") {
+		t.Fatalf("wrong error %v", err)
+	}
+}
+
+func TestSchemaErrors(t *testing.T) {
+	tests := []struct {
+		schema, err string
+	}{
+		{`{"$defs": {"Server": {"properties": {"host": {"type": "integer"}}}}}`, "schema of field Host: type string is not integer"},
+		{`{"$defs": {"Server": {"properties": {"port": {"minimum": 70000}}}}}`, "schema of field Port: bounds can't be satisfied by type uint16"},
+		{`{"$defs": {"Server": {"properties": {"mode": {"enum": ["prod"]}}}}}`, `schema of field Mode: default value "dev" must be one of "prod"`},
+		{`{"$defs": {"Server": {"properties": {"name": {"type": "string"}}}}}`, `property "name" has no field`},
+		{`{"$defs": {"Client": {}}}`, "has no schema of Server"},
+	}
+	dir := t.TempDir()
+	for i, test := range tests {
+		file := filepath.Join(dir, fmt.Sprintf("schema%d.json", i))
+		if err := ioutil.WriteFile(file, []byte(test.schema), 0644); err != nil {
+			t.Fatal(err)
+		}
+		cfg := Config{Dir: filepath.Join("..", "internal", "tests", "schema"), Type: "Server", Schema: file}
+		_, err := Generate(&cfg)
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("schema %s: got error %v, want %q", test.schema, err, test.err)
+		}
+	}
+}
+
+func TestLoadProject(t *testing.T) {
+	files := map[string]string{
+		"gencodec.yaml": `
+targets:
+  - dir: types
+    type: Header,Body
+    field-override: headerMarshaling,
+    formats: [json, yaml]
+    schema: schema.json
+    out: types_json.go
+  - type: X
+    strict: true
+    out: /tmp/x_json.go
+`,
+		"gencodec.toml": `
+[[targets]]
+dir = "types"
+type = "Header,Body"
+field-override = "headerMarshaling,"
+formats = ["json", "yaml"]
+schema = "schema.json"
+out = "types_json.go"
+
+[[targets]]
+type = "X"
+strict = true
+out = "/tmp/x_json.go"
+`,
+	}
+	for name, content := range files {
+		dir := t.TempDir()
+		file := filepath.Join(dir, name)
+		if err := ioutil.WriteFile(file, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		targets, err := LoadProject(file)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		want := []Target{
+			{
+				Config: Config{
+					Dir:           filepath.Join(dir, "types"),
+					Type:          "Header,Body",
+					FieldOverride: "headerMarshaling,",
+					Formats:       []string{"json", "yaml"},
+					Schema:        filepath.Join(dir, "types", "schema.json"),
+				},
+				Out: filepath.Join(dir, "types", "types_json.go"),
+			},
+			{
+				Config: Config{Dir: dir, Type: "X", Strict: true},
+				Out:    "/tmp/x_json.go",
+			},
+		}
+		if !reflect.DeepEqual(targets, want) {
+			t.Errorf("%s: wrong targets\ngot  %+v\nwant %+v", name, targets, want)
+		}
+	}
+}
+
+func TestLoadProjectErrors(t *testing.T) {
+	tests := []struct {
+		name, content, err string
+	}{
+		{"gencodec.yaml", "targets:\n  - type: X\n", "target 0 (X) has no output file"},
+		{"gencodec.yaml", "targets:\n  - out: x.go\n", "target 0 has no type"},
+		{"gencodec.yaml", "targets:\n  - type: X\n    out: x.go\n    format: [json]\n", "field format not found"},
+		{"gencodec.toml", "[[targets]]\ntype = \"X\"\nout = \"x.go\"\nformat = [\"json\"]\n", `unknown key "targets.format"`},
+		{"gencodec.toml", "", "no targets"},
+	}
+	for _, test := range tests {
+		file := filepath.Join(t.TempDir(), test.name)
+		if err := ioutil.WriteFile(file, []byte(test.content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		_, err := LoadProject(file)
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("%q: got error %v, want %q", test.content, err, test.err)
+		}
+	}
+}
+
+func TestTargetOrder(t *testing.T) {
+	var (
+		dir      = filepath.Join("..", "internal", "tests", "crossoverride")
+		utilDir  = filepath.Join(dir, "marshalutil")
+		utilPath = "github.com/fjl/gencodec/internal/tests/crossoverride/marshalutil"
+	)
+	targets := []Target{
+		{Config: Config{Dir: dir, Type: "X", FieldOverride: utilPath + ".XMarshaling"}},
+		{Config: Config{Dir: filepath.Join("..", "internal", "tests", "enum"), Type: "Color"}},
+		{Config: Config{Dir: utilDir, Type: "XMarshaling"}},
+	}
+	order, err := TargetOrder(targets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{1, 2, 0}; !reflect.DeepEqual(order, want) {
+		t.Errorf("wrong order %v, want %v", order, want)
+	}
+
+	// An override type in package crossoverride creates a cycle.
+	targets[2].FieldOverride = "github.com/fjl/gencodec/internal/tests/crossoverride.X"
+	_, err = TargetOrder(targets)
+	want := "dependency cycle between targets: github.com/fjl/gencodec/internal/tests/crossoverride -> " + utilPath + " -> github.com/fjl/gencodec/internal/tests/crossoverride"
+	if err == nil || err.Error() != want {
+		t.Errorf("wrong error %v, want %q", err, want)
+	}
+}
+
+func TestState(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		t.Helper()
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("go.mod", "module example.com/state\n")
+	write("a.go", "package state\n\ntype X struct{ A int }\n")
+	file := filepath.Join(dir, "state.json")
+	target := &Target{Config: Config{Dir: dir, Type: "X"}, Out: filepath.Join(dir, "gen.go")}
+	changed := func(want bool) {
+		t.Helper()
+		s, err := LoadState(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c, err := s.Changed(target); err != nil {
+			t.Fatal(err)
+		} else if c != want {
+			t.Fatalf("Changed returned %t, want %t", c, want)
+		}
+	}
+	record := func() {
+		t.Helper()
+		s, err := LoadState(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := s.Record(target); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.Save(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	changed(true)
+	record()
+	changed(false)
+	// Changes of the package, the output file and the configuration are detected.
+	write("a.go", "package state\n\ntype X struct{ B int }\n")
+	changed(true)
+	record()
+	write("gen.go", "package state\n")
+	changed(true)
+	record()
+	changed(false)
+	target.Formats = []string{"yaml"}
+	changed(true)
+}
+
+func TestWriteFile(t *testing.T) {
+	dir := t.TempDir()
+	generated := []byte("// Code generated by github.com/fjl/gencodec. DO NOT EDIT.\n\npackage x\n")
+
+	// New files are created with mode 0644, along with their directory.
+	file := filepath.Join(dir, "sub", "gen.go")
+	if err := WriteFile(file, generated, true); err != nil {
+		t.Fatal(err)
+	}
+	if info, err := os.Stat(file); err != nil {
+		t.Fatal(err)
+	} else if info.Mode().Perm() != 0644 {
+		t.Errorf("new file has mode %v, want 0644", info.Mode().Perm())
+	}
+
+	// The permissions of existing files are kept.
+	if err := os.Chmod(file, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteFile(file, append(generated, "// v2\n"...), true); err != nil {
+		t.Fatal(err)
+	}
+	if info, _ := os.Stat(file); info.Mode().Perm() != 0600 {
+		t.Errorf("mode changed to %v", info.Mode().Perm())
+	}
+
+	// Hand-written files are protected.
+	manual := filepath.Join(dir, "manual.go")
+	if err := ioutil.WriteFile(manual, []byte("package x\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteFile(manual, generated, true); err == nil {
+		t.Error("hand-written file overwritten")
+	}
+	if content, _ := ioutil.ReadFile(manual); string(content) != "package x\n" {
+		t.Errorf("hand-written file changed: %q", content)
+	}
+	// The existing file is checked for outputs which have no header as well.
+	if err := WriteFile(manual, []byte("{}\n"), true); err == nil {
+		t.Error("hand-written file overwritten by output without header")
+	}
+	if err := WriteFile(manual, generated, false); err != nil {
+		t.Errorf("write without protection failed: %v", err)
+	}
+
+	// The seed corpus and the model carry the marker outside of a header.
+	ts, err := Load(&Config{Dir: filepath.Join("..", "internal", "tests", "conv"), Type: "X", FieldOverride: "Xo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ir, err := ts.IR()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range map[string][]byte{"corpus": fuzzCorpusFile([]byte("{}")), "ir.json": ir} {
+		file := filepath.Join(dir, name)
+		if err := WriteFile(file, content, true); err != nil {
+			t.Fatal(err)
+		}
+		if err := WriteFile(file, content, true); err != nil {
+			t.Errorf("generated %s not replaced: %v", name, err)
+		}
+	}
+
+	// No temporary files are left behind.
+	entries, _ := os.ReadDir(filepath.Join(dir, "sub"))
+	if len(entries) != 1 {
+		t.Errorf("directory holds %d files, want 1", len(entries))
+	}
+}
+
+func TestHeader(t *testing.T) {
+	cfg := Config{
+		Dir:          filepath.Join("..", "internal", "tests", "typederrors"),
+		Type:         "X",
+		Header:       "// Code generated by gencodec format {{.FormatVersion}} for {{.Types}} ({{.Args}}). DO NOT EDIT.",
+		Args:         []string{"-type", "X", "-out", "my file.go"},
+		VersionConst: true,
+	}
+	code, err := Generate(&cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `// Code generated by gencodec format 1 for X (-type X -out "my file.go"). DO NOT EDIT.`
+	if line := strings.SplitN(string(code), "\n", 2)[0]; line != want {
+		t.Errorf("wrong header %q", line)
+	}
+	if !strings.Contains(string(code), "\nconst gencodecFormatX = 1\n") {
+		t.Error("format version constant missing")
+	}
+	if !hasGeneratedHeader(code) {
+		t.Error("custom header not recognized as generated")
+	}
+
+	cfg.Header = "// Code generated by gencodec."
+	if _, err := Generate(&cfg); err == nil {
+		t.Error("no error for header without DO NOT EDIT")
+	}
+}
+
+func TestFindProject(t *testing.T) {
+	root := t.TempDir()
+	pkg := filepath.Join(root, "a", "b")
+	if err := os.MkdirAll(pkg, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"go.mod", "gencodec.toml"} {
+		if err := ioutil.WriteFile(filepath.Join(root, name), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	file, err := FindProject(pkg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(root, "gencodec.toml"); file != want {
+		t.Errorf("found %s, want %s", file, want)
+	}
+
+	// The search stops at the module root.
+	if err := ioutil.WriteFile(filepath.Join(root, "a", "go.mod"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if file, err := FindProject(pkg); err == nil {
+		t.Errorf("found %s outside of module", file)
+	}
+}
+
+func TestScanDirectives(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"a/a.go": `package a
+
+//go:generate gencodec -type A -formats "json,yaml" -out gen_$GOFILE
+
+//gencodec:generate -strict
+type B struct{}
+
+type (
+	// C has a directive.
+	//gencodec:generate -out c_json.go
+	C struct{}
+
+	//gencodec:generatex
+	D struct{}
+)
+`,
+		"a/gen_a.go":        "// Code generated by github.com/fjl/gencodec. DO NOT EDIT.\n\npackage a\n\n//go:generate gencodec -type X\n",
+		"a/b/b.go":          "package b\n\n//go:generate go run github.com/fjl/gencodec -type B -out b_json.go\n",
+		"a/testdata/t.go":   "package t\n\n//go:generate gencodec -type T\n",
+		"a/mod/go.mod":      "module mod\n",
+		"a/mod/m.go":        "package m\n\n//go:generate gencodec -type M\n",
+		"a/other.txt":       "//go:generate gencodec -type O\n",
+		"a/b/c/doc.go":      "// Package c runs gencodec.\npackage c\n\n//go:generate echo gencodec\n",
+		"a/b/c/generate.go": "package c\n\n//gencodec:generate -type X\ntype C struct{}\n",
+	}
+	for name, content := range files {
+		file := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(file, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ds, err := ScanDirectives(filepath.Join(root, "a", "b"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ds) != 1 || !reflect.DeepEqual(ds[0].Args, []string{"-type", "B", "-out", "b_json.go"}) {
+		t.Errorf("wrong directives in a/b: %+v", ds)
+	}
+
+	ds, err = ScanDirectives(filepath.Join(root, "a") + "/...")
+	if err == nil || !strings.Contains(err.Error(), "can't have the -type flag") {
+		t.Fatalf("wrong error %v", err)
+	}
+	os.Remove(filepath.Join(root, "a", "b", "c", "generate.go"))
+	ds, err = ScanDirectives(filepath.Join(root, "a") + "/...")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Directive{
+		{Dir: filepath.Join(root, "a"), Args: []string{"-type", "A", "-formats", "json,yaml", "-out", "gen_a.go"}},
+		{Dir: filepath.Join(root, "a"), Args: []string{"-type", "B", "-strict", "-out", "gen_b_json.go"}},
+		{Dir: filepath.Join(root, "a"), Args: []string{"-type", "C", "-out", "c_json.go"}},
+		{Dir: filepath.Join(root, "a", "b"), Args: []string{"-type", "B", "-out", "b_json.go"}},
+	}
+	for i := range ds {
+		ds[i].Pos = token.Position{}
+	}
+	if !reflect.DeepEqual(ds, want) {
+		t.Errorf("wrong directives\ngot  %+v\nwant %+v", ds, want)
+	}
+}
+
+func TestCheckFormats(t *testing.T) {
+	tests := []struct {
+		formats []string
+		err     string
+	}{
+		{formats: []string{"json", "yaml", "toml"}},
+		{formats: []string{"json", "json"}, err: `duplicate format: "json"`},
+		{formats: []string{"json", ""}, err: `unknown format: ""`},
+		{formats: []string{"proto"}, err: `unknown format: "proto"`},
+	}
+	for _, test := range tests {
+		err := checkFormats(test.formats)
+		if (err == nil && test.err != "") || (err != nil && err.Error() != test.err) {
+			t.Errorf("%q: got error %v, want %q", test.formats, err, test.err)
+		}
+	}
+}
+
+func TestCompat(t *testing.T) {
+	var (
+		dir = filepath.Join("..", "internal", "tests", "compat")
+		v1  = Config{Dir: filepath.Join(dir, "v1"), Type: "X"}
+		v2  = Config{Dir: filepath.Join(dir, "v2"), Type: "X"}
+	)
+	problems, err := Compat(&v1, &v2, "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		`required field "removed" removed`,
+		`field "optional" became required`,
+		`field "count" changed type from int to int64`,
+		`field Email renamed from "email" to "mail"`,
+		`required field "added" added`,
+	}
+	if d := diff.Diff(strings.Join(problems, "\n"), strings.Join(want, "\n")); d != "" {
+		t.Errorf("wrong problems\n\n%s", d)
+	}
+}
+
+func TestVet(t *testing.T) {
+	problems, err := Vet(filepath.Join("..", "internal", "tests", "testdata", "vet"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range problems {
+		problems[i] = filepath.Base(problems[i])
+	}
+	want := []string{
+		`input.go:9:2: field BadTag has invalid struct tag: unterminated value of key "json"`,
+		`input.go:10:2: field Unknown has unknown gencodec option "requried"`,
+		`input.go:13:2: field Mismatch has json key "a" but yaml key "b"`,
+		`input.go:14:2: optional field Count has omitempty, absence can't be distinguished from the zero value`,
+		`input.go:16:2: required field Req has omitempty, it is treated as optional`,
+		`input.go:12:2: fields Dup1 and Dup2 of X have the same json key "dup"`,
+	}
+	if d := diff.Diff(strings.Join(problems, "\n"), strings.Join(want, "\n")); d != "" {
+		t.Errorf("wrong problems\n\n%s", d)
+	}
+}
+
+func TestCoverage(t *testing.T) {
+	problems, err := Coverage(filepath.Join("..", "internal", "tests", "testdata", "coverage"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range problems {
+		problems[i] = filepath.Base(problems[i])
+	}
+	want := []string{
+		`input.go:31:2: Plain is encoded by json.Marshal but has no MarshalJSON method`,
+		`input.go:32:2: Plain is encoded by json.MarshalIndent but has no MarshalJSON method`,
+		`input.go:35:2: Plain is encoded by json.Encoder.Encode but has no MarshalJSON method`,
+		`input.go:42:2: Plain is encoded by json.Unmarshal but has no UnmarshalJSON method`,
+		`input.go:44:2: Custom is encoded by json.Decoder.Decode but has no UnmarshalJSON method`,
+	}
+	if d := diff.Diff(strings.Join(problems, "\n"), strings.Join(want, "\n")); d != "" {
+		t.Errorf("wrong problems\n\n%s", d)
+	}
+}
+
+func TestIR(t *testing.T) {
+	cfg := Config{Dir: filepath.Join("..", "internal", "tests", "conv"), Type: "X", FieldOverride: "Xo", Formats: []string{"json", "yaml"}}
+	ts, err := Load(&cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ir, err := ts.IR()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var dump irDump
+	if err := json.Unmarshal(ir, &dump); err != nil {
+		t.Fatal(err)
+	}
+	if dump.Package != "github.com/fjl/gencodec/internal/tests/conv" {
+		t.Errorf("wrong package %q", dump.Package)
+	}
+	if len(dump.Types) != 1 || len(dump.Types[0].Fields) != 3 {
+		t.Fatalf("wrong types in dump:\n%s", ir)
+	}
+	port := dump.Types[0].Fields[1]
+	want := irField{Name: "Port", Type: "string", OrigType: "int", Tag: port.Tag, Kind: "conv", Conv: []string{"strconv.Itoa", "strconv.Atoi"}, Pos: port.Pos}
+	if !reflect.DeepEqual(port, want) {
+		t.Errorf("wrong field in dump\ngot  %+v\nwant %+v", port, want)
+	}
+	yaml := dump.Types[0].Formats["yaml"]
+	if yaml.Marshal.Var != "enc" || len(yaml.Fields) != 3 || yaml.Fields[1].Encode != "enc.Port = strconv.Itoa(x.Port)" {
+		t.Errorf("wrong template input of yaml: %+v", yaml)
+	}
+}
+
+func TestBuildTags(t *testing.T) {
+	dir := filepath.Join("..", "internal", "tests", "testdata", "buildtags")
+	cfg := Config{Dir: dir, Type: "X"}
+	if _, err := Generate(&cfg); err == nil {
+		t.Fatal("no error for type excluded by build constraint")
+	}
+	cfg = Config{Dir: dir, Type: "X", BuildTags: []string{"special"}}
+	code, err := Generate(&cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(code), "func (x X) MarshalJSON()") {
+		t.Errorf("MarshalJSON missing from output:\n%s", code)
+	}
+}
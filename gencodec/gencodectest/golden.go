@@ -0,0 +1,117 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+// Package gencodectest provides golden tests of gencodec output for authors of
+// custom templates and plugins. An example package holds the input types and a
+// project file, and the files generated from it are checked in next to it:
+//
+//	func TestTemplates(t *testing.T) {
+//		gencodectest.Golden(t, "testdata/example")
+//	}
+//
+// When the output changes on purpose, the files are updated by running the test
+// with the -gencodectest.update flag.
+package gencodectest
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fjl/gencodec/gencodec"
+	"github.com/kylelemons/godebug/diff"
+)
+
+var update = flag.Bool("gencodectest.update", false, "write the output of gencodectest.Golden to the golden files")
+
+// output is a generated file of a target.
+type output struct {
+	file string
+	gen  func(*gencodec.TypeSet) ([]byte, error)
+}
+
+// Golden generates the targets of the project file in dir and compares the results
+// with the output files of the targets. The project file must be in dir itself, it
+// is not searched in the parent directories. Every target is checked in a subtest
+// named after its output file.
+//
+// All outputs of a target are compared except for the fuzz seed corpus. Mismatches
+// are reported as diffs. With the -gencodectest.update flag, the files are written
+// instead.
+func Golden(t *testing.T, dir string) {
+	t.Helper()
+	var file string
+	for _, name := range gencodec.ProjectFiles {
+		if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+			file = filepath.Join(dir, name)
+			break
+		}
+	}
+	if file == "" {
+		t.Fatalf("no project file in %s", dir)
+	}
+	targets, err := gencodec.LoadProject(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range targets {
+		target := &targets[i]
+		name, err := filepath.Rel(dir, target.Out)
+		if err != nil {
+			name = target.Out
+		}
+		t.Run(filepath.ToSlash(name), func(t *testing.T) {
+			checkTarget(t, target)
+		})
+	}
+}
+
+func checkTarget(t *testing.T, target *gencodec.Target) {
+	types, err := gencodec.Load(&target.Config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	outputs := []output{
+		{target.Out, (*gencodec.TypeSet).Code},
+		{target.GraphQL, (*gencodec.TypeSet).GraphQLSchema},
+		{target.CRD, (*gencodec.TypeSet).CRDSchema},
+		{target.JSONSchema, (*gencodec.TypeSet).JSONSchema},
+		{target.OpenAPI, (*gencodec.TypeSet).OpenAPISchemas},
+		{target.TypeScript, (*gencodec.TypeSet).TypeScript},
+		{target.Plugin, (*gencodec.TypeSet).Plugin},
+		{target.WASM, (*gencodec.TypeSet).WASM},
+		{target.Cgo, (*gencodec.TypeSet).Cgo},
+		{target.Tests, func(ts *gencodec.TypeSet) ([]byte, error) { return ts.Tests(target.TestDir) }},
+		{target.Fuzz, (*gencodec.TypeSet).FuzzTests},
+		{target.DumpIR, (*gencodec.TypeSet).IR},
+	}
+	for _, out := range outputs {
+		if out.file == "" {
+			continue
+		}
+		got, err := out.gen(types)
+		if err != nil {
+			t.Errorf("%s: %v", out.file, err)
+			continue
+		}
+		if *update {
+			if err := os.MkdirAll(filepath.Dir(out.file), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(out.file, got, 0644); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+		want, err := os.ReadFile(out.file)
+		if err != nil {
+			t.Errorf("%v (run the test with -gencodectest.update to create it)", err)
+			continue
+		}
+		if d := diff.Diff(string(want), string(got)); d != "" {
+			t.Errorf("%s: output mismatch\n\n%s", out.file, d)
+		}
+	}
+}
@@ -0,0 +1,11 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodectest
+
+import "testing"
+
+func TestGolden(t *testing.T) {
+	Golden(t, "testdata/example")
+}
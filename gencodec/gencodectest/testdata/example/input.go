@@ -0,0 +1,10 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package example
+
+type X struct {
+	Name  string `json:"name" gencodec:"required"`
+	Count int    `json:"count"`
+}
@@ -0,0 +1,978 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"fmt"
+	"go/ast"
+	"go/printer"
+	"go/token"
+	"go/types"
+	"io"
+	"strconv"
+	"strings"
+
+	. "github.com/garslo/gogen"
+)
+
+var (
+	NIL     = Name("nil")
+	intType = types.Universe.Lookup("int").Type()
+)
+
+type marshalMethod struct {
+	mtyp        *marshalerType
+	scope       *funcScope
+	isUnmarshal bool
+	present     string // variable of the presence flags in UnmarshalJSON, empty if unused
+	appendHooks bool   // hooked fields are appended by MarshalJSONTo instead of converted
+	node        string // variable of the *yaml.Node in UnmarshalYAML, empty if unused
+	errs        string // variable collecting the errors of -all-errors, empty until declared
+	// cached identifiers for map, slice conversions
+	iterKey, iterVal Var
+}
+
+func newMarshalMethod(mtyp *marshalerType, isUnmarshal bool) *marshalMethod {
+	s := newFuncScope(mtyp.scope)
+	return &marshalMethod{
+		mtyp:        mtyp,
+		scope:       newFuncScope(mtyp.scope),
+		isUnmarshal: isUnmarshal,
+		iterKey:     Name(s.newIdent("k")),
+		iterVal:     Name(s.newIdent("v")),
+	}
+}
+
+func writeFunction(w io.Writer, fs *token.FileSet, fn Function) {
+	printer.Fprint(w, fs, fn.Declaration())
+	fmt.Fprintln(w)
+}
+
+// genUnmarshalJSON generates the UnmarshalJSON method.
+func genUnmarshalJSON(mtyp *marshalerType) Function {
+	return genUnmarshalLikeJSON(mtyp, "JSON", "encoding/json")
+}
+
+// genUnmarshalCBOR generates the UnmarshalCBOR method.
+func genUnmarshalCBOR(mtyp *marshalerType) Function {
+	return genUnmarshalLikeJSON(mtyp, "CBOR", "github.com/fxamacker/cbor/v2")
+}
+
+// genUnmarshalBSON generates the UnmarshalBSON method.
+func genUnmarshalBSON(mtyp *marshalerType) Function {
+	return genUnmarshalLikeJSON(mtyp, "BSON", "go.mongodb.org/mongo-driver/bson")
+}
+
+// genUnmarshalLikeJSON generates an unmarshaling method taking the encoded input,
+// which is decoded using the Unmarshal function of package path.
+func genUnmarshalLikeJSON(mtyp *marshalerType, name, path string) Function {
+	m := newMarshalMethod(mtyp, true)
+	if name == "JSON" && mtyp.presence {
+		m.present = m.scope.newIdent("present")
+	}
+	var (
+		recv     = m.receiver()
+		input    = Name(m.scope.newIdent("input"))
+		intertyp = m.intermediateType(m.intermediateName(strings.ToLower(name)), strings.ToLower(name))
+		dec      = Name(m.scope.newIdent("dec"))
+		pkg      = Name(m.scope.parent.packageName(path))
+	)
+	fn := Function{
+		Receiver:    recv,
+		Name:        "Unmarshal" + name,
+		ReturnTypes: Types{{TypeName: "error"}},
+		Parameters:  Types{{Name: input.Name, TypeName: "[]byte"}},
+		Body: []Statement{
+			declStmt{intertyp},
+			Declare{Name: dec.Name, TypeName: intertyp.Name},
+		},
+	}
+	if name == "JSON" && mtyp.allErrors {
+		m.errs = m.scope.newIdent("errs")
+		fn.Body = append(fn.Body, Declare{Name: m.errs, TypeName: "[]error"})
+		fn.Body = append(fn.Body, m.decodeJSONFields(input, dec)...)
+	} else if mtyp.strict && name == "JSON" {
+		if mtyp.typedErrors {
+			fn.Body = append(fn.Body, m.checkUnknownJSONKeys(input)...)
+		}
+		fn.Body = append(fn.Body, m.strictDecodeJSON(input, dec)...)
+	} else {
+		fn.Body = append(fn.Body, errCheck(CallFunction{
+			Func:   Dotted{Receiver: pkg, Name: "Unmarshal"},
+			Params: []Expression{input, AddressOf{Value: dec}},
+		}))
+	}
+	fn.Body = append(fn.Body, m.decodePresence(input)...)
+	fn.Body = append(fn.Body, m.unmarshalConversions(dec, Name(recv.Name), strings.ToLower(name))...)
+	if name == "JSON" && mtyp.unknown != nil {
+		fn.Body = append(fn.Body, m.collectUnknownJSON(input, Name(recv.Name))...)
+	}
+	fn.Body = append(fn.Body, m.unmarshalReturn(Name(recv.Name)))
+	if name == "JSON" && mtyp.metrics != "" {
+		fn.Body = m.reportMetrics(fn.Body, input)
+	}
+	return fn
+}
+
+// genMarshalJSON generates the MarshalJSON method.
+func genMarshalJSON(mtyp *marshalerType) Function {
+	return genMarshalLikeJSON(mtyp, "JSON", "encoding/json")
+}
+
+// genMarshalCBOR generates the MarshalCBOR method.
+func genMarshalCBOR(mtyp *marshalerType) Function {
+	return genMarshalLikeJSON(mtyp, "CBOR", "github.com/fxamacker/cbor/v2")
+}
+
+// genMarshalBSON generates the MarshalBSON method.
+func genMarshalBSON(mtyp *marshalerType) Function {
+	return genMarshalLikeJSON(mtyp, "BSON", "go.mongodb.org/mongo-driver/bson")
+}
+
+// genMarshalLikeJSON generates a marshaling method returning the output
+// of the Marshal function of package path.
+func genMarshalLikeJSON(mtyp *marshalerType, name, path string) Function {
+	var (
+		m        = newMarshalMethod(mtyp, false)
+		recv     = m.receiver()
+		intertyp = m.intermediateType(m.intermediateName(strings.ToLower(name)), strings.ToLower(name))
+		enc      = Name(m.scope.newIdent("enc"))
+		pkg      = Name(m.scope.parent.packageName(path))
+	)
+	fn := Function{
+		Receiver:    recv,
+		Name:        "Marshal" + name,
+		ReturnTypes: Types{{TypeName: "[]byte"}, {TypeName: "error"}},
+		Body: []Statement{
+			declStmt{intertyp},
+			Declare{Name: enc.Name, TypeName: intertyp.Name},
+		},
+	}
+	fn.Body = append(fn.Body, m.marshalConversions(Name(recv.Name), enc, strings.ToLower(name))...)
+	if name == "JSON" && mtyp.unknown != nil {
+		fn.Body = append(fn.Body, m.returnWithUnknownJSON(enc, Name(recv.Name))...)
+		return fn
+	}
+	fn.Body = append(fn.Body, Return{Values: []Expression{
+		CallFunction{
+			Func:   Dotted{Receiver: pkg, Name: "Marshal"},
+			Params: []Expression{AddressOf{Value: enc}},
+		},
+	}})
+	return fn
+}
+
+// genUnmarshalYAML generates the UnmarshalYAML method.
+func genUnmarshalYAML(mtyp *marshalerType) Function {
+	return genUnmarshalLikeYAML(mtyp, "YAML")
+}
+
+// genUnmarshalTOML generates the UnmarshalTOML method.
+func genUnmarshalTOML(mtyp *marshalerType) Function {
+	if mtyp.tomlValue {
+		return genUnmarshalTOMLValue(mtyp)
+	}
+	return genUnmarshalLikeYAML(mtyp, "TOML")
+}
+
+func genUnmarshalLikeYAML(mtyp *marshalerType, name string) Function {
+	var (
+		m         = newMarshalMethod(mtyp, true)
+		recv      = m.receiver()
+		node      = name == "YAML" && mtyp.yamlNode
+		unmarshal Var
+	)
+	if !node {
+		unmarshal = Name(m.scope.newIdent("unmarshal"))
+	}
+	var (
+		intertyp = m.intermediateType(m.intermediateName(strings.ToLower(name)), strings.ToLower(name))
+		dec      = Name(m.scope.newIdent("dec"))
+		tag      = strings.ToLower(name)
+		param    = Types{{Name: unmarshal.Name, TypeName: "func (interface{}) error"}}
+		decode   = Statement(errCheck(CallFunction{Func: unmarshal, Params: []Expression{AddressOf{Value: dec}}}))
+	)
+	if node {
+		param, decode = m.yamlNodeParam(dec)
+	}
+	if name == "YAML" && mtyp.unknown != nil {
+		intertyp.Fields = append(intertyp.Fields, m.unknownYAMLField())
+	}
+	fn := Function{
+		Receiver:    recv,
+		Name:        "Unmarshal" + name,
+		ReturnTypes: Types{{TypeName: "error"}},
+		Parameters:  param,
+		Body:        []Statement{declStmt{intertyp}, Declare{Name: dec.Name, TypeName: intertyp.Name}, decode},
+	}
+	switch {
+	case mtyp.strict && node:
+		fn.Body = append(fn.Body, m.checkUnknownYAMLNodeKeys()...)
+	case mtyp.strict:
+		fn.Body = append(fn.Body, m.checkUnknownKeys(unmarshal, tag)...)
+	}
+	fn.Body = append(fn.Body, m.unmarshalConversions(dec, Name(recv.Name), tag)...)
+	if name == "YAML" && mtyp.unknown != nil {
+		fn.Body = append(fn.Body, m.collectUnknownYAML(dec, Name(recv.Name))...)
+	}
+	fn.Body = append(fn.Body, m.unmarshalReturn(Name(recv.Name)))
+	return fn
+}
+
+// strictDecodeJSON decodes input into dec, rejecting unknown keys and trailing data.
+func (m *marshalMethod) strictDecodeJSON(input, dec Var) []Statement {
+	var (
+		d      = Name(m.scope.newIdent("d"))
+		err    = Name("err")
+		bytes  = Name(m.scope.parent.packageName("bytes"))
+		errors = Name(m.scope.parent.packageName("errors"))
+		io     = Name(m.scope.parent.packageName("io"))
+		json   = Name(m.scope.parent.packageName("encoding/json"))
+	)
+	reader := CallFunction{Func: Dotted{Receiver: bytes, Name: "NewReader"}, Params: []Expression{input}}
+	return []Statement{
+		DeclareAndAssign{
+			Lhs: d,
+			Rhs: CallFunction{Func: Dotted{Receiver: json, Name: "NewDecoder"}, Params: []Expression{reader}},
+		},
+		CallFunction{Func: Dotted{Receiver: d, Name: "DisallowUnknownFields"}},
+		errCheck(CallFunction{
+			Func:   Dotted{Receiver: d, Name: "Decode"},
+			Params: []Expression{AddressOf{Value: dec}},
+		}),
+		If{
+			Init: declareMulti{
+				Lhs: []Expression{Name("_"), err},
+				Rhs: CallFunction{Func: Dotted{Receiver: d, Name: "Token"}},
+			},
+			Condition: NotEqual{Lhs: err, Rhs: Dotted{Receiver: io, Name: "EOF"}},
+			Body: []Statement{
+				Return{Values: []Expression{
+					CallFunction{
+						Func:   Dotted{Receiver: errors, Name: "New"},
+						Params: []Expression{stringLit{"unexpected data after JSON value for " + m.mtyp.name}},
+					},
+				}},
+			},
+		},
+	}
+}
+
+// checkUnknownKeys decodes the keys of the input using the unmarshal function
+// and returns an error for the first unknown key in sorted order.
+func (m *marshalMethod) checkUnknownKeys(unmarshal Var, format string) []Statement {
+	keys := Name(m.scope.newIdent("keys"))
+	return append([]Statement{
+		Declare{Name: keys.Name, TypeName: "map[string]interface{}"},
+		errCheck(CallFunction{Func: unmarshal, Params: []Expression{AddressOf{Value: keys}}}),
+	}, m.firstUnknownKey(keys, format)...)
+}
+
+// firstUnknownKey returns an error for the key of the map keys which doesn't belong
+// to a field and comes first in sorted order. Sorting the unknown keys makes the
+// error independent of the map iteration order when there are several.
+func (m *marshalMethod) firstUnknownKey(keys Var, format string) []Statement {
+	var (
+		key     = Name(m.scope.newIdent("key"))
+		unknown = Name(m.scope.newIdent("unknown"))
+		sort    = Name(m.scope.parent.packageName("sort"))
+	)
+	body := append(m.skipKnown(key, format), Assign{
+		Lhs: unknown,
+		Rhs: CallFunction{Func: Name("append"), Params: []Expression{unknown, key}},
+	})
+	loop := Range{Key: key, RangeValue: keys, Body: body}.Statement().(*ast.RangeStmt)
+	loop.Value = nil // range over the keys only
+	return []Statement{
+		Declare{Name: unknown.Name, TypeName: "[]string"},
+		Thunk{Stmt: loop},
+		If{
+			Condition: GreaterThan{Lhs: CallFunction{Func: Name("len"), Params: []Expression{unknown}}, Rhs: Int(0)},
+			Body: []Statement{
+				CallFunction{Func: Dotted{Receiver: sort, Name: "Strings"}, Params: []Expression{unknown}},
+				Return{Values: []Expression{m.unknownFieldError(Index{Value: unknown, Index: Int(0)})}},
+			},
+		},
+	}
+}
+
+// genMarshalYAML generates the MarshalYAML method.
+func genMarshalYAML(mtyp *marshalerType) Function {
+	return genMarshalLikeYAML(mtyp, "YAML")
+}
+
+// genMarshalTOML generates the MarshalTOML method.
+func genMarshalTOML(mtyp *marshalerType) Function {
+	if mtyp.tomlValue {
+		return genMarshalTOMLValue(mtyp)
+	}
+	return genMarshalLikeYAML(mtyp, "TOML")
+}
+
+func genMarshalLikeYAML(mtyp *marshalerType, name string) Function {
+	var (
+		m        = newMarshalMethod(mtyp, false)
+		recv     = m.receiver()
+		intertyp = m.intermediateType(m.intermediateName(strings.ToLower(name)), strings.ToLower(name))
+		enc      = Name(m.scope.newIdent("enc"))
+		tag      = strings.ToLower(name)
+	)
+	if name == "YAML" && mtyp.unknown != nil {
+		intertyp.Fields = append(intertyp.Fields, m.unknownYAMLField())
+	}
+	fn := Function{
+		Receiver:    recv,
+		Name:        "Marshal" + name,
+		ReturnTypes: Types{{TypeName: "interface{}"}, {TypeName: "error"}},
+		Body: []Statement{
+			declStmt{intertyp},
+			Declare{Name: enc.Name, TypeName: intertyp.Name},
+		},
+	}
+	fn.Body = append(fn.Body, m.marshalConversions(Name(recv.Name), enc, tag)...)
+	if name == "YAML" && mtyp.unknown != nil {
+		fn.Body = append(fn.Body, m.convertUnknownYAML(enc, Name(recv.Name))...)
+	}
+	fn.Body = append(fn.Body, Return{Values: []Expression{AddressOf{Value: enc}, NIL}})
+	return fn
+}
+
+// genDecodeMsgpack generates the DecodeMsgpack method.
+func genDecodeMsgpack(mtyp *marshalerType) Function {
+	var (
+		m        = newMarshalMethod(mtyp, true)
+		recv     = m.receiver()
+		d        = Name(m.scope.newIdent("d"))
+		intertyp = m.intermediateType(m.intermediateName("msgpack"), "msgpack")
+		dec      = Name(m.scope.newIdent("dec"))
+		msgpack  = m.scope.parent.packageName("github.com/vmihailenco/msgpack/v5")
+	)
+	fn := Function{
+		Receiver:    recv,
+		Name:        "DecodeMsgpack",
+		ReturnTypes: Types{{TypeName: "error"}},
+		Parameters:  Types{{Name: d.Name, TypeName: "*" + msgpack + ".Decoder"}},
+		Body: []Statement{
+			declStmt{intertyp},
+			Declare{Name: dec.Name, TypeName: intertyp.Name},
+			errCheck(CallFunction{
+				Func:   Dotted{Receiver: d, Name: "Decode"},
+				Params: []Expression{AddressOf{Value: dec}},
+			}),
+		},
+	}
+	fn.Body = append(fn.Body, m.unmarshalConversions(dec, Name(recv.Name), "msgpack")...)
+	fn.Body = append(fn.Body, m.unmarshalReturn(Name(recv.Name)))
+	return fn
+}
+
+// genEncodeMsgpack generates the EncodeMsgpack method.
+func genEncodeMsgpack(mtyp *marshalerType) Function {
+	var (
+		m        = newMarshalMethod(mtyp, false)
+		recv     = m.receiver()
+		e        = Name(m.scope.newIdent("e"))
+		intertyp = m.intermediateType(m.intermediateName("msgpack"), "msgpack")
+		enc      = Name(m.scope.newIdent("enc"))
+		msgpack  = m.scope.parent.packageName("github.com/vmihailenco/msgpack/v5")
+	)
+	fn := Function{
+		Receiver:    recv,
+		Name:        "EncodeMsgpack",
+		ReturnTypes: Types{{TypeName: "error"}},
+		Parameters:  Types{{Name: e.Name, TypeName: "*" + msgpack + ".Encoder"}},
+		Body: []Statement{
+			declStmt{intertyp},
+			Declare{Name: enc.Name, TypeName: intertyp.Name},
+		},
+	}
+	fn.Body = append(fn.Body, m.marshalConversions(Name(recv.Name), enc, "msgpack")...)
+	fn.Body = append(fn.Body, Return{Values: []Expression{
+		CallFunction{
+			Func:   Dotted{Receiver: e, Name: "Encode"},
+			Params: []Expression{AddressOf{Value: enc}},
+		},
+	}})
+	return fn
+}
+
+// genUnmarshalXML generates the UnmarshalXML method.
+func genUnmarshalXML(mtyp *marshalerType) Function {
+	var (
+		m        = newMarshalMethod(mtyp, true)
+		recv     = m.receiver()
+		d        = Name(m.scope.newIdent("d"))
+		start    = Name(m.scope.newIdent("start"))
+		intertyp = m.intermediateType(m.intermediateName("xml"), "xml")
+		dec      = Name(m.scope.newIdent("dec"))
+		xml      = m.scope.parent.packageName("encoding/xml")
+	)
+	fn := Function{
+		Receiver:    recv,
+		Name:        "UnmarshalXML",
+		ReturnTypes: Types{{TypeName: "error"}},
+		Parameters: Types{
+			{Name: d.Name, TypeName: "*" + xml + ".Decoder"},
+			{Name: start.Name, TypeName: xml + ".StartElement"},
+		},
+		Body: []Statement{
+			declStmt{intertyp},
+			Declare{Name: dec.Name, TypeName: intertyp.Name},
+			errCheck(CallFunction{
+				Func:   Dotted{Receiver: d, Name: "DecodeElement"},
+				Params: []Expression{AddressOf{Value: dec}, AddressOf{Value: start}},
+			}),
+		},
+	}
+	fn.Body = append(fn.Body, m.unmarshalConversions(dec, Name(recv.Name), "xml")...)
+	fn.Body = append(fn.Body, m.unmarshalReturn(Name(recv.Name)))
+	return fn
+}
+
+// genMarshalXML generates the MarshalXML method.
+func genMarshalXML(mtyp *marshalerType) Function {
+	var (
+		m        = newMarshalMethod(mtyp, false)
+		recv     = m.receiver()
+		e        = Name(m.scope.newIdent("e"))
+		start    = Name(m.scope.newIdent("start"))
+		intertyp = m.intermediateType(m.intermediateName("xml"), "xml")
+		enc      = Name(m.scope.newIdent("enc"))
+		xml      = m.scope.parent.packageName("encoding/xml")
+	)
+	fn := Function{
+		Receiver:    recv,
+		Name:        "MarshalXML",
+		ReturnTypes: Types{{TypeName: "error"}},
+		Parameters: Types{
+			{Name: e.Name, TypeName: "*" + xml + ".Encoder"},
+			{Name: start.Name, TypeName: xml + ".StartElement"},
+		},
+		Body: []Statement{
+			declStmt{intertyp},
+			Declare{Name: enc.Name, TypeName: intertyp.Name},
+		},
+	}
+	fn.Body = append(fn.Body, m.marshalConversions(Name(recv.Name), enc, "xml")...)
+	fn.Body = append(fn.Body, Return{Values: []Expression{
+		CallFunction{
+			Func:   Dotted{Receiver: e, Name: "EncodeElement"},
+			Params: []Expression{AddressOf{Value: enc}, start},
+		},
+	}})
+	return fn
+}
+
+func (m *marshalMethod) receiver() Receiver {
+	letter := strings.ToLower(m.mtyp.name[:1])
+	r := Receiver{Name: m.scope.newIdent(letter), Type: Name(m.mtyp.recvType())}
+	if m.isUnmarshal {
+		r.Type = Star{Value: r.Type}
+	}
+	return r
+}
+
+// intermediateName returns the name of the intermediate type of a format, which is
+// the name of the original type followed by the configured suffix.
+func (m *marshalMethod) intermediateName(format string) string {
+	suffix, ok := m.mtyp.interSuffix[format]
+	if !ok {
+		suffix = m.mtyp.interSuffix[""]
+	}
+	return m.scope.newIdent(m.mtyp.orig.Obj().Name() + suffix)
+}
+
+func (m *marshalMethod) intermediateType(name, format string) Struct {
+	s := Struct{Name: name}
+	for _, f := range m.mtyp.Fields {
+		if m.isUnmarshal && f.function != nil {
+			continue // fields generated from functions cannot be assigned on unmarshal
+		}
+		if !f.inFormat(format) || (m.appendHooks && f.hooked(format)) {
+			continue
+		}
+		typ := f.typ
+		if m.isUnmarshal {
+			typ = m.decodedType(f)
+		}
+		name := typeString(typ, m.mtyp.scope.qualify)
+		if f.hooked(format) {
+			name = m.mtyp.rawMessage()
+		}
+		s.Fields = append(s.Fields, Field{Name: f.name, TypeName: name, Tag: f.tag})
+	}
+	return s
+}
+
+// access creates the selector expression of the field in the original type.
+func (mf *marshalerField) access(recv Expression) Expression {
+	if mf.path == nil {
+		return Dotted{Receiver: recv, Name: mf.name}
+	}
+	for _, name := range mf.path {
+		recv = Dotted{Receiver: recv, Name: name}
+	}
+	return recv
+}
+
+func (m *marshalMethod) unmarshalConversions(from, to Var, format string) (s []Statement) {
+	if m.mtyp.allErrors {
+		return m.unmarshalAllErrors(from, to, format)
+	}
+	for _, f := range m.mtyp.Fields {
+		if f.function != nil || !f.inFormat(format) {
+			continue // fields generated from functions cannot be assigned
+		}
+		s = append(s, m.unmarshalField(f, from, to, format)...)
+	}
+	return s
+}
+
+// unmarshalField assigns the decoded value of a field in from to the receiver to.
+// Absent required fields are reported as an error.
+func (m *marshalMethod) unmarshalField(f *marshalerField, from, to Var, format string) (s []Statement) {
+	accessFrom := Dotted{Receiver: from, Name: f.name}
+	accessTo := f.access(to)
+	conv := m.decodeField(f, accessFrom, to, format)
+	if f.schema != nil {
+		v := Expression(accessFrom)
+		if isPointer(m.decodedType(f)) {
+			v = Star{Value: v}
+		}
+		conv = append(m.checkSchema(f, v, format), conv...)
+	}
+	if !f.isRequired(format) {
+		check := If{Condition: m.isPresent(f, accessFrom), Body: conv}
+		if f.defValue != nil {
+			return []Statement{ifElse{If: check, Else: []Statement{Assign{Lhs: accessTo, Rhs: f.defValue}}}}
+		}
+		return []Statement{check}
+	}
+	s = append(s, If{
+		Condition: m.isAbsent(f, accessFrom),
+		Body:      []Statement{Return{Values: []Expression{m.missingFieldError(f, format)}}},
+	})
+	return append(s, conv...)
+}
+
+// decodeField converts the decoded value of a field and assigns it to the field of
+// the receiver recv. Amounts of money also set the currency field, and the values
+// of dynamic fields are checked against the interface type of the field.
+func (m *marshalMethod) decodeField(f *marshalerField, from, recv Expression, format string) []Statement {
+	if f.money != nil {
+		return m.unmarshalMoney(f, from, f.access(recv), f.money.currency.access(recv), format)
+	}
+	if f.dynamic {
+		return m.unmarshalDynamic(f, from, f.access(recv), format)
+	}
+	if f.union != nil {
+		return []Statement{Assign{Lhs: f.access(recv), Rhs: Dotted{Receiver: from, Name: "v"}}}
+	}
+	if f.hooked(format) {
+		return m.unmarshalHook(f, from, f.access(recv), format)
+	}
+	if f.conv != nil {
+		return m.unmarshalConv(f, from, f.access(recv))
+	}
+	return m.convertDecoded(f, from, f.access(recv), format)
+}
+
+// convertDecoded converts the decoded value of a field to the original field type.
+func (m *marshalMethod) convertDecoded(f *marshalerField, from, to Expression, format string) []Statement {
+	wk := f.wellKnown
+	if wk == nil {
+		return m.convert(from, to, m.decodedType(f), f.origTyp)
+	}
+	in := Star{Value: from}
+	s := append(wk.decode(m, wk, in, to, m.fieldDesc(f, format)), m.checkLimits(wk, to, m.fieldDesc(f, format), format)...)
+	if wk.emptyZero {
+		empty := Equals{Lhs: in, Rhs: stringLit{""}}
+		return []Statement{ifElse{If: If{Condition: empty, Body: []Statement{Assign{Lhs: to, Rhs: wk.zeroValue(m)}}}, Else: s}}
+	}
+	return s
+}
+
+// fieldDesc describes field f by its key in error messages.
+func (m *marshalMethod) fieldDesc(f *marshalerField, format string) string {
+	key, _ := f.key(format)
+	return fmt.Sprintf("field '%s' of %s", key, m.mtyp.name)
+}
+
+// checkLimits generates the precision and scale checks of a decimal value. When
+// marshaling, the value is checked before it is encoded, so that it is not rounded
+// to the scale.
+func (m *marshalMethod) checkLimits(wk *wellKnownField, v Expression, field, format string) (s []Statement) {
+	if wk.validate == nil {
+		return nil
+	}
+	errors := m.scope.parent.packageName("errors")
+	for _, check := range wk.validate(m, wk, v) {
+		err := CallFunction{
+			Func:   Dotted{Receiver: Name(errors), Name: "New"},
+			Params: []Expression{stringLit{fmt.Sprintf("%s exceeds %s", field, check.desc)}},
+		}
+		ret := Return{Values: []Expression{err}}
+		if !m.isUnmarshal {
+			ret = marshalErrorReturn(format, err)
+		}
+		s = append(s, If{Condition: check.cond, Body: []Statement{ret}})
+	}
+	return s
+}
+
+// marshalErrorReturn returns err from a marshaling method of format. The methods
+// of msgpack and XML only return an error, the others also return the encoding.
+func marshalErrorReturn(format string, err Expression) Return {
+	if format == "msgpack" || format == "xml" {
+		return Return{Values: []Expression{err}}
+	}
+	return Return{Values: []Expression{NIL, err}}
+}
+
+func (m *marshalMethod) marshalConversions(from, to Var, format string) (s []Statement) {
+	for _, f := range m.mtyp.Fields {
+		if f.inFormat(format) && !(m.appendHooks && f.hooked(format)) {
+			s = append(s, m.marshalField(f, from, to, format)...)
+		}
+	}
+	return s
+}
+
+// marshalField assigns the field of the intermediate value to from the receiver from.
+func (m *marshalMethod) marshalField(f *marshalerField, from, to Var, format string) []Statement {
+	accessFrom := f.access(from)
+	accessTo := Dotted{Receiver: to, Name: f.name}
+	switch {
+	case f.function != nil:
+		return m.convert(CallFunction{Func: accessFrom}, accessTo, f.origTyp, f.typ)
+	case f.money != nil:
+		return m.marshalMoney(f, from, accessTo, format)
+	case f.dynamic:
+		return m.marshalDynamic(f, accessFrom, accessTo)
+	case f.union != nil:
+		return m.marshalUnion(f, accessFrom, accessTo)
+	case f.hooked(format):
+		return m.marshalHook(f, accessFrom, accessTo)
+	case f.conv != nil:
+		return m.marshalConv(f, accessFrom, accessTo)
+	case f.wellKnown != nil:
+		return m.marshalWellKnown(f, accessFrom, accessTo, format)
+	default:
+		return m.convert(accessFrom, accessTo, f.origTyp, f.typ)
+	}
+}
+
+func (m *marshalMethod) convert(from, to Expression, fromtyp, totyp types.Type) (s []Statement) {
+	// Remove pointer introduced by ensureNilCheckable during field building.
+	if isPointer(fromtyp) && !isPointer(totyp) {
+		from = Star{Value: from}
+		fromtyp = fromtyp.(*types.Pointer).Elem()
+	} else if !isPointer(fromtyp) && isPointer(totyp) {
+		from = AddressOf{Value: from}
+		fromtyp = types.NewPointer(fromtyp)
+	}
+	// Generate the conversion.
+	return m.convertValue(from, to, fromtyp, totyp, false)
+}
+
+// convertValue generates the conversion of from to to. Slices, arrays and maps whose
+// elements aren't convertible are converted element by element. The nested flag is
+// set for the conversion of such elements.
+func (m *marshalMethod) convertValue(from, to Expression, fromtyp, totyp types.Type, nested bool) []Statement {
+	qf := m.mtyp.scope.qualify
+	switch {
+	case underlyingSlice(fromtyp) != nil && underlyingArray(totyp) != nil:
+		return m.arrayConv(from, to, fromtyp, totyp, nested)
+	case types.ConvertibleTo(fromtyp, totyp):
+		return []Statement{Assign{Lhs: to, Rhs: simpleConv(from, fromtyp, totyp, qf)}}
+	case underlyingSlice(fromtyp) != nil, underlyingArray(fromtyp) != nil:
+		return m.loopConv(from, to, seqKV(fromtyp), seqKV(totyp), nested)
+	case underlyingMap(fromtyp) != nil:
+		return m.loopConv(from, to, mapKV(fromtyp), mapKV(totyp), nested)
+	default:
+		invalidConv(fromtyp, totyp, qf)
+		return nil
+	}
+}
+
+// arrayConv converts a slice to an array after checking its length. It is used by
+// unmarshaling methods only.
+func (m *marshalMethod) arrayConv(from, to Expression, fromtyp, totyp types.Type, nested bool) []Statement {
+	var (
+		qf     = m.mtyp.scope.qualify
+		length = underlyingArray(totyp).Len()
+		errors = Name(m.scope.parent.packageName("errors"))
+		errMsg = fmt.Sprintf("%s value must have length %d", types.TypeString(totyp, qf), length)
+	)
+	check := If{
+		Condition: NotEqual{Lhs: CallFunction{Func: Name("len"), Params: []Expression{from}}, Rhs: Int(int(length))},
+		Body: []Statement{Return{Values: []Expression{
+			CallFunction{Func: Dotted{Receiver: errors, Name: "New"}, Params: []Expression{stringLit{errMsg}}},
+		}}},
+	}
+	if types.ConvertibleTo(fromtyp, totyp) {
+		return []Statement{check, Assign{Lhs: to, Rhs: simpleConv(from, fromtyp, totyp, qf)}}
+	}
+	return append([]Statement{check}, m.loopConv(from, to, seqKV(fromtyp), seqKV(totyp), nested)...)
+}
+
+// marshalWellKnown converts a field of well-known type to its representation.
+// The representation is left nil if the field value is not present.
+func (m *marshalMethod) marshalWellKnown(f *marshalerField, from, to Expression, format string) []Statement {
+	wk := f.wellKnown
+	v := Name(m.scope.newIdent("v"))
+	conv := append(m.checkLimits(wk, from, m.fieldDesc(f, format), format),
+		DeclareAndAssign{Lhs: v, Rhs: wk.encode(wk, from)},
+		Assign{Lhs: to, Rhs: AddressOf{Value: v}},
+	)
+	value := from
+	if wk.ptr {
+		value = Star{Value: from}
+	}
+	switch {
+	case wk.zero != "" && f.hasOption("omitzero"):
+		zero := Dotted{Receiver: Name(m.mtyp.scope.qualify(wk.named.Obj().Pkg())), Name: wk.zero}
+		conv = []Statement{If{Condition: NotEqual{Lhs: value, Rhs: zero}, Body: conv}}
+	case wk.emptyZero && f.hasOption("omitzero"):
+		conv = []Statement{If{Condition: wk.present(value), Body: conv}}
+	case wk.emptyZero:
+		// Values which are not present marshal as the empty string.
+		conv = []Statement{
+			Declare{Name: v.Name, TypeName: "string"},
+			If{Condition: wk.present(value), Body: []Statement{Assign{Lhs: v, Rhs: wk.encode(wk, from)}}},
+			Assign{Lhs: to, Rhs: AddressOf{Value: v}},
+		}
+	}
+	switch {
+	case wk.ptr:
+		return []Statement{If{Condition: NotEqual{Lhs: from, Rhs: NIL}, Body: conv}}
+	case wk.present != nil && !wk.emptyZero:
+		return []Statement{If{Condition: wk.present(from), Body: conv}}
+	default:
+		return conv
+	}
+}
+
+// parseField is parseChecked for the value of a field. The error names the field,
+// which is described by field.
+func (m *marshalMethod) parseField(v Var, call Expression, field string) []Statement {
+	var (
+		err    = Name("err")
+		fmtpkg = Name(m.scope.parent.packageName("fmt"))
+	)
+	errorf := CallFunction{
+		Func:   Dotted{Receiver: fmtpkg, Name: "Errorf"},
+		Params: []Expression{stringLit{"invalid value for " + field + ": %v"}, err},
+	}
+	return []Statement{
+		declareMulti{Lhs: []Expression{v, err}, Rhs: call},
+		If{
+			Condition: NotEqual{Lhs: err, Rhs: NIL},
+			Body:      []Statement{Return{Values: []Expression{errorf}}},
+		},
+	}
+}
+
+// parseChecked assigns the result of a fallible call to v. The generated
+// code returns the error if the call fails.
+func (m *marshalMethod) parseChecked(v Var, call Expression) []Statement {
+	err := Name("err")
+	return []Statement{
+		declareMulti{Lhs: []Expression{v, err}, Rhs: call},
+		If{
+			Condition: NotEqual{Lhs: err, Rhs: NIL},
+			Body:      []Statement{Return{Values: []Expression{err}}},
+		},
+	}
+}
+
+type kvType struct {
+	Type      types.Type
+	Key, Elem types.Type
+}
+
+func mapKV(typ types.Type) kvType {
+	maptyp := underlyingMap(typ)
+	return kvType{typ, maptyp.Key(), maptyp.Elem()}
+}
+
+func sliceKV(typ types.Type) kvType {
+	slicetyp := underlyingSlice(typ)
+	return kvType{typ, intType, slicetyp.Elem()}
+}
+
+// seqKV is like sliceKV, but also accepts arrays.
+func seqKV(typ types.Type) kvType {
+	if arraytyp := underlyingArray(typ); arraytyp != nil {
+		return kvType{typ, intType, arraytyp.Elem()}
+	}
+	return sliceKV(typ)
+}
+
+// loopConv converts a map, slice or array by assigning each element. Elements which
+// can't be converted directly are converted into a new variable first.
+func (m *marshalMethod) loopConv(from, to Expression, fromTyp, toTyp kvType, nested bool) (conv []Statement) {
+	if hasSideEffects(from) {
+		orig := from
+		from = Name(m.scope.newIdent("tmp"))
+		conv = []Statement{DeclareAndAssign{Lhs: from, Rhs: orig}}
+	}
+	qf := m.scope.parent.qualify
+	key, val := m.iterKey, m.iterVal
+	if nested {
+		m.scope.used[key.Name], m.scope.used[val.Name] = true, true
+		key, val = Name(m.scope.newIdent("k")), Name(m.scope.newIdent("v"))
+	}
+	elemTo := Index{Value: to, Index: simpleConv(key, fromTyp.Key, toTyp.Key, qf)}
+	var body []Statement
+	if underlyingArray(toTyp.Elem) == nil && types.ConvertibleTo(fromTyp.Elem, toTyp.Elem) {
+		body = []Statement{Assign{Lhs: elemTo, Rhs: simpleConv(val, fromTyp.Elem, toTyp.Elem, qf)}}
+	} else {
+		elem := Name(m.scope.newIdent("elem"))
+		body = []Statement{Declare{Name: elem.Name, TypeName: types.TypeString(toTyp.Elem, qf)}}
+		body = append(body, m.convertValue(val, elem, fromTyp.Elem, toTyp.Elem, true)...)
+		body = append(body, Assign{Lhs: elemTo, Rhs: elem})
+	}
+	// The actual conversion is a loop that assigns each element.
+	var inner []Statement
+	if underlyingArray(toTyp.Type) == nil {
+		inner = append(inner, Assign{Lhs: to, Rhs: makeExpr(toTyp.Type, from, qf)})
+	}
+	inner = append(inner, Range{Key: key, Value: val, RangeValue: from, Body: body})
+	// Preserve nil maps and slices when marshaling. This is not required for unmarshaling
+	// methods because the field is already nil-checked earlier, but nested values
+	// may be nil in both directions.
+	if (!m.isUnmarshal || nested) && underlyingArray(fromTyp.Type) == nil {
+		inner = []Statement{If{
+			Condition: NotEqual{Lhs: from, Rhs: NIL},
+			Body:      inner,
+		}}
+	}
+	return append(conv, inner...)
+}
+
+func simpleConv(from Expression, fromtyp, totyp types.Type, qf types.Qualifier) Expression {
+	if types.AssignableTo(fromtyp, totyp) {
+		return from
+	}
+	if !types.ConvertibleTo(fromtyp, totyp) {
+		invalidConv(fromtyp, totyp, qf)
+	}
+	toname := types.TypeString(totyp, qf)
+	if isPointer(totyp) {
+		toname = "(" + toname + ")" // hack alert!
+	}
+	return CallFunction{Func: Name(toname), Params: []Expression{from}}
+}
+
+func invalidConv(from, to types.Type, qf types.Qualifier) {
+	panic(fmt.Errorf("BUG: invalid conversion %s -> %s", types.TypeString(from, qf), types.TypeString(to, qf)))
+}
+
+func makeExpr(typ types.Type, lenfrom Expression, qf types.Qualifier) Expression {
+	return CallFunction{Func: Name("make"), Params: []Expression{
+		Name(types.TypeString(typ, qf)),
+		CallFunction{Func: Name("len"), Params: []Expression{lenfrom}},
+	}}
+}
+
+func errCheck(expr Expression) If {
+	err := Name("err")
+	return If{
+		Init:      DeclareAndAssign{Lhs: err, Rhs: expr},
+		Condition: NotEqual{Lhs: err, Rhs: NIL},
+		Body:      []Statement{Return{Values: []Expression{err}}},
+	}
+}
+
+// hasSideEffects returns whether an expression may have side effects.
+func hasSideEffects(expr Expression) bool {
+	switch expr := expr.(type) {
+	case Var:
+		return false
+	case Dotted:
+		return hasSideEffects(expr.Receiver)
+	case Star:
+		return hasSideEffects(expr.Value)
+	case Index:
+		return hasSideEffects(expr.Index) && hasSideEffects(expr.Value)
+	default:
+		return true
+	}
+}
+
+type stringLit struct {
+	V string
+}
+
+func (l stringLit) Expression() ast.Expr {
+	return &ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(l.V)}
+}
+
+// binaryExpr is a binary expression like x + y.
+type binaryExpr struct {
+	Op   token.Token
+	X, Y Expression
+}
+
+func (e binaryExpr) Expression() ast.Expr {
+	return &ast.BinaryExpr{X: e.X.Expression(), Op: e.Op, Y: e.Y.Expression()}
+}
+
+// declareMulti is a short variable declaration with multiple variables.
+type declareMulti struct {
+	Lhs []Expression
+	Rhs Expression
+}
+
+func (d declareMulti) Statement() ast.Stmt {
+	lhs := make([]ast.Expr, len(d.Lhs))
+	for i, e := range d.Lhs {
+		lhs[i] = e.Expression()
+	}
+	return &ast.AssignStmt{Lhs: lhs, Tok: token.DEFINE, Rhs: []ast.Expr{d.Rhs.Expression()}}
+}
+
+type declStmt struct {
+	d Declaration
+}
+
+func (ds declStmt) Statement() ast.Stmt {
+	return &ast.DeclStmt{Decl: ds.d.Declaration()}
+}
+
+// ifElse is an if statement with an else branch.
+type ifElse struct {
+	If
+	Else []Statement
+}
+
+func (s ifElse) Statement() ast.Stmt {
+	stmt := s.If.Statement().(*ast.IfStmt)
+	block := &ast.BlockStmt{List: make([]ast.Stmt, len(s.Else))}
+	for i, e := range s.Else {
+		block.List[i] = e.Statement()
+	}
+	stmt.Else = block
+	return stmt
+}
+
+// switchCase is a switch statement with a single case clause.
+type switchCase struct {
+	Tag    Expression
+	Values []Expression
+	Body   []Statement
+}
+
+func (s switchCase) Statement() ast.Stmt {
+	clause := &ast.CaseClause{}
+	for _, v := range s.Values {
+		clause.List = append(clause.List, v.Expression())
+	}
+	for _, stmt := range s.Body {
+		clause.Body = append(clause.Body, stmt.Statement())
+	}
+	return &ast.SwitchStmt{Tag: s.Tag.Expression(), Body: &ast.BlockStmt{List: []ast.Stmt{clause}}}
+}
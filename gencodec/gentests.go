@@ -0,0 +1,295 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"bytes"
+	"fmt"
+	"go/types"
+	"path/filepath"
+	"strconv"
+	"text/template"
+	"unicode"
+	"unicode/utf8"
+
+	. "github.com/garslo/gogen"
+	"golang.org/x/tools/imports"
+)
+
+// testFileTemplate generates the round-trip tests of the JSON methods. The tests
+// start from a sample value, whose fields of basic type are set to non-zero values.
+// If there is a fixture directory, the fixtures of each type are decoded with each
+// of the required keys removed.
+var testFileTemplate = template.Must(template.New("tests").Parse(`
+{{- define "without"}}
+	// without returns the input without the given key.
+	without := func(t *{{.Testing}}.T, input []byte, key string) []byte {
+		var obj map[string]{{.JSON}}.RawMessage
+		if err := {{.JSON}}.Unmarshal(input, &obj); err != nil {
+			t.Fatal(err)
+		}
+		delete(obj, key)
+		enc, err := {{.JSON}}.Marshal(obj)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return enc
+	}
+{{- end}}
+{{- range $typ := .Types}}
+// Test{{.Name}}JSON checks that {{.Type}} survives a round trip through JSON, that
+// required fields are checked and that absent optional fields are left at their
+// zero or default value.
+func Test{{.Name}}JSON(t *{{$.Testing}}.T) {
+	var {{.V}} {{.Type}}
+	{{- range .Sample}}
+	{{.}}
+	{{- end}}
+	input, err := {{$.JSON}}.Marshal(&{{.V}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	{{- if or .Required .Optional}}
+	{{- template "without" $}}
+	{{- end}}
+
+	t.Run("RoundTrip", func(t *{{$.Testing}}.T) {
+		var dec {{.Type}}
+		if err := {{$.JSON}}.Unmarshal(input, &dec); err != nil {
+			t.Fatal(err)
+		}
+		if !{{$.Reflect}}.DeepEqual(dec, {{.V}}) {
+			t.Errorf("decoded %+v, want %+v", dec, {{.V}})
+		}
+	})
+	{{- range .Required}}
+	t.Run("Missing/{{.Key}}", func(t *{{$.Testing}}.T) {
+		var dec {{$typ.Type}}
+		err := {{$.JSON}}.Unmarshal(without(t, input, {{printf "%q" .Key}}), &dec)
+		if want := {{printf "%q" .Err}}; err == nil || err.Error() != want {
+			t.Errorf("got error %v, want %q", err, want)
+		}
+	})
+	{{- end}}
+	{{- range .Optional}}
+	t.Run("Absent/{{.Key}}", func(t *{{$.Testing}}.T) {
+		var dec {{$typ.Type}}
+		want := {{$typ.V}}
+		{{.Reset}}
+		if err := {{$.JSON}}.Unmarshal(without(t, input, {{printf "%q" .Key}}), &dec); err != nil {
+			t.Fatal(err)
+		}
+		if !{{$.Reflect}}.DeepEqual(dec, want) {
+			t.Errorf("decoded %+v, want %+v", dec, want)
+		}
+	})
+	{{- end}}
+}
+{{- if and $.Fixtures .Required}}
+
+// Test{{.Name}}Fixtures checks that the fixtures of {{.Type}} can be decoded, and that
+// decoding fails with the missing field error when a required key is removed.
+func Test{{.Name}}Fixtures(t *{{$.Testing}}.T) {
+	files, err := {{$.Filepath}}.Glob({{$.Filepath}}.Join({{printf "%q" $.Fixtures}}, {{printf "%q" .Type}}, "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Skip("no fixtures")
+	}
+	{{- template "without" $}}
+	required := []struct{ key, err string }{
+		{{- range .Required}}
+		{ {{- printf "%q" .Key}}, {{printf "%q" .Err -}} },
+		{{- end}}
+	}
+	for _, file := range files {
+		input, err := {{$.OS}}.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Run({{$.Filepath}}.Base(file), func(t *{{$.Testing}}.T) {
+			if err := {{$.JSON}}.Unmarshal(input, new({{$typ.Type}})); err != nil {
+				t.Fatalf("can't decode fixture: %v", err)
+			}
+			for _, f := range required {
+				err := {{$.JSON}}.Unmarshal(without(t, input, f.key), new({{$typ.Type}}))
+				if err == nil || err.Error() != f.err {
+					t.Errorf("without %s: got error %v, want %q", f.key, err, f.err)
+				}
+			}
+		})
+	}
+}
+{{- end}}
+{{end}}`))
+
+type testFileData struct {
+	Types    []testTypeData
+	Fixtures string // directory holding the fixtures in a subdirectory for each type
+
+	// package names
+	JSON, Reflect, Testing, OS, Filepath string
+}
+
+type testTypeData struct {
+	Type, Name string
+	V          string   // variable holding the sample value
+	Sample     []string // statements setting the fields of the sample value
+	Required   []testField
+	Optional   []testField
+}
+
+type testField struct {
+	Key   string
+	Err   string // error of decoding without the required field
+	Reset string // statements setting the field of want to its value when absent
+}
+
+// genTests returns a test file covering the JSON methods of mtyps. The fixtures of
+// each type are read from a subdirectory of fixtures named after the type, if the
+// fixture directory is set. Its path is relative to the package directory.
+func genTests(mtyps []*marshalerType, fixtures string) ([]byte, error) {
+	scope := mtyps[0].scope
+	paths := []string{"encoding/json", "reflect", "testing"}
+	if fixtures != "" {
+		paths = append(paths, "os", "path/filepath")
+	}
+	for _, path := range paths {
+		if err := scope.requireImport(path); err != nil {
+			return nil, err
+		}
+	}
+	data := testFileData{
+		Fixtures: filepath.ToSlash(fixtures),
+		JSON:     scope.packageName("encoding/json"),
+		Reflect:  scope.packageName("reflect"),
+		Testing:  scope.packageName("testing"),
+	}
+	if fixtures != "" {
+		data.OS = scope.packageName("os")
+		data.Filepath = scope.packageName("path/filepath")
+	}
+	for _, mtyp := range mtyps {
+		td, err := testType(mtyp)
+		if err != nil {
+			return nil, err
+		}
+		data.Types = append(data.Types, td)
+	}
+
+	return writeTestFile(mtyps, testFileTemplate, data)
+}
+
+// writeTestFile executes a test file template. The import declaration is
+// written last because the sample values can add imports.
+func writeTestFile(mtyps []*marshalerType, tmpl *template.Template, data any) ([]byte, error) {
+	body := new(bytes.Buffer)
+	if err := tmpl.Execute(body, data); err != nil {
+		return nil, err
+	}
+	w := new(bytes.Buffer)
+	fmt.Fprint(w, "// Code generated by github.com/fjl/gencodec. DO NOT EDIT.\n\n")
+	fmt.Fprintln(w, "package", mtyps[0].orig.Obj().Pkg().Name())
+	fmt.Fprintln(w)
+	mtyps[0].scope.writeImportDecl(w)
+	w.Write(body.Bytes())
+	opt := &imports.Options{Comments: true, TabIndent: true, TabWidth: 8}
+	code, err := imports.Process("", w.Bytes(), opt)
+	if err != nil {
+		return nil, fmt.Errorf("can't gofmt generated tests: %v", err)
+	}
+	return code, nil
+}
+
+func testType(mtyp *marshalerType) (testTypeData, error) {
+	if !mtyp.hasFormat("json") {
+		return testTypeData{}, fmt.Errorf("tests of %s require the json format", mtyp.name)
+	}
+	if mtyp.orig.TypeParams().Len() > 0 {
+		return testTypeData{}, fmt.Errorf("tests can't be generated for generic type %s", mtyp.name)
+	}
+	if mtyp.templates["json"] != nil {
+		return testTypeData{}, fmt.Errorf("tests can't be generated for %s because its JSON methods use a template", mtyp.name)
+	}
+	td := testTypeData{
+		Type: mtyp.name,
+		Name: testName(mtyp),
+		V:    "v",
+	}
+	v, want, zero := Name(td.V), Name("want"), Name("zero")
+	declZero := Declare{Name: zero.Name, TypeName: mtyp.name}
+	for i, f := range mtyp.Fields {
+		key, ok := f.key("json")
+		if !ok || f.function != nil {
+			continue
+		}
+		if f.schema != nil {
+			return testTypeData{}, fmt.Errorf("%v: tests can't be generated for field %s, which has a schema", mtyp.fs.Position(f.pos), f.name)
+		}
+		if sample := sampleStatements(mtyp, f.access(v), f.origTyp, i, f.isRequired("json")); sample != nil {
+			td.Sample = append(td.Sample, printStatements(mtyp, sample))
+		}
+		tf := testField{Key: key}
+		if f.isRequired("json") {
+			tf.Err = fmt.Sprintf("missing required field '%s' for %s", f.encodedName("json"), mtyp.name)
+			td.Required = append(td.Required, tf)
+			continue
+		}
+		if f.defValue != nil {
+			tf.Reset = printStatements(mtyp, []Statement{Assign{Lhs: f.access(want), Rhs: f.defValue}})
+		} else {
+			tf.Reset = printStatements(mtyp, []Statement{declZero, Assign{Lhs: f.access(want), Rhs: f.access(zero)}})
+		}
+		td.Optional = append(td.Optional, tf)
+	}
+	return td, nil
+}
+
+// testName returns the name of mtyp in the names of test functions,
+// which is capitalized so that the go tool finds the tests.
+func testName(mtyp *marshalerType) string {
+	first, size := utf8.DecodeRuneInString(mtyp.name)
+	return string(unicode.ToUpper(first)) + mtyp.name[size:]
+}
+
+// sampleStatements assigns a sample value of type typ to lhs. Basic types get a
+// non-zero constant, which differs between fields and fits into all integer types.
+// Pointers, slices and maps of required fields are allocated, so that the fields
+// aren't null in the encoding. Other types are left at their zero value.
+func sampleStatements(mtyp *marshalerType, lhs Expression, typ types.Type, i int, required bool) []Statement {
+	switch t := typ.Underlying().(type) {
+	case *types.Basic:
+		info := t.Info()
+		switch {
+		case info&types.IsBoolean != 0:
+			return []Statement{Assign{Lhs: lhs, Rhs: Name("true")}}
+		case info&types.IsString != 0:
+			return []Statement{Assign{Lhs: lhs, Rhs: Name(strconv.Quote(fmt.Sprintf("value%d", i)))}}
+		case info&types.IsInteger != 0:
+			return []Statement{Assign{Lhs: lhs, Rhs: Name(strconv.Itoa(i%100 + 1))}}
+		case info&types.IsFloat != 0:
+			return []Statement{Assign{Lhs: lhs, Rhs: Name(strconv.Itoa(i%100+1) + ".5")}}
+		}
+	case *types.Pointer:
+		if !required {
+			return nil
+		}
+		alloc := CallFunction{Func: Name("new"), Params: []Expression{Name(sampleTypeName(mtyp, t.Elem()))}}
+		stmts := []Statement{Assign{Lhs: lhs, Rhs: alloc}}
+		return append(stmts, sampleStatements(mtyp, Star{Value: lhs}, t.Elem(), i, required)...)
+	case *types.Slice, *types.Map:
+		if !required {
+			return nil
+		}
+		return []Statement{Assign{Lhs: lhs, Rhs: Name(sampleTypeName(mtyp, typ) + "{}")}}
+	}
+	return nil
+}
+
+func sampleTypeName(mtyp *marshalerType, typ types.Type) string {
+	mtyp.scope.addReferences(typ)
+	return types.TypeString(typ, mtyp.scope.qualify)
+}
@@ -0,0 +1,133 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"bytes"
+	"fmt"
+	"go/types"
+	"io"
+	"regexp"
+	"strings"
+)
+
+var graphQLName = regexp.MustCompile(`^[_A-Za-z][_0-9A-Za-z]*$`)
+
+// genGraphQLSchema creates the GraphQL schema of the marshaling types.
+func genGraphQLSchema(mtyps []*marshalerType) ([]byte, error) {
+	w := new(bytes.Buffer)
+	fmt.Fprint(w, "# Code generated by github.com/fjl/gencodec. DO NOT EDIT.\n")
+	for _, mtyp := range mtyps {
+		fmt.Fprintln(w)
+		if err := writeGraphQLType(w, mtyp); err != nil {
+			return nil, err
+		}
+	}
+	return w.Bytes(), nil
+}
+
+// writeGraphQLType writes the GraphQL object type of mtyp. Field names are the
+// JSON keys, and field types are derived from the intermediate type. The schema
+// uses the @goField directive of gqlgen to bind fields whose key doesn't match
+// the Go field name.
+func writeGraphQLType(w io.Writer, mtyp *marshalerType) error {
+	if mtyp.orig.TypeParams().Len() > 0 {
+		return fmt.Errorf("generic type %s has no GraphQL representation", mtyp.name)
+	}
+	fmt.Fprintf(w, "type %s {\n", mtyp.name)
+	for _, f := range mtyp.Fields {
+		key, ok := f.key("json")
+		if !ok {
+			continue
+		}
+		if !graphQLName.MatchString(key) {
+			return fmt.Errorf("%v: field %s: key %q is not a valid GraphQL name", mtyp.fs.Position(f.pos), f.name, key)
+		}
+		typ, err := graphQLType(f.typ)
+		if err != nil {
+			return fmt.Errorf("%v: field %s: %v", mtyp.fs.Position(f.pos), f.name, err)
+		}
+		if f.isRequired("json") || (isNonNull(f.typ) && !f.hasTagOption("json", "omitempty")) {
+			typ += "!"
+		}
+		fmt.Fprintf(w, "  %s: %s", key, typ)
+		switch {
+		case len(f.path) > 1 || f.function != nil:
+			// The field isn't a field of the Go type and needs a resolver.
+			fmt.Fprint(w, " @goField(forceResolver: true)")
+		case !strings.EqualFold(key, f.name):
+			fmt.Fprintf(w, " @goField(name: %q)", f.name)
+		}
+		fmt.Fprintln(w)
+	}
+	fmt.Fprintln(w, "}")
+	return nil
+}
+
+// graphQLType returns the nullable GraphQL type of a Go type. Types with a text
+// encoding are strings, and other named struct types are referenced by name.
+func graphQLType(typ types.Type) (string, error) {
+	if isTextMarshaler(typ) {
+		return "String", nil
+	}
+	switch u := typ.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case u.Info()&types.IsBoolean != 0:
+			return "Boolean", nil
+		case u.Info()&types.IsString != 0:
+			return "String", nil
+		case u.Info()&types.IsInteger != 0:
+			return "Int", nil
+		case u.Info()&types.IsFloat != 0:
+			return "Float", nil
+		}
+	case *types.Pointer:
+		return graphQLType(u.Elem())
+	case *types.Slice:
+		if isByte(u.Elem()) {
+			return "String", nil // base64
+		}
+		return graphQLListType(u.Elem())
+	case *types.Array:
+		return graphQLListType(u.Elem())
+	case *types.Struct:
+		if named, ok := typ.(*types.Named); ok {
+			return named.Obj().Name(), nil
+		}
+	}
+	return "", fmt.Errorf("type %s has no GraphQL representation", typ)
+}
+
+func graphQLListType(elem types.Type) (string, error) {
+	t, err := graphQLType(elem)
+	if err != nil {
+		return "", err
+	}
+	if isNonNull(elem) {
+		t += "!"
+	}
+	return "[" + t + "]", nil
+}
+
+func isByte(typ types.Type) bool {
+	basic, ok := typ.Underlying().(*types.Basic)
+	return ok && basic.Kind() == types.Byte
+}
+
+// isNonNull reports whether values of typ can't be null in JSON.
+func isNonNull(typ types.Type) bool {
+	switch typ.Underlying().(type) {
+	case *types.Pointer, *types.Slice, *types.Map, *types.Interface:
+		return false
+	}
+	return true
+}
+
+// hasTagOption reports whether the struct tag of the given format contains the option.
+func (mf *marshalerField) hasTagOption(format, opt string) bool {
+	_, opts, _ := strings.Cut(mf.formatTag(format), ",")
+	return hasTagOption(opts, opt)
+}
@@ -0,0 +1,83 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"fmt"
+	"go/types"
+
+	. "github.com/garslo/gogen"
+)
+
+const (
+	halLinksMethod = "HALLinks"
+	halLinksKey    = "_links"
+)
+
+// genMarshalHAL generates the MarshalHAL method. It encodes like MarshalJSON
+// and adds the result of the HALLinks method of the type as the _links member.
+func genMarshalHAL(mtyp *marshalerType) (Function, error) {
+	linksTyp, err := mtyp.halLinksType()
+	if err != nil {
+		return Function{}, err
+	}
+	var (
+		m        = newMarshalMethod(mtyp, false)
+		recv     = m.receiver()
+		intertyp = m.intermediateType(m.intermediateName("json"), "json")
+		enc      = Name(m.scope.newIdent("enc"))
+		json     = Name(m.scope.parent.packageName("encoding/json"))
+	)
+	// The links are stored in a field named like the hook method,
+	// which can't clash with the fields of the type.
+	intertyp.Fields = append(intertyp.Fields, Field{
+		Name:     halLinksMethod,
+		TypeName: typeString(linksTyp, mtyp.scope.qualify),
+		Tag:      fmt.Sprintf(`json:"%s,omitempty"`, halLinksKey),
+	})
+	fn := Function{
+		Receiver:    recv,
+		Name:        "MarshalHAL",
+		ReturnTypes: Types{{TypeName: "[]byte"}, {TypeName: "error"}},
+		Body: []Statement{
+			declStmt{intertyp},
+			Declare{Name: enc.Name, TypeName: intertyp.Name},
+		},
+	}
+	fn.Body = append(fn.Body, m.marshalConversions(Name(recv.Name), enc, "json")...)
+	fn.Body = append(fn.Body,
+		Assign{
+			Lhs: Dotted{Receiver: enc, Name: halLinksMethod},
+			Rhs: CallFunction{Func: Dotted{Receiver: Name(recv.Name), Name: halLinksMethod}},
+		},
+		Return{Values: []Expression{
+			CallFunction{
+				Func:   Dotted{Receiver: json, Name: "Marshal"},
+				Params: []Expression{AddressOf{Value: enc}},
+			},
+		}},
+	)
+	return fn, nil
+}
+
+// halLinksType returns the result type of the HALLinks method of mtyp.
+// The method may have a pointer receiver because the receiver of the
+// generated method is addressable.
+func (mtyp *marshalerType) halLinksType() (types.Type, error) {
+	sel := types.NewMethodSet(types.NewPointer(mtyp.orig)).Lookup(nil, halLinksMethod)
+	if sel == nil {
+		return nil, fmt.Errorf("type %s has no %s method", mtyp.name, halLinksMethod)
+	}
+	sig := sel.Type().(*types.Signature)
+	if sig.Params().Len() != 0 || sig.Results().Len() != 1 {
+		return nil, fmt.Errorf("method %s.%s must have no parameters and a single result", mtyp.name, halLinksMethod)
+	}
+	for _, f := range mtyp.Fields {
+		if key, ok := f.key("json"); ok && key == halLinksKey {
+			return nil, fmt.Errorf("%v: field %s: json key %q is reserved for links", mtyp.fs.Position(f.pos), f.name, halLinksKey)
+		}
+	}
+	return sig.Results().At(0).Type(), nil
+}
@@ -0,0 +1,251 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"fmt"
+	"go/types"
+	"io"
+	"reflect"
+	"strings"
+	"text/template"
+
+	. "github.com/garslo/gogen"
+)
+
+const (
+	hclPath   = "github.com/hashicorp/hcl/v2"
+	gohclPath = "github.com/hashicorp/hcl/v2/gohcl"
+)
+
+// The hcl format only generates DecodeHCL, which decodes an HCL body like the
+// gohcl package does, with the required and default semantics of gencodec. Fields
+// with an hcl struct tag are attributes, blocks or labels, like in gohcl:
+//
+//	hcl:"name"        attribute
+//	hcl:"name,attr"   attribute
+//	hcl:"name,block"  block, the field is a struct, a pointer to one or a slice
+//	hcl:"name,label"  label of the block, set by the decoder of the parent
+//
+// Attributes are decoded by gohcl.DecodeExpression. Blocks are decoded by the
+// DecodeHCL method of their type, which must be generated as well.
+
+// hclTemplate generates the DecodeHCL method.
+var hclTemplate = template.Must(template.New("hcl").Parse(`
+// DecodeHCL decodes the attributes and blocks of {{.Body}} into {{.Recv}}, evaluating expressions
+// in {{.Ctx}}. Missing required attributes and blocks, unknown ones and invalid values are
+// reported as diagnostics. Absent optional attributes get their default value or are left
+// unchanged.
+func ({{.Recv}} *{{.RecvType}}) DecodeHCL({{.Body}} {{.HCL}}.Body, {{.Ctx}} *{{.HCL}}.EvalContext) {{.HCL}}.Diagnostics {
+	{{.Schema}} := &{{.HCL}}.BodySchema{
+		{{- if .Attrs}}
+		Attributes: []{{.HCL}}.AttributeSchema{
+			{{- range .Attrs}}
+			{Name: {{printf "%q" .Name}}{{if .Required}}, Required: true{{end}}},
+			{{- end}}
+		},
+		{{- end}}
+		{{- if .Blocks}}
+		Blocks: []{{.HCL}}.BlockHeaderSchema{
+			{{- range .Blocks}}
+			{Type: {{printf "%q" .Name}}{{if .Labels}}, LabelNames: []string{ {{- range $i, $l := .Labels}}{{if $i}}, {{end}}{{printf "%q" $l.Name}}{{end -}} }{{end}}},
+			{{- end}}
+		},
+		{{- end}}
+	}
+	{{.Content}}, {{.Diags}} := {{.Body}}.Content({{.Schema}})
+	{{- range .Attrs}}
+	if {{$.Attr}}, {{$.Ok}} := {{$.Content}}.Attributes[{{printf "%q" .Name}}]; {{$.Ok}} {
+		{{$.Diags}} = append({{$.Diags}}, {{$.Gohcl}}.DecodeExpression({{$.Attr}}.Expr, {{$.Ctx}}, &{{.Field}})...)
+	}{{if .Default}} else {
+		{{.Default}}
+	}{{end}}
+	{{- end}}
+	{{- range .Blocks}}
+	{{- $b := .}}
+	{{- if .Slice}}
+	if {{$.BlockList}} := {{$.Content}}.Blocks.OfType({{printf "%q" .Name}}); len({{$.BlockList}}) > 0 {
+		{{.Field}} = make({{.Type}}, len({{$.BlockList}}))
+		for {{$.I}}, {{$.Block}} := range {{$.BlockList}} {
+			{{- range $i, $l := .Labels}}
+			{{$b.Field}}[{{$.I}}].{{$l.Field}} = {{$.Block}}.Labels[{{$i}}]
+			{{- end}}
+			{{$.Diags}} = append({{$.Diags}}, {{.Field}}[{{$.I}}].DecodeHCL({{$.Block}}.Body, {{$.Ctx}})...)
+		}
+	}{{if .Required}} else {
+		{{$.Diags}} = append({{$.Diags}}, {{- template "missing" .}})
+	}{{end}}
+	{{- else}}
+	switch {{$.BlockList}} := {{$.Content}}.Blocks.OfType({{printf "%q" .Name}}); {
+	case len({{$.BlockList}}) == 1:
+		{{- if .Ptr}}
+		{{.Field}} = new({{.Type}})
+		{{- end}}
+		{{- range $i, $l := .Labels}}
+		{{$b.Field}}.{{$l.Field}} = {{$.BlockList}}[0].Labels[{{$i}}]
+		{{- end}}
+		{{$.Diags}} = append({{$.Diags}}, {{.Field}}.DecodeHCL({{$.BlockList}}[0].Body, {{$.Ctx}})...)
+	case len({{$.BlockList}}) > 1:
+		{{$.Diags}} = append({{$.Diags}}, &{{$.HCL}}.Diagnostic{
+			Severity: {{$.HCL}}.DiagError,
+			Summary:  "Duplicate {{.Name}} block",
+			Detail:   "Only one {{.Name}} block is allowed in {{$.Type}}.",
+			Subject:  &{{$.BlockList}}[1].DefRange,
+		})
+	{{- if .Required}}
+	default:
+		{{$.Diags}} = append({{$.Diags}}, {{- template "missing" .}})
+	{{- end}}
+	}
+	{{- end}}
+	{{- end}}
+	return {{.Diags}}
+}
+
+{{- define "missing"}}&{{.HCL}}.Diagnostic{
+			Severity: {{.HCL}}.DiagError,
+			Summary:  "Missing {{.Name}} block",
+			Detail:   "A {{.Name}} block is required in {{.Parent}}.",
+			Subject:  {{.Body}}.MissingItemRange().Ptr(),
+		}
+{{- end}}
+`))
+
+type hclData struct {
+	Type, RecvType string
+	Attrs          []hclAttr
+	Blocks         []hclBlock
+
+	// package and variable names
+	HCL, Gohcl                           string
+	Recv, Body, Ctx, Schema, Content     string
+	Diags, Attr, Ok, BlockList, Block, I string
+}
+
+type hclAttr struct {
+	Name     string
+	Field    string // the field expression
+	Required bool
+	Default  string // statements assigning the default value, empty if none
+}
+
+type hclBlock struct {
+	Name     string
+	Field    string // the field expression
+	Type     string // the field type of slices, the element type otherwise
+	Slice    bool   // the field holds any number of blocks
+	Ptr      bool   // the field is a pointer
+	Required bool
+	Labels   []hclLabel
+
+	// used by the missing block template
+	HCL, Parent, Body string
+}
+
+type hclLabel struct {
+	Name  string
+	Field string // field of the block type
+}
+
+// hclTag returns the name and kind of the hcl struct tag of a field. Fields without
+// an hcl tag return an empty name.
+func hclTag(tag string) (name, kind string) {
+	name, kind, _ = strings.Cut(reflect.StructTag(tag).Get("hcl"), ",")
+	if name == "-" {
+		return "", ""
+	}
+	if kind == "" || kind == "optional" {
+		kind = "attr"
+	}
+	return name, kind
+}
+
+// genDecodeHCL writes the DecodeHCL method of mtyp.
+func genDecodeHCL(w io.Writer, mtyp *marshalerType) error {
+	var (
+		m    = newMarshalMethod(mtyp, true)
+		recv = m.receiver().Name
+	)
+	data := hclData{
+		Type:      mtyp.name,
+		RecvType:  mtyp.recvType(),
+		HCL:       mtyp.scope.packageName(hclPath),
+		Gohcl:     mtyp.scope.packageName(gohclPath),
+		Recv:      recv,
+		Body:      m.scope.newIdent("body"),
+		Ctx:       m.scope.newIdent("ctx"),
+		Schema:    m.scope.newIdent("schema"),
+		Content:   m.scope.newIdent("content"),
+		Diags:     m.scope.newIdent("diags"),
+		Attr:      m.scope.newIdent("attr"),
+		Ok:        m.scope.newIdent("ok"),
+		BlockList: m.scope.newIdent("blocks"),
+		Block:     m.scope.newIdent("block"),
+		I:         m.scope.newIdent("i"),
+	}
+	for _, f := range mtyp.Fields {
+		name, kind := hclTag(f.tag)
+		if name == "" || f.function != nil {
+			continue
+		}
+		pos := mtyp.fs.Position(f.pos)
+		field := printExpr(mtyp, f.access(Name(recv)))
+		switch kind {
+		case "attr":
+			attr := hclAttr{Name: name, Field: field, Required: f.isRequired("hcl")}
+			if f.defValue != nil {
+				attr.Default = printStatements(mtyp, []Statement{Assign{Lhs: f.access(Name(recv)), Rhs: f.defValue}})
+			}
+			data.Attrs = append(data.Attrs, attr)
+		case "block":
+			block, err := newHCLBlock(mtyp, name, field, f.origTyp)
+			if err != nil {
+				return fmt.Errorf("%v: field %s: %v", pos, f.name, err)
+			}
+			block.Required = f.isRequired("hcl")
+			block.HCL, block.Parent, block.Body = data.HCL, mtyp.name, data.Body
+			data.Blocks = append(data.Blocks, block)
+		case "label":
+			// Labels are set by the decoder of the parent block.
+		default:
+			return fmt.Errorf("%v: field %s has unknown hcl kind %q", pos, f.name, kind)
+		}
+	}
+	if len(data.Attrs) == 0 && len(data.Blocks) == 0 {
+		return fmt.Errorf("type %s has no fields with an hcl tag", mtyp.name)
+	}
+	return mtyp.template(hclTemplate).Execute(w, data)
+}
+
+// newHCLBlock creates the block of a field of type typ. Its labels are the fields
+// with an hcl label tag of the block type.
+func newHCLBlock(mtyp *marshalerType, name, field string, typ types.Type) (hclBlock, error) {
+	block := hclBlock{Name: name, Field: field}
+	elem := typ
+	switch t := typ.Underlying().(type) {
+	case *types.Slice:
+		block.Slice, elem = true, t.Elem()
+		block.Type = types.TypeString(typ, mtyp.scope.qualify)
+	case *types.Pointer:
+		block.Ptr, elem = true, t.Elem()
+		block.Type = types.TypeString(elem, mtyp.scope.qualify)
+	default:
+		block.Type = types.TypeString(typ, mtyp.scope.qualify)
+	}
+	styp, ok := elem.Underlying().(*types.Struct)
+	if !ok {
+		return block, fmt.Errorf("block type %s is not a struct", elem)
+	}
+	for i := 0; i < styp.NumFields(); i++ {
+		if name, kind := hclTag(styp.Tag(i)); name != "" && kind == "label" {
+			f := styp.Field(i)
+			if !types.Identical(f.Type(), types.Typ[types.String]) {
+				return block, fmt.Errorf("label %s of block type %s is not a string", f.Name(), elem)
+			}
+			block.Labels = append(block.Labels, hclLabel{Name: name, Field: f.Name()})
+		}
+	}
+	return block, nil
+}
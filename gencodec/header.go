@@ -0,0 +1,99 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// FormatVersion is the version of the generated code. It is increased when the code
+// generated by a new version of gencodec is incompatible with the code generated by
+// older versions, for example because generated types of other packages are used in a
+// different way.
+const FormatVersion = 1
+
+// defaultHeader is the first line of generated Go files.
+const defaultHeader = "// Code generated by github.com/fjl/gencodec. DO NOT EDIT."
+
+// generatedLine matches the comment which marks a Go file as generated, as defined
+// by the go command.
+var generatedLine = regexp.MustCompile(`^// Code generated .* DO NOT EDIT\.$`)
+
+// HeaderData is the input of the template of Config.Header.
+type HeaderData struct {
+	Version       string // version of gencodec, "(devel)" if unknown
+	Args          string // command line arguments, quoted where needed
+	Types         string // the generated types, separated by commas
+	FormatVersion int    // version of the generated code
+}
+
+// header returns the first line of the generated Go file.
+func (cfg *Config) header() (string, error) {
+	if cfg.Header == "" {
+		return defaultHeader, nil
+	}
+	tmpl, err := template.New("header").Parse(cfg.Header)
+	if err != nil {
+		return "", fmt.Errorf("header: %v", err)
+	}
+	data := HeaderData{
+		Version:       gencodecVersion(),
+		Args:          quoteArgs(cfg.Args),
+		Types:         cfg.Type,
+		FormatVersion: FormatVersion,
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("header: %v", err)
+	}
+	line := buf.String()
+	if !generatedLine.MatchString(line) {
+		return "", fmt.Errorf("header %q doesn't match %s", line, generatedLine)
+	}
+	return line, nil
+}
+
+// gencodecVersion returns the version of the gencodec module in the running binary.
+func gencodecVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "(devel)"
+	}
+	mods := append([]*debug.Module{&info.Main}, info.Deps...)
+	for _, m := range mods {
+		if m.Path == "github.com/fjl/gencodec" && m.Version != "" {
+			return m.Version
+		}
+	}
+	return "(devel)"
+}
+
+// quoteArgs joins command line arguments, quoting those which a shell would split.
+func quoteArgs(args []string) string {
+	quoted := make([]string, len(args))
+	for i, a := range args {
+		quoted[i] = a
+		if a == "" || strings.IndexFunc(a, func(r rune) bool { return unicode.IsSpace(r) || strings.ContainsRune(`"'\$*?;&|<>()`+"`", r) }) >= 0 {
+			quoted[i] = strconv.Quote(a)
+		}
+	}
+	return strings.Join(quoted, " ")
+}
+
+// formatVersionConst returns the declaration of the format version constant of a
+// generated file. It is named after the first type, so files generated in the same
+// package don't clash.
+func formatVersionConst(mtyps []*marshalerType) string {
+	name := mtyps[0].name
+	name = "gencodecFormat" + strings.ToUpper(name[:1]) + name[1:]
+	return fmt.Sprintf("// %s is the version of the code format of this file, see gencodec.FormatVersion.\nconst %s = %d\n\n", name, name, FormatVersion)
+}
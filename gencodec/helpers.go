@@ -0,0 +1,192 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"fmt"
+	"go/types"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	. "github.com/garslo/gogen"
+)
+
+// helper is an additional method which can be generated using the -helpers flag.
+type helper struct {
+	imports []string // packages used by the generated code
+	// load checks the type before any code is written and adds the packages
+	// needed by its options. It is optional.
+	load func(mtyp *marshalerType) error
+	// gen writes the code of a single type. Helpers which only
+	// have genSet leave it nil.
+	gen func(w io.Writer, mtyp *marshalerType) error
+	// genSet writes code for all types of the invocation. It is optional
+	// and runs after the methods of all types have been written.
+	genSet func(w io.Writer, mtyps []*marshalerType) error
+}
+
+var helpers = map[string]helper{
+	"spanattrs": {
+		imports: []string{"go.opentelemetry.io/otel/attribute"},
+		gen: writeFunctionOf(genSpanAttributes,
+			"returns the fields with an otel struct tag as OpenTelemetry attributes."),
+	},
+	"fingerprint": {gen: genFingerprint},
+	"fieldnames":  {gen: genFieldNames},
+	"fieldinfo":   {gen: genFieldInfo},
+	"text":        {imports: []string{"strconv"}, gen: genText},
+	"hal": {
+		imports: []string{"encoding/json"},
+		gen: writeFunctionOf(genMarshalHAL,
+			"encodes like MarshalJSON and adds the result of HALLinks as the _links member."),
+	},
+	"sse": {imports: []string{"bytes", "errors", "io", "strings"}, gen: genSSE},
+	"ws": {
+		imports: []string{"encoding/json", "fmt"},
+		gen:     genWSMessage,
+		genSet:  genWSDecoder,
+	},
+	"deepcopy":       {genSet: genDeepCopy},
+	"deepcopyobject": {imports: []string{k8sRuntimePath}, gen: genDeepCopyObject},
+	"kafka":          {imports: []string{"encoding/binary", "errors"}, gen: genKafka},
+	"binary":         {imports: []string{"encoding/binary", "fmt", "math"}, load: loadBinary, gen: genBinary},
+	"gzip":           {imports: []string{"bytes", "compress/gzip", "fmt", "io"}, gen: genGzip},
+	"frame":          {imports: []string{"encoding/binary", "fmt", "io"}, gen: genFrame(false)},
+	"frame32":        {imports: []string{"encoding/binary", "fmt", "io", "math"}, gen: genFrame(true)},
+	"nats":           {imports: []string{"fmt", natsPath}, gen: genNATS},
+	"lambda":         {imports: []string{"encoding/base64", "fmt", "sort", lambdaEventsPath}, gen: genLambda},
+	"jsonstream":     {imports: []string{"encoding/json", "fmt"}, gen: genJSONStream},
+	"jsonfields":     {imports: []string{"bytes", "encoding/json", "errors", "fmt"}, gen: genJSONFields},
+	"peek":           {imports: []string{"bytes", "encoding/json", "errors"}, gen: genPeek},
+	"pretty":         {imports: []string{"fmt", "strings"}, gen: genPretty},
+	"prompt":         {imports: []string{"bufio", "fmt", "io", "strconv", "strings"}, gen: genPrompt},
+	"table":          {imports: []string{"fmt", "io", "strconv", "strings", "text/tabwriter"}, gen: genTable},
+	"seal":           {imports: []string{"crypto/aes", "crypto/cipher", "crypto/rand", "errors", "fmt"}, gen: genSeal},
+	"values":         {imports: []string{"fmt", "net/url", "strconv"}, gen: genValues},
+	"jsonapi":        {imports: []string{"encoding/json", "errors", "fmt", "strconv"}, gen: genJSONAPI},
+	"flags": {
+		imports: []string{"flag", "strconv"},
+		gen: writeFunctionOf(genRegisterFlags,
+			"defines a flag for each field with a flag tag, which sets the field."),
+	},
+	"labels": {
+		imports: []string{"strconv"},
+		gen: writeFunctionOf(genMetricLabels,
+			"returns the fields with a label struct tag as metric labels."),
+	},
+}
+
+// writeFunctionOf adapts a generator of a single function to the helper interface.
+// The doc comment of the function is its name followed by doc.
+func writeFunctionOf(gen func(mtyp *marshalerType) (Function, error), doc string) func(io.Writer, *marshalerType) error {
+	return func(w io.Writer, mtyp *marshalerType) error {
+		fn, err := gen(mtyp)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "// %s %s\n", fn.Name, doc)
+		writeFunction(w, mtyp.fs, fn)
+		return nil
+	}
+}
+
+// loadHelpers verifies the helper names and adds the packages needed by them.
+func (mtyp *marshalerType) loadHelpers(names []string) error {
+	for _, name := range names {
+		h, ok := helpers[name]
+		if !ok {
+			return fmt.Errorf("unknown helper %q (available: %s)", name, strings.Join(helperNames(), ", "))
+		}
+		for _, path := range h.imports {
+			if err := mtyp.scope.requireImport(path); err != nil {
+				return fmt.Errorf("helper %s: %v", name, err)
+			}
+		}
+		if h.load != nil {
+			if err := h.load(mtyp); err != nil {
+				return fmt.Errorf("helper %s: %v", name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// genHelperSets writes the code of helpers which cover all types.
+func genHelperSets(w io.Writer, mtyps []*marshalerType, names []string) error {
+	for _, name := range names {
+		if gen := helpers[name].genSet; gen != nil {
+			if err := gen(w, mtyps); err != nil {
+				return fmt.Errorf("helper %s: %v", name, err)
+			}
+			fmt.Fprintln(w)
+		}
+	}
+	return nil
+}
+
+// genHelpers writes the helper methods of mtyp.
+func genHelpers(w io.Writer, mtyp *marshalerType, names []string) error {
+	for _, name := range names {
+		gen := helpers[name].gen
+		if gen == nil {
+			continue
+		}
+		if err := gen(w, mtyp); err != nil {
+			return fmt.Errorf("helper %s: %v", name, err)
+		}
+		fmt.Fprintln(w)
+	}
+	return nil
+}
+
+func helperNames() []string {
+	var names []string
+	for name := range helpers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// taggedFields returns the fields selected by the given struct tag.
+// Fields with tag value "-" are not selected.
+func (mtyp *marshalerType) taggedFields(tag string) (fields []*marshalerField) {
+	for _, f := range mtyp.Fields {
+		key, ok := reflect.StructTag(f.tag).Lookup(tag)
+		if ok && key != "-" && f.function == nil {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// stringValue creates an expression which converts v to a string, using the
+// representation of well-known types and strconv for basic types.
+func (m *marshalMethod) stringValue(f *marshalerField, v Expression, typ types.Type) (Expression, error) {
+	if wk := f.wellKnown; wk != nil && types.Identical(wk.repr(m.mtyp.scope), types.Typ[types.String]) {
+		return wk.encode(wk, v), nil
+	}
+	switch {
+	case isStringer(typ):
+		return CallFunction{Func: Dotted{Receiver: v, Name: "String"}}, nil
+	case isStringer(types.NewPointer(typ)):
+		return CallFunction{Func: Dotted{Receiver: v, Name: "String"}}, nil
+	case isString(typ):
+		return convertTo(v, typ, types.String), nil
+	case isNumber(typ):
+		return m.formatNumber(v, typ), nil
+	case isBool(typ):
+		strconv := Name(m.scope.parent.packageName("strconv"))
+		return CallFunction{Func: Dotted{Receiver: strconv, Name: "FormatBool"}, Params: []Expression{convertTo(v, typ, types.Bool)}}, nil
+	}
+	return nil, fmt.Errorf("type %s has no string representation", typ)
+}
+
+func isBool(typ types.Type) bool {
+	basic, ok := typ.Underlying().(*types.Basic)
+	return ok && basic.Info()&types.IsBoolean != 0
+}
@@ -0,0 +1,145 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"errors"
+	"fmt"
+	"go/types"
+	"reflect"
+	"strings"
+
+	. "github.com/garslo/gogen"
+)
+
+// hookFuncs are the functions of the gencodec:"hook=append,parse" option, which
+// replace the JSON encoding of a field with hand-written code.
+type hookFuncs struct {
+	append *types.Func // func(dst []byte, v T) []byte
+	parse  *types.Func // func(src []byte) (T, int, error)
+}
+
+// parseHook returns the function names of the hook option in a struct tag. Like
+// the conv option, it takes two elements of the option list.
+func parseHook(tag string) (appendName, parseName string, ok bool, err error) {
+	opts := strings.Split(reflect.StructTag(tag).Get("gencodec"), ",")
+	for i, o := range opts {
+		k, v, _ := strings.Cut(strings.TrimSpace(o), "=")
+		if k != "hook" {
+			continue
+		}
+		if v == "" || i+1 == len(opts) || strings.Contains(opts[i+1], "=") {
+			return "", "", false, errors.New("hook option needs two functions, like hook=appendHex,parseHex")
+		}
+		return v, strings.TrimSpace(opts[i+1]), true, nil
+	}
+	return "", "", false, nil
+}
+
+// loadHookFields resolves the functions of fields with the hook option. The
+// option is only read from the original type.
+func (mtyp *marshalerType) loadHookFields(imp types.Importer, pkg *types.Package) error {
+	for _, f := range mtyp.Fields {
+		pos := mtyp.fs.Position(f.pos)
+		appendName, parseName, ok, err := parseHook(f.tag)
+		if err != nil {
+			return fmt.Errorf("%v: field %s: %v", pos, f.name, err)
+		}
+		if !ok {
+			continue
+		}
+		switch {
+		case f.function != nil || f.money != nil || f.dynamic || f.union != nil || f.conv != nil:
+			return fmt.Errorf("%v: field %s: the hook option can't be combined with other conversions", pos, f.name)
+		case f.hasTagOption("json", "omitempty") || f.hasTagOption("json", "omitzero") || f.hasTagOption("json", "string"):
+			return fmt.Errorf("%v: field %s: the hook option can't be combined with json tag options", pos, f.name)
+		}
+		hook := new(hookFuncs)
+		if hook.append, err = lookupConvFunc(imp, pkg, appendName); err != nil {
+			return fmt.Errorf("%v: field %s: %v", pos, f.name, err)
+		}
+		if hook.parse, err = lookupConvFunc(imp, pkg, parseName); err != nil {
+			return fmt.Errorf("%v: field %s: %v", pos, f.name, err)
+		}
+		if err := checkHookFuncs(hook, f.origTyp); err != nil {
+			return fmt.Errorf("%v: field %s: %v", pos, f.name, err)
+		}
+		for _, fn := range []*types.Func{hook.append, hook.parse} {
+			if fn.Pkg() != pkg {
+				mtyp.scope.addImport(fn.Pkg().Path())
+			}
+		}
+		f.hook = hook
+	}
+	return nil
+}
+
+// checkHookFuncs checks the signatures of the hook functions of a field of type typ.
+func checkHookFuncs(hook *hookFuncs, typ types.Type) error {
+	var (
+		bytes = types.NewSlice(types.Typ[types.Byte])
+		errT  = types.Universe.Lookup("error").Type()
+		sig   = hook.append.Type().(*types.Signature)
+	)
+	if sig.Params().Len() != 2 || sig.Results().Len() != 1 ||
+		!types.Identical(sig.Params().At(0).Type(), bytes) || !types.AssignableTo(typ, sig.Params().At(1).Type()) ||
+		!types.Identical(sig.Results().At(0).Type(), bytes) {
+		return fmt.Errorf("hook function %s must have signature func(dst []byte, v %s) []byte", hook.append.Name(), typ)
+	}
+	sig = hook.parse.Type().(*types.Signature)
+	res := sig.Results()
+	if sig.Params().Len() != 1 || res.Len() != 3 ||
+		!types.Identical(sig.Params().At(0).Type(), bytes) || !types.AssignableTo(res.At(0).Type(), typ) ||
+		!types.Identical(res.At(1).Type(), intType) || !types.Identical(res.At(2).Type(), errT) {
+		return fmt.Errorf("hook function %s must have signature func(src []byte) (%s, int, error)", hook.parse.Name(), typ)
+	}
+	return nil
+}
+
+// hooked reports whether f is encoded by hook functions in format.
+func (mf *marshalerField) hooked(format string) bool {
+	return mf.hook != nil && format == "json"
+}
+
+// rawMessage returns the name of json.RawMessage, which is the type of hooked
+// fields in intermediate types.
+func (mtyp *marshalerType) rawMessage() string {
+	return mtyp.scope.packageName("encoding/json") + ".RawMessage"
+}
+
+// marshalHook assigns the output of the append function to the intermediate field.
+func (m *marshalMethod) marshalHook(f *marshalerField, from, to Expression) []Statement {
+	call := CallFunction{Func: m.mtyp.funcName(f.hook.append), Params: []Expression{NIL, from}}
+	return []Statement{Assign{Lhs: to, Rhs: call}}
+}
+
+// unmarshalHook decodes the raw value of a hooked field by the parse function,
+// which must consume all of it.
+func (m *marshalMethod) unmarshalHook(f *marshalerField, from, to Expression, format string) []Statement {
+	var (
+		v      = Name(m.scope.newIdent("v"))
+		n      = Name(m.scope.newIdent("n"))
+		err    = Name("err")
+		errors = Name(m.scope.parent.packageName("errors"))
+		msg    = fmt.Sprintf("trailing data after value of field '%s' for %s", f.encodedName(format), m.mtyp.name)
+	)
+	return []Statement{
+		declareMulti{
+			Lhs: []Expression{v, n, err},
+			Rhs: CallFunction{Func: m.mtyp.funcName(f.hook.parse), Params: []Expression{from}},
+		},
+		If{
+			Condition: NotEqual{Lhs: err, Rhs: NIL},
+			Body:      []Statement{Return{Values: []Expression{err}}},
+		},
+		If{
+			Condition: NotEqual{Lhs: n, Rhs: CallFunction{Func: Name("len"), Params: []Expression{from}}},
+			Body: []Statement{Return{Values: []Expression{
+				CallFunction{Func: Dotted{Receiver: errors, Name: "New"}, Params: []Expression{stringLit{msg}}},
+			}}},
+		},
+		Assign{Lhs: to, Rhs: v},
+	}
+}
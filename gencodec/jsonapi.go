@@ -0,0 +1,336 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"bytes"
+	"fmt"
+	"go/printer"
+	"go/types"
+	"io"
+	"reflect"
+	"strings"
+	"text/template"
+
+	. "github.com/garslo/gogen"
+)
+
+// jsonapiTemplate generates the JSON:API methods of a type. The attributes are
+// converted by the same code as the JSON methods, the template adds the
+// document envelope, the resource identifier and the relationships.
+var jsonapiTemplate = template.Must(template.New("jsonapi").Parse(`
+// MarshalJSONAPI encodes {{.Recv}} as a JSON:API document.
+func ({{.Recv}} {{.RecvType}}) MarshalJSONAPI() ([]byte, error) {
+	{{.EncType}}
+	type {{.Ident}} struct {
+		Type string ` + "`" + `json:"type"` + "`" + `
+		ID   string ` + "`" + `json:"id"` + "`" + `
+	}
+	type {{.Rel}} struct {
+		Data interface{} ` + "`" + `json:"data"` + "`" + `
+	}
+	var {{.Doc}} struct {
+		Data struct {
+			Type          string ` + "`" + `json:"type"` + "`" + `
+			ID            string ` + "`" + `json:"id,omitempty"` + "`" + `
+			Attributes    *{{.Inter}} ` + "`" + `json:"attributes,omitempty"` + "`" + `
+			Relationships map[string]{{.Rel}} ` + "`" + `json:"relationships,omitempty"` + "`" + `
+		} ` + "`" + `json:"data"` + "`" + `
+	}
+	var {{.Enc}} {{.Inter}}
+	{{.EncConversions}}
+	{{.Doc}}.Data.Type = {{printf "%q" .ResourceType}}
+	{{- if .ID}}
+	{{.Doc}}.Data.ID = {{.ID.Encode}}
+	{{- end}}
+	{{.Doc}}.Data.Attributes = &{{.Enc}}
+	{{- if .Relations}}
+	{{.Doc}}.Data.Relationships = make(map[string]{{.Rel}}, {{len .Relations}})
+	{{- end}}
+	{{- range .Relations}}
+	{{- if .Many}}
+	{{.Var}} := make([]{{$.Ident}}, len({{.Field}}))
+	for {{$.Index}}, {{$.Value}} := range {{.Field}} {
+		{{.Var}}[{{$.Index}}] = {{$.Ident}}{Type: {{printf "%q" .Type}}, ID: {{.Encode}}}
+	}
+	{{$.Doc}}.Data.Relationships[{{printf "%q" .Name}}] = {{$.Rel}}{Data: {{.Var}}}
+	{{- else if .Ptr}}
+	if {{.Field}} != nil {
+		{{$.Doc}}.Data.Relationships[{{printf "%q" .Name}}] = {{$.Rel}}{Data: {{$.Ident}}{Type: {{printf "%q" .Type}}, ID: {{.Encode}}}}
+	} else {
+		{{$.Doc}}.Data.Relationships[{{printf "%q" .Name}}] = {{$.Rel}}{Data: nil}
+	}
+	{{- else}}
+	{{$.Doc}}.Data.Relationships[{{printf "%q" .Name}}] = {{$.Rel}}{Data: {{$.Ident}}{Type: {{printf "%q" .Type}}, ID: {{.Encode}}}}
+	{{- end}}
+	{{- end}}
+	return {{.JSON}}.Marshal(&{{.Doc}})
+}
+
+// UnmarshalJSONAPI decodes {{.Recv}} from a JSON:API document.
+func ({{.Recv}} *{{.RecvType}}) UnmarshalJSONAPI({{.Input}} []byte) error {
+	{{.DecType}}
+	type {{.Ident}} struct {
+		Type string ` + "`" + `json:"type"` + "`" + `
+		ID   string ` + "`" + `json:"id"` + "`" + `
+	}
+	var {{.Doc}} struct {
+		Data struct {
+			Type          string ` + "`" + `json:"type"` + "`" + `
+			ID            string ` + "`" + `json:"id"` + "`" + `
+			Attributes    {{.Inter}} ` + "`" + `json:"attributes"` + "`" + `
+			Relationships map[string]struct {
+				Data {{.JSON}}.RawMessage ` + "`" + `json:"data"` + "`" + `
+			} ` + "`" + `json:"relationships"` + "`" + `
+		} ` + "`" + `json:"data"` + "`" + `
+	}
+	if err := {{.JSON}}.Unmarshal({{.Input}}, &{{.Doc}}); err != nil {
+		return err
+	}
+	if {{.Doc}}.Data.Type != {{printf "%q" .ResourceType}} {
+		return {{.Fmt}}.Errorf("wrong resource type %q for {{.Type}}", {{.Doc}}.Data.Type)
+	}
+	{{- if .ID}}
+	{{.ID.Decode}}
+	{{- end}}
+	{{- if .DecConversions}}
+	{{.Dec}} := {{.Doc}}.Data.Attributes
+	{{.DecConversions}}
+	{{- end}}
+	{{- range .Relations}}
+	if {{$.Value}}, {{$.Ok}} := {{$.Doc}}.Data.Relationships[{{printf "%q" .Name}}]; {{$.Ok}} {
+		{{- if .Many}}
+		var {{.Var}} []{{$.Ident}}
+		if err := {{$.JSON}}.Unmarshal({{$.Value}}.Data, &{{.Var}}); err != nil {
+			return err
+		}
+		{{.Field}} = make({{.GoType}}, len({{.Var}}))
+		for {{$.Index}} := range {{.Var}} {
+			{{.Decode}}
+		}
+		{{- else}}
+		var {{.Var}} *{{$.Ident}}
+		if err := {{$.JSON}}.Unmarshal({{$.Value}}.Data, &{{.Var}}); err != nil {
+			return err
+		}
+		if {{.Var}} != nil {
+			{{.Decode}}
+		}
+		{{- end}}
+	}
+	{{- if .Required}} else {
+		return {{$.Errors}}.New("missing required relationship '{{.Name}}' for {{$.Type}}")
+	}
+	{{- end}}
+	{{- end}}
+	return nil
+}
+`))
+
+// jsonapiData is the input of jsonapiTemplate.
+type jsonapiData struct {
+	Type, RecvType string
+	ResourceType   string
+	ID             *jsonapiValue
+	Relations      []*jsonapiRelation
+
+	// intermediate types and conversions of the attributes
+	Inter                          string // name of the intermediate types
+	EncType, DecType               string
+	EncConversions, DecConversions string
+
+	// package and variable names
+	JSON, Fmt, Errors                      string
+	Recv, Input, Doc, Enc, Dec, Ident, Rel string
+	Index, Value, Ok                       string
+}
+
+// jsonapiValue is a resource ID in the generated code.
+type jsonapiValue struct {
+	Encode string // string expression of the ID
+	Decode string // statements assigning the ID
+}
+
+// jsonapiRelation is a relationship of the resource.
+type jsonapiRelation struct {
+	Name, Type string
+	Field      string // selector of the field
+	GoType     string
+	Var        string
+	Many, Ptr  bool
+	Required   bool
+	jsonapiValue
+}
+
+// genJSONAPI writes the MarshalJSONAPI and UnmarshalJSONAPI methods of mtyp.
+// The resource type and ID are given by the field with the jsonapi:"primary,type"
+// tag, attributes by jsonapi:"attr,name" tags and relationships to other resources
+// by jsonapi:"relation,name,type" tags. Fields without a jsonapi tag are ignored.
+func genJSONAPI(w io.Writer, mtyp *marshalerType) error {
+	// Both methods share one scope because the conversions are printed
+	// into the template, which uses the same variable names for both.
+	var (
+		attrs = *mtyp
+		enc   = newMarshalMethod(&attrs, false)
+		dec   = newMarshalMethod(&attrs, true)
+		scope = enc.scope
+		recv  = enc.receiver().Name
+	)
+	dec.scope = scope
+	scope.used[enc.iterKey.Name] = true
+	scope.used[enc.iterVal.Name] = true
+	data := jsonapiData{
+		Type:     mtyp.name,
+		RecvType: mtyp.recvType(),
+		JSON:     mtyp.scope.packageName("encoding/json"),
+		Fmt:      mtyp.scope.packageName("fmt"),
+		Errors:   mtyp.scope.packageName("errors"),
+		Recv:     recv,
+		Input:    scope.newIdent("input"),
+		Doc:      scope.newIdent("doc"),
+		Enc:      scope.newIdent("enc"),
+		Dec:      scope.newIdent("dec"),
+		Ident:    scope.newIdent("identifier"),
+		Rel:      scope.newIdent("relationship"),
+		Index:    scope.newIdent("i"),
+		Value:    scope.newIdent("r"),
+		Ok:       scope.newIdent("ok"),
+	}
+	attrs.Fields = nil
+	for _, f := range mtyp.Fields {
+		tag, ok := reflect.StructTag(f.tag).Lookup("jsonapi")
+		if !ok || f.function != nil {
+			continue
+		}
+		opts := strings.Split(tag, ",")
+		errorf := func(format string, args ...interface{}) error {
+			return fmt.Errorf("%v: field %s: %s", mtyp.fs.Position(f.pos), f.name, fmt.Sprintf(format, args...))
+		}
+		switch {
+		case opts[0] == "primary" && len(opts) == 2:
+			if data.ID != nil {
+				return errorf("more than one primary field")
+			}
+			if !isResourceID(f.origTyp) {
+				return errorf("type %s can't be a resource ID, it must be a string or integer", f.origTyp)
+			}
+			field := f.access(Name(recv))
+			data.ResourceType = opts[1]
+			data.ID = &jsonapiValue{
+				Encode: printExpr(mtyp, encodeResourceID(enc, field, f.origTyp)),
+				Decode: printStatements(mtyp, decodeResourceID(dec, Name(data.Doc+".Data.ID"), field, f.origTyp)),
+			}
+		case opts[0] == "attr" && len(opts) >= 2:
+			// Attributes are encoded like JSON fields with the attribute name as the key.
+			attr := *f
+			attr.tag = setStructTag(f.tag, "json", strings.Join(opts[1:], ","))
+			attrs.Fields = append(attrs.Fields, &attr)
+		case opts[0] == "relation" && len(opts) == 3:
+			rel, err := newJSONAPIRelation(dec, f, Name(recv), scope.newIdent(uncapitalize(f.name)), data.Index, data.Value)
+			if err != nil {
+				return errorf("%v", err)
+			}
+			rel.Name, rel.Type = opts[1], opts[2]
+			data.Relations = append(data.Relations, rel)
+		default:
+			return errorf("invalid jsonapi tag %q", tag)
+		}
+	}
+	if data.ID == nil {
+		return fmt.Errorf(`type %s has no field with jsonapi:"primary,type" tag`, mtyp.name)
+	}
+
+	data.Inter = enc.intermediateName("json")
+	data.EncType = printStatements(mtyp, []Statement{declStmt{enc.intermediateType(data.Inter, "json")}})
+	data.DecType = printStatements(mtyp, []Statement{declStmt{dec.intermediateType(data.Inter, "json")}})
+	data.EncConversions = printStatements(mtyp, enc.marshalConversions(Name(recv), Name(data.Enc), "json"))
+	data.DecConversions = printStatements(mtyp, dec.unmarshalConversions(Name(data.Dec), Name(recv), "json"))
+	return mtyp.template(jsonapiTemplate).Execute(w, data)
+}
+
+// newJSONAPIRelation creates the relationship of field f. To-one relationships are
+// ID fields or pointers to IDs, to-many relationships are slices of IDs. The
+// decoded resource identifiers are stored in the variable v.
+func newJSONAPIRelation(m *marshalMethod, f *marshalerField, recv Expression, v, index, elem string) (*jsonapiRelation, error) {
+	field := f.access(recv)
+	rel := &jsonapiRelation{
+		Field:    printExpr(m.mtyp, field),
+		GoType:   types.TypeString(f.origTyp, m.mtyp.scope.qualify),
+		Var:      v,
+		Required: f.hasOption("required"),
+	}
+	typ := f.origTyp
+	switch u := typ.(type) {
+	case *types.Pointer:
+		rel.Ptr, typ = true, u.Elem()
+	case *types.Slice:
+		rel.Many, typ = true, u.Elem()
+	}
+	if !isResourceID(typ) {
+		return nil, fmt.Errorf("type %s can't be a relationship, it must be a string or integer ID, a pointer or a slice of IDs", f.origTyp)
+	}
+
+	var decode []Statement
+	switch {
+	case rel.Ptr:
+		// Decode into a new variable and store its address.
+		id := Name(m.scope.newIdent(v + "ID"))
+		decode = append(decode, Declare{Name: id.Name, TypeName: types.TypeString(typ, m.mtyp.scope.qualify)})
+		decode = append(decode, decodeResourceID(m, Name(v+".ID"), id, typ)...)
+		decode = append(decode, Assign{Lhs: field, Rhs: AddressOf{Value: id}})
+		rel.Encode = printExpr(m.mtyp, encodeResourceID(m, Star{Value: field}, typ))
+	case rel.Many:
+		decode = decodeResourceID(m, Name(v+"["+index+"].ID"), Index{Value: field, Index: Name(index)}, typ)
+		rel.Encode = printExpr(m.mtyp, encodeResourceID(m, Name(elem), typ))
+	default:
+		decode = decodeResourceID(m, Name(v+".ID"), field, typ)
+		rel.Encode = printExpr(m.mtyp, encodeResourceID(m, field, typ))
+	}
+	rel.Decode = printStatements(m.mtyp, decode)
+	return rel, nil
+}
+
+// isResourceID reports whether typ can be used as a resource ID.
+func isResourceID(typ types.Type) bool {
+	basic, ok := typ.Underlying().(*types.Basic)
+	return ok && basic.Info()&(types.IsString|types.IsInteger) != 0
+}
+
+// encodeResourceID creates an expression that converts the ID v to a string.
+func encodeResourceID(m *marshalMethod, v Expression, typ types.Type) Expression {
+	if isString(typ) {
+		return convertTo(v, typ, types.String)
+	}
+	return m.formatNumber(v, typ)
+}
+
+// decodeResourceID generates code that converts the ID string s and assigns it to out.
+func decodeResourceID(m *marshalMethod, s, out Expression, typ types.Type) []Statement {
+	if !isString(typ) {
+		return m.parseNumber(s, out, typ)
+	}
+	var conv Expression = s
+	if !types.Identical(typ, types.Typ[types.String]) {
+		conv = CallFunction{Func: Name(types.TypeString(typ, m.mtyp.scope.qualify)), Params: []Expression{s}}
+	}
+	return []Statement{Assign{Lhs: out, Rhs: conv}}
+}
+
+func printExpr(mtyp *marshalerType, e Expression) string {
+	buf := new(bytes.Buffer)
+	printer.Fprint(buf, mtyp.fs, e.Expression())
+	return buf.String()
+}
+
+func printStatements(mtyp *marshalerType, stmts []Statement) string {
+	buf := new(bytes.Buffer)
+	for i, s := range stmts {
+		if i > 0 {
+			buf.WriteByte('\n')
+		}
+		printer.Fprint(buf, mtyp.fs, s.Statement())
+	}
+	return buf.String()
+}
@@ -0,0 +1,168 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"fmt"
+	"go/types"
+	"io"
+	"text/template"
+
+	. "github.com/garslo/gogen"
+)
+
+// jsonFieldsTemplate generates the partial JSON decoder of a type. Each field has a
+// bit in the masks of requested and found keys. Reading stops when all requested
+// keys have been found.
+var jsonFieldsTemplate = template.Must(template.New("jsonfields").Parse(`
+// DecodeJSONFields decodes the given fields of {{.Recv}} from the JSON object in {{.Input}}. Fields
+// are named by their JSON key. The values of other keys are skipped without decoding them,
+// and the input isn't read any further once all requested fields have been found, so
+// syntax errors after them aren't reported. Fields which aren't requested are left unchanged.
+func ({{.Recv}} *{{.RecvType}}) DecodeJSONFields({{.Input}} []byte, {{.Fields}} ...string) error {
+	var {{.Want}}, {{.Seen}} uint64
+	for _, {{.F}} := range {{.Fields}} {
+		switch {{.F}} {
+		{{- range .Cases}}
+		case {{printf "%q" .Key}}:
+			{{$.Want}} |= 1 << {{.Bit}}
+		{{- end}}
+		default:
+			return {{.Fmt}}.Errorf("unknown field '%s' for {{.Type}}", {{.F}})
+		}
+	}
+	{{.D}} := {{.JSON}}.NewDecoder({{.Bytes}}.NewReader({{.Input}}))
+	{{.Tok}}, {{.Err}} := {{.D}}.Token()
+	if {{.Err}} != nil {
+		return {{.Err}}
+	}
+	if {{.Tok}} != {{.JSON}}.Delim('{') {
+		return {{.Errors}}.New("expected JSON object for {{.Type}}")
+	}
+	for {{.Seen}} != {{.Want}} && {{.D}}.More() {
+		{{.Tok}}, {{.Err}} = {{.D}}.Token()
+		if {{.Err}} != nil {
+			return {{.Err}}
+		}
+		switch {{.Tok}} {
+		{{- range .Cases}}
+		case {{printf "%q" .Key}}:
+			if {{$.Want}}&(1<<{{.Bit}}) == 0 {
+				break
+			}
+			var {{$.V}} {{.Type}}
+			if {{$.Err}} := {{$.D}}.Decode(&{{$.V}}); {{$.Err}} != nil {
+				return {{$.Err}}
+			}
+			{{$.Seen}} |= 1 << {{.Bit}}
+			if {{$.V}} != nil {
+				{{.Decode}}
+			}
+			{{- if .Missing}} else {
+				{{.Missing}}
+			}
+			{{- end}}
+			continue
+		{{- end}}
+		}
+		var {{.Skip}} {{.JSON}}.RawMessage
+		if {{.Err}} := {{.D}}.Decode(&{{.Skip}}); {{.Err}} != nil {
+			return {{.Err}}
+		}
+	}
+	{{- if .Checks}}
+	{{.Missed}} := {{.Want}} &^ {{.Seen}}
+	{{- range .Checks}}
+	if {{$.Missed}}&(1<<{{.Bit}}) != 0 {
+		{{.Missing}}
+	}
+	{{- end}}
+	{{- end}}
+	return nil
+}
+`))
+
+type jsonFieldsData struct {
+	Type, RecvType string
+	Cases          []jsonFieldsCase
+	Checks         []jsonFieldsCase
+
+	// package and variable names
+	Bytes, JSON, Errors, Fmt        string
+	Recv, Input, Fields, F          string
+	Want, Seen, Missed, D, Tok, Err string
+	V, Skip                         string
+}
+
+// jsonFieldsCase decodes the value of a key.
+type jsonFieldsCase struct {
+	Key, Type string
+	Bit       int
+	Decode    string // statements assigning the decoded value
+	Missing   string // statements handling a missing or null value, if any
+}
+
+// genJSONFields writes the DecodeJSONFields method of mtyp.
+func genJSONFields(w io.Writer, mtyp *marshalerType) error {
+	var (
+		m    = newMarshalMethod(mtyp, true)
+		recv = m.receiver().Name
+	)
+	m.scope.used[m.iterKey.Name] = true
+	m.scope.used[m.iterVal.Name] = true
+	data := jsonFieldsData{
+		Type:     mtyp.name,
+		RecvType: mtyp.recvType(),
+		Bytes:    mtyp.scope.packageName("bytes"),
+		JSON:     mtyp.scope.packageName("encoding/json"),
+		Errors:   mtyp.scope.packageName("errors"),
+		Fmt:      mtyp.scope.packageName("fmt"),
+		Recv:     recv,
+		Input:    m.scope.newIdent("input"),
+		Fields:   m.scope.newIdent("fields"),
+		F:        m.scope.newIdent("f"),
+		Want:     m.scope.newIdent("want"),
+		Seen:     m.scope.newIdent("seen"),
+		Missed:   m.scope.newIdent("missed"),
+		D:        m.scope.newIdent("d"),
+		Tok:      m.scope.newIdent("tok"),
+		Err:      m.scope.newIdent("err"),
+		V:        m.scope.newIdent("val"),
+		Skip:     m.scope.newIdent("skip"),
+	}
+	for _, f := range mtyp.Fields {
+		key, ok := f.key("json")
+		if !ok || f.function != nil {
+			continue
+		}
+		if f.hasTagOption("json", "string") {
+			return fmt.Errorf("%v: field %s: the string option is not supported by DecodeJSONFields", mtyp.fs.Position(f.pos), f.name)
+		}
+		c := jsonFieldsCase{
+			Key:    key,
+			Type:   types.TypeString(ensureNilCheckable(f.typ), mtyp.scope.qualify),
+			Bit:    len(data.Cases),
+			Decode: printStatements(mtyp, m.decodeField(f, Name(data.V), Name(recv), "json")),
+		}
+		if f.hook != nil {
+			c.Type = mtyp.rawMessage()
+		}
+		switch {
+		case f.defValue != nil:
+			c.Missing = printStatements(mtyp, []Statement{Assign{Lhs: f.access(Name(recv)), Rhs: f.defValue}})
+		case f.isRequired("json"):
+			msg := fmt.Sprintf("missing required field '%s' for %s", f.encodedName("json"), mtyp.name)
+			c.Missing = fmt.Sprintf("return %s.New(%q)", data.Errors, msg)
+		}
+		data.Cases = append(data.Cases, c)
+		if c.Missing != "" {
+			data.Checks = append(data.Checks, c)
+		}
+	}
+	if len(data.Cases) > 64 {
+		return fmt.Errorf("DecodeJSONFields supports at most 64 fields, %s has %d", mtyp.name, len(data.Cases))
+	}
+	return mtyp.template(jsonFieldsTemplate).Execute(w, data)
+}
@@ -0,0 +1,398 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"go/types"
+	"reflect"
+	"strings"
+)
+
+const jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// jsonSchema is a JSON Schema of the 2020-12 dialect. Empty keywords are left
+// out, so the zero value accepts any JSON value.
+type jsonSchema struct {
+	Schema          string          `json:"$schema,omitempty"`
+	Comment         string          `json:"$comment,omitempty"`
+	Ref             string          `json:"$ref,omitempty"`
+	Const           interface{}     `json:"const,omitempty"`
+	Enum            []string        `json:"enum,omitempty"`
+	Type            interface{}     `json:"type,omitempty"` // string or []string
+	Format          string          `json:"format,omitempty"`
+	ContentEncoding string          `json:"contentEncoding,omitempty"`
+	Minimum         *int            `json:"minimum,omitempty"`
+	AnyOf           []*jsonSchema   `json:"anyOf,omitempty"`
+	AllOf           []*jsonSchema   `json:"allOf,omitempty"`
+	Properties      jsonSchemaProps `json:"properties,omitempty"`
+	Required        []string        `json:"required,omitempty"`
+	Additional      interface{}     `json:"additionalProperties,omitempty"` // *jsonSchema or false
+	Items           *jsonSchema     `json:"items,omitempty"`
+	MinItems        *int            `json:"minItems,omitempty"`
+	MaxItems        *int            `json:"maxItems,omitempty"`
+	Defs            jsonSchemaProps `json:"$defs,omitempty"`
+}
+
+// jsonSchemaProps are schemas by name, in the order of definition.
+type jsonSchemaProps []jsonSchemaProp
+
+type jsonSchemaProp struct {
+	Name   string
+	Schema *jsonSchema
+}
+
+// genJSONSchema creates a JSON Schema defining the marshaling types in $defs.
+// If there is only one type, the root schema refers to it.
+func genJSONSchema(mtyps []*marshalerType) ([]byte, error) {
+	c := newJSONSchemaConverter(mtyps, "#/$defs/")
+	defs, err := c.definitions(mtyps)
+	if err != nil {
+		return nil, err
+	}
+	root := &jsonSchema{
+		Schema:  jsonSchemaDialect,
+		Comment: "Code generated by github.com/fjl/gencodec. DO NOT EDIT.",
+		Defs:    defs,
+	}
+	if structs, _ := structTypes(mtyps); len(structs) == 1 {
+		root.Ref = c.ref(structs[0].name)
+	}
+	out, err := json.MarshalIndent(root, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(out, '\n'), nil
+}
+
+// jsonSchemaConverter creates the schemas of Go types. Named struct types are
+// defined in $defs and referenced by name, so recursive types are allowed.
+type jsonSchemaConverter struct {
+	refPrefix string                             // location of the definitions
+	mtyps     map[*types.TypeName]bool           // marshaling types, which are defined by typeSchema
+	enums     map[*types.TypeName]*marshalerType // enum types, whose schemas are inlined
+	defined   map[string]types.Type              // types by definition name
+	pending   []jsonSchemaDef                    // nested struct types which must be defined
+}
+
+type jsonSchemaDef struct {
+	name string
+	typ  *types.Named
+}
+
+func newJSONSchemaConverter(mtyps []*marshalerType, refPrefix string) *jsonSchemaConverter {
+	c := &jsonSchemaConverter{
+		refPrefix: refPrefix,
+		mtyps:     make(map[*types.TypeName]bool),
+		enums:     make(map[*types.TypeName]*marshalerType),
+		defined:   make(map[string]types.Type),
+	}
+	for _, mtyp := range mtyps {
+		if mtyp.enum != nil {
+			c.enums[mtyp.orig.Obj()] = mtyp
+			continue
+		}
+		c.mtyps[mtyp.orig.Obj()] = true
+		c.defined[mtyp.name] = mtyp.orig
+	}
+	return c
+}
+
+// definitions creates the schemas of the marshaling types, followed by the
+// schemas of the nested struct types referenced by them.
+func (c *jsonSchemaConverter) definitions(mtyps []*marshalerType) (jsonSchemaProps, error) {
+	var defs jsonSchemaProps
+	for _, mtyp := range mtyps {
+		if mtyp.enum != nil {
+			continue // enums are inlined
+		}
+		if mtyp.orig.TypeParams().Len() > 0 {
+			return nil, fmt.Errorf("generic type %s has no JSON Schema", mtyp.name)
+		}
+		schema, err := c.typeSchema(mtyp)
+		if err != nil {
+			return nil, err
+		}
+		defs = append(defs, jsonSchemaProp{mtyp.name, schema})
+	}
+	for i := 0; i < len(c.pending); i++ {
+		def := c.pending[i]
+		schema, err := c.structSchema(def.typ, def.typ.Underlying().(*types.Struct))
+		if err != nil {
+			return nil, err
+		}
+		defs = append(defs, jsonSchemaProp{def.name, schema})
+	}
+	return defs, nil
+}
+
+// typeSchema creates the object schema of mtyp. Properties are the JSON keys and
+// their types follow the intermediate type. Values of optional fields may be null.
+func (c *jsonSchemaConverter) typeSchema(mtyp *marshalerType) (*jsonSchema, error) {
+	schema := &jsonSchema{Type: "object"}
+	if mtyp.strict {
+		schema.Additional = false
+	}
+	for _, f := range mtyp.Fields {
+		key, ok := f.key("json")
+		if !ok {
+			continue
+		}
+		typ := f.typ
+		if f.wellKnown != nil {
+			typ = f.wellKnown.repr(mtyp.scope)
+		}
+		var (
+			prop *jsonSchema
+			err  error
+		)
+		switch {
+		case f.dynamic:
+			prop = dynamicJSONSchema()
+		case f.union != nil:
+			prop, err = c.unionJSONSchema(f.union)
+		default:
+			prop, err = c.schema(typ)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%v: field %s: %v", mtyp.fs.Position(f.pos), f.name, err)
+		}
+		// Required fields can't be null because the unmarshaling
+		// methods treat null like a missing value.
+		if f.isRequired("json") {
+			schema.Required = append(schema.Required, key)
+			prop = prop.notNull()
+		} else {
+			prop = prop.orNull()
+		}
+		schema.Properties = append(schema.Properties, jsonSchemaProp{key, prop})
+	}
+	return schema, nil
+}
+
+// schema creates the schema of the JSON encoding of typ.
+func (c *jsonSchemaConverter) schema(typ types.Type) (*jsonSchema, error) {
+	if types.TypeString(typ, nil) == "encoding/json.RawMessage" {
+		return &jsonSchema{}, nil
+	}
+	if named, ok := typ.(*types.Named); ok {
+		obj := named.Obj()
+		switch {
+		case obj.Pkg() != nil && obj.Pkg().Path() == "time" && obj.Name() == "Time":
+			return &jsonSchema{Type: "string", Format: "date-time"}, nil
+		case obj.Pkg() != nil && obj.Pkg().Path() == "math/big" && obj.Name() == "Int":
+			return &jsonSchema{Type: "integer"}, nil
+		case c.enums[obj] != nil:
+			return enumJSONSchema(c.enums[obj]), nil
+		case c.mtyps[obj]:
+			return &jsonSchema{Ref: c.ref(obj.Name())}, nil
+		case isJSONMarshaler(typ) || isJSONMarshaler(types.NewPointer(typ)):
+			// The encoding of other types with a MarshalJSON method is unknown.
+			return &jsonSchema{}, nil
+		}
+	}
+	if isTextMarshaler(typ) {
+		return &jsonSchema{Type: "string"}, nil
+	}
+	switch u := typ.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case u.Info()&types.IsBoolean != 0:
+			return &jsonSchema{Type: "boolean"}, nil
+		case u.Info()&types.IsString != 0:
+			return &jsonSchema{Type: "string"}, nil
+		case u.Info()&types.IsUnsigned != 0:
+			zero := 0
+			return &jsonSchema{Type: "integer", Minimum: &zero}, nil
+		case u.Info()&types.IsInteger != 0:
+			return &jsonSchema{Type: "integer"}, nil
+		case u.Info()&types.IsFloat != 0:
+			return &jsonSchema{Type: "number"}, nil
+		}
+	case *types.Pointer:
+		elem, err := c.schema(u.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return elem.orNull(), nil
+	case *types.Slice:
+		if isByte(u.Elem()) && !c.isEnum(u.Elem()) {
+			return (&jsonSchema{Type: "string", ContentEncoding: "base64"}).orNull(), nil
+		}
+		items, err := c.schema(u.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return (&jsonSchema{Type: "array", Items: items}).orNull(), nil
+	case *types.Array:
+		items, err := c.schema(u.Elem())
+		if err != nil {
+			return nil, err
+		}
+		n := int(u.Len())
+		return &jsonSchema{Type: "array", Items: items, MinItems: &n, MaxItems: &n}, nil
+	case *types.Map:
+		key, _ := u.Key().Underlying().(*types.Basic)
+		if !isString(u.Key()) && !isTextMarshaler(u.Key()) && (key == nil || key.Info()&types.IsInteger == 0) {
+			return nil, fmt.Errorf("map key type %s is not a string or integer", u.Key())
+		}
+		elem, err := c.schema(u.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return (&jsonSchema{Type: "object", Additional: elem}).orNull(), nil
+	case *types.Interface:
+		return &jsonSchema{}, nil
+	case *types.Struct:
+		named, ok := typ.(*types.Named)
+		if !ok {
+			return c.structSchema(typ, u)
+		}
+		name, err := c.define(named)
+		if err != nil {
+			return nil, err
+		}
+		return &jsonSchema{Ref: c.ref(name)}, nil
+	}
+	return nil, fmt.Errorf("type %s has no JSON Schema", typ)
+}
+
+// define returns the definition name of a named struct type, adding the
+// type to the pending definitions when it is first referenced. If the name
+// is taken by another type, it is qualified by the package name.
+func (c *jsonSchemaConverter) define(typ *types.Named) (string, error) {
+	for name, t := range c.defined {
+		if types.Identical(t, typ) {
+			return name, nil
+		}
+	}
+	obj := typ.Obj()
+	name := obj.Name()
+	if t, ok := c.defined[name]; ok && !types.Identical(t, typ) {
+		name = obj.Pkg().Name() + "." + name
+	}
+	if t, ok := c.defined[name]; ok && !types.Identical(t, typ) {
+		return "", fmt.Errorf("JSON Schema definition name %s is used by %s and %s", name, t, typ)
+	}
+	c.defined[name] = typ
+	c.pending = append(c.pending, jsonSchemaDef{name, typ})
+	return name, nil
+}
+
+// structSchema creates the object schema of a nested struct type. Its fields
+// are encoded by encoding/json, embedded structs without a key are inlined.
+func (c *jsonSchemaConverter) structSchema(typ types.Type, styp *types.Struct) (*jsonSchema, error) {
+	schema := &jsonSchema{Type: "object"}
+	for i := 0; i < styp.NumFields(); i++ {
+		f, tag := styp.Field(i), styp.Tag(i)
+		key, opts, _ := strings.Cut(reflect.StructTag(tag).Get("json"), ",")
+		if key == "-" && opts == "" {
+			continue
+		}
+		if f.Embedded() && key == "" {
+			ftyp := f.Type()
+			if ptr, ok := ftyp.(*types.Pointer); ok {
+				ftyp = ptr.Elem()
+			}
+			if inner, ok := ftyp.Underlying().(*types.Struct); ok {
+				embedded, err := c.structSchema(ftyp, inner)
+				if err != nil {
+					return nil, err
+				}
+				schema.Properties = append(schema.Properties, embedded.Properties...)
+				schema.Required = append(schema.Required, embedded.Required...)
+				continue
+			}
+		}
+		if !f.Exported() {
+			continue
+		}
+		if key == "" {
+			key = f.Name()
+		}
+		prop, err := c.schema(f.Type())
+		if err != nil {
+			return nil, fmt.Errorf("field %s.%s: %v", typ, f.Name(), err)
+		}
+		schema.Properties = append(schema.Properties, jsonSchemaProp{key, prop})
+		gencodecOpts := "," + reflect.StructTag(tag).Get("gencodec") + ","
+		if strings.Contains(gencodecOpts, ",required,") && !hasTagOption(opts, "omitempty") {
+			schema.Required = append(schema.Required, key)
+		}
+	}
+	return schema, nil
+}
+
+// orNull returns a schema which also accepts null.
+func (s *jsonSchema) orNull() *jsonSchema {
+	switch t := s.Type.(type) {
+	case nil:
+		if n := len(s.AnyOf); n > 0 && s.AnyOf[n-1].Type != "null" {
+			s.AnyOf = append(s.AnyOf, &jsonSchema{Type: "null"})
+			return s
+		}
+		if s.Ref == "" {
+			return s // accepts any value or null
+		}
+		return &jsonSchema{AnyOf: []*jsonSchema{s, {Type: "null"}}}
+	case string:
+		s.Type = []string{t, "null"}
+	}
+	return s
+}
+
+// notNull returns a schema which doesn't accept null.
+func (s *jsonSchema) notNull() *jsonSchema {
+	if t, ok := s.Type.([]string); ok {
+		s.Type = t[0]
+	}
+	if len(s.AnyOf) == 2 && s.AnyOf[1].Type == "null" {
+		return s.AnyOf[0]
+	}
+	return s
+}
+
+// isEnum reports whether typ is one of the enum types, which are encoded as strings
+// even in slices of bytes.
+func (c *jsonSchemaConverter) isEnum(typ types.Type) bool {
+	named, ok := typ.(*types.Named)
+	return ok && c.enums[named.Obj()] != nil
+}
+
+func (c *jsonSchemaConverter) ref(name string) string {
+	return c.refPrefix + name
+}
+
+// MarshalJSON encodes the properties as an object, keeping their order.
+func (props jsonSchemaProps) MarshalJSON() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	buf.WriteByte('{')
+	for i, p := range props {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(p.Name)
+		buf.Write(key)
+		buf.WriteByte(':')
+		value, err := json.Marshal(p.Schema)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func isJSONMarshaler(typ types.Type) bool {
+	var (
+		bytes = types.NewVar(token.NoPos, nil, "", types.NewSlice(types.Typ[types.Byte]))
+		err   = types.NewVar(token.NoPos, nil, "", types.Universe.Lookup("error").Type())
+	)
+	return hasMethod(typ, "MarshalJSON", types.NewSignature(nil, nil, types.NewTuple(bytes, err), false))
+}
@@ -0,0 +1,163 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"fmt"
+	"go/types"
+	"io"
+	"text/template"
+
+	. "github.com/garslo/gogen"
+)
+
+// jsonStreamTemplate generates the token-driven JSON decoder of a type. Fields are
+// decoded one at a time into the receiver. The bits of the presence mask are set
+// for fields which are required or have a default value.
+var jsonStreamTemplate = template.Must(template.New("jsonstream").Parse(`
+// DecodeJSON decodes {{.Recv}} from the next JSON value read by {{.D}}. Unlike UnmarshalJSON,
+// it reads the object token by token and decodes the fields directly into {{.Recv}}, so the
+// input isn't held in memory. Object keys must match the field keys exactly.
+func ({{.Recv}} *{{.RecvType}}) DecodeJSON({{.D}} *{{.JSON}}.Decoder) error {
+	{{.Tok}}, {{.Err}} := {{.D}}.Token()
+	if {{.Err}} != nil {
+		return {{.Err}}
+	}
+	if {{.Tok}} != {{.JSON}}.Delim('{') {
+		return {{.Errors}}.New("expected JSON object for {{.Type}}")
+	}
+	{{- if .Checks}}
+	var {{.Seen}} uint64
+	{{- end}}
+	for {{.D}}.More() {
+		{{.Tok}}, {{.Err}} = {{.D}}.Token()
+		if {{.Err}} != nil {
+			return {{.Err}}
+		}
+		switch {{.Tok}} {
+		{{- range .Cases}}
+		case {{printf "%q" .Key}}:
+			var {{$.V}} {{.Type}}
+			if {{$.Err}} := {{$.D}}.Decode(&{{$.V}}); {{$.Err}} != nil {
+				return {{$.Err}}
+			}
+			if {{$.V}} != nil {
+				{{.Decode}}
+				{{- if ge .Bit 0}}
+				{{$.Seen}} |= 1 << {{.Bit}}
+				{{- end}}
+			}
+		{{- end}}
+		default:
+			{{- if .Strict}}
+			return {{.Fmt}}.Errorf("unknown field '%v' for {{.Type}}", {{.Tok}})
+			{{- else}}
+			var {{.Skip}} {{.JSON}}.RawMessage
+			if {{.Err}} := {{.D}}.Decode(&{{.Skip}}); {{.Err}} != nil {
+				return {{.Err}}
+			}
+			{{- end}}
+		}
+	}
+	if _, {{.Err}} := {{.D}}.Token(); {{.Err}} != nil {
+		return {{.Err}}
+	}
+	{{- range .Checks}}
+	if {{$.Seen}}&(1<<{{.Bit}}) == 0 {
+		{{- if .Default}}
+		{{.Default}}
+		{{- else}}
+		return {{$.Errors}}.New({{printf "%q" .Error}})
+		{{- end}}
+	}
+	{{- end}}
+	return nil
+}
+`))
+
+type jsonStreamData struct {
+	Type, RecvType string
+	Strict         bool
+	Cases          []jsonStreamCase
+	Checks         []jsonStreamCheck
+
+	// package and variable names
+	JSON, Errors, Fmt                string
+	Recv, D, Tok, Err, Seen, V, Skip string
+}
+
+// jsonStreamCase decodes the value of a key.
+type jsonStreamCase struct {
+	Key, Type string
+	Decode    string // statements assigning the decoded value
+	Bit       int    // presence bit, -1 if the field isn't tracked
+}
+
+// jsonStreamCheck handles a missing field after the object is read.
+type jsonStreamCheck struct {
+	Bit     int
+	Error   string // missing required field
+	Default string // statement assigning the default value
+}
+
+// genJSONStream writes the DecodeJSON method of mtyp.
+func genJSONStream(w io.Writer, mtyp *marshalerType) error {
+	var (
+		m    = newMarshalMethod(mtyp, true)
+		recv = m.receiver().Name
+	)
+	m.scope.used[m.iterKey.Name] = true
+	m.scope.used[m.iterVal.Name] = true
+	data := jsonStreamData{
+		Type:     mtyp.name,
+		RecvType: mtyp.recvType(),
+		Strict:   mtyp.strict,
+		JSON:     mtyp.scope.packageName("encoding/json"),
+		Errors:   mtyp.scope.packageName("errors"),
+		Recv:     recv,
+		D:        m.scope.newIdent("d"),
+		Tok:      m.scope.newIdent("tok"),
+		Err:      m.scope.newIdent("err"),
+		Seen:     m.scope.newIdent("seen"),
+		V:        m.scope.newIdent("val"),
+		Skip:     m.scope.newIdent("skip"),
+	}
+	if mtyp.strict {
+		data.Fmt = mtyp.scope.packageName("fmt")
+	}
+	for _, f := range mtyp.Fields {
+		key, ok := f.key("json")
+		if !ok || f.function != nil {
+			continue
+		}
+		if f.hasTagOption("json", "string") {
+			return fmt.Errorf("%v: field %s: the string option is not supported by DecodeJSON", mtyp.fs.Position(f.pos), f.name)
+		}
+		c := jsonStreamCase{
+			Key:    key,
+			Type:   types.TypeString(ensureNilCheckable(f.typ), mtyp.scope.qualify),
+			Decode: printStatements(mtyp, m.decodeField(f, Name(data.V), Name(recv), "json")),
+			Bit:    -1,
+		}
+		if f.hook != nil {
+			c.Type = mtyp.rawMessage()
+		}
+		if f.isRequired("json") || f.defValue != nil {
+			c.Bit = len(data.Checks)
+			check := jsonStreamCheck{Bit: c.Bit}
+			if f.defValue != nil {
+				check.Default = printStatements(mtyp, []Statement{Assign{Lhs: f.access(Name(recv)), Rhs: f.defValue}})
+			} else {
+				check.Error = fmt.Sprintf("missing required field '%s' for %s", f.encodedName("json"), mtyp.name)
+			}
+			data.Checks = append(data.Checks, check)
+		}
+		data.Cases = append(data.Cases, c)
+	}
+	if len(data.Checks) > 64 {
+		return fmt.Errorf("DecodeJSON supports at most 64 required fields and fields with defaults, %s has %d", mtyp.name, len(data.Checks))
+	}
+	return mtyp.template(jsonStreamTemplate).Execute(w, data)
+}
@@ -0,0 +1,123 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"text/template"
+)
+
+// kafkaFormats are the formats whose methods encode to a byte slice, in order
+// of preference. The Kafka adapter and the framing helpers use the first one
+// generated for the type, the gzip and seal helpers all of them.
+var kafkaFormats = []string{"json", "cbor", "bson"}
+
+// kafkaTemplate generates the Kafka adapter of a type. The encoder implements
+// sarama.Encoder without importing sarama, the decoder is a plain function.
+var kafkaTemplate = template.Must(template.New("kafka").Parse(`
+// {{.Encoder}} encodes {{.Type}} as a Kafka message key or value in {{.Format}}
+// format. It implements the Encoder interface of github.com/IBM/sarama.
+type {{.Encoder}} struct {
+	Value *{{.Type}}
+	// Framed enables the wire format of the Confluent schema registry. The encoded
+	// value is then preceded by a zero byte and SchemaID as a big-endian uint32.
+	Framed   bool
+	SchemaID uint32
+
+	encoded []byte
+	err     error
+}
+
+// Encode returns the encoded message.
+func ({{.Enc}} *{{.Encoder}}) Encode() ([]byte, error) {
+	if {{.Enc}}.encoded == nil && {{.Enc}}.err == nil {
+		{{.Enc}}.encoded, {{.Enc}}.err = {{.Enc}}.encode()
+	}
+	return {{.Enc}}.encoded, {{.Enc}}.err
+}
+
+// Length returns the length of the encoded message.
+func ({{.Enc}} *{{.Encoder}}) Length() int {
+	{{.Data}}, _ := {{.Enc}}.Encode()
+	return len({{.Data}})
+}
+
+func ({{.Enc}} *{{.Encoder}}) encode() ([]byte, error) {
+	if {{.Enc}}.Value == nil {
+		return nil, {{.Errors}}.New("nil {{.Type}} value")
+	}
+	{{.Data}}, {{.Err}} := {{.Enc}}.Value.Marshal{{.Format}}()
+	if {{.Err}} != nil || !{{.Enc}}.Framed {
+		return {{.Data}}, {{.Err}}
+	}
+	{{.Framed}} := make([]byte, 5, 5+len({{.Data}}))
+	{{.Binary}}.BigEndian.PutUint32({{.Framed}}[1:], {{.Enc}}.SchemaID)
+	return append({{.Framed}}, {{.Data}}...), nil
+}
+
+// {{.Decode}} decodes {{.Type}} from a Kafka message key or value in {{.Format}}
+// format. If framed is true, the message must be in the wire format of the Confluent
+// schema registry and its schema ID is returned.
+func {{.Decode}}({{.Data}} []byte, {{.Framed}} bool) (*{{.Type}}, uint32, error) {
+	var {{.SchemaID}} uint32
+	if {{.Framed}} {
+		if len({{.Data}}) < 5 || {{.Data}}[0] != 0 {
+			return nil, 0, {{.Errors}}.New("invalid schema registry header in {{.Type}} message")
+		}
+		{{.SchemaID}} = {{.Binary}}.BigEndian.Uint32({{.Data}}[1:5])
+		{{.Data}} = {{.Data}}[5:]
+	}
+	{{.Value}} := new({{.Type}})
+	if {{.Err}} := {{.Value}}.Unmarshal{{.Format}}({{.Data}}); {{.Err}} != nil {
+		return nil, 0, {{.Err}}
+	}
+	return {{.Value}}, {{.SchemaID}}, nil
+}
+`))
+
+type kafkaData struct {
+	Type, Encoder, Decode string
+	Format                string // method name suffix, e.g. JSON
+
+	// package and variable names
+	Binary, Errors                          string
+	Enc, Data, Err, Framed, SchemaID, Value string
+}
+
+// genKafka writes the Kafka adapter of mtyp: the type TKafkaEncoder and
+// the function DecodeTKafka.
+func genKafka(w io.Writer, mtyp *marshalerType) error {
+	var format string
+	for _, f := range kafkaFormats {
+		if mtyp.hasFormat(f) {
+			format = f
+			break
+		}
+	}
+	if format == "" {
+		return fmt.Errorf("Kafka adapter requires one of the formats %v", kafkaFormats)
+	}
+	if mtyp.orig.TypeParams().Len() > 0 {
+		return errors.New("Kafka adapter is not supported for generic types")
+	}
+	scope := newFuncScope(mtyp.scope)
+	data := kafkaData{
+		Type:     mtyp.name,
+		Encoder:  mtyp.name + "KafkaEncoder",
+		Decode:   "Decode" + mtyp.name + "Kafka",
+		Format:   formatNames[format],
+		Binary:   mtyp.scope.packageName("encoding/binary"),
+		Errors:   mtyp.scope.packageName("errors"),
+		Enc:      scope.newIdent("e"),
+		Data:     scope.newIdent("data"),
+		Err:      scope.newIdent("err"),
+		Framed:   scope.newIdent("framed"),
+		SchemaID: scope.newIdent("schemaID"),
+		Value:    scope.newIdent("v"),
+	}
+	return mtyp.template(kafkaTemplate).Execute(w, data)
+}
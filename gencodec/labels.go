@@ -0,0 +1,79 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"fmt"
+	"go/types"
+	"reflect"
+
+	. "github.com/garslo/gogen"
+)
+
+// genMetricLabels generates the MetricLabels method, which returns the fields
+// with a "label" struct tag as a map of strings.
+func genMetricLabels(mtyp *marshalerType) (Function, error) {
+	var (
+		m      = newMarshalMethod(mtyp, false)
+		recv   = m.receiver()
+		labels = Name(m.scope.newIdent("labels"))
+		fields = mtyp.taggedFields("label")
+	)
+	fn := Function{
+		Receiver:    recv,
+		Name:        "MetricLabels",
+		ReturnTypes: Types{{TypeName: "map[string]string"}},
+		Body: []Statement{
+			DeclareAndAssign{Lhs: labels, Rhs: CallFunction{
+				Func:   Name("make"),
+				Params: []Expression{Name("map[string]string"), Int(len(fields))},
+			}},
+		},
+	}
+	for _, f := range fields {
+		key := reflect.StructTag(f.tag).Get("label")
+		if key == "" {
+			key, _ = f.key("json")
+		}
+		label := Index{Value: labels, Index: stringLit{key}}
+		str, cond, err := m.fieldString(f, Name(recv.Name))
+		if err != nil {
+			return fn, err
+		}
+		// Labels of absent values are empty, so that all labels are always set.
+		if cond == nil {
+			fn.Body = append(fn.Body, Assign{Lhs: label, Rhs: str})
+			continue
+		}
+		fn.Body = append(fn.Body,
+			Assign{Lhs: label, Rhs: stringLit{""}},
+			If{Condition: cond, Body: []Statement{Assign{Lhs: label, Rhs: str}}},
+		)
+	}
+	fn.Body = append(fn.Body, Return{Values: []Expression{labels}})
+	return fn, nil
+}
+
+// fieldString creates an expression which converts field f of v to a string. If the
+// field can be absent, cond is the condition under which it is present.
+func (m *marshalMethod) fieldString(f *marshalerField, v Expression) (str, cond Expression, err error) {
+	value, typ := f.access(v), f.origTyp
+	switch {
+	case f.wellKnown != nil && f.wellKnown.ptr:
+		cond = NotEqual{Lhs: value, Rhs: NIL}
+	case f.wellKnown != nil && f.wellKnown.present != nil:
+		cond = f.wellKnown.present(value)
+	case f.wellKnown == nil && isPointer(typ):
+		cond = NotEqual{Lhs: value, Rhs: NIL}
+		if !isStringer(typ) {
+			value, typ = Star{Value: value}, typ.(*types.Pointer).Elem()
+		}
+	}
+	str, err = m.stringValue(f, value, typ)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%v: field %s: %v", m.mtyp.fs.Position(f.pos), f.name, err)
+	}
+	return str, cond, nil
+}
@@ -0,0 +1,123 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"text/template"
+)
+
+const lambdaEventsPath = "github.com/aws/aws-lambda-go/events"
+
+var lambdaTemplate = template.Must(template.New("lambda").Parse(`
+// DecodeAPIGatewayRequest decodes {{.Recv}} from the JSON body of an API Gateway proxy
+// request. Unlike UnmarshalJSON, it rejects keys which don't belong to {{.Type}},
+// reporting the first of them in sorted order.
+// If decoding fails, it returns the error and a 400 response describing it.
+func ({{.Recv}} *{{.Type}}) DecodeAPIGatewayRequest({{.Req}} {{.Events}}.APIGatewayProxyRequest) ({{.Events}}.APIGatewayProxyResponse, error) {
+	{{.Body}} := []byte({{.Req}}.Body)
+	var {{.Err}} error
+	if {{.Req}}.IsBase64Encoded {
+		{{.Body}}, {{.Err}} = {{.Base64}}.StdEncoding.DecodeString({{.Req}}.Body)
+	}
+	if {{.Err}} == nil {
+		var {{.Fields}} map[string]{{.JSON}}.RawMessage
+		{{.Err}} = {{.JSON}}.Unmarshal({{.Body}}, &{{.Fields}})
+		var {{.Unknown}} []string
+		for {{.Key}} := range {{.Fields}} {
+			{{- if .Keys}}
+			switch {{.Key}} {
+			case {{range $i, $k := .Keys}}{{if $i}}, {{end}}{{printf "%q" $k}}{{end}}:
+				continue
+			}
+			{{- end}}
+			{{.Unknown}} = append({{.Unknown}}, {{.Key}})
+		}
+		if len({{.Unknown}}) > 0 {
+			{{.Sort}}.Strings({{.Unknown}})
+			{{.Err}} = {{.Fmt}}.Errorf("unknown field '%s' for {{.Type}}", {{.Unknown}}[0])
+		}
+	}
+	if {{.Err}} == nil {
+		{{.Err}} = {{.Recv}}.UnmarshalJSON({{.Body}})
+	}
+	if {{.Err}} != nil {
+		{{.Msg}}, _ := {{.JSON}}.Marshal(map[string]string{"error": {{.Err}}.Error()})
+		{{.Resp}} := {{.Events}}.APIGatewayProxyResponse{
+			StatusCode: 400,
+			Headers:    map[string]string{"Content-Type": "application/json"},
+			Body:       string({{.Msg}}),
+		}
+		return {{.Resp}}, {{.Err}}
+	}
+	return {{.Events}}.APIGatewayProxyResponse{}, nil
+}
+
+// APIGatewayResponse creates an API Gateway proxy response with the given status
+// code and the JSON encoding of {{.Recv}} as the body.
+func ({{.Recv}} {{.Type}}) APIGatewayResponse({{.Status}} int) ({{.Events}}.APIGatewayProxyResponse, error) {
+	{{.Body}}, {{.Err}} := {{.Recv}}.MarshalJSON()
+	if {{.Err}} != nil {
+		return {{.Events}}.APIGatewayProxyResponse{StatusCode: 500}, {{.Err}}
+	}
+	{{.Resp}} := {{.Events}}.APIGatewayProxyResponse{
+		StatusCode: {{.Status}},
+		Headers:    map[string]string{"Content-Type": "application/json"},
+		Body:       string({{.Body}}),
+	}
+	return {{.Resp}}, nil
+}
+`))
+
+type lambdaData struct {
+	Type string
+	Keys []string
+
+	// package and variable names
+	Base64, Events, Fmt, JSON, Sort                               string
+	Recv, Req, Body, Err, Fields, Key, Unknown, Msg, Resp, Status string
+}
+
+// genLambda writes methods which decode mtyp from API Gateway proxy requests
+// and encode it as the body of responses.
+func genLambda(w io.Writer, mtyp *marshalerType) error {
+	if !mtyp.hasFormat("json") {
+		return errors.New("API Gateway methods require the json format")
+	}
+	if mtyp.orig.TypeParams().Len() > 0 {
+		return fmt.Errorf("API Gateway methods are not supported for generic type %s", mtyp.name)
+	}
+	var (
+		scope = newFuncScope(mtyp.scope)
+		recv  = newMarshalMethod(mtyp, false).receiver().Name
+	)
+	scope.used[recv] = true
+	data := lambdaData{
+		Type:    mtyp.name,
+		Base64:  mtyp.scope.packageName("encoding/base64"),
+		Events:  mtyp.scope.packageName(lambdaEventsPath),
+		Fmt:     mtyp.scope.packageName("fmt"),
+		JSON:    mtyp.scope.packageName("encoding/json"),
+		Sort:    mtyp.scope.packageName("sort"),
+		Recv:    recv,
+		Req:     scope.newIdent("req"),
+		Body:    scope.newIdent("body"),
+		Err:     scope.newIdent("err"),
+		Fields:  scope.newIdent("fields"),
+		Key:     scope.newIdent("key"),
+		Unknown: scope.newIdent("unknown"),
+		Msg:     scope.newIdent("msg"),
+		Resp:    scope.newIdent("resp"),
+		Status:  scope.newIdent("status"),
+	}
+	for _, f := range mtyp.Fields {
+		if key, ok := f.key("json"); ok {
+			data.Keys = append(data.Keys, key)
+		}
+	}
+	return mtyp.template(lambdaTemplate).Execute(w, data)
+}
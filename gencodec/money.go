@@ -0,0 +1,256 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+
+	. "github.com/garslo/gogen"
+)
+
+// moneyField describes an amount field that is marshaled together with
+// its currency field.
+type moneyField struct {
+	currency *marshalerField
+	object   bool // marshal as {"amount": ..., "currency": ...} instead of "12.50 USD"
+}
+
+// loadMoneyFields combines amount fields with their currency fields. The
+// currency field is removed from the intermediate type because its value
+// is marshaled as part of the amount.
+func (mtyp *marshalerType) loadMoneyFields() error {
+	var currencies []*marshalerField
+	for _, f := range mtyp.Fields {
+		name, ok := f.option("currency")
+		if !ok {
+			continue
+		}
+		pos := mtyp.fs.Position(f.pos)
+		cur := mtyp.fieldByName(name)
+		switch {
+		case cur == nil || cur == f:
+			return fmt.Errorf("%v: no currency field %s in %s", pos, name, mtyp.name)
+		case !isString(cur.origTyp):
+			return fmt.Errorf("%v: currency field %s must have string type", pos, name)
+		}
+		f.money = &moneyField{currency: cur, object: f.hasOption("currencyobject")}
+		if !f.money.object && f.decimalAmount() == nil && !isNumber(f.origTyp) {
+			return fmt.Errorf("%v: amount field %s must have numeric or decimal type", pos, f.name)
+		}
+		if f.money.object {
+			f.typ = mtyp.moneyObjectType(f)
+		} else {
+			f.typ = types.Typ[types.String]
+			for _, path := range []string{"fmt", "strconv", "strings"} {
+				mtyp.scope.addImport(path)
+			}
+		}
+		currencies = append(currencies, cur)
+	}
+	for _, cur := range currencies {
+		mtyp.removeField(cur)
+	}
+	return nil
+}
+
+// decimalAmount returns the well-known decimal type of an amount field.
+func (f *marshalerField) decimalAmount() *wellKnownField {
+	if f.wellKnown == nil || f.wellKnown.ptr || f.wellKnown.validate == nil {
+		return nil
+	}
+	return f.wellKnown
+}
+
+// moneyObjectType creates the nested object type of an amount field.
+func (mtyp *marshalerType) moneyObjectType(f *marshalerField) types.Type {
+	var tags []string
+	for _, name := range []string{"amount", "currency"} {
+		var tag string
+		for i, format := range mtyp.formats {
+			if i > 0 {
+				tag += " "
+			}
+			tag += fmt.Sprintf("%s:%q", format, name)
+		}
+		tags = append(tags, tag)
+	}
+	fields := []*types.Var{
+		types.NewField(token.NoPos, nil, "Amount", f.origTyp, false),
+		types.NewField(token.NoPos, nil, "Currency", f.money.currency.origTyp, false),
+	}
+	return types.NewStruct(fields, tags)
+}
+
+func (mtyp *marshalerType) removeField(f *marshalerField) {
+	for i := range mtyp.Fields {
+		if mtyp.Fields[i] == f {
+			mtyp.Fields = append(mtyp.Fields[:i], mtyp.Fields[i+1:]...)
+			return
+		}
+	}
+}
+
+// marshalMoney generates the conversion of an amount field and its currency.
+func (m *marshalMethod) marshalMoney(f *marshalerField, from, to Expression, format string) []Statement {
+	amount := f.access(from)
+	currency := f.money.currency.access(from)
+	if f.money.object {
+		return []Statement{
+			Assign{Lhs: Dotted{Receiver: to, Name: "Amount"}, Rhs: amount},
+			Assign{Lhs: Dotted{Receiver: to, Name: "Currency"}, Rhs: currency},
+		}
+	}
+	var (
+		s   []Statement
+		str Expression
+	)
+	if wk := f.decimalAmount(); wk != nil {
+		s = m.checkLimits(wk, amount, m.fieldDesc(f, format), format)
+		str = wk.encode(wk, amount)
+	} else {
+		str = m.formatNumber(amount, f.origTyp)
+	}
+	str = binaryExpr{Op: token.ADD, X: str, Y: stringLit{" "}}
+	str = binaryExpr{Op: token.ADD, X: str, Y: convertTo(currency, f.money.currency.origTyp, types.String)}
+	return append(s, Assign{Lhs: to, Rhs: str})
+}
+
+// unmarshalMoney generates the conversion of a decoded amount field, assigning
+// the amount to amountTo and the currency to currencyTo.
+func (m *marshalMethod) unmarshalMoney(f *marshalerField, from, amountTo, currencyTo Expression, format string) []Statement {
+	curType := types.TypeString(f.money.currency.origTyp, m.mtyp.scope.qualify)
+	if f.money.object {
+		return []Statement{
+			Assign{Lhs: amountTo, Rhs: Dotted{Receiver: from, Name: "Amount"}},
+			Assign{Lhs: currencyTo, Rhs: Dotted{Receiver: from, Name: "Currency"}},
+		}
+	}
+
+	var (
+		amount   = Name(m.scope.newIdent("amount"))
+		currency = Name(m.scope.newIdent("currency"))
+		ok       = Name(m.scope.newIdent("ok"))
+		strings  = Name(m.scope.parent.packageName("strings"))
+		fmtpkg   = Name(m.scope.parent.packageName("fmt"))
+		errMsg   = fmt.Sprintf("invalid amount %%q for field '%s' of %s", f.encodedName(format), m.mtyp.name)
+	)
+	s := []Statement{
+		declareMulti{Lhs: []Expression{amount, currency, ok}, Rhs: CallFunction{
+			Func:   Dotted{Receiver: strings, Name: "Cut"},
+			Params: []Expression{Star{Value: from}, stringLit{" "}},
+		}},
+		If{
+			Condition: Not{Value: ok},
+			Body: []Statement{Return{Values: []Expression{CallFunction{
+				Func:   Dotted{Receiver: fmtpkg, Name: "Errorf"},
+				Params: []Expression{stringLit{errMsg}, Star{Value: from}},
+			}}}},
+		},
+	}
+	if wk := f.decimalAmount(); wk != nil {
+		s = append(s, wk.decode(m, wk, amount, amountTo, m.fieldDesc(f, format))...)
+		s = append(s, m.checkLimits(wk, amountTo, m.fieldDesc(f, format), format)...)
+	} else {
+		s = append(s, m.parseNumber(amount, amountTo, f.origTyp)...)
+	}
+	var conv Expression = currency
+	if !types.Identical(f.money.currency.origTyp, types.Typ[types.String]) {
+		conv = CallFunction{Func: Name(curType), Params: []Expression{currency}}
+	}
+	return append(s, Assign{Lhs: currencyTo, Rhs: conv})
+}
+
+// formatNumber creates an expression that formats the number v using strconv.
+func (m *marshalMethod) formatNumber(v Expression, typ types.Type) Expression {
+	strconv := Name(m.scope.parent.packageName("strconv"))
+	basic := typ.Underlying().(*types.Basic)
+	switch {
+	case basic.Info()&types.IsFloat != 0:
+		return CallFunction{Func: Dotted{Receiver: strconv, Name: "FormatFloat"}, Params: []Expression{
+			convertTo(v, typ, types.Float64), Name("'f'"), Int(-1), Int(64),
+		}}
+	case basic.Info()&types.IsUnsigned != 0:
+		return CallFunction{Func: Dotted{Receiver: strconv, Name: "FormatUint"}, Params: []Expression{
+			convertTo(v, typ, types.Uint64), Int(10),
+		}}
+	default:
+		return CallFunction{Func: Dotted{Receiver: strconv, Name: "FormatInt"}, Params: []Expression{
+			convertTo(v, typ, types.Int64), Int(10),
+		}}
+	}
+}
+
+// convertTo converts v of type typ to the given basic type.
+// No conversion is emitted if the types are identical.
+func convertTo(v Expression, typ types.Type, kind types.BasicKind) Expression {
+	if types.Identical(typ, types.Typ[kind]) {
+		return v
+	}
+	return CallFunction{Func: Name(types.Typ[kind].Name()), Params: []Expression{v}}
+}
+
+// parseNumber generates code that parses the number in string s and assigns it to out.
+func (m *marshalMethod) parseNumber(s, out Expression, typ types.Type) []Statement {
+	var (
+		strconv = Name(m.scope.parent.packageName("strconv"))
+		basic   = typ.Underlying().(*types.Basic)
+		size    = Int(bitSize(basic))
+		n       = Name(m.scope.newIdent("n"))
+		parse   CallFunction
+	)
+	switch {
+	case basic.Info()&types.IsFloat != 0:
+		parse = CallFunction{Func: Dotted{Receiver: strconv, Name: "ParseFloat"}, Params: []Expression{s, size}}
+	case basic.Info()&types.IsUnsigned != 0:
+		parse = CallFunction{Func: Dotted{Receiver: strconv, Name: "ParseUint"}, Params: []Expression{s, Int(10), size}}
+	default:
+		parse = CallFunction{Func: Dotted{Receiver: strconv, Name: "ParseInt"}, Params: []Expression{s, Int(10), size}}
+	}
+	var conv Expression = n
+	if !types.Identical(typ, parsedType(basic)) {
+		conv = CallFunction{Func: Name(types.TypeString(typ, m.mtyp.scope.qualify)), Params: []Expression{n}}
+	}
+	return append(m.parseChecked(n, parse), Assign{Lhs: out, Rhs: conv})
+}
+
+// parsedType returns the result type of the strconv function parsing typ.
+func parsedType(typ *types.Basic) types.Type {
+	switch {
+	case typ.Info()&types.IsFloat != 0:
+		return types.Typ[types.Float64]
+	case typ.Info()&types.IsUnsigned != 0:
+		return types.Typ[types.Uint64]
+	default:
+		return types.Typ[types.Int64]
+	}
+}
+
+// bitSize returns the bitSize argument of strconv parsing functions for typ.
+func bitSize(typ *types.Basic) int {
+	switch typ.Kind() {
+	case types.Int8, types.Uint8:
+		return 8
+	case types.Int16, types.Uint16:
+		return 16
+	case types.Int32, types.Uint32, types.Float32:
+		return 32
+	case types.Int64, types.Uint64, types.Float64:
+		return 64
+	default:
+		return 0 // int, uint
+	}
+}
+
+func isString(typ types.Type) bool {
+	basic, ok := typ.Underlying().(*types.Basic)
+	return ok && basic.Info()&types.IsString != 0
+}
+
+func isNumber(typ types.Type) bool {
+	basic, ok := typ.Underlying().(*types.Basic)
+	return ok && basic.Info()&(types.IsInteger|types.IsFloat) != 0 && basic.Kind() != types.Uintptr
+}
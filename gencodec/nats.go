@@ -0,0 +1,90 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"text/template"
+)
+
+const (
+	natsPath   = "github.com/nats-io/nats.go"
+	natsHeader = "Schema-Fingerprint"
+)
+
+var natsTemplate = template.Must(template.New("nats").Parse(`
+// NATSMsg creates a NATS message with the given subject. The data of the message is
+// the JSON encoding of {{.Recv}}, the {{.Header}} header holds the schema fingerprint
+// of {{.Type}}.
+func ({{.Recv}} {{.Type}}) NATSMsg({{.Subj}} string) (*{{.NATS}}.Msg, error) {
+	{{.Data}}, {{.Err}} := {{.Recv}}.MarshalJSON()
+	if {{.Err}} != nil {
+		return nil, {{.Err}}
+	}
+	{{.Msg}} := {{.NATS}}.NewMsg({{.Subj}})
+	{{.Msg}}.Header.Set({{printf "%q" .Header}}, {{printf "%q" .Fingerprint}})
+	{{.Msg}}.Data = {{.Data}}
+	return {{.Msg}}, nil
+}
+
+// PublishTo publishes {{.Recv}} to the given subject of a JetStream stream.
+func ({{.Recv}} {{.Type}}) PublishTo({{.JS}} {{.NATS}}.JetStreamContext, {{.Subj}} string) (*{{.NATS}}.PubAck, error) {
+	{{.Msg}}, {{.Err}} := {{.Recv}}.NATSMsg({{.Subj}})
+	if {{.Err}} != nil {
+		return nil, {{.Err}}
+	}
+	return {{.JS}}.PublishMsg({{.Msg}})
+}
+
+// DecodeNATSMsg decodes {{.Recv}} from the data of a NATS message. Messages whose
+// {{.Header}} header doesn't match the schema of {{.Type}} are rejected.
+func ({{.Recv}} *{{.Type}}) DecodeNATSMsg({{.Msg}} *{{.NATS}}.Msg) error {
+	if {{.FP}} := {{.Msg}}.Header.Get({{printf "%q" .Header}}); {{.FP}} != "" && {{.FP}} != {{printf "%q" .Fingerprint}} {
+		return {{.Fmt}}.Errorf("schema fingerprint %s of message doesn't match {{.Type}}", {{.FP}})
+	}
+	return {{.Recv}}.UnmarshalJSON({{.Msg}}.Data)
+}
+`))
+
+type natsData struct {
+	Type, Header, Fingerprint string
+
+	// package and variable names
+	NATS, Fmt                          string
+	Recv, Subj, Data, Err, Msg, JS, FP string
+}
+
+// genNATS writes methods which send and receive mtyp as NATS messages.
+// The messages carry the schema fingerprint of the type in a header.
+func genNATS(w io.Writer, mtyp *marshalerType) error {
+	if !mtyp.hasFormat("json") {
+		return errors.New("NATS messages require the json format")
+	}
+	if mtyp.orig.TypeParams().Len() > 0 {
+		return fmt.Errorf("generic type %s can't be a NATS message", mtyp.name)
+	}
+	var (
+		scope = newFuncScope(mtyp.scope)
+		recv  = newMarshalMethod(mtyp, false).receiver().Name
+	)
+	scope.used[recv] = true
+	data := natsData{
+		Type:        mtyp.name,
+		Header:      natsHeader,
+		Fingerprint: mtyp.fingerprint(),
+		NATS:        mtyp.scope.packageName(natsPath),
+		Fmt:         mtyp.scope.packageName("fmt"),
+		Recv:        recv,
+		Subj:        scope.newIdent("subj"),
+		Data:        scope.newIdent("data"),
+		Err:         scope.newIdent("err"),
+		Msg:         scope.newIdent("msg"),
+		JS:          scope.newIdent("js"),
+		FP:          scope.newIdent("fp"),
+	}
+	return mtyp.template(natsTemplate).Execute(w, data)
+}
@@ -0,0 +1,65 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"gopkg.in/yaml.v3"
+)
+
+// genOpenAPISchemas creates the components section of an OpenAPI 3.1 document
+// holding the schemas of the marshaling types. OpenAPI 3.1 schemas are JSON
+// Schemas, so they are the same as the definitions of the -jsonschema output.
+func genOpenAPISchemas(mtyps []*marshalerType) ([]byte, error) {
+	c := newJSONSchemaConverter(mtyps, "#/components/schemas/")
+	defs, err := c.definitions(mtyps)
+	if err != nil {
+		return nil, err
+	}
+	// The schemas are converted to YAML through the JSON encoding, which
+	// keeps the order of properties.
+	enc, err := json.Marshal(defs)
+	if err != nil {
+		return nil, err
+	}
+	var schemas yaml.Node
+	if err := yaml.Unmarshal(enc, &schemas); err != nil {
+		return nil, err
+	}
+	resetYAMLStyle(&schemas)
+	doc := &yaml.Node{
+		Kind:        yaml.DocumentNode,
+		HeadComment: "Code generated by github.com/fjl/gencodec. DO NOT EDIT.",
+		Content: []*yaml.Node{yamlMapping(
+			yamlString("components"), yamlMapping(yamlString("schemas"), schemas.Content[0]),
+		)},
+	}
+	w := new(bytes.Buffer)
+	e := yaml.NewEncoder(w)
+	e.SetIndent(2)
+	if err := e.Encode(doc); err != nil {
+		return nil, err
+	}
+	return w.Bytes(), e.Close()
+}
+
+// resetYAMLStyle makes the encoder choose the style of nodes decoded from JSON,
+// which would otherwise be written in flow style with quoted strings.
+func resetYAMLStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, c := range n.Content {
+		resetYAMLStyle(c)
+	}
+}
+
+func yamlMapping(content ...*yaml.Node) *yaml.Node {
+	return &yaml.Node{Kind: yaml.MappingNode, Content: content}
+}
+
+func yamlString(s string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: s}
+}
@@ -0,0 +1,147 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// TargetOrder returns the indices of targets in the order of generation. A target
+// is generated after the targets of the packages it depends on, which are the
+// packages imported by its package, directly or indirectly, and the packages of
+// its field override types. This way, the methods generated for a package are
+// visible when generating the packages using it. Targets which don't depend on each
+// other keep their order. An error is returned if the dependencies form a cycle,
+// which is possible through field override types.
+func TargetOrder(targets []Target) ([]int, error) {
+	var (
+		paths = make([]string, len(targets))
+		deps  = make([]map[string]bool, len(targets))
+		cache = make(map[string]*packages.Package)
+	)
+	for i, t := range targets {
+		pkg, err := loadTargetPackage(cache, &t.Config)
+		if err != nil {
+			return nil, err
+		}
+		paths[i] = pkg.PkgPath
+		deps[i] = make(map[string]bool)
+		packages.Visit([]*packages.Package{pkg}, func(p *packages.Package) bool {
+			deps[i][p.PkgPath] = true
+			return true
+		}, nil)
+		for _, o := range strings.Split(t.FieldOverride, ",") {
+			if path, ok := overridePackage(strings.TrimSpace(o)); ok {
+				deps[i][path] = true
+			}
+		}
+	}
+	// dependsOn reports whether target i must be generated after target j.
+	dependsOn := func(i, j int) bool {
+		return paths[i] != paths[j] && deps[i][paths[j]]
+	}
+
+	// Pick the first target whose dependencies are done until all targets are.
+	var (
+		order = make([]int, 0, len(targets))
+		done  = make([]bool, len(targets))
+	)
+	for len(order) < len(targets) {
+		next := -1
+		for i := range targets {
+			if !done[i] && !hasPending(i, done, dependsOn) {
+				next = i
+				break
+			}
+		}
+		if next == -1 {
+			return nil, fmt.Errorf("dependency cycle between targets: %s", strings.Join(findCycle(paths, done, dependsOn), " -> "))
+		}
+		done[next] = true
+		order = append(order, next)
+	}
+	return order, nil
+}
+
+// hasPending reports whether target i depends on a target which isn't done.
+func hasPending(i int, done []bool, dependsOn func(i, j int) bool) bool {
+	for j := range done {
+		if !done[j] && dependsOn(i, j) {
+			return true
+		}
+	}
+	return false
+}
+
+// findCycle returns the package paths of a cycle among the pending targets.
+// Every pending target has a pending dependency, so following the first one
+// eventually returns to a target seen before.
+func findCycle(paths []string, done []bool, dependsOn func(i, j int) bool) []string {
+	var (
+		seen  = make(map[int]int)
+		chain []int
+		i     = 0
+	)
+	for done[i] {
+		i++
+	}
+	for {
+		if start, ok := seen[i]; ok {
+			cycle := make([]string, 0, len(chain)-start+1)
+			for _, j := range chain[start:] {
+				cycle = append(cycle, paths[j])
+			}
+			return append(cycle, paths[i])
+		}
+		seen[i] = len(chain)
+		chain = append(chain, i)
+		for j := range done {
+			if !done[j] && dependsOn(i, j) {
+				i = j
+				break
+			}
+		}
+	}
+}
+
+// loadTargetPackage loads the package of a target with its dependencies.
+// Packages are cached by directory.
+func loadTargetPackage(cache map[string]*packages.Package, cfg *Config) (*packages.Package, error) {
+	dir, err := filepath.Abs(cfg.Dir)
+	if err != nil {
+		return nil, err
+	}
+	key := dir + " " + strings.Join(cfg.BuildTags, ",")
+	if pkg := cache[key]; pkg != nil {
+		return pkg, nil
+	}
+	pcfg := &packages.Config{Mode: packages.NeedName | packages.NeedFiles | packages.NeedImports | packages.NeedDeps | packages.NeedModule, Dir: dir}
+	if len(cfg.BuildTags) > 0 {
+		pcfg.BuildFlags = []string{"-tags=" + strings.Join(cfg.BuildTags, ",")}
+	}
+	ps, err := packages.Load(pcfg, ".")
+	if err != nil {
+		return nil, err
+	}
+	if len(ps) == 0 || ps[0].PkgPath == "" {
+		return nil, fmt.Errorf("can't find go package in %s", cfg.Dir)
+	}
+	cache[key] = ps[0]
+	return ps[0], nil
+}
+
+// overridePackage returns the qualifier of a field override type, which is the
+// path or the name of its package.
+func overridePackage(override string) (string, bool) {
+	dot := strings.LastIndexByte(override, '.')
+	if dot == -1 {
+		return "", false
+	}
+	return override[:dot], true
+}
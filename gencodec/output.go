@@ -0,0 +1,93 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// generatedMarker is part of the header of the files generated by gencodec.
+var generatedMarker = []byte("Code generated by github.com/fjl/gencodec.")
+
+// WriteFile writes a generated file atomically. The content is written to a temporary
+// file in the same directory, which then replaces the file, so readers never see a
+// partially written file. The permissions of an existing file are kept, new files are
+// created with mode 0644. Missing directories are created.
+//
+// If protect is set, an existing file which lacks the header of generated files is
+// not overwritten, to prevent clobbering hand-written files of the same name. All
+// outputs of gencodec carry the marker of the header, in a "$comment" key in JSON
+// files and in a comment before the value in the files of the fuzz seed corpus.
+func WriteFile(name string, content []byte, protect bool) error {
+	mode := fs.FileMode(0644)
+	if real, err := filepath.EvalSymlinks(name); err == nil {
+		name = real // replace the target of a symlink, not the link
+	}
+	info, err := os.Stat(name)
+	switch {
+	case err == nil && !info.Mode().IsRegular():
+		return fmt.Errorf("can't write %s: not a regular file", name)
+	case err == nil:
+		mode = info.Mode().Perm()
+		if protect {
+			old, err := os.ReadFile(name)
+			if err != nil {
+				return err
+			}
+			if !hasGeneratedHeader(old) {
+				return fmt.Errorf("refusing to overwrite %s, which was not generated by gencodec", name)
+			}
+		}
+	case !errors.Is(err, fs.ErrNotExist):
+		return err
+	}
+
+	dir := filepath.Dir(name)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(name)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(content)
+	if err == nil {
+		err = tmp.Chmod(mode)
+	}
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), name)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+	return err
+}
+
+// hasGeneratedHeader reports whether the start of a file holds the marker of files
+// generated by gencodec or a comment marking a Go file as generated.
+func hasGeneratedHeader(content []byte) bool {
+	const headerSize = 1024
+	if len(content) > headerSize {
+		content = content[:headerSize]
+	}
+	if bytes.Contains(content, generatedMarker) {
+		return true
+	}
+	for _, line := range strings.Split(string(content), "\n") {
+		if generatedLine.MatchString(strings.TrimSuffix(line, "\r")) {
+			return true
+		}
+	}
+	return false
+}
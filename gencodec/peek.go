@@ -0,0 +1,183 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"fmt"
+	"io"
+	"text/template"
+)
+
+// peekTemplate generates a function which finds the discriminator key of a JSON
+// object. The scanner only tracks strings and the nesting of objects and arrays.
+// The value of the key is converted without allocations unless it has escapes.
+var peekTemplate = template.Must(template.New("peek").Parse(`
+// Peek{{.Type}}Type returns the "{{.Key}}" key of the JSON object in {{.Data}} without decoding
+// the object. The values of other keys are skipped by a minimal scanner, which doesn't fully
+// validate them. Keys must match exactly.
+func Peek{{.Type}}Type({{.Data}} []byte) (string, error) {
+	var {{.I}} int
+	{{.Space}} := func() {
+		for ; {{.I}} < len({{.Data}}); {{.I}}++ {
+			switch {{.Data}}[{{.I}}] {
+			case ' ', '\t', '\n', '\r':
+			default:
+				return
+			}
+		}
+	}
+	// {{.Str}} moves past the string at {{.I}} and reports whether it has escapes.
+	{{.Str}} := func() (escaped, ok bool) {
+		for {{.I}}++; {{.I}} < len({{.Data}}); {{.I}}++ {
+			switch {{.Data}}[{{.I}}] {
+			case '\\':
+				escaped = true
+				{{.I}}++
+			case '"':
+				{{.I}}++
+				return escaped, true
+			}
+		}
+		return false, false
+	}
+	// {{.Value}} moves past the value at {{.I}}.
+	{{.Value}} := func() bool {
+		{{.Depth}} := 0
+		for {{.I}} < len({{.Data}}) {
+			switch {{.Data}}[{{.I}}] {
+			case '"':
+				if _, {{.Ok}} := {{.Str}}(); !{{.Ok}} || {{.Depth}} == 0 {
+					return {{.Ok}}
+				}
+				continue
+			case '{', '[':
+				{{.Depth}}++
+			case '}', ']':
+				if {{.Depth}} == 0 {
+					return true
+				}
+				if {{.Depth}}--; {{.Depth}} == 0 {
+					{{.I}}++
+					return true
+				}
+			case ',', ' ', '\t', '\n', '\r':
+				if {{.Depth}} == 0 {
+					return true
+				}
+			}
+			{{.I}}++
+		}
+		return {{.Depth}} == 0
+	}
+	{{.Space}}()
+	if {{.I}} == len({{.Data}}) || {{.Data}}[{{.I}}] != '{' {
+		return "", {{.Errors}}.New("expected JSON object for {{.Type}}")
+	}
+	for {{.I}}++; ; {{.I}}++ {
+		{{.Space}}()
+		if {{.I}} < len({{.Data}}) && {{.Data}}[{{.I}}] == '}' {
+			break
+		}
+		{{.Start}} := {{.I}}
+		if {{.I}} == len({{.Data}}) || {{.Data}}[{{.I}}] != '"' {
+			return "", {{.Errors}}.New("invalid JSON object for {{.Type}}")
+		}
+		if _, {{.Ok}} := {{.Str}}(); !{{.Ok}} {
+			return "", {{.Errors}}.New("invalid JSON object for {{.Type}}")
+		}
+		{{.K}} := {{.Data}}[{{.Start}}+1 : {{.I}}-1]
+		{{.Space}}()
+		if {{.I}} == len({{.Data}}) || {{.Data}}[{{.I}}] != ':' {
+			return "", {{.Errors}}.New("invalid JSON object for {{.Type}}")
+		}
+		{{.I}}++
+		{{.Space}}()
+		if string({{.K}}) == {{printf "%q" .Key}} {
+			if {{.Bytes}}.HasPrefix({{.Data}}[{{.I}}:], []byte("null")) {
+				break
+			}
+			if {{.I}} == len({{.Data}}) || {{.Data}}[{{.I}}] != '"' {
+				return "", {{.Errors}}.New("field '{{.Key}}' of {{.Type}} is not a string")
+			}
+			{{.Start}} = {{.I}}
+			{{.Esc}}, {{.Ok}} := {{.Str}}()
+			if !{{.Ok}} {
+				return "", {{.Errors}}.New("invalid JSON object for {{.Type}}")
+			}
+			if !{{.Esc}} {
+				return string({{.Data}}[{{.Start}}+1 : {{.I}}-1]), nil
+			}
+			var {{.S}} string
+			{{.Err}} := {{.JSON}}.Unmarshal({{.Data}}[{{.Start}}:{{.I}}], &{{.S}})
+			return {{.S}}, {{.Err}}
+		}
+		if !{{.Value}}() {
+			return "", {{.Errors}}.New("invalid JSON object for {{.Type}}")
+		}
+		{{.Space}}()
+		if {{.I}} < len({{.Data}}) && {{.Data}}[{{.I}}] == '}' {
+			break
+		}
+		if {{.I}} == len({{.Data}}) || {{.Data}}[{{.I}}] != ',' {
+			return "", {{.Errors}}.New("invalid JSON object for {{.Type}}")
+		}
+	}
+	return "", {{.Errors}}.New("missing required field '{{.Key}}' for {{.Type}}")
+}
+`))
+
+type peekData struct {
+	Type, Key string
+
+	// package and variable names
+	Bytes, Errors, JSON               string
+	Data, I, Space, Str, Value, Depth string
+	Ok, Start, K, Esc, S, Err         string
+}
+
+// genPeek writes the PeekTType function of mtyp, which returns the value of the
+// field with the gencodec:"discriminator" option.
+func genPeek(w io.Writer, mtyp *marshalerType) error {
+	var disc *marshalerField
+	for _, f := range mtyp.Fields {
+		if f.function == nil && f.hasOption("discriminator") {
+			if disc != nil {
+				return fmt.Errorf("%v: more than one discriminator field in %s", mtyp.fs.Position(f.pos), mtyp.name)
+			}
+			disc = f
+		}
+	}
+	if disc == nil {
+		return fmt.Errorf(`type %s has no discriminator field, mark one with gencodec:"discriminator"`, mtyp.name)
+	}
+	key, ok := disc.key("json")
+	if !ok {
+		return fmt.Errorf("%v: discriminator field %s has no JSON key", mtyp.fs.Position(disc.pos), disc.name)
+	}
+	if !isString(disc.origTyp) {
+		return fmt.Errorf("%v: discriminator field %s must be a string", mtyp.fs.Position(disc.pos), disc.name)
+	}
+	scope := newFuncScope(mtyp.scope)
+	data := peekData{
+		Type:   mtyp.name,
+		Key:    key,
+		Bytes:  mtyp.scope.packageName("bytes"),
+		Errors: mtyp.scope.packageName("errors"),
+		JSON:   mtyp.scope.packageName("encoding/json"),
+		Data:   scope.newIdent("data"),
+		I:      scope.newIdent("i"),
+		Space:  scope.newIdent("skipSpace"),
+		Str:    scope.newIdent("skipString"),
+		Value:  scope.newIdent("skipValue"),
+		Depth:  scope.newIdent("depth"),
+		Ok:     scope.newIdent("ok"),
+		Start:  scope.newIdent("start"),
+		K:      scope.newIdent("key"),
+		Esc:    scope.newIdent("esc"),
+		S:      scope.newIdent("s"),
+		Err:    scope.newIdent("err"),
+	}
+	return mtyp.template(peekTemplate).Execute(w, data)
+}
@@ -0,0 +1,99 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+
+	"golang.org/x/tools/imports"
+)
+
+// pluginTemplate generates the main package of a Go plugin. The symbol tables have
+// unnamed types, so hosts can look them up without importing the plugin.
+var pluginTemplate = template.Must(template.New("plugin").Parse(`// Code generated by github.com/fjl/gencodec. DO NOT EDIT.
+
+// This package is built as a Go plugin with
+//
+//	go build -buildmode=plugin
+//
+// Hosts look up the Encoders and Decoders symbols, which hold the JSON
+// codecs of the types of package {{.Pkg}}, keyed by type name.
+package main
+
+import (
+	"fmt"
+
+	{{.Pkg}} {{printf "%q" .Path}}
+)
+
+// Encoders holds the functions marshaling values of the types as JSON. They take a
+// pointer to the type.
+var Encoders = map[string]func(any) ([]byte, error){
+	{{- range .Types}}
+	{{printf "%q" .}}: func(v any) ([]byte, error) {
+		x, ok := v.(*{{$.Pkg}}.{{.}})
+		if !ok {
+			return nil, fmt.Errorf("can't encode %T as {{.}}", v)
+		}
+		return x.MarshalJSON()
+	},
+	{{- end}}
+}
+
+// Decoders holds the functions unmarshaling values of the types from JSON. They return
+// a pointer to a new value of the type.
+var Decoders = map[string]func([]byte) (any, error){
+	{{- range .Types}}
+	{{printf "%q" .}}: func(input []byte) (any, error) {
+		x := new({{$.Pkg}}.{{.}})
+		if err := x.UnmarshalJSON(input); err != nil {
+			return nil, err
+		}
+		return x, nil
+	},
+	{{- end}}
+}
+
+// main is never called. It makes the package build without -buildmode=plugin.
+func main() {}
+`))
+
+// pluginData is the input of pluginTemplate and cgoTemplate.
+type pluginData struct {
+	Pkg, Path string // name and import path of the package of the types
+	Types     []string
+}
+
+// genPlugin returns the main package of a Go plugin exposing the JSON methods of mtyps.
+func genPlugin(mtyps []*marshalerType) ([]byte, error) {
+	pkg := mtyps[0].orig.Obj().Pkg()
+	data := pluginData{Pkg: pkg.Name(), Path: pkg.Path()}
+	if data.Pkg == "fmt" || data.Pkg == "main" {
+		return nil, fmt.Errorf("package %s can't be imported by a plugin", pkg.Name())
+	}
+	for _, mtyp := range mtyps {
+		switch {
+		case mtyp.orig.TypeParams().Len() > 0:
+			return nil, fmt.Errorf("generic type %s can't be exposed by a plugin", mtyp.name)
+		case !mtyp.orig.Obj().Exported():
+			return nil, fmt.Errorf("unexported type %s can't be exposed by a plugin", mtyp.name)
+		case !mtyp.hasFormat("json"):
+			return nil, fmt.Errorf("plugin codecs of %s require the json format", mtyp.name)
+		}
+		data.Types = append(data.Types, mtyp.name)
+	}
+	w := new(bytes.Buffer)
+	if err := pluginTemplate.Execute(w, data); err != nil {
+		return nil, err
+	}
+	opt := &imports.Options{Comments: true, TabIndent: true, TabWidth: 8, FormatOnly: true}
+	code, err := imports.Process("", w.Bytes(), opt)
+	if err != nil {
+		return nil, fmt.Errorf("can't gofmt generated plugin: %v", err)
+	}
+	return code, nil
+}
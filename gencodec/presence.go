@@ -0,0 +1,109 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"fmt"
+	"go/types"
+	"io"
+
+	. "github.com/garslo/gogen"
+)
+
+// With presence flags, UnmarshalJSON decodes fields of value type into values instead
+// of pointers, which saves an allocation per field. Whether a key is present is
+// recorded by decoding the input a second time into a struct of flags, whose
+// UnmarshalJSON method doesn't allocate. Keys are matched by encoding/json in both
+// passes, so the flags agree with the decoded values.
+
+// presenceTypeName returns the name of the flag type of mtyp.
+func presenceTypeName(mtyp *marshalerType) string {
+	return uncapitalize(mtyp.name) + "Presence"
+}
+
+// genPresence writes the flag type of mtyp if UnmarshalJSON uses presence flags.
+func genPresence(w io.Writer, mtyp *marshalerType) {
+	if !mtyp.presence || !mtyp.hasFormat("json") || mtyp.templates["json"] != nil {
+		return
+	}
+	tracked := false
+	for _, f := range mtyp.Fields {
+		tracked = tracked || (f.function == nil && hasPresenceFlag(f))
+	}
+	if !tracked {
+		return
+	}
+	name := presenceTypeName(mtyp)
+	fmt.Fprintf(w, "// %s records whether a key of %s is present in JSON input.\n", name, mtyp.name)
+	fmt.Fprintf(w, "type %s bool\n\n", name)
+	fmt.Fprintf(w, "// UnmarshalJSON sets the flag unless the value is null.\n")
+	fmt.Fprintf(w, "func (p *%s) UnmarshalJSON(input []byte) error {\n", name)
+	fmt.Fprintf(w, "\t*p = string(input) != \"null\"\n")
+	fmt.Fprintf(w, "\treturn nil\n}\n\n")
+}
+
+// hasPresenceFlag reports whether the presence of f can be tracked by a flag. Fields
+// which are nil-checkable without a pointer don't need a flag, and fields with
+// a special decoding keep the pointer.
+func hasPresenceFlag(f *marshalerField) bool {
+	if f.money != nil || f.dynamic || f.union != nil || f.wellKnown != nil || f.hook != nil {
+		return false
+	}
+	return isPointer(ensureNilCheckable(f.typ)) && !isPointer(f.typ)
+}
+
+// tracksPresence reports whether the presence of f is tracked by a flag in m.
+func (m *marshalMethod) tracksPresence(f *marshalerField) bool {
+	return m.present != "" && hasPresenceFlag(f)
+}
+
+// decodedType returns the type of f in the intermediate type of an unmarshaling method.
+func (m *marshalMethod) decodedType(f *marshalerField) types.Type {
+	if m.tracksPresence(f) {
+		return f.typ
+	}
+	return ensureNilCheckable(f.typ)
+}
+
+// isPresent returns the condition checking whether f is present in the decoded
+// value from.
+func (m *marshalMethod) isPresent(f *marshalerField, from Expression) Expression {
+	if m.tracksPresence(f) {
+		return Dotted{Receiver: Name(m.present), Name: f.name}
+	}
+	return NotEqual{Lhs: from, Rhs: NIL}
+}
+
+// isAbsent is the negation of isPresent.
+func (m *marshalMethod) isAbsent(f *marshalerField, from Expression) Expression {
+	if m.tracksPresence(f) {
+		return Not{Value: Dotted{Receiver: Name(m.present), Name: f.name}}
+	}
+	return Equals{Lhs: from, Rhs: NIL}
+}
+
+// decodePresence decodes the presence flags of input. It returns nil if no field
+// is tracked.
+func (m *marshalMethod) decodePresence(input Var) []Statement {
+	s := Struct{Name: m.scope.newIdent(m.mtyp.orig.Obj().Name() + "Presence")}
+	for _, f := range m.mtyp.Fields {
+		if f.function == nil && f.inFormat("json") && m.tracksPresence(f) {
+			s.Fields = append(s.Fields, Field{Name: f.name, TypeName: presenceTypeName(m.mtyp), Tag: f.tag})
+		}
+	}
+	if len(s.Fields) == 0 {
+		m.present = ""
+		return nil
+	}
+	json := Name(m.scope.parent.packageName("encoding/json"))
+	return []Statement{
+		declStmt{s},
+		Declare{Name: m.present, TypeName: s.Name},
+		errCheck(CallFunction{
+			Func:   Dotted{Receiver: json, Name: "Unmarshal"},
+			Params: []Expression{input, AddressOf{Value: Name(m.present)}},
+		}),
+	}
+}
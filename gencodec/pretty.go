@@ -0,0 +1,153 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"go/types"
+	"io"
+	"strings"
+	"text/template"
+
+	. "github.com/garslo/gogen"
+)
+
+// Values longer than these limits are truncated by PrettyString.
+const (
+	prettyMaxString = 64
+	prettyMaxBytes  = 32
+)
+
+// prettyTemplate generates the PrettyString method. Each field is written on its own
+// line, with the key padded to the length of the longest key.
+var prettyTemplate = template.Must(template.New("pretty").Parse(`
+// PrettyString returns a multi-line rendering of {{.Recv}} for debugging and command line
+// output. Fields are listed by their JSON key. Strings longer than {{.MaxString}} bytes and byte
+// slices longer than {{.MaxBytes}} bytes are truncated. Bytes are shown in hex.
+func ({{.Recv}} {{.RecvType}}) PrettyString() string {
+	var {{.B}} {{.Strings}}.Builder
+	{{.B}}.WriteString("{{.Type}}\n")
+	{{- range .Fields}}
+	{{$.B}}.WriteString({{printf "%q" .Label}})
+	{{- if .Nil}}
+	if {{.Nil}} == nil {
+		{{$.B}}.WriteString("<nil>\n")
+	} else {
+		{{$.V}} := *{{.Nil}}
+	{{- end}}
+	{{- if eq .Kind "bytes"}}
+	if len({{.Value}}) > {{$.MaxBytes}} {
+		{{$.Fmt}}.Fprintf(&{{$.B}}, "0x%x... (%d bytes)\n", {{.Value}}[:{{$.MaxBytes}}], len({{.Value}}))
+	} else {
+		{{$.Fmt}}.Fprintf(&{{$.B}}, "0x%x\n", {{.Value}})
+	}
+	{{- else if eq .Kind "longbytes"}}
+	{{$.Fmt}}.Fprintf(&{{$.B}}, "0x%x... (%d bytes)\n", {{.Value}}[:{{$.MaxBytes}}], len({{.Value}}))
+	{{- else if eq .Kind "shortbytes"}}
+	{{$.Fmt}}.Fprintf(&{{$.B}}, "0x%x\n", {{.Value}})
+	{{- else if or (eq .Kind "string") (eq .Kind "json")}}
+	{{- $verb := "%q"}}{{if eq .Kind "json"}}{{$verb = "%s"}}{{end}}
+	if len({{.Value}}) > {{$.MaxString}} {
+		{{$.Fmt}}.Fprintf(&{{$.B}}, "{{$verb}}... (%d bytes)\n", {{.Value}}[:{{$.MaxString}}], len({{.Value}}))
+	} else {
+		{{$.Fmt}}.Fprintf(&{{$.B}}, "{{$verb}}\n", {{.Value}})
+	}
+	{{- else if eq .Kind "stringer"}}
+	{{$.Fmt}}.Fprintf(&{{$.B}}, "%v\n", {{.Value}})
+	{{- else}}
+	{{$.Fmt}}.Fprintf(&{{$.B}}, "%+v\n", {{.Value}})
+	{{- end}}
+	{{- if .Nil}}
+	}
+	{{- end}}
+	{{- end}}
+	return {{.B}}.String()
+}
+`))
+
+type prettyData struct {
+	Type, RecvType      string
+	Fields              []prettyField
+	MaxString, MaxBytes int
+
+	// package and variable names
+	Fmt, Strings string
+	Recv, B, V   string
+}
+
+type prettyField struct {
+	Label string // key and padding
+	Kind  string // bytes, longbytes, shortbytes, string, json, stringer or value
+	Value string // expression of the value
+	Nil   string // pointer which is checked for nil, if any. Value holds its element.
+}
+
+// genPretty writes the PrettyString method of mtyp.
+func genPretty(w io.Writer, mtyp *marshalerType) error {
+	var (
+		m    = newMarshalMethod(mtyp, false)
+		recv = Name(m.receiver().Name)
+	)
+	data := prettyData{
+		Type:      mtyp.name,
+		RecvType:  mtyp.recvType(),
+		MaxString: prettyMaxString,
+		MaxBytes:  prettyMaxBytes,
+		Fmt:       mtyp.scope.packageName("fmt"),
+		Strings:   mtyp.scope.packageName("strings"),
+		Recv:      recv.Name,
+		B:         m.scope.newIdent("buf"),
+		V:         m.scope.newIdent("v"),
+	}
+	width := 0
+	for _, f := range mtyp.Fields {
+		key, ok := f.key("json")
+		if !ok || f.function != nil {
+			continue
+		}
+		width = max(width, len(key))
+		pf := prettyField{Label: key, Value: printExpr(mtyp, f.access(recv))}
+		typ := f.origTyp
+		if ptr, ok := typ.Underlying().(*types.Pointer); ok && !isStringer(typ) {
+			pf.Nil, pf.Value, typ = pf.Value, data.V, ptr.Elem()
+		}
+		pf.Kind = prettyKind(typ)
+		data.Fields = append(data.Fields, pf)
+	}
+	for i := range data.Fields {
+		f := &data.Fields[i]
+		f.Label = "  " + f.Label + ":" + strings.Repeat(" ", width-len(f.Label)+1)
+	}
+	return mtyp.template(prettyTemplate).Execute(w, data)
+}
+
+// prettyKind returns how PrettyString renders values of typ. Types with a String
+// method are rendered by it. The JSON documents held by json.RawMessage are
+// shown as they are.
+func prettyKind(typ types.Type) string {
+	if types.TypeString(typ, nil) == "encoding/json.RawMessage" {
+		return "json"
+	}
+	if isStringer(typ) {
+		return "stringer"
+	}
+	switch t := typ.Underlying().(type) {
+	case *types.Basic:
+		if t.Info()&types.IsString != 0 {
+			return "string"
+		}
+	case *types.Slice:
+		if isByte(t.Elem()) {
+			return "bytes"
+		}
+	case *types.Array:
+		switch {
+		case isByte(t.Elem()) && t.Len() > prettyMaxBytes:
+			return "longbytes"
+		case isByte(t.Elem()):
+			return "shortbytes"
+		}
+	}
+	return "value"
+}
@@ -0,0 +1,210 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// ProjectFiles are the names of project files, in the order FindProject looks for them.
+var ProjectFiles = []string{"gencodec.yaml", "gencodec.yml", "gencodec.toml"}
+
+// Target is a generation target of a project file.
+type Target struct {
+	Config
+	Out        string // output file of the Go code
+	GraphQL    string // output file of the GraphQL schema, optional
+	CRD        string // output file of the CRD schema, optional
+	JSONSchema string // output file of the JSON Schema, optional
+	OpenAPI    string // output file of the OpenAPI components, optional
+	TypeScript string // output file of the TypeScript declarations, optional
+	Plugin     string // output file of the Go plugin package, optional
+	WASM       string // output file of the WebAssembly bindings, optional
+	Cgo        string // output file of the cgo exports package, optional
+	Tests      string // output file of the generated tests, optional
+	TestDir    string // fixture directory of the tests, relative to the package, optional
+	Fuzz       string // output file of the fuzz targets, optional
+	FuzzCorpus string // output directory of the fuzz seed corpus, optional
+	Fixtures   string // glob pattern of files added to the seed corpus, optional
+	DumpIR     string // output file of the generator's model of the types, optional
+}
+
+// projectFile is the content of a project file. The keys of targets
+// are the names of the command line flags.
+type projectFile struct {
+	Targets []projectTarget `yaml:"targets" toml:"targets"`
+}
+
+type projectTarget struct {
+	Dir           string   `yaml:"dir" toml:"dir"`
+	Type          string   `yaml:"type" toml:"type"`
+	FieldOverride string   `yaml:"field-override" toml:"field-override"`
+	Formats       []string `yaml:"formats" toml:"formats"`
+	Codec         bool     `yaml:"codec" toml:"codec"`
+	Helpers       []string `yaml:"helpers" toml:"helpers"`
+	OmitEmpty     bool     `yaml:"omitempty" toml:"omitempty"`
+	RequireAll    bool     `yaml:"required-by-default" toml:"required-by-default"`
+	Strict        bool     `yaml:"strict" toml:"strict"`
+	Presence      bool     `yaml:"presence-flags" toml:"presence-flags"`
+	KeepUnknown   bool     `yaml:"keep-unknown" toml:"keep-unknown"`
+	AllErrors     bool     `yaml:"all-errors" toml:"all-errors"`
+	TypedErrors   bool     `yaml:"typed-errors" toml:"typed-errors"`
+	Validate      string   `yaml:"validate-method" toml:"validate-method"`
+	YAML          string   `yaml:"yaml" toml:"yaml"`
+	TOML          string   `yaml:"toml" toml:"toml"`
+	Appender      bool     `yaml:"appender" toml:"appender"`
+	InterSuffix   string   `yaml:"intermediate-suffix" toml:"intermediate-suffix"`
+	Unexported    bool     `yaml:"include-unexported" toml:"include-unexported"`
+	Unions        string   `yaml:"union" toml:"union"`
+	TemplateDir   string   `yaml:"template-dir" toml:"template-dir"`
+	Schema        string   `yaml:"schema" toml:"schema"`
+	Header        string   `yaml:"header" toml:"header"`
+	VersionConst  bool     `yaml:"version-const" toml:"version-const"`
+	BuildTags     []string `yaml:"tags" toml:"tags"`
+	Out           string   `yaml:"out" toml:"out"`
+	GraphQL       string   `yaml:"graphql" toml:"graphql"`
+	CRD           string   `yaml:"crd" toml:"crd"`
+	JSONSchema    string   `yaml:"jsonschema" toml:"jsonschema"`
+	OpenAPI       string   `yaml:"openapi" toml:"openapi"`
+	TypeScript    string   `yaml:"typescript" toml:"typescript"`
+	Plugin        string   `yaml:"plugin" toml:"plugin"`
+	WASM          string   `yaml:"wasm" toml:"wasm"`
+	Cgo           string   `yaml:"cgo" toml:"cgo"`
+	Tests         string   `yaml:"gen-tests" toml:"gen-tests"`
+	TestFixtures  string   `yaml:"test-fixtures" toml:"test-fixtures"`
+	Fuzz          string   `yaml:"gen-fuzz" toml:"gen-fuzz"`
+	FuzzCorpus    string   `yaml:"gen-fuzz-corpus" toml:"gen-fuzz-corpus"`
+	Fixtures      string   `yaml:"fuzz-fixtures" toml:"fuzz-fixtures"`
+	DumpIR        string   `yaml:"dump-ir" toml:"dump-ir"`
+}
+
+// LoadProject reads the targets of a project file. The file is parsed as TOML if its
+// name ends in .toml, and as YAML otherwise. The package directories of targets are
+// relative to the directory containing the file. Other paths are relative to the
+// package, as in go:generate comments.
+func LoadProject(file string) ([]Target, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var pf projectFile
+	if filepath.Ext(file) == ".toml" {
+		md, err := toml.Decode(string(data), &pf)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", file, err)
+		}
+		if keys := md.Undecoded(); len(keys) > 0 {
+			return nil, fmt.Errorf("%s: unknown key %q", file, keys[0].String())
+		}
+	} else {
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		if err := dec.Decode(&pf); err != nil {
+			return nil, fmt.Errorf("%s: %v", file, err)
+		}
+	}
+	if len(pf.Targets) == 0 {
+		return nil, fmt.Errorf("%s: no targets", file)
+	}
+
+	root := filepath.Dir(file)
+	targets := make([]Target, len(pf.Targets))
+	for i, t := range pf.Targets {
+		if t.Type == "" {
+			return nil, fmt.Errorf("%s: target %d has no type", file, i)
+		}
+		if t.Out == "" {
+			return nil, fmt.Errorf("%s: target %d (%s) has no output file", file, i, t.Type)
+		}
+		dir := t.Dir
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(root, dir)
+		}
+		targets[i] = Target{
+			Config: Config{
+				Dir:           dir,
+				Type:          t.Type,
+				FieldOverride: t.FieldOverride,
+				Formats:       t.Formats,
+				Codec:         t.Codec,
+				Helpers:       t.Helpers,
+				OmitEmpty:     t.OmitEmpty,
+				RequireAll:    t.RequireAll,
+				Strict:        t.Strict,
+				Presence:      t.Presence,
+				KeepUnknown:   t.KeepUnknown,
+				AllErrors:     t.AllErrors,
+				TypedErrors:   t.TypedErrors,
+				Validate:      t.Validate,
+				YAML:          t.YAML,
+				TOML:          t.TOML,
+				Appender:      t.Appender,
+				InterSuffix:   t.InterSuffix,
+				Unexported:    t.Unexported,
+				Unions:        t.Unions,
+				TemplateDir:   projectPath(dir, t.TemplateDir),
+				Schema:        projectPath(dir, t.Schema),
+				Header:        t.Header,
+				VersionConst:  t.VersionConst,
+				BuildTags:     t.BuildTags,
+			},
+			Out:        projectPath(dir, t.Out),
+			GraphQL:    projectPath(dir, t.GraphQL),
+			CRD:        projectPath(dir, t.CRD),
+			JSONSchema: projectPath(dir, t.JSONSchema),
+			OpenAPI:    projectPath(dir, t.OpenAPI),
+			TypeScript: projectPath(dir, t.TypeScript),
+			Plugin:     projectPath(dir, t.Plugin),
+			WASM:       projectPath(dir, t.WASM),
+			Cgo:        projectPath(dir, t.Cgo),
+			Tests:      projectPath(dir, t.Tests),
+			TestDir:    t.TestFixtures, // read by the tests, which run in the package
+			Fuzz:       projectPath(dir, t.Fuzz),
+			FuzzCorpus: projectPath(dir, t.FuzzCorpus),
+			Fixtures:   projectPath(dir, t.Fixtures),
+			DumpIR:     projectPath(dir, t.DumpIR),
+		}
+	}
+	return targets, nil
+}
+
+// FindProject returns the project file in dir or the closest parent directory
+// holding one. The search stops at the module root, which contains go.mod.
+func FindProject(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for {
+		for _, name := range ProjectFiles {
+			file := filepath.Join(dir, name)
+			if _, err := os.Stat(file); err == nil {
+				return file, nil
+			}
+		}
+		_, err := os.Stat(filepath.Join(dir, "go.mod"))
+		parent := filepath.Dir(dir)
+		if err == nil || parent == dir {
+			break
+		}
+		dir = parent
+	}
+	return "", errors.New("no project file found (want one of " + strings.Join(ProjectFiles, ", ") + ")")
+}
+
+func projectPath(root, path string) string {
+	if path == "" || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(root, path)
+}
@@ -0,0 +1,212 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"fmt"
+	"go/types"
+	"io"
+	"text/template"
+
+	. "github.com/garslo/gogen"
+)
+
+// promptTemplate generates the PromptFill method. Each missing field is read by
+// ask, which repeats the prompt until the input can be parsed.
+var promptTemplate = template.Must(template.New("prompt").Parse(`
+// PromptFill asks for the required fields of {{.Recv}} which have their zero value,
+// writing a prompt for each field to {{.W}} and reading the value from a line of {{.R}}.
+// Fields are named by their JSON key. The prompt is repeated if the input is empty
+// or invalid. PromptFill returns an error if {{.R}} ends before all fields are set.
+func ({{.Recv}} *{{.RecvType}}) PromptFill({{.R}} {{.IO}}.Reader, {{.W}} {{.IO}}.Writer) error {
+	{{.Sc}} := {{.Bufio}}.NewScanner({{.R}})
+	{{.Ask}} := func({{.Key}} string, {{.Parse}} func(string) error) error {
+		for {
+			{{.Fmt}}.Fprintf({{.W}}, "%s: ", {{.Key}})
+			if !{{.Sc}}.Scan() {
+				if {{.Err}} := {{.Sc}}.Err(); {{.Err}} != nil {
+					return {{.Err}}
+				}
+				return {{.Fmt}}.Errorf("missing required field '%s' for {{.Type}}", {{.Key}})
+			}
+			{{.S}} := {{.Strings}}.TrimSpace({{.Sc}}.Text())
+			if {{.S}} == "" {
+				continue
+			}
+			if {{.Err}} := {{.Parse}}({{.S}}); {{.Err}} != nil {
+				{{.Fmt}}.Fprintf({{.W}}, "invalid %s: %v\n", {{.Key}}, {{.Err}})
+				continue
+			}
+			return nil
+		}
+	}
+	{{- range .Fields}}
+	if {{.Missing}} {
+		if {{$.Err}} := {{$.Ask}}({{printf "%q" .Key}}, func({{$.S}} string) error {
+			{{.Parse}}
+			return nil
+		}); {{$.Err}} != nil {
+			return {{$.Err}}
+		}
+	}
+	{{- end}}
+	return nil
+}
+`))
+
+type promptData struct {
+	Type, RecvType string
+	Fields         []promptField
+
+	// package and variable names
+	Bufio, Fmt, IO, Strings string
+	Recv, R, W, Sc, Ask     string
+	Key, Parse, Err, S      string
+}
+
+type promptField struct {
+	Key     string
+	Missing string // condition under which the field is asked for
+	Parse   string // statements assigning the field from the input
+}
+
+// genPrompt writes the PromptFill method of mtyp, which reads the required fields.
+func genPrompt(w io.Writer, mtyp *marshalerType) error {
+	var (
+		m    = newMarshalMethod(mtyp, true)
+		recv = m.receiver().Name
+	)
+	data := promptData{
+		Type:     mtyp.name,
+		RecvType: mtyp.recvType(),
+		Bufio:    mtyp.scope.packageName("bufio"),
+		Fmt:      mtyp.scope.packageName("fmt"),
+		IO:       mtyp.scope.packageName("io"),
+		Strings:  mtyp.scope.packageName("strings"),
+		Recv:     recv,
+		R:        m.scope.newIdent("r"),
+		W:        m.scope.newIdent("w"),
+		Sc:       m.scope.newIdent("sc"),
+		Ask:      m.scope.newIdent("ask"),
+		Key:      m.scope.newIdent("key"),
+		Parse:    m.scope.newIdent("parse"),
+		Err:      m.scope.newIdent("err"),
+		S:        m.scope.newIdent("s"),
+	}
+	for _, f := range mtyp.Fields {
+		if f.function != nil || !f.hasOption("required") {
+			continue
+		}
+		key, ok := f.key("json")
+		if !ok {
+			key = f.name
+		}
+		// Each field is parsed in its own function, which has a fresh scope.
+		fm := newMarshalMethod(mtyp, true)
+		fm.receiver()
+		fm.scope.used[data.S] = true
+		value := f.access(Name(recv))
+		missing, parse, err := promptParse(fm, value, f.origTyp, Name(data.S))
+		if err != nil {
+			return fmt.Errorf("%v: field %s: %v", mtyp.fs.Position(f.pos), f.name, err)
+		}
+		data.Fields = append(data.Fields, promptField{
+			Key:     key,
+			Missing: printExpr(mtyp, missing),
+			Parse:   printStatements(mtyp, parse),
+		})
+	}
+	if len(data.Fields) == 0 {
+		return fmt.Errorf("type %s has no required fields", mtyp.name)
+	}
+	return mtyp.template(promptTemplate).Execute(w, data)
+}
+
+// promptParse returns the condition under which value is missing, and statements
+// which parse the input s and assign it to value. Pointers are missing if they are
+// nil. Other values are missing if they are zero.
+func promptParse(m *marshalMethod, value Expression, typ types.Type, s Expression) (missing Expression, parse []Statement, err error) {
+	if _, ok := typ.(*types.Pointer); ok {
+		missing = Equals{Lhs: value, Rhs: NIL}
+	} else if missing, err = promptZero(m, value, typ); err != nil {
+		return nil, nil, err
+	}
+	parse, err = parseString(m, value, typ, s)
+	return missing, parse, err
+}
+
+// parseString returns statements which parse s and assign it to value. Pointers are
+// set to a new value.
+func parseString(m *marshalMethod, value Expression, typ types.Type, s Expression) ([]Statement, error) {
+	ptr, ok := typ.(*types.Pointer)
+	if !ok {
+		return promptParseValue(m, value, typ, s)
+	}
+	v := Name(m.scope.newIdent("v"))
+	parse, err := promptParseValue(m, v, ptr.Elem(), s)
+	if err != nil {
+		return nil, err
+	}
+	parse = append([]Statement{Declare{Name: v.Name, TypeName: types.TypeString(ptr.Elem(), m.mtyp.scope.qualify)}}, parse...)
+	return append(parse, Assign{Lhs: value, Rhs: AddressOf{Value: v}}), nil
+}
+
+// promptParseValue returns statements which parse s and assign it to value.
+func promptParseValue(m *marshalMethod, value Expression, typ types.Type, s Expression) ([]Statement, error) {
+	switch {
+	case isTextUnmarshaler(typ):
+		input := CallFunction{Func: Name("[]byte"), Params: []Expression{s}}
+		unmarshal := CallFunction{Func: Dotted{Receiver: value, Name: "UnmarshalText"}, Params: []Expression{input}}
+		err := Name("err")
+		return []Statement{
+			DeclareAndAssign{Lhs: err, Rhs: unmarshal},
+			If{Condition: NotEqual{Lhs: err, Rhs: NIL}, Body: []Statement{Return{Values: []Expression{err}}}},
+		}, nil
+	case isString(typ):
+		var conv Expression = s
+		if !types.Identical(typ, types.Typ[types.String]) {
+			conv = CallFunction{Func: Name(types.TypeString(typ, m.mtyp.scope.qualify)), Params: []Expression{s}}
+		}
+		return []Statement{Assign{Lhs: value, Rhs: conv}}, nil
+	case isNumber(typ):
+		return m.parseNumber(s, value, typ), nil
+	case isBool(typ):
+		var (
+			strconv = Name(m.scope.parent.packageName("strconv"))
+			b       = Name(m.scope.newIdent("b"))
+			conv    Expression
+		)
+		conv = b
+		if !types.Identical(typ, types.Typ[types.Bool]) {
+			conv = CallFunction{Func: Name(types.TypeString(typ, m.mtyp.scope.qualify)), Params: []Expression{b}}
+		}
+		parse := CallFunction{Func: Dotted{Receiver: strconv, Name: "ParseBool"}, Params: []Expression{s}}
+		return append(m.parseChecked(b, parse), Assign{Lhs: value, Rhs: conv}), nil
+	}
+	return nil, fmt.Errorf("type %s can't be parsed from a string", typ)
+}
+
+// promptZero returns the condition under which value is zero. It uses the
+// IsZero method if the type has one, and compares with the zero value otherwise.
+func promptZero(m *marshalMethod, value Expression, typ types.Type) (Expression, error) {
+	sig := types.NewSignature(nil, nil, types.NewTuple(types.NewParam(0, nil, "", types.Typ[types.Bool])), false)
+	switch {
+	case isString(typ):
+		return Equals{Lhs: value, Rhs: stringLit{""}}, nil
+	case isNumber(typ):
+		return Equals{Lhs: value, Rhs: Int(0)}, nil
+	case isBool(typ):
+		return Not{Value: value}, nil
+	case hasMethod(typ, "IsZero", sig):
+		return CallFunction{Func: Dotted{Receiver: value, Name: "IsZero"}}, nil
+	case types.Comparable(typ):
+		zero := Name("(" + types.TypeString(typ, m.mtyp.scope.qualify) + "{})")
+		if _, ok := typ.Underlying().(*types.Struct); !ok {
+			zero = Name("*new(" + types.TypeString(typ, m.mtyp.scope.qualify) + ")")
+		}
+		return Equals{Lhs: value, Rhs: zero}, nil
+	}
+	return nil, fmt.Errorf("type %s has no IsZero method and isn't comparable", typ)
+}
@@ -0,0 +1,677 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	. "github.com/garslo/gogen"
+)
+
+// fieldSchema holds the constraints of a field from a schema file. They are
+// checked by the generated unmarshaling methods after the field is decoded.
+type fieldSchema struct {
+	types                []string       // JSON types
+	min, max             constant.Value // nil if unset
+	exclMin, exclMax     bool
+	multipleOf           constant.Value
+	minLength, maxLength int // -1 if unset
+	minItems, maxItems   int // -1 if unset
+	pattern              string
+	enum                 []constant.Value
+}
+
+// schemaAnnotations are the keywords of JSON Schema which don't constrain values.
+var schemaAnnotations = map[string]bool{
+	"$comment": true, "$ref": true, "title": true, "description": true, "default": true,
+	"examples": true, "format": true, "deprecated": true, "readOnly": true, "writeOnly": true,
+}
+
+// readSchema reads a JSON Schema or, if the file name ends in .cue, CUE definitions.
+// It returns the object schemas of the types by name. A JSON Schema defines types in
+// $defs or definitions, and the root schema defines the type if there is only one.
+func readSchema(file string, typeNames []string) (map[string]map[string]interface{}, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	if filepath.Ext(file) == ".cue" {
+		return parseCUE(file, data)
+	}
+	var root map[string]interface{}
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	if err := d.Decode(&root); err != nil {
+		return nil, fmt.Errorf("%s: %v", file, err)
+	}
+	defs := make(map[string]map[string]interface{})
+	for _, key := range []string{"$defs", "definitions"} {
+		m, _ := root[key].(map[string]interface{})
+		for name, def := range m {
+			if s, ok := def.(map[string]interface{}); ok {
+				defs[name] = s
+			}
+		}
+	}
+	if _, ok := root["properties"]; ok && len(typeNames) == 1 && defs[typeNames[0]] == nil {
+		defs[typeNames[0]] = root
+	}
+	return defs, nil
+}
+
+func hasSchema(schemas map[string]map[string]interface{}, typeNames []string) bool {
+	for _, name := range typeNames {
+		if schemas[name] != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// loadSchema attaches the constraints of a type schema to the fields. Required
+// properties make fields required. Constraints which can be decided from the field
+// type are checked now, and properties without a field are reported as errors.
+func (mtyp *marshalerType) loadSchema(schema map[string]interface{}) error {
+	props, _ := schema["properties"].(map[string]interface{})
+	keys := make([]string, 0, len(props))
+	for key := range props {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		f := mtyp.fieldByKey("json", key)
+		if f == nil || f.function != nil {
+			return fmt.Errorf("schema of %s: property %q has no field", mtyp.name, key)
+		}
+		m, ok := props[key].(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("schema of %s: property %q is not an object", mtyp.name, key)
+		}
+		fs, err := parseFieldSchema(m)
+		if err == nil {
+			err = fs.check(f.valueType())
+		}
+		if err == nil && f.defValue != nil {
+			err = fs.checkDefault(f)
+		}
+		if err != nil {
+			return fmt.Errorf("%v: schema of field %s: %v", mtyp.fs.Position(f.pos), f.name, err)
+		}
+		f.schema = fs
+	}
+	required, _ := schema["required"].([]interface{})
+	for _, r := range required {
+		key, _ := r.(string)
+		f := mtyp.fieldByKey("json", key)
+		if f == nil || f.function != nil {
+			return fmt.Errorf("schema of %s: required property %q has no field", mtyp.name, key)
+		}
+		if f.defValue != nil {
+			return fmt.Errorf("%v: field %s is required by the schema but has a default value", mtyp.fs.Position(f.pos), f.name)
+		}
+		if !f.hasOption("required") {
+			opts := reflect.StructTag(f.tag).Get("gencodec")
+			if opts != "" {
+				opts += ","
+			}
+			f.tag = setStructTag(f.tag, "gencodec", opts+"required")
+		}
+	}
+	for _, f := range mtyp.Fields {
+		if f.schema == nil {
+			continue
+		}
+		if f.schema.pattern != "" {
+			mtyp.scope.addImport("regexp")
+		}
+		if f.schema.minLength >= 0 || f.schema.maxLength >= 0 {
+			mtyp.scope.addImport("unicode/utf8")
+		}
+	}
+	return nil
+}
+
+func (mtyp *marshalerType) fieldByKey(format, key string) *marshalerField {
+	for _, f := range mtyp.Fields {
+		if k, ok := f.key(format); ok && k == key {
+			return f
+		}
+	}
+	return nil
+}
+
+// valueType returns the type of the decoded field value, without the pointer
+// added to detect its presence.
+func (mf *marshalerField) valueType() types.Type {
+	if ptr, ok := ensureNilCheckable(mf.typ).(*types.Pointer); ok {
+		return ptr.Elem()
+	}
+	return mf.typ
+}
+
+func parseFieldSchema(m map[string]interface{}) (*fieldSchema, error) {
+	fs := &fieldSchema{minLength: -1, maxLength: -1, minItems: -1, maxItems: -1}
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var err error
+	for _, key := range keys {
+		v := m[key]
+		switch key {
+		case "type":
+			switch t := v.(type) {
+			case string:
+				fs.types = []string{t}
+			case []interface{}:
+				for _, e := range t {
+					s, ok := e.(string)
+					if !ok {
+						return nil, fmt.Errorf("invalid type %v", e)
+					}
+					fs.types = append(fs.types, s)
+				}
+			default:
+				return nil, fmt.Errorf("invalid type %v", v)
+			}
+		case "minimum":
+			fs.min, err = schemaNumber(key, v)
+		case "maximum":
+			fs.max, err = schemaNumber(key, v)
+		case "exclusiveMinimum", "exclusiveMaximum":
+			// Draft 4 uses booleans modifying minimum and maximum.
+			if b, ok := v.(bool); ok {
+				if key == "exclusiveMinimum" {
+					fs.exclMin = b
+				} else {
+					fs.exclMax = b
+				}
+				continue
+			}
+			var n constant.Value
+			if n, err = schemaNumber(key, v); err != nil {
+				break
+			}
+			if key == "exclusiveMinimum" {
+				fs.min, fs.exclMin = n, true
+			} else {
+				fs.max, fs.exclMax = n, true
+			}
+		case "multipleOf":
+			fs.multipleOf, err = schemaNumber(key, v)
+		case "minLength":
+			fs.minLength, err = schemaCount(key, v)
+		case "maxLength":
+			fs.maxLength, err = schemaCount(key, v)
+		case "minItems":
+			fs.minItems, err = schemaCount(key, v)
+		case "maxItems":
+			fs.maxItems, err = schemaCount(key, v)
+		case "pattern":
+			s, ok := v.(string)
+			if !ok {
+				return nil, fmt.Errorf("pattern is not a string")
+			}
+			if _, err := regexp.Compile(s); err != nil {
+				return nil, fmt.Errorf("invalid pattern: %v", err)
+			}
+			fs.pattern = s
+		case "enum", "const":
+			values, ok := v.([]interface{})
+			if key == "const" {
+				values, ok = []interface{}{v}, true
+			}
+			if !ok {
+				return nil, fmt.Errorf("enum is not an array")
+			}
+			for _, e := range values {
+				if e == nil {
+					continue // null is decoded as absent
+				}
+				c, err := schemaConstant(e)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %v", key, err)
+				}
+				fs.enum = append(fs.enum, c)
+			}
+		default:
+			if !schemaAnnotations[key] {
+				return nil, fmt.Errorf("unsupported keyword %q", key)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return fs, nil
+}
+
+func schemaNumber(key string, v interface{}) (constant.Value, error) {
+	n, ok := v.(json.Number)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a number", key)
+	}
+	return schemaConstant(n)
+}
+
+func schemaCount(key string, v interface{}) (int, error) {
+	n, ok := v.(json.Number)
+	if !ok {
+		return 0, fmt.Errorf("%s is not a number", key)
+	}
+	i, err := n.Int64()
+	if err != nil || i < 0 {
+		return 0, fmt.Errorf("%s is not a non-negative integer", key)
+	}
+	return int(i), nil
+}
+
+// schemaConstant converts a string, number or boolean of a schema to a constant.
+func schemaConstant(v interface{}) (constant.Value, error) {
+	switch v := v.(type) {
+	case string:
+		return constant.MakeString(v), nil
+	case bool:
+		return constant.MakeBool(v), nil
+	case json.Number:
+		tok := token.INT
+		if strings.ContainsAny(string(v), ".eE") {
+			tok = token.FLOAT
+		}
+		c := constant.MakeFromLiteral(string(v), tok, 0)
+		if c.Kind() == constant.Unknown {
+			return nil, fmt.Errorf("invalid number %s", v)
+		}
+		return c, nil
+	}
+	return nil, fmt.Errorf("unsupported value %v", v)
+}
+
+// jsonTypes returns the JSON types of values of typ.
+func jsonTypes(typ types.Type) []string {
+	if isTextMarshaler(typ) {
+		return []string{"string"}
+	}
+	switch u := typ.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case u.Info()&types.IsBoolean != 0:
+			return []string{"boolean"}
+		case u.Info()&types.IsInteger != 0:
+			return []string{"integer", "number"}
+		case u.Info()&types.IsFloat != 0:
+			return []string{"number"}
+		case u.Info()&types.IsString != 0:
+			return []string{"string"}
+		}
+	case *types.Pointer:
+		return append(jsonTypes(u.Elem()), "null")
+	case *types.Slice:
+		if isByte(u.Elem()) {
+			return []string{"string", "null"}
+		}
+		return []string{"array", "null"}
+	case *types.Array:
+		return []string{"array"}
+	case *types.Map:
+		return []string{"object", "null"}
+	case *types.Struct:
+		return []string{"object"}
+	}
+	return nil // any
+}
+
+// check verifies that the constraints apply to values of typ. Bounds which
+// can't be satisfied by typ are errors, and bounds implied by typ are removed.
+func (fs *fieldSchema) check(typ types.Type) error {
+	if len(fs.types) > 0 {
+		if jt := jsonTypes(typ); jt != nil && !intersects(fs.types, jt) {
+			return fmt.Errorf("type %s is not %s", typ, strings.Join(fs.types, " or "))
+		}
+	}
+	basic, _ := typ.Underlying().(*types.Basic)
+	isInt := basic != nil && basic.Info()&types.IsInteger != 0
+	if fs.min != nil || fs.max != nil || fs.multipleOf != nil {
+		if !isNumber(typ) {
+			return fmt.Errorf("numeric constraint on field of type %s", typ)
+		}
+	}
+	if fs.multipleOf != nil {
+		if !isInt || fs.multipleOf.Kind() != constant.Int || constant.Sign(fs.multipleOf) <= 0 {
+			return fmt.Errorf("multipleOf is only supported for positive integers on integer fields")
+		}
+	}
+	if isInt {
+		fs.normalizeIntBounds(basic)
+		if fs.min != nil && fs.max != nil && constant.Compare(fs.min, token.GTR, fs.max) {
+			return fmt.Errorf("no integer is in the range [%s, %s]", fs.min, fs.max)
+		}
+		lo, hi := intRange(basic)
+		if fs.min != nil && constant.Compare(fs.min, token.GTR, hi) || fs.max != nil && constant.Compare(fs.max, token.LSS, lo) {
+			return fmt.Errorf("bounds can't be satisfied by type %s", typ)
+		}
+		if fs.min != nil && constant.Compare(fs.min, token.LEQ, lo) {
+			fs.min = nil
+		}
+		if fs.max != nil && constant.Compare(fs.max, token.GEQ, hi) {
+			fs.max = nil
+		}
+	}
+	if (fs.minLength >= 0 || fs.maxLength >= 0 || fs.pattern != "") && !isString(typ) {
+		return fmt.Errorf("string constraint on field of type %s", typ)
+	}
+	if fs.minItems >= 0 || fs.maxItems >= 0 {
+		if underlyingSlice(typ) == nil {
+			if _, ok := typ.Underlying().(*types.Array); !ok {
+				return fmt.Errorf("array constraint on field of type %s", typ)
+			}
+		}
+	}
+	for _, e := range fs.enum {
+		var ok bool
+		switch e.Kind() {
+		case constant.String:
+			ok = isString(typ)
+		case constant.Bool:
+			ok = isBool(typ)
+		case constant.Int:
+			ok = isNumber(typ)
+		case constant.Float:
+			ok = isNumber(typ) && !isInt
+		}
+		if !ok {
+			return fmt.Errorf("enum value %s doesn't match type %s", e, typ)
+		}
+	}
+	return nil
+}
+
+// normalizeIntBounds turns the bounds of an integer field into inclusive
+// integer bounds.
+func (fs *fieldSchema) normalizeIntBounds(basic *types.Basic) {
+	if fs.min != nil {
+		n := constant.ToInt(fs.min)
+		if n.Kind() != constant.Int {
+			n = ceilConstant(fs.min)
+		} else if fs.exclMin {
+			n = constant.BinaryOp(n, token.ADD, constant.MakeInt64(1))
+		}
+		fs.min, fs.exclMin = n, false
+	}
+	if fs.max != nil {
+		n := constant.ToInt(fs.max)
+		if n.Kind() != constant.Int {
+			n = constant.BinaryOp(ceilConstant(fs.max), token.SUB, constant.MakeInt64(1))
+		} else if fs.exclMax {
+			n = constant.BinaryOp(n, token.SUB, constant.MakeInt64(1))
+		}
+		fs.max, fs.exclMax = n, false
+	}
+}
+
+// ceilConstant returns the smallest integer greater than the non-integer x.
+func ceilConstant(x constant.Value) constant.Value {
+	n := constant.MakeInt64(0)
+	if f, _ := constant.Float64Val(x); f >= -1e18 && f <= 1e18 {
+		n = constant.MakeInt64(int64(f))
+	}
+	for constant.Compare(n, token.LEQ, x) {
+		n = constant.BinaryOp(n, token.ADD, constant.MakeInt64(1))
+	}
+	for constant.Compare(constant.BinaryOp(n, token.SUB, constant.MakeInt64(1)), token.GTR, x) {
+		n = constant.BinaryOp(n, token.SUB, constant.MakeInt64(1))
+	}
+	return n
+}
+
+// intRange returns the range of values of an integer type.
+func intRange(basic *types.Basic) (lo, hi constant.Value) {
+	size := bitSize(basic)
+	if size == 0 {
+		size = 64
+	}
+	one := constant.MakeInt64(1)
+	if basic.Info()&types.IsUnsigned != 0 {
+		hi = constant.BinaryOp(constant.Shift(one, token.SHL, uint(size)), token.SUB, one)
+		return constant.MakeInt64(0), hi
+	}
+	hi = constant.BinaryOp(constant.Shift(one, token.SHL, uint(size-1)), token.SUB, one)
+	lo = constant.UnaryOp(token.SUB, constant.Shift(one, token.SHL, uint(size-1)), 0)
+	return lo, hi
+}
+
+// checkDefault verifies the default value of f against the constraints.
+func (fs *fieldSchema) checkDefault(f *marshalerField) error {
+	var v constant.Value
+	switch lit := f.defValue.(type) {
+	case stringLit:
+		v = constant.MakeString(lit.V)
+	case basicLit:
+		v = constant.MakeFromLiteral(lit.Value, lit.Kind, 0)
+	case Var:
+		v = constant.MakeBool(lit.Name == "true")
+	default:
+		return nil
+	}
+	for _, c := range fs.constantChecks() {
+		if c.fails(v) {
+			return fmt.Errorf("default value %s %s", v, c.desc)
+		}
+	}
+	return nil
+}
+
+func intersects(a, b []string) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x == y {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// schemaCheck is a constraint which can be evaluated for constants.
+type schemaCheck struct {
+	desc  string
+	fails func(v constant.Value) bool
+}
+
+// constantChecks returns the scalar constraints of fs.
+func (fs *fieldSchema) constantChecks() (checks []schemaCheck) {
+	if fs.min != nil {
+		op, desc := token.LSS, ">= "
+		if fs.exclMin {
+			op, desc = token.LEQ, "> "
+		}
+		min := fs.min
+		checks = append(checks, schemaCheck{"must be " + desc + min.String(), func(v constant.Value) bool {
+			return v.Kind() != constant.String && constant.Compare(v, op, min)
+		}})
+	}
+	if fs.max != nil {
+		op, desc := token.GTR, "<= "
+		if fs.exclMax {
+			op, desc = token.GEQ, "< "
+		}
+		max := fs.max
+		checks = append(checks, schemaCheck{"must be " + desc + max.String(), func(v constant.Value) bool {
+			return v.Kind() != constant.String && constant.Compare(v, op, max)
+		}})
+	}
+	if fs.minLength >= 0 {
+		checks = append(checks, schemaCheck{fmt.Sprintf("must be at least %d characters long", fs.minLength), func(v constant.Value) bool {
+			return len([]rune(constant.StringVal(v))) < fs.minLength
+		}})
+	}
+	if fs.maxLength >= 0 {
+		checks = append(checks, schemaCheck{fmt.Sprintf("must be at most %d characters long", fs.maxLength), func(v constant.Value) bool {
+			return len([]rune(constant.StringVal(v))) > fs.maxLength
+		}})
+	}
+	if fs.pattern != "" {
+		re := regexp.MustCompile(fs.pattern)
+		checks = append(checks, schemaCheck{"must match " + fs.pattern, func(v constant.Value) bool {
+			return !re.MatchString(constant.StringVal(v))
+		}})
+	}
+	if len(fs.enum) > 0 {
+		checks = append(checks, schemaCheck{"must be one of " + fs.enumString(), func(v constant.Value) bool {
+			for _, e := range fs.enum {
+				if constant.Compare(v, token.EQL, e) {
+					return false
+				}
+			}
+			return true
+		}})
+	}
+	return checks
+}
+
+func (fs *fieldSchema) enumString() string {
+	s := make([]string, len(fs.enum))
+	for i, e := range fs.enum {
+		s[i] = e.ExactString()
+	}
+	return strings.Join(s, ", ")
+}
+
+// schemaPatternVar returns the name of the variable holding the compiled
+// pattern of a field.
+func schemaPatternVar(mtyp *marshalerType, f *marshalerField) string {
+	return uncapitalize(mtyp.name) + f.name + "Pattern"
+}
+
+// genSchemaPatterns writes the variables holding the compiled patterns of mtyp.
+func genSchemaPatterns(w io.Writer, mtyp *marshalerType) {
+	var fields []*marshalerField
+	for _, f := range mtyp.Fields {
+		if f.schema != nil && f.schema.pattern != "" {
+			fields = append(fields, f)
+		}
+	}
+	if len(fields) == 0 {
+		return
+	}
+	regexp := mtyp.scope.packageName("regexp")
+	fmt.Fprintf(w, "// Patterns of %s from the schema.\n", mtyp.name)
+	fmt.Fprintln(w, "var (")
+	for _, f := range fields {
+		fmt.Fprintf(w, "\t%s = %s.MustCompile(%s)\n", schemaPatternVar(mtyp, f), regexp, quoteRegexp(f.schema.pattern))
+	}
+	fmt.Fprintln(w, ")")
+	fmt.Fprintln(w)
+}
+
+func quoteRegexp(re string) string {
+	if !strings.Contains(re, "`") {
+		return "`" + re + "`"
+	}
+	return fmt.Sprintf("%q", re)
+}
+
+// checkSchema generates the checks of the schema constraints of a decoded field.
+// The value v has the value type of the field.
+func (m *marshalMethod) checkSchema(f *marshalerField, v Expression, format string) (s []Statement) {
+	var (
+		fs     = f.schema
+		typ    = f.valueType()
+		errors = m.scope.parent.packageName("errors")
+		checks []limitCheck
+	)
+	num := func(c constant.Value) Expression {
+		if c.Kind() == constant.Float {
+			return basicLit{token.FLOAT, c.ExactString()}
+		}
+		return basicLit{token.INT, c.ExactString()}
+	}
+	if fs.min != nil {
+		op, desc := token.LSS, ">= "
+		if fs.exclMin {
+			op, desc = token.LEQ, "> "
+		}
+		checks = append(checks, limitCheck{binaryExpr{op, v, num(fs.min)}, "must be " + desc + fs.min.String()})
+	}
+	if fs.max != nil {
+		op, desc := token.GTR, "<= "
+		if fs.exclMax {
+			op, desc = token.GEQ, "< "
+		}
+		checks = append(checks, limitCheck{binaryExpr{op, v, num(fs.max)}, "must be " + desc + fs.max.String()})
+	}
+	if fs.multipleOf != nil {
+		rem := binaryExpr{token.REM, v, num(fs.multipleOf)}
+		checks = append(checks, limitCheck{binaryExpr{token.NEQ, rem, Int(0)}, "must be a multiple of " + fs.multipleOf.String()})
+	}
+	if fs.minLength >= 0 || fs.maxLength >= 0 {
+		utf8 := Name(m.scope.parent.packageName("unicode/utf8"))
+		n := CallFunction{Func: Dotted{Receiver: utf8, Name: "RuneCountInString"}, Params: []Expression{convertTo(v, typ, types.String)}}
+		if fs.minLength > 0 {
+			desc := fmt.Sprintf("must be at least %d characters long", fs.minLength)
+			checks = append(checks, limitCheck{binaryExpr{token.LSS, n, Int(fs.minLength)}, desc})
+		}
+		if fs.maxLength >= 0 {
+			desc := fmt.Sprintf("must be at most %d characters long", fs.maxLength)
+			checks = append(checks, limitCheck{binaryExpr{token.GTR, n, Int(fs.maxLength)}, desc})
+		}
+	}
+	if fs.pattern != "" {
+		match := CallFunction{Func: Dotted{Receiver: Name(schemaPatternVar(m.mtyp, f)), Name: "MatchString"}, Params: []Expression{convertTo(v, typ, types.String)}}
+		checks = append(checks, limitCheck{Not{Value: match}, "must match " + fs.pattern})
+	}
+	if fs.minItems > 0 {
+		n := CallFunction{Func: Name("len"), Params: []Expression{v}}
+		checks = append(checks, limitCheck{binaryExpr{token.LSS, n, Int(fs.minItems)}, fmt.Sprintf("must have at least %d items", fs.minItems)})
+	}
+	if fs.maxItems >= 0 {
+		n := CallFunction{Func: Name("len"), Params: []Expression{v}}
+		checks = append(checks, limitCheck{binaryExpr{token.GTR, n, Int(fs.maxItems)}, fmt.Sprintf("must have at most %d items", fs.maxItems)})
+	}
+	if len(fs.enum) > 0 {
+		var cond Expression
+		for _, e := range fs.enum {
+			var lit Expression
+			switch e.Kind() {
+			case constant.String:
+				lit = stringLit{constant.StringVal(e)}
+			case constant.Bool:
+				lit = Name(e.String())
+			default:
+				lit = num(e)
+			}
+			ne := binaryExpr{token.NEQ, v, lit}
+			if cond == nil {
+				cond = ne
+			} else {
+				cond = binaryExpr{token.LAND, cond, ne}
+			}
+		}
+		checks = append(checks, limitCheck{cond, "must be one of " + fs.enumString()})
+	}
+	for _, check := range checks {
+		err := fmt.Sprintf("field '%s' of %s %s", f.encodedName(format), m.mtyp.name, check.desc)
+		s = append(s, If{
+			Condition: check.cond,
+			Body: []Statement{Return{Values: []Expression{
+				CallFunction{Func: Dotted{Receiver: Name(errors), Name: "New"}, Params: []Expression{stringLit{err}}},
+			}}},
+		})
+	}
+	return s
+}
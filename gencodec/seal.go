@@ -0,0 +1,117 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"fmt"
+	"io"
+	"text/template"
+)
+
+// sealVersion is the first byte of sealed values. The rest is the random nonce and
+// the AES-GCM ciphertext.
+const sealVersion = 1
+
+// sealTemplate generates methods encrypting the encoding of a type, for each of the
+// formats which encode to a byte slice.
+var sealTemplate = template.Must(template.New("seal").Parse(`
+{{- range .Formats}}
+// Seal{{.}} encodes {{$.Recv}} as {{.}} and encrypts it with AES-GCM. The key must be 16, 24
+// or 32 bytes long. The result holds a version byte, a random nonce and the ciphertext,
+// which also authenticates the type name, so it can only be opened as {{$.Type}}.
+// Random nonces are safe for up to 2^32 values sealed with the same key.
+func ({{$.Recv}} {{$.RecvType}}) Seal{{.}}({{$.Key}} []byte) ([]byte, error) {
+	{{$.Data}}, {{$.Err}} := {{$.Recv}}.Marshal{{.}}()
+	if {{$.Err}} != nil {
+		return nil, {{$.Err}}
+	}
+	{{$.Block}}, {{$.Err}} := {{$.AES}}.NewCipher({{$.Key}})
+	if {{$.Err}} != nil {
+		return nil, {{$.Err}}
+	}
+	{{$.AEAD}}, {{$.Err}} := {{$.Cipher}}.NewGCM({{$.Block}})
+	if {{$.Err}} != nil {
+		return nil, {{$.Err}}
+	}
+	{{$.Out}} := make([]byte, 1+{{$.AEAD}}.NonceSize(), 1+{{$.AEAD}}.NonceSize()+len({{$.Data}})+{{$.AEAD}}.Overhead())
+	{{$.Out}}[0] = {{$.Version}}
+	if _, {{$.Err}} := {{$.Rand}}.Read({{$.Out}}[1:]); {{$.Err}} != nil {
+		return nil, {{$.Err}}
+	}
+	return {{$.AEAD}}.Seal({{$.Out}}, {{$.Out}}[1:], {{$.Data}}, []byte({{printf "%q" $.Type}})), nil
+}
+
+// Open{{.}} decrypts {{$.Sealed}}, which was created by Seal{{.}} with the same key, and decodes
+// it into {{$.Recv}}. It returns an error if the value was modified or sealed as another type.
+func ({{$.Recv}} *{{$.RecvType}}) Open{{.}}({{$.Key}}, {{$.Sealed}} []byte) error {
+	{{$.Block}}, {{$.Err}} := {{$.AES}}.NewCipher({{$.Key}})
+	if {{$.Err}} != nil {
+		return {{$.Err}}
+	}
+	{{$.AEAD}}, {{$.Err}} := {{$.Cipher}}.NewGCM({{$.Block}})
+	if {{$.Err}} != nil {
+		return {{$.Err}}
+	}
+	if len({{$.Sealed}}) < 1+{{$.AEAD}}.NonceSize()+{{$.AEAD}}.Overhead() {
+		return {{$.Errors}}.New("sealed {{$.Type}} too short")
+	}
+	if {{$.Sealed}}[0] != {{$.Version}} {
+		return {{$.Fmt}}.Errorf("unknown version %d of sealed {{$.Type}}", {{$.Sealed}}[0])
+	}
+	{{$.Nonce}}, {{$.Ciphertext}} := {{$.Sealed}}[1:1+{{$.AEAD}}.NonceSize()], {{$.Sealed}}[1+{{$.AEAD}}.NonceSize():]
+	{{$.Data}}, {{$.Err}} := {{$.AEAD}}.Open(nil, {{$.Nonce}}, {{$.Ciphertext}}, []byte({{printf "%q" $.Type}}))
+	if {{$.Err}} != nil {
+		return {{$.Fmt}}.Errorf("can't open sealed {{$.Type}}: %v", {{$.Err}})
+	}
+	return {{$.Recv}}.Unmarshal{{.}}({{$.Data}})
+}
+{{end}}`))
+
+type sealData struct {
+	Type, RecvType string
+	Formats        []string // method name suffixes, e.g. JSON
+	Version        int
+
+	// package and variable names
+	AES, Cipher, Errors, Fmt, Rand string
+	Recv, Key, Sealed, Data, Err   string
+	Block, AEAD, Out               string
+	Nonce, Ciphertext              string
+}
+
+// genSeal writes the Seal and Open methods of mtyp for each of its formats which
+// encode to a byte slice.
+func genSeal(w io.Writer, mtyp *marshalerType) error {
+	m := newMarshalMethod(mtyp, false)
+	data := sealData{
+		Type:       mtyp.name,
+		RecvType:   mtyp.recvType(),
+		Version:    sealVersion,
+		AES:        mtyp.scope.packageName("crypto/aes"),
+		Cipher:     mtyp.scope.packageName("crypto/cipher"),
+		Errors:     mtyp.scope.packageName("errors"),
+		Fmt:        mtyp.scope.packageName("fmt"),
+		Rand:       mtyp.scope.packageName("crypto/rand"),
+		Recv:       m.receiver().Name,
+		Key:        m.scope.newIdent("key"),
+		Sealed:     m.scope.newIdent("sealed"),
+		Data:       m.scope.newIdent("data"),
+		Err:        m.scope.newIdent("err"),
+		Block:      m.scope.newIdent("block"),
+		AEAD:       m.scope.newIdent("aead"),
+		Out:        m.scope.newIdent("out"),
+		Nonce:      m.scope.newIdent("nonce"),
+		Ciphertext: m.scope.newIdent("ciphertext"),
+	}
+	for _, f := range kafkaFormats {
+		if mtyp.hasFormat(f) {
+			data.Formats = append(data.Formats, formatNames[f])
+		}
+	}
+	if len(data.Formats) == 0 {
+		return fmt.Errorf("seal helper requires one of the formats %v", kafkaFormats)
+	}
+	return mtyp.template(sealTemplate).Execute(w, data)
+}
@@ -0,0 +1,115 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"fmt"
+	"go/types"
+	"reflect"
+
+	. "github.com/garslo/gogen"
+)
+
+// attributeSliceTypes maps slice element types to the attribute constructors for slices.
+var attributeSliceTypes = map[types.BasicKind]string{
+	types.String:  "StringSlice",
+	types.Int:     "IntSlice",
+	types.Int64:   "Int64Slice",
+	types.Float64: "Float64Slice",
+	types.Bool:    "BoolSlice",
+}
+
+// genSpanAttributes generates the SpanAttributes method, which returns
+// the fields with an "otel" struct tag as OpenTelemetry attributes.
+func genSpanAttributes(mtyp *marshalerType) (Function, error) {
+	var (
+		m     = newMarshalMethod(mtyp, false)
+		recv  = m.receiver()
+		attrs = Name(m.scope.newIdent("attrs"))
+		attr  = Name(m.scope.parent.packageName("go.opentelemetry.io/otel/attribute"))
+	)
+	fn := Function{
+		Receiver:    recv,
+		Name:        "SpanAttributes",
+		ReturnTypes: Types{{TypeName: "[]" + attr.Name + ".KeyValue"}},
+		Body: []Statement{
+			Declare{Name: attrs.Name, TypeName: "[]" + attr.Name + ".KeyValue"},
+		},
+	}
+	for _, f := range mtyp.taggedFields("otel") {
+		key, _ := reflect.StructTag(f.tag).Lookup("otel")
+		if key == "" {
+			key, _ = f.key("json")
+		}
+		var (
+			value = f.access(Name(recv.Name))
+			typ   = f.origTyp
+			cond  Expression
+		)
+		if ptr, ok := typ.(*types.Pointer); ok {
+			cond = NotEqual{Lhs: value, Rhs: NIL}
+			if !isStringer(typ) {
+				value, typ = Star{Value: value}, ptr.Elem()
+			}
+		}
+		kv, err := m.attributeValue(stringLit{key}, value, typ)
+		if err != nil {
+			return fn, fmt.Errorf("%v: field %s: %v", mtyp.fs.Position(f.pos), f.name, err)
+		}
+		add := Assign{Lhs: attrs, Rhs: CallFunction{Func: Name("append"), Params: []Expression{attrs, kv}}}
+		if cond != nil {
+			fn.Body = append(fn.Body, If{Condition: cond, Body: []Statement{add}})
+		} else {
+			fn.Body = append(fn.Body, add)
+		}
+	}
+	fn.Body = append(fn.Body, Return{Values: []Expression{attrs}})
+	return fn, nil
+}
+
+// attributeValue creates the attribute.KeyValue expression of a value.
+func (m *marshalMethod) attributeValue(key, v Expression, typ types.Type) (Expression, error) {
+	attr := Name(m.scope.parent.packageName("go.opentelemetry.io/otel/attribute"))
+	call := func(name string, v Expression) Expression {
+		return CallFunction{Func: Dotted{Receiver: attr, Name: name}, Params: []Expression{key, v}}
+	}
+	switch {
+	case isStringer(typ):
+		return call("Stringer", v), nil
+	case isStringer(types.NewPointer(typ)):
+		return call("Stringer", AddressOf{Value: v}), nil
+	}
+	switch u := typ.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case u.Info()&types.IsBoolean != 0:
+			return call("Bool", convertTo(v, typ, types.Bool)), nil
+		case u.Info()&types.IsString != 0:
+			return call("String", convertTo(v, typ, types.String)), nil
+		case u.Kind() == types.Int:
+			return call("Int", convertTo(v, typ, types.Int)), nil
+		case u.Info()&types.IsInteger != 0:
+			return call("Int64", convertTo(v, typ, types.Int64)), nil
+		case u.Info()&types.IsFloat != 0:
+			return call("Float64", convertTo(v, typ, types.Float64)), nil
+		}
+	case *types.Slice:
+		if elem, ok := u.Elem().(*types.Basic); ok && attributeSliceTypes[elem.Kind()] != "" {
+			return call(attributeSliceTypes[elem.Kind()], v), nil
+		}
+	}
+	return nil, fmt.Errorf("type %s has no attribute representation", typ)
+}
+
+// isStringer reports whether typ implements fmt.Stringer.
+func isStringer(typ types.Type) bool {
+	sel := types.NewMethodSet(typ).Lookup(nil, "String")
+	if sel == nil {
+		return false
+	}
+	sig := sel.Type().(*types.Signature)
+	return sig.Params().Len() == 0 && sig.Results().Len() == 1 &&
+		types.Identical(sig.Results().At(0).Type(), types.Typ[types.String])
+}
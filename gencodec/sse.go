@@ -0,0 +1,84 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"errors"
+	"io"
+	"text/template"
+)
+
+var sseTemplate = template.Must(template.New("sse").Parse(`
+// WriteSSE writes {{.Recv}} as a server-sent event with the given event name. The data
+// of the event is the JSON encoding of {{.Recv}}. If {{.W}} has a Flush method, such as
+// http.Flusher, it is called after the event is written.
+func ({{.Recv}} {{.RecvType}}) WriteSSE({{.W}} {{.IO}}.Writer, {{.Event}} string) error {
+	if {{.Strings}}.ContainsAny({{.Event}}, "\r\n") {
+		return {{.Errors}}.New("invalid SSE event name")
+	}
+	{{.Data}}, {{.Err}} := {{.Recv}}.MarshalJSON()
+	if {{.Err}} != nil {
+		return {{.Err}}
+	}
+	var {{.Buf}} {{.Bytes}}.Buffer
+	if {{.Event}} != "" {
+		{{.Buf}}.WriteString("event: ")
+		{{.Buf}}.WriteString({{.Event}})
+		{{.Buf}}.WriteByte('\n')
+	}
+	for _, {{.Line}} := range {{.Bytes}}.Split({{.Data}}, []byte("\n")) {
+		{{.Buf}}.WriteString("data: ")
+		{{.Buf}}.Write({{.Line}})
+		{{.Buf}}.WriteByte('\n')
+	}
+	{{.Buf}}.WriteByte('\n')
+	if _, {{.Err}} := {{.W}}.Write({{.Buf}}.Bytes()); {{.Err}} != nil {
+		return {{.Err}}
+	}
+	if {{.Flusher}}, {{.Ok}} := {{.W}}.(interface{ Flush() }); {{.Ok}} {
+		{{.Flusher}}.Flush()
+	}
+	return nil
+}
+`))
+
+type sseData struct {
+	RecvType string
+
+	// package and variable names
+	Bytes, Errors, IO, Strings           string
+	Recv, W, Event, Data, Err, Buf, Line string
+	Flusher, Ok                          string
+}
+
+// genSSE writes the WriteSSE method of mtyp. The event data is encoded
+// by the MarshalJSON method, so the type must be generated with the json format.
+func genSSE(w io.Writer, mtyp *marshalerType) error {
+	if !mtyp.hasFormat("json") {
+		return errors.New("WriteSSE requires the json format")
+	}
+	var (
+		scope = newFuncScope(mtyp.scope)
+		recv  = newMarshalMethod(mtyp, false).receiver().Name
+	)
+	scope.used[recv] = true
+	data := sseData{
+		RecvType: mtyp.recvType(),
+		Bytes:    mtyp.scope.packageName("bytes"),
+		Errors:   mtyp.scope.packageName("errors"),
+		IO:       mtyp.scope.packageName("io"),
+		Strings:  mtyp.scope.packageName("strings"),
+		Recv:     recv,
+		W:        scope.newIdent("w"),
+		Event:    scope.newIdent("event"),
+		Data:     scope.newIdent("data"),
+		Err:      scope.newIdent("err"),
+		Buf:      scope.newIdent("buf"),
+		Line:     scope.newIdent("line"),
+		Flusher:  scope.newIdent("f"),
+		Ok:       scope.newIdent("ok"),
+	}
+	return mtyp.template(sseTemplate).Execute(w, data)
+}
@@ -0,0 +1,198 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// State records the input hashes of generated targets in a state file, keyed by
+// output file. A target whose hash matches the recorded one doesn't need to be
+// generated again.
+//
+// The hash covers the configuration of the target, the Go files of its package
+// and of the packages it depends on outside of the standard library, its
+// templates and schema, its output files, and the gencodec executable. Output
+// files are included so that edited or deleted files are generated again.
+type State struct {
+	file   string
+	hashes map[string]string
+}
+
+// LoadState reads a state file. A missing file yields an empty state.
+func LoadState(file string) (*State, error) {
+	s := &State{file: file, hashes: make(map[string]string)}
+	content, err := os.ReadFile(file)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(content, &s.hashes); err != nil {
+		return nil, fmt.Errorf("%s: %v", file, err)
+	}
+	return s, nil
+}
+
+// Changed reports whether the inputs of t differ from the recorded ones.
+func (s *State) Changed(t *Target) (bool, error) {
+	h, err := inputHash(t)
+	if err != nil {
+		return false, err
+	}
+	return s.hashes[s.key(t)] != h, nil
+}
+
+// Record stores the current input hash of t. It must be called after generating
+// all targets, because the output of one target is an input of the targets
+// depending on its package.
+func (s *State) Record(t *Target) error {
+	h, err := inputHash(t)
+	if err != nil {
+		return err
+	}
+	s.hashes[s.key(t)] = h
+	return nil
+}
+
+// Save writes the state file.
+func (s *State) Save() error {
+	content, err := json.MarshalIndent(s.hashes, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.file, append(content, '\n'), 0644)
+}
+
+// key returns the output file of t relative to the directory of the state file,
+// so that the state doesn't depend on the working directory.
+func (s *State) key(t *Target) string {
+	out, err := filepath.Abs(t.Out)
+	if err != nil {
+		return t.Out
+	}
+	dir, err := filepath.Abs(filepath.Dir(s.file))
+	if err != nil {
+		return out
+	}
+	if rel, err := filepath.Rel(dir, out); err == nil {
+		return filepath.ToSlash(rel)
+	}
+	return out
+}
+
+// inputHash computes the input hash of t.
+func inputHash(t *Target) (string, error) {
+	gen, err := generatorHash()
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	fmt.Fprintf(h, "gencodec %s\n", gen)
+
+	c := *t
+	c.Importer, c.FileSet = nil, nil
+	config, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	fmt.Fprintf(h, "config %s\n", config)
+
+	pkg, err := loadTargetPackage(make(map[string]*packages.Package), &t.Config)
+	if err != nil {
+		return "", err
+	}
+	var files []string
+	packages.Visit([]*packages.Package{pkg}, nil, func(p *packages.Package) {
+		if p == pkg || p.Module != nil {
+			files = append(files, p.GoFiles...)
+		}
+	})
+	if t.TemplateDir != "" {
+		entries, err := os.ReadDir(t.TemplateDir)
+		if err != nil {
+			return "", err
+		}
+		for _, e := range entries {
+			if e.Type().IsRegular() {
+				files = append(files, filepath.Join(t.TemplateDir, e.Name()))
+			}
+		}
+	}
+	if t.Schema != "" {
+		files = append(files, t.Schema)
+	}
+	for _, out := range []string{t.Out, t.GraphQL, t.CRD, t.JSONSchema, t.OpenAPI, t.TypeScript, t.Plugin, t.WASM, t.Cgo, t.Tests, t.Fuzz, t.DumpIR} {
+		if out != "" {
+			files = append(files, out)
+		}
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		if err := hashFile(h, file); err != nil {
+			return "", err
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// hashFile adds the name and content of a file to h. Missing files are
+// recorded as such.
+func hashFile(h hash.Hash, file string) error {
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(file)
+	if errors.Is(err, fs.ErrNotExist) {
+		fmt.Fprintf(h, "missing %s\n", abs)
+		return nil
+	} else if err != nil {
+		return err
+	}
+	defer f.Close()
+	fh := sha256.New()
+	if _, err := io.Copy(fh, f); err != nil {
+		return fmt.Errorf("%s: %v", file, err)
+	}
+	fmt.Fprintf(h, "file %s %x\n", abs, fh.Sum(nil))
+	return nil
+}
+
+var (
+	generatorOnce sync.Once
+	generatorSum  string
+	generatorErr  error
+)
+
+// generatorHash returns the hash of the running executable, so that targets are
+// generated again by a different version of gencodec.
+func generatorHash() (string, error) {
+	generatorOnce.Do(func() {
+		exe, err := os.Executable()
+		if err != nil {
+			generatorErr = err
+			return
+		}
+		h := sha256.New()
+		if generatorErr = hashFile(h, exe); generatorErr == nil {
+			generatorSum = hex.EncodeToString(h.Sum(nil))
+		}
+	})
+	return generatorSum, generatorErr
+}
@@ -0,0 +1,98 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	. "github.com/garslo/gogen"
+)
+
+// genTable writes the TableHeader and TableRow methods of mtyp and the function
+// WriteTTable, which renders a slice of values using package text/tabwriter.
+func genTable(w io.Writer, mtyp *marshalerType) error {
+	fields := mtyp.taggedFields("table")
+	if len(fields) == 0 {
+		return fmt.Errorf("type %s has no fields with a table struct tag", mtyp.name)
+	}
+	if mtyp.orig.TypeParams().Len() > 0 {
+		return errors.New("tables are not supported for generic types")
+	}
+	var (
+		m     = newMarshalMethod(mtyp, false)
+		recv  = m.receiver()
+		cells = Name(m.scope.newIdent("cells"))
+		names = make([]string, len(fields))
+	)
+	row := Function{
+		Receiver:    recv,
+		Name:        "TableRow",
+		ReturnTypes: Types{{TypeName: "string"}},
+		Body:        []Statement{Declare{Name: cells.Name, TypeName: fmt.Sprintf("[%d]string", len(fields))}},
+	}
+	for i, f := range fields {
+		names[i] = reflect.StructTag(f.tag).Get("table")
+		if names[i] == "" {
+			key, _ := f.key("json")
+			names[i] = strings.ToUpper(key)
+		}
+		str, cond, err := m.fieldString(f, Name(recv.Name))
+		if err != nil {
+			return err
+		}
+		// Cells of absent values are left empty.
+		cell := Index{Value: cells, Index: Int(i)}
+		if cond == nil {
+			row.Body = append(row.Body, Assign{Lhs: cell, Rhs: str})
+		} else {
+			row.Body = append(row.Body, If{Condition: cond, Body: []Statement{Assign{Lhs: cell, Rhs: str}}})
+		}
+	}
+	join := Dotted{Receiver: Name(mtyp.scope.packageName("strings")), Name: "Join"}
+	row.Body = append(row.Body, Return{Values: []Expression{
+		CallFunction{Func: join, Params: []Expression{Name(cells.Name + "[:]"), stringLit{"\t"}}},
+	}})
+	header := Function{
+		Receiver:    recv,
+		Name:        "TableHeader",
+		ReturnTypes: Types{{TypeName: "string"}},
+		Body:        []Statement{Return{Values: []Expression{stringLit{strings.Join(names, "\t")}}}},
+	}
+
+	fmt.Fprintln(w, "// TableHeader returns the column names of the fields with a table struct tag, separated by tabs.")
+	writeFunction(w, mtyp.fs, header)
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "// TableRow returns the fields with a table struct tag as cells separated by tabs.")
+	writeFunction(w, mtyp.fs, row)
+	fmt.Fprintln(w)
+	return genWriteTable(w, mtyp)
+}
+
+// genWriteTable writes the function which renders a slice of mtyp values as a table.
+func genWriteTable(w io.Writer, mtyp *marshalerType) error {
+	var (
+		scope     = newFuncScope(mtyp.scope)
+		out       = scope.newIdent("w")
+		rows      = scope.newIdent("rows")
+		tw        = scope.newIdent("tw")
+		row       = scope.newIdent("row")
+		fmtPkg    = mtyp.scope.packageName("fmt")
+		tabwriter = mtyp.scope.packageName("text/tabwriter")
+	)
+	fmt.Fprintf(w, "// Write%sTable writes rows as a table with aligned columns, starting with the header.\n", mtyp.name)
+	fmt.Fprintf(w, "func Write%sTable(%s %s.Writer, %s []%s) error {\n", mtyp.name, out, mtyp.scope.packageName("io"), rows, mtyp.name)
+	fmt.Fprintf(w, "\t%s := %s.NewWriter(%s, 0, 8, 2, ' ', 0)\n", tw, tabwriter, out)
+	fmt.Fprintf(w, "\t%s.Fprintln(%s, %s{}.TableHeader())\n", fmtPkg, tw, mtyp.name)
+	fmt.Fprintf(w, "\tfor _, %s := range %s {\n", row, rows)
+	fmt.Fprintf(w, "\t\t%s.Fprintln(%s, %s.TableRow())\n", fmtPkg, tw, row)
+	fmt.Fprintln(w, "\t}")
+	fmt.Fprintf(w, "\treturn %s.Flush()\n", tw)
+	fmt.Fprintln(w, "}")
+	return nil
+}
@@ -0,0 +1,243 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	. "github.com/garslo/gogen"
+)
+
+// builtinTemplates are the templates of helpers and codec objects. They can be
+// replaced by files of the same name in the template directory.
+var builtinTemplates = []*template.Template{
+	codecTemplate,
+	deepCopyObjectTemplate,
+	dynamicTemplate,
+	enumTemplate,
+	frameTemplate,
+	gzipTemplate,
+	jsonapiTemplate,
+	jsonFieldsTemplate,
+	jsonStreamTemplate,
+	kafkaTemplate,
+	lambdaTemplate,
+	natsTemplate,
+	peekTemplate,
+	prettyTemplate,
+	promptTemplate,
+	sealTemplate,
+	sseTemplate,
+	unionTemplate,
+	valuesTemplate,
+	wsTemplate,
+	wsDecoderTemplate,
+}
+
+// loadTemplates parses the templates in dir. A file named after a format, like
+// json.tmpl, replaces the methods of the format. Other files replace the built-in
+// template of the same name. Packages used through the pkg function are added to
+// the imports of scope.
+func loadTemplates(dir string, scope *fileScope) (map[string]*template.Template, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.tmpl"))
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no templates in %s", dir)
+	}
+	tmpls := make(map[string]*template.Template)
+	for _, file := range files {
+		name := strings.TrimSuffix(filepath.Base(file), ".tmpl")
+		if !isKnownFormat(name) && lookupBuiltinTemplate(name) == nil {
+			return nil, fmt.Errorf("%s: unknown template %q (available: %s)", file, name, strings.Join(templateNames(), ", "))
+		}
+		text, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		t, err := template.New(name).Funcs(templateFuncs(scope)).Parse(string(text))
+		if err != nil {
+			return nil, err
+		}
+		for _, path := range templateImports(t.Tree.Root) {
+			if err := scope.requireImport(path); err != nil {
+				return nil, fmt.Errorf("%s: %v", file, err)
+			}
+		}
+		tmpls[name] = t
+	}
+	return tmpls, nil
+}
+
+// templateFuncs are the functions available in templates. The pkg function
+// returns the name under which a package is imported in the generated file.
+func templateFuncs(scope *fileScope) template.FuncMap {
+	return template.FuncMap{"pkg": scope.packageName}
+}
+
+// templateImports returns the packages given to the pkg function in a template.
+// They have to be known before the template runs because the import declaration
+// is written first.
+func templateImports(node parse.Node) (paths []string) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return nil
+		}
+		for _, c := range n.Nodes {
+			paths = append(paths, templateImports(c)...)
+		}
+	case *parse.ActionNode:
+		paths = templateImports(n.Pipe)
+	case *parse.PipeNode:
+		if n == nil {
+			return nil
+		}
+		for _, cmd := range n.Cmds {
+			if id, ok := cmd.Args[0].(*parse.IdentifierNode); ok && id.Ident == "pkg" && len(cmd.Args) == 2 {
+				if s, ok := cmd.Args[1].(*parse.StringNode); ok {
+					paths = append(paths, s.Text)
+				}
+			}
+			for _, arg := range cmd.Args {
+				if p, ok := arg.(*parse.PipeNode); ok {
+					paths = append(paths, templateImports(p)...)
+				}
+			}
+		}
+	case *parse.IfNode:
+		paths = templateImports(&n.BranchNode)
+	case *parse.RangeNode:
+		paths = templateImports(&n.BranchNode)
+	case *parse.WithNode:
+		paths = templateImports(&n.BranchNode)
+	case *parse.BranchNode:
+		paths = append(templateImports(n.Pipe), templateImports(n.List)...)
+		paths = append(paths, templateImports(n.ElseList)...)
+	}
+	return paths
+}
+
+func lookupBuiltinTemplate(name string) *template.Template {
+	for _, t := range builtinTemplates {
+		if t.Name() == name {
+			return t
+		}
+	}
+	return nil
+}
+
+func templateNames() []string {
+	names := append([]string(nil), AllFormats...)
+	for _, t := range builtinTemplates {
+		names = append(names, t.Name())
+	}
+	sort.Strings(names)
+	return names
+}
+
+// template returns the replacement of a built-in template, or t itself
+// if there is none.
+func (mtyp *marshalerType) template(t *template.Template) *template.Template {
+	if r := mtyp.templates[t.Name()]; r != nil {
+		return r
+	}
+	return t
+}
+
+// methodTemplateData is the input of format templates. The code of the generated
+// methods is split into pieces, so templates can change the error handling or add
+// statements while the conversions are done like in the built-in methods.
+type methodTemplateData struct {
+	Type, RecvType string
+	Recv           string // receiver name
+	Format, Name   string // format and its name in doc comments, e.g. "json" and "JSON"
+	Marshal        methodTemplateBody
+	Unmarshal      methodTemplateBody
+	Fields         []methodTemplateField
+	Validate       string // method validating the decoded value, empty if none
+}
+
+// methodTemplateBody holds the code of a marshaling or unmarshaling method.
+type methodTemplateBody struct {
+	Decl        string // declarations of the intermediate type and Var
+	Var         string // variable holding the intermediate value
+	Conversions string // statements converting between the receiver and Var
+}
+
+// methodTemplateField describes a field of the intermediate type.
+type methodTemplateField struct {
+	Name     string // field name in the intermediate type
+	Key      string // key in the format, empty if the field is skipped
+	Type     string // field type when marshaling
+	Required bool
+	Encode   string // statements assigning the field of Marshal.Var
+	Decode   string // statements assigning the decoded field, which must be non-nil
+}
+
+// genMethodTemplate writes the methods of a format using template t.
+func genMethodTemplate(w io.Writer, mtyp *marshalerType, t *template.Template, format string) error {
+	return t.Execute(w, newMethodTemplateData(mtyp, format))
+}
+
+// newMethodTemplateData creates the input of the template of a format.
+func newMethodTemplateData(mtyp *marshalerType, format string) methodTemplateData {
+	var (
+		enc     = newMarshalMethod(mtyp, false)
+		dec     = newMarshalMethod(mtyp, true)
+		recv    = Name(enc.receiver().Name)
+		decRecv = Name(dec.receiver().Name) // same as recv, both are the first identifier
+		encVar  = Name(enc.scope.newIdent("enc"))
+		decVar  = Name(dec.scope.newIdent("dec"))
+		encType = enc.intermediateType(enc.intermediateName(format), format)
+		decType = dec.intermediateType(dec.intermediateName(format), format)
+	)
+	data := methodTemplateData{
+		Type:     mtyp.name,
+		RecvType: mtyp.recvType(),
+		Recv:     recv.Name,
+		Format:   format,
+		Name:     formatNames[format],
+		Validate: mtyp.validate,
+		Marshal: methodTemplateBody{
+			Decl:        printStatements(mtyp, []Statement{declStmt{encType}, Declare{Name: encVar.Name, TypeName: encType.Name}}),
+			Var:         encVar.Name,
+			Conversions: printStatements(mtyp, enc.marshalConversions(recv, encVar, format)),
+		},
+		Unmarshal: methodTemplateBody{
+			Decl:        printStatements(mtyp, []Statement{declStmt{decType}, Declare{Name: decVar.Name, TypeName: decType.Name}}),
+			Var:         decVar.Name,
+			Conversions: printStatements(mtyp, dec.unmarshalConversions(decVar, decRecv, format)),
+		},
+	}
+	for _, f := range mtyp.Fields {
+		if !f.inFormat(format) {
+			continue
+		}
+		tf := methodTemplateField{
+			Name:     f.name,
+			Type:     typeString(f.typ, mtyp.scope.qualify),
+			Required: f.isRequired(format),
+			Encode:   printStatements(mtyp, enc.marshalField(f, recv, encVar, format)),
+		}
+		if f.hooked(format) {
+			tf.Type = mtyp.rawMessage()
+		}
+		tf.Key, _ = f.key(format)
+		if f.function == nil {
+			tf.Decode = printStatements(mtyp, dec.decodeField(f, Dotted{Receiver: decVar, Name: f.name}, decRecv, format))
+		}
+		data.Fields = append(data.Fields, tf)
+	}
+	return data
+}
@@ -0,0 +1,154 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"go/types"
+	"io"
+
+	. "github.com/garslo/gogen"
+)
+
+// genText writes the MarshalText and UnmarshalText methods of mtyp, which
+// encode the value field of the type as text.
+func genText(w io.Writer, mtyp *marshalerType) error {
+	f, err := mtyp.valueField()
+	if err != nil {
+		return err
+	}
+	if !isTextMarshaler(f.origTyp) && !isString(f.origTyp) && !isNumber(f.origTyp) && !isBool(f.origTyp) {
+		return fmt.Errorf("%v: value field %s: type %s has no text representation", mtyp.fs.Position(f.pos), f.name, f.origTyp)
+	}
+	fmt.Fprintf(w, "// MarshalText encodes the %s field as text.\n", f.name)
+	writeFunction(w, mtyp.fs, genMarshalText(mtyp, f))
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "// UnmarshalText decodes the %s field from text.\n", f.name)
+	writeFunction(w, mtyp.fs, genUnmarshalText(mtyp, f))
+	return nil
+}
+
+// valueField returns the field marked with the gencodec:"value" option,
+// or the only field if the type has just one.
+func (mtyp *marshalerType) valueField() (*marshalerField, error) {
+	var value *marshalerField
+	for _, f := range mtyp.Fields {
+		if f.function == nil && f.hasOption("value") {
+			if value != nil {
+				return nil, fmt.Errorf("%v: more than one value field in %s", mtyp.fs.Position(f.pos), mtyp.name)
+			}
+			value = f
+		}
+	}
+	switch {
+	case value != nil:
+		return value, nil
+	case len(mtyp.Fields) == 1 && mtyp.Fields[0].function == nil:
+		return mtyp.Fields[0], nil
+	default:
+		return nil, errors.New("type " + mtyp.name + ` has no value field, mark one with gencodec:"value"`)
+	}
+}
+
+func genMarshalText(mtyp *marshalerType, f *marshalerField) Function {
+	var (
+		m     = newMarshalMethod(mtyp, false)
+		recv  = m.receiver()
+		value = f.access(Name(recv.Name))
+		typ   = f.origTyp
+		str   Expression
+	)
+	fn := Function{
+		Receiver:    recv,
+		Name:        "MarshalText",
+		ReturnTypes: Types{{TypeName: "[]byte"}, {TypeName: "error"}},
+	}
+	switch {
+	case isTextMarshaler(typ):
+		fn.Body = []Statement{Return{Values: []Expression{CallFunction{Func: Dotted{Receiver: value, Name: "MarshalText"}}}}}
+		return fn
+	case isString(typ):
+		str = value
+	case isNumber(typ):
+		str = m.formatNumber(value, typ)
+	default:
+		strconv := Name(m.scope.parent.packageName("strconv"))
+		str = CallFunction{Func: Dotted{Receiver: strconv, Name: "FormatBool"}, Params: []Expression{convertTo(value, typ, types.Bool)}}
+	}
+	bytes := CallFunction{Func: Name("[]byte"), Params: []Expression{str}}
+	fn.Body = []Statement{Return{Values: []Expression{bytes, NIL}}}
+	return fn
+}
+
+func genUnmarshalText(mtyp *marshalerType, f *marshalerField) Function {
+	var (
+		m     = newMarshalMethod(mtyp, true)
+		recv  = m.receiver()
+		input = Name(m.scope.newIdent("input"))
+		value = f.access(Name(recv.Name))
+		typ   = f.origTyp
+		str   = CallFunction{Func: Name("string"), Params: []Expression{input}}
+	)
+	fn := Function{
+		Receiver:    recv,
+		Name:        "UnmarshalText",
+		ReturnTypes: Types{{TypeName: "error"}},
+		Parameters:  Types{{Name: input.Name, TypeName: "[]byte"}},
+	}
+	switch {
+	case isTextMarshaler(typ):
+		fn.Body = []Statement{Return{Values: []Expression{CallFunction{Func: Dotted{Receiver: value, Name: "UnmarshalText"}, Params: []Expression{input}}}}}
+		return fn
+	case isString(typ):
+		conv := CallFunction{Func: Name(types.TypeString(typ, mtyp.scope.qualify)), Params: []Expression{input}}
+		fn.Body = []Statement{Assign{Lhs: value, Rhs: conv}}
+	case isNumber(typ):
+		fn.Body = m.parseNumber(str, value, typ)
+	default:
+		var (
+			strconv = Name(m.scope.parent.packageName("strconv"))
+			b       = Name(m.scope.newIdent("b"))
+			conv    Expression
+		)
+		conv = b
+		if !types.Identical(typ, types.Typ[types.Bool]) {
+			conv = CallFunction{Func: Name(types.TypeString(typ, mtyp.scope.qualify)), Params: []Expression{b}}
+		}
+		parse := CallFunction{Func: Dotted{Receiver: strconv, Name: "ParseBool"}, Params: []Expression{str}}
+		fn.Body = append(m.parseChecked(b, parse), Assign{Lhs: value, Rhs: conv})
+	}
+	fn.Body = append(fn.Body, Return{Values: []Expression{NIL}})
+	return fn
+}
+
+// isTextMarshaler reports whether typ implements encoding.TextMarshaler
+// and *typ implements encoding.TextUnmarshaler.
+func isTextMarshaler(typ types.Type) bool {
+	var (
+		bytes = types.NewVar(token.NoPos, nil, "", types.NewSlice(types.Typ[types.Byte]))
+		err   = types.NewVar(token.NoPos, nil, "", types.Universe.Lookup("error").Type())
+	)
+	marshal := types.NewSignature(nil, nil, types.NewTuple(bytes, err), false)
+	return hasMethod(typ, "MarshalText", marshal) && isTextUnmarshaler(typ)
+}
+
+// isTextUnmarshaler reports whether *typ implements encoding.TextUnmarshaler.
+func isTextUnmarshaler(typ types.Type) bool {
+	var (
+		bytes = types.NewVar(token.NoPos, nil, "", types.NewSlice(types.Typ[types.Byte]))
+		err   = types.NewVar(token.NoPos, nil, "", types.Universe.Lookup("error").Type())
+	)
+	unmarshal := types.NewSignature(nil, types.NewTuple(bytes), types.NewTuple(err), false)
+	return hasMethod(types.NewPointer(typ), "UnmarshalText", unmarshal)
+}
+
+// hasMethod reports whether the method set of typ contains a method with the
+// given name and signature.
+func hasMethod(typ types.Type, name string, sig *types.Signature) bool {
+	sel := types.NewMethodSet(typ).Lookup(nil, name)
+	return sel != nil && types.Identical(sel.Type(), sig)
+}
@@ -0,0 +1,83 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"errors"
+	"fmt"
+	"go/types"
+	"time"
+
+	. "github.com/garslo/gogen"
+)
+
+// unixTimeFormats are the integer representations of the timeformat tag,
+// mapped to the method converting a time to the integer and the function of
+// package time converting it back.
+var unixTimeFormats = map[string]struct{ method, fn string }{
+	"unix":      {"Unix", "Unix"},
+	"unixmilli": {"UnixMilli", "UnixMilli"},
+	"unixmicro": {"UnixMicro", "UnixMicro"},
+	"unixnano":  {"UnixNano", "Unix"},
+}
+
+// lookupTimeFormat returns the representation of a time.Time field with the
+// timeformat tag. The format is rfc3339, one of unixTimeFormats or a layout
+// accepted by time.Format.
+func lookupTimeFormat(s *fileScope, typ types.Type, format string) (*wellKnownField, error) {
+	wk := new(wellKnownField)
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ, wk.ptr = ptr.Elem(), true
+	}
+	named, ok := typ.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil || named.Obj().Pkg().Path() != "time" || named.Obj().Name() != "Time" {
+		return nil, errors.New("timeformat is only supported on time.Time fields")
+	}
+	wk.named = named
+	if unix, ok := unixTimeFormats[format]; ok {
+		wk.wellKnownType = &wellKnownType{
+			imports: []string{"time"},
+			repr:    reprBasic(types.Int64),
+			encode:  methodCall(unix.method),
+			decode: func(m *marshalMethod, wk *wellKnownField, in, out Expression, field string) []Statement {
+				args := []Expression{in}
+				switch format {
+				case "unix":
+					args = []Expression{in, Int(0)}
+				case "unixnano":
+					args = []Expression{Int(0), in}
+				}
+				t := Name(m.scope.newIdent("t"))
+				return []Statement{
+					DeclareAndAssign{Lhs: t, Rhs: wk.call(m, unix.fn, args...)},
+					Assign{Lhs: out, Rhs: wk.adapt(t, false)},
+				}
+			},
+		}
+		return wk, nil
+	}
+
+	// Layouts without any element of the reference time are rejected because
+	// they can't hold a time.
+	layout := func() Expression { return stringLit{format} }
+	if format == "rfc3339" {
+		layout = func() Expression { return Dotted{Receiver: Name(s.packageName("time")), Name: "RFC3339Nano"} }
+	} else if ref := time.Date(1999, 12, 31, 23, 59, 58, 0, time.UTC); ref.Format(format) == format {
+		return nil, fmt.Errorf("invalid timeformat %q, expected rfc3339, unix, unixmilli, unixmicro, unixnano or a time layout", format)
+	}
+	wk.wellKnownType = &wellKnownType{
+		imports: []string{"time"},
+		repr:    reprBasic(types.String),
+		encode: func(wk *wellKnownField, v Expression) Expression {
+			return CallFunction{Func: Dotted{Receiver: v, Name: "Format"}, Params: []Expression{layout()}}
+		},
+		decode: func(m *marshalMethod, wk *wellKnownField, in, out Expression, field string) []Statement {
+			t := Name(m.scope.newIdent("t"))
+			s := m.parseChecked(t, wk.call(m, "Parse", layout(), in))
+			return append(s, Assign{Lhs: out, Rhs: wk.adapt(t, false)})
+		},
+	}
+	return wk, nil
+}
@@ -0,0 +1,192 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"go/types"
+
+	. "github.com/garslo/gogen"
+)
+
+// tomlPath is the TOML package whose Unmarshaler is implemented with -toml burntsushi.
+const tomlPath = "github.com/BurntSushi/toml"
+
+// With -toml burntsushi, UnmarshalTOML implements the Unmarshaler interface of
+// github.com/BurntSushi/toml, which passes the decoded value of the table instead
+// of a function decoding it. The value is encoded as TOML again and decoded into
+// the intermediate type, whose metadata also holds the unknown keys for -strict.
+//
+// MarshalTOML implements the Marshaler interface, whose result is written as is.
+// It is the whole document when the type is encoded at the top level, but the value
+// of the key when the type is nested, so the document of a nested value is decoded
+// into a table before the intermediate value is encoded.
+
+// genMarshalTOMLValue generates MarshalTOML() ([]byte, error).
+func genMarshalTOMLValue(mtyp *marshalerType) Function {
+	var (
+		m        = newMarshalMethod(mtyp, false)
+		recv     = m.receiver()
+		intertyp = m.intermediateType(m.intermediateName("toml"), "toml")
+		enc      = Name(m.scope.newIdent("enc"))
+		buf      = Name(m.scope.newIdent("buf"))
+		bytes    = Name(m.scope.parent.packageName("bytes"))
+		toml     = Name(m.scope.parent.packageName(tomlPath))
+	)
+	for i, f := range m.tomlFields() {
+		if m.isTOMLTable(f) {
+			intertyp.Fields[i].TypeName = "map[string]interface{}"
+		}
+	}
+	fn := Function{
+		Receiver:    recv,
+		Name:        "MarshalTOML",
+		ReturnTypes: Types{{TypeName: "[]byte"}, {TypeName: "error"}},
+		Body: []Statement{
+			declStmt{intertyp},
+			Declare{Name: enc.Name, TypeName: intertyp.Name},
+		},
+	}
+	for _, f := range m.tomlFields() {
+		if m.isTOMLTable(f) {
+			fn.Body = append(fn.Body, m.marshalTOMLTable(f, Name(recv.Name), enc)...)
+		} else {
+			fn.Body = append(fn.Body, m.marshalField(f, Name(recv.Name), enc, "toml")...)
+		}
+	}
+	encoder := CallFunction{Func: Dotted{Receiver: toml, Name: "NewEncoder"}, Params: []Expression{AddressOf{Value: buf}}}
+	fn.Body = append(fn.Body,
+		Declare{Name: buf.Name, TypeName: bytes.Name + ".Buffer"},
+		If{
+			Init:      DeclareAndAssign{Lhs: Name("err"), Rhs: CallFunction{Func: Dotted{Receiver: encoder, Name: "Encode"}, Params: []Expression{AddressOf{Value: enc}}}},
+			Condition: NotEqual{Lhs: Name("err"), Rhs: NIL},
+			Body:      []Statement{Return{Values: []Expression{NIL, Name("err")}}},
+		},
+		Return{Values: []Expression{CallFunction{Func: Dotted{Receiver: buf, Name: "Bytes"}}, NIL}},
+	)
+	return fn
+}
+
+// tomlFields returns the fields of the intermediate type of MarshalTOML.
+func (m *marshalMethod) tomlFields() (fields []*marshalerField) {
+	for _, f := range m.mtyp.Fields {
+		if f.inFormat("toml") && !(m.appendHooks && f.hooked("toml")) {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// isTOMLTable reports whether the field holds a value whose MarshalTOML returns a
+// document, which is decoded into a table of the intermediate value.
+func (m *marshalMethod) isTOMLTable(f *marshalerField) bool {
+	if f.function != nil || !types.Identical(f.typ, f.origTyp) {
+		return false
+	}
+	typ := f.typ
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+	named, ok := types.Unalias(typ).(*types.Named)
+	if !ok {
+		return false
+	}
+	if m.mtyp.tomlDocs[named.Obj()] {
+		return true
+	}
+	obj, _, _ := types.LookupFieldOrMethod(types.NewPointer(typ), true, m.mtyp.orig.Obj().Pkg(), "MarshalTOML")
+	method, ok := obj.(*types.Func)
+	if !ok {
+		return false
+	}
+	res := method.Type().(*types.Signature).Results()
+	return res.Len() == 2 && types.Identical(res.At(0).Type(), types.NewSlice(types.Typ[types.Byte]))
+}
+
+// marshalTOMLTable decodes the document of a nested value into the table to.
+func (m *marshalMethod) marshalTOMLTable(f *marshalerField, from, to Var) []Statement {
+	var (
+		access = f.access(from)
+		data   = Name(m.scope.newIdent("data"))
+		err    = Name("err")
+		toml   = Name(m.scope.parent.packageName(tomlPath))
+		decode = CallFunction{
+			Func:   Dotted{Receiver: toml, Name: "Decode"},
+			Params: []Expression{CallFunction{Func: Name("string"), Params: []Expression{data}}, AddressOf{Value: Dotted{Receiver: to, Name: f.name}}},
+		}
+		returnErr = []Statement{Return{Values: []Expression{NIL, err}}}
+	)
+	conv := []Statement{
+		declareMulti{Lhs: []Expression{data, err}, Rhs: CallFunction{Func: Dotted{Receiver: access, Name: "MarshalTOML"}}},
+		If{Condition: NotEqual{Lhs: err, Rhs: NIL}, Body: returnErr},
+		If{
+			Init:      declareMulti{Lhs: []Expression{Name("_"), err}, Rhs: decode},
+			Condition: NotEqual{Lhs: err, Rhs: NIL},
+			Body:      returnErr,
+		},
+	}
+	if isPointer(f.typ) {
+		return []Statement{If{Condition: NotEqual{Lhs: access, Rhs: NIL}, Body: conv}}
+	}
+	return conv
+}
+
+// genUnmarshalTOMLValue generates UnmarshalTOML(interface{}) error.
+func genUnmarshalTOMLValue(mtyp *marshalerType) Function {
+	var (
+		m        = newMarshalMethod(mtyp, true)
+		recv     = m.receiver()
+		input    = Name(m.scope.newIdent("input"))
+		intertyp = m.intermediateType(m.intermediateName("toml"), "toml")
+		dec      = Name(m.scope.newIdent("dec"))
+		buf      = Name(m.scope.newIdent("buf"))
+		md       = Name("_")
+		err      = Name("err")
+		bytes    = Name(m.scope.parent.packageName("bytes"))
+		toml     = Name(m.scope.parent.packageName(tomlPath))
+	)
+	if mtyp.strict {
+		md = Name(m.scope.newIdent("md"))
+	}
+	encoder := CallFunction{Func: Dotted{Receiver: toml, Name: "NewEncoder"}, Params: []Expression{AddressOf{Value: buf}}}
+	decoder := CallFunction{Func: Dotted{Receiver: toml, Name: "NewDecoder"}, Params: []Expression{AddressOf{Value: buf}}}
+	fn := Function{
+		Receiver:    recv,
+		Name:        "UnmarshalTOML",
+		Parameters:  Types{{Name: input.Name, TypeName: "interface{}"}},
+		ReturnTypes: Types{{TypeName: "error"}},
+		Body: []Statement{
+			declStmt{intertyp},
+			Declare{Name: dec.Name, TypeName: intertyp.Name},
+			Declare{Name: buf.Name, TypeName: bytes.Name + ".Buffer"},
+			errCheck(CallFunction{Func: Dotted{Receiver: encoder, Name: "Encode"}, Params: []Expression{input}}),
+			declareMulti{
+				Lhs: []Expression{md, err},
+				Rhs: CallFunction{Func: Dotted{Receiver: decoder, Name: "Decode"}, Params: []Expression{AddressOf{Value: dec}}},
+			},
+			If{Condition: NotEqual{Lhs: err, Rhs: NIL}, Body: []Statement{Return{Values: []Expression{err}}}},
+		},
+	}
+	if mtyp.strict {
+		fn.Body = append(fn.Body, m.checkUndecodedTOMLKeys(md)...)
+	}
+	fn.Body = append(fn.Body, m.unmarshalConversions(dec, Name(recv.Name), "toml")...)
+	fn.Body = append(fn.Body, m.unmarshalReturn(Name(recv.Name)))
+	return fn
+}
+
+// checkUndecodedTOMLKeys returns an error for the first key of the input which
+// wasn't decoded into a field. The input is encoded with sorted keys, so the first
+// unknown key in sorted order is reported.
+func (m *marshalMethod) checkUndecodedTOMLKeys(md Var) []Statement {
+	undecoded := Name(m.scope.newIdent("undecoded"))
+	first := CallFunction{Func: Dotted{Receiver: Index{Value: undecoded, Index: Int(0)}, Name: "String"}}
+	return []Statement{
+		If{
+			Init:      DeclareAndAssign{Lhs: undecoded, Rhs: CallFunction{Func: Dotted{Receiver: md, Name: "Undecoded"}}},
+			Condition: GreaterThan{Lhs: CallFunction{Func: Name("len"), Params: []Expression{undecoded}}, Rhs: Int(0)},
+			Body:      []Statement{Return{Values: []Expression{m.unknownFieldError(first)}}},
+		},
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+
+	. "github.com/garslo/gogen"
+)
+
+// codecerrPath is the package of the errors returned with -typed-errors.
+const codecerrPath = "github.com/fjl/gencodec/codecerr"
+
+// missingFieldError returns the error of an absent required field.
+func (m *marshalMethod) missingFieldError(f *marshalerField, format string) Expression {
+	var line Expression
+	if m.node != "" {
+		line = Dotted{Receiver: Name(m.node), Name: "Line"}
+	}
+	switch {
+	case !m.mtyp.typedErrors && line != nil:
+		return m.yamlLineError(f)
+	case !m.mtyp.typedErrors:
+		err := fmt.Sprintf("missing required field '%s' for %s", f.encodedName(format), m.mtyp.name)
+		errors := m.scope.parent.packageName("errors")
+		return CallFunction{Func: Dotted{Receiver: Name(errors), Name: "New"}, Params: []Expression{stringLit{err}}}
+	}
+	// The typed error holds the key of the input, which is also set for untagged fields.
+	key, _ := f.key(format)
+	return m.codecError("MissingFieldError", stringLit{key}, line)
+}
+
+// unknownFieldError returns the error of the unknown key.
+func (m *marshalMethod) unknownFieldError(key Expression) Expression {
+	if !m.mtyp.typedErrors {
+		fmt := Name(m.scope.parent.packageName("fmt"))
+		return CallFunction{
+			Func:   Dotted{Receiver: fmt, Name: "Errorf"},
+			Params: []Expression{stringLit{"unknown field '%s' for " + m.mtyp.name}, key},
+		}
+	}
+	return m.codecError("UnknownFieldError", key, nil)
+}
+
+// codecError creates a pointer to an error of package codecerr. The line of
+// the field in the input is set unless it is nil.
+func (m *marshalMethod) codecError(name string, field, line Expression) Expression {
+	pkg := m.scope.parent.packageName(codecerrPath)
+	lit := &ast.CompositeLit{
+		Type: Dotted{Receiver: Name(pkg), Name: name}.Expression(),
+		Elts: []ast.Expr{
+			&ast.KeyValueExpr{Key: ast.NewIdent("Type"), Value: stringLit{m.mtyp.name}.Expression()},
+			&ast.KeyValueExpr{Key: ast.NewIdent("Field"), Value: field.Expression()},
+		},
+	}
+	if line != nil {
+		lit.Elts = append(lit.Elts, &ast.KeyValueExpr{Key: ast.NewIdent("Line"), Value: line.Expression()})
+	}
+	return Thunk{Expr: &ast.UnaryExpr{Op: token.AND, X: lit}}
+}
+
+// checkUnknownJSONKeys returns an UnknownFieldError for the first unknown key of the
+// JSON input in sorted order. It runs before decoding in strict mode, where
+// json.Decoder would report unknown keys with an error of its own.
+func (m *marshalMethod) checkUnknownJSONKeys(input Var) []Statement {
+	var (
+		json = Name(m.scope.parent.packageName("encoding/json"))
+		keys = Name(m.scope.newIdent("keys"))
+	)
+	return append([]Statement{
+		Declare{Name: keys.Name, TypeName: "map[string]" + json.Name + ".RawMessage"},
+		errCheck(CallFunction{Func: Dotted{Receiver: json, Name: "Unmarshal"}, Params: []Expression{input, AddressOf{Value: keys}}}),
+	}, m.firstUnknownKey(keys, "json")...)
+}
@@ -0,0 +1,130 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var typeScriptIdent = regexp.MustCompile(`^[_$A-Za-z][_$0-9A-Za-z]*$`)
+
+// genTypeScript creates TypeScript interface declarations of the JSON encoding
+// of the marshaling types. The interfaces are derived from the JSON Schema
+// definitions, so keys, optionality and types match the -jsonschema output.
+// Nested struct types get interfaces of their own.
+func genTypeScript(mtyps []*marshalerType) ([]byte, error) {
+	c := newJSONSchemaConverter(mtyps, "")
+	defs, err := c.definitions(mtyps)
+	if err != nil {
+		return nil, err
+	}
+	w := new(bytes.Buffer)
+	fmt.Fprint(w, "// Code generated by github.com/fjl/gencodec. DO NOT EDIT.\n")
+	for _, def := range defs {
+		fmt.Fprintf(w, "\nexport interface %s {\n", typeScriptName(def.Name))
+		for _, prop := range def.Schema.Properties {
+			fmt.Fprintf(w, "  %s;\n", typeScriptProp(def.Schema, prop))
+		}
+		fmt.Fprintln(w, "}")
+	}
+	return w.Bytes(), nil
+}
+
+// typeScriptProp returns the declaration of an object property. Properties which
+// aren't required are optional.
+func typeScriptProp(obj *jsonSchema, prop jsonSchemaProp) string {
+	key := prop.Name
+	if !typeScriptIdent.MatchString(key) {
+		enc, _ := json.Marshal(key)
+		key = string(enc)
+	}
+	if !slices.Contains(obj.Required, prop.Name) {
+		key += "?"
+	}
+	return key + ": " + typeScriptType(prop.Schema)
+}
+
+// typeScriptType returns the TypeScript type of the values accepted by s.
+func typeScriptType(s *jsonSchema) string {
+	if s.Ref != "" {
+		return typeScriptName(s.Ref)
+	}
+	if len(s.AnyOf) > 0 {
+		var union []string
+		for _, alt := range s.AnyOf {
+			union = append(union, typeScriptType(alt))
+		}
+		return strings.Join(union, " | ")
+	}
+	if len(s.AllOf) > 0 {
+		var all []string
+		for _, part := range s.AllOf {
+			all = append(all, typeScriptType(part))
+		}
+		return strings.Join(all, " & ")
+	}
+	if s.Const != nil {
+		enc, _ := json.Marshal(s.Const)
+		return string(enc)
+	}
+	if len(s.Enum) > 0 {
+		var names []string
+		for _, name := range s.Enum {
+			enc, _ := json.Marshal(name)
+			names = append(names, string(enc))
+		}
+		return strings.Join(names, " | ")
+	}
+	switch t := s.Type.(type) {
+	case string:
+		return typeScriptBasic(s, t)
+	case []string:
+		var union []string
+		for _, name := range t {
+			union = append(union, typeScriptBasic(s, name))
+		}
+		return strings.Join(union, " | ")
+	}
+	return "unknown"
+}
+
+// typeScriptBasic returns the TypeScript type of the values of JSON type t
+// accepted by s.
+func typeScriptBasic(s *jsonSchema, t string) string {
+	switch t {
+	case "integer", "number":
+		return "number"
+	case "array":
+		elem := typeScriptType(s.Items)
+		if strings.Contains(elem, " | ") {
+			elem = "(" + elem + ")"
+		}
+		return elem + "[]"
+	case "object":
+		if values, ok := s.Additional.(*jsonSchema); ok {
+			return "Record<string, " + typeScriptType(values) + ">"
+		}
+		var props []string
+		for _, prop := range s.Properties {
+			props = append(props, typeScriptProp(s, prop))
+		}
+		if len(props) == 0 {
+			return "{}"
+		}
+		return "{ " + strings.Join(props, "; ") + " }"
+	}
+	return t // string, boolean, null
+}
+
+// typeScriptName returns the TypeScript name of a definition. Names qualified by
+// their package use an underscore instead of the dot.
+func typeScriptName(name string) string {
+	return strings.ReplaceAll(name, ".", "_")
+}
@@ -2,15 +2,21 @@
 // Use of this source code is governed by the MIT license,
 // which can be found in the LICENSE file.
 
-package main
+package gencodec
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
 	"go/types"
 	"io"
 	"sort"
 	"strconv"
+	"strings"
 )
 
 // walkNamedTypes runs the callback for all named types contained in the given type.
@@ -24,10 +30,18 @@ func walkNamedTypes(typ types.Type, callback func(*types.Named)) {
 		walkNamedTypes(typ.Elem(), callback)
 	case *types.Named:
 		callback(typ)
+		for i := 0; i < typ.TypeArgs().Len(); i++ {
+			walkNamedTypes(typ.TypeArgs().At(i), callback)
+		}
+	case *types.TypeParam:
 	case *types.Pointer:
 		walkNamedTypes(typ.Elem(), callback)
 	case *types.Slice:
 		walkNamedTypes(typ.Elem(), callback)
+	case *types.Array:
+		walkNamedTypes(typ.Elem(), callback)
+	case *types.Alias:
+		walkNamedTypes(types.Unalias(typ), callback)
 	case *types.Struct:
 		for i := 0; i < typ.NumFields(); i++ {
 			walkNamedTypes(typ.Field(i).Type(), callback)
@@ -41,6 +55,70 @@ func walkNamedTypes(typ types.Type, callback func(*types.Named)) {
 	}
 }
 
+// typeString is like types.TypeString, but writes struct tags
+// of anonymous struct types as raw string literals.
+func typeString(typ types.Type, qf types.Qualifier) string {
+	str := types.TypeString(typ, qf)
+	if !strings.Contains(str, "struct{") {
+		return str
+	}
+	expr, err := parser.ParseExpr(str)
+	if err != nil {
+		panic(fmt.Errorf("BUG: can't parse type %s: %v", str, err))
+	}
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if lit, ok := n.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+			tag, err := strconv.Unquote(lit.Value)
+			if err == nil && strconv.CanBackquote(tag) {
+				lit.Value = "`" + tag + "`"
+			}
+		}
+		return true
+	})
+	buf := new(bytes.Buffer)
+	printer.Fprint(buf, token.NewFileSet(), expr)
+	return buf.String()
+}
+
+// setStructTag sets the value of key in a struct tag, adding the key if it doesn't exist.
+func setStructTag(tag, key, value string) string {
+	var (
+		out   []string
+		found bool
+	)
+	for tag != "" {
+		// Skip leading space.
+		tag = strings.TrimLeft(tag, " ")
+		// Scan to colon and quoted value. This follows the parser in reflect.StructTag.
+		i := strings.Index(tag, ":\"")
+		if i <= 0 {
+			break
+		}
+		name := tag[:i]
+		j := i + 2
+		for j < len(tag) && tag[j] != '"' {
+			if tag[j] == '\\' {
+				j++
+			}
+			j++
+		}
+		if j >= len(tag) {
+			break
+		}
+		item := tag[:j+1]
+		tag = tag[j+1:]
+		if name == key {
+			item = fmt.Sprintf("%s:%q", key, value)
+			found = true
+		}
+		out = append(out, item)
+	}
+	if !found {
+		out = append(out, fmt.Sprintf("%s:%q", key, value))
+	}
+	return strings.Join(out, " ")
+}
+
 func lookupStructType(scope *types.Scope, name string) (*types.Named, error) {
 	typ, err := lookupType(scope, name)
 	if err != nil {
@@ -53,6 +131,34 @@ func lookupStructType(scope *types.Scope, name string) (*types.Named, error) {
 	return typ, nil
 }
 
+// lookupOverrideType finds the field override type name. Names of the form pkg.T
+// refer to type T of another package, given by its import path or by the name of
+// a package imported by pkg.
+func lookupOverrideType(imp types.Importer, pkg *types.Package, name string) (*types.Named, error) {
+	dot := strings.LastIndexByte(name, '.')
+	if dot == -1 {
+		return lookupStructType(pkg.Scope(), name)
+	}
+	qual, name := name[:dot], name[dot+1:]
+	var opkg *types.Package
+	for _, p := range pkg.Imports() {
+		if p.Path() == qual || p.Name() == qual {
+			opkg = p
+			break
+		}
+	}
+	if opkg == nil {
+		var err error
+		if opkg, err = imp.Import(qual); err != nil {
+			return nil, fmt.Errorf("can't import %q: %v", qual, err)
+		}
+	}
+	if opkg != pkg && !token.IsExported(name) {
+		return nil, fmt.Errorf("type %s of package %s is not exported", name, opkg.Path())
+	}
+	return lookupStructType(opkg.Scope(), name)
+}
+
 func lookupType(scope *types.Scope, name string) (*types.Named, error) {
 	obj := scope.Lookup(name)
 	if obj == nil {
@@ -96,6 +202,11 @@ func underlyingMap(typ types.Type) *types.Map {
 	}
 }
 
+func underlyingArray(typ types.Type) *types.Array {
+	arr, _ := typ.Underlying().(*types.Array)
+	return arr
+}
+
 func ensureNilCheckable(typ types.Type) types.Type {
 	orig := typ
 	named := false
@@ -124,31 +235,46 @@ func ensureNilCheckable(typ types.Type) types.Type {
 // returns nil if convertible and a descriptive error otherwise.
 // See package documentation for this definition of 'convertible'.
 func checkConvertible(from, to types.Type) error {
-	if types.ConvertibleTo(from, to) {
-		return nil
-	}
-	// Slices.
-	sfrom := underlyingSlice(from)
-	sto := underlyingSlice(to)
-	if sfrom != nil && sto != nil {
-		if !types.ConvertibleTo(sfrom.Elem(), sto.Elem()) {
-			return fmt.Errorf("slice element type %s is not convertible to %s", sfrom.Elem(), sto.Elem())
+	return checkConversion(from, to, false)
+}
+
+// checkConversion is like checkConvertible. If lengthChecked is set, slices may be
+// converted to arrays because the generated code checks their length. This is only
+// possible in unmarshaling methods, which can return an error.
+func checkConversion(from, to types.Type, lengthChecked bool) error {
+	var (
+		sfrom, sto = underlyingSlice(from), underlyingSlice(to)
+		afrom, ato = underlyingArray(from), underlyingArray(to)
+		mfrom, mto = underlyingMap(from), underlyingMap(to)
+	)
+	var kind string
+	var elemFrom, elemTo types.Type
+	switch {
+	case sfrom != nil && ato != nil:
+		if !lengthChecked {
+			return fmt.Errorf("slice type %s can't be converted to array type %s", from, to)
 		}
+		kind, elemFrom, elemTo = "slice", sfrom.Elem(), ato.Elem()
+	case types.ConvertibleTo(from, to):
 		return nil
-	}
-	// Maps.
-	mfrom := underlyingMap(from)
-	mto := underlyingMap(to)
-	if mfrom != nil && mto != nil {
+	case sfrom != nil && sto != nil:
+		kind, elemFrom, elemTo = "slice", sfrom.Elem(), sto.Elem()
+	case afrom != nil && sto != nil:
+		kind, elemFrom, elemTo = "array", afrom.Elem(), sto.Elem()
+	case afrom != nil && ato != nil && afrom.Len() == ato.Len():
+		kind, elemFrom, elemTo = "array", afrom.Elem(), ato.Elem()
+	case mfrom != nil && mto != nil:
 		if !types.ConvertibleTo(mfrom.Key(), mto.Key()) {
 			return fmt.Errorf("map key type %s is not convertible to %s", mfrom.Key(), mto.Key())
 		}
-		if !types.ConvertibleTo(mfrom.Elem(), mto.Elem()) {
-			return fmt.Errorf("map element type %s is not convertible to %s", mfrom.Elem(), mto.Elem())
-		}
-		return nil
+		kind, elemFrom, elemTo = "map", mfrom.Elem(), mto.Elem()
+	default:
+		return fmt.Errorf("type %s is not convertible to %s", from, to)
 	}
-	return fmt.Errorf("type %s is not convertible to %s", from, to)
+	if err := checkConversion(elemFrom, elemTo, lengthChecked); err != nil {
+		return fmt.Errorf("%s element type %s is not convertible to %s", kind, elemFrom, elemTo)
+	}
+	return nil
 }
 
 // fileScope tracks imports and other names at file scope.
@@ -168,8 +294,10 @@ func newFileScope(imp types.Importer, pkg *types.Package) *fileScope {
 func (s *fileScope) writeImportDecl(w io.Writer) {
 	fmt.Fprintln(w, "import (")
 	for _, pkg := range s.imports {
-		if s.importNames[pkg.Path()] != pkg.Name() {
-			fmt.Fprintf(w, "\t%s %q\n", s.importNames[pkg.Path()], pkg.Path())
+		// The name is written if it differs from the one goimports assumes for the
+		// path, because goimports drops imports it can't resolve and thinks unused.
+		if name := s.importNames[pkg.Path()]; name != pkg.Name() || name != assumedPackageName(pkg.Path()) {
+			fmt.Fprintf(w, "\t%s %q\n", name, pkg.Path())
 		} else {
 			fmt.Fprintf(w, "\t%q\n", pkg.Path())
 		}
@@ -177,6 +305,24 @@ func (s *fileScope) writeImportDecl(w io.Writer) {
 	fmt.Fprintln(w, ")")
 }
 
+// assumedPackageName returns the package name goimports assumes for an import path:
+// the last path element without a major version suffix and without a "go-" prefix,
+// up to the first character that isn't valid in an identifier.
+func assumedPackageName(path string) string {
+	elems := strings.Split(path, "/")
+	base := elems[len(elems)-1]
+	if len(elems) > 1 && len(base) > 1 && base[0] == 'v' && strings.Trim(base[1:], "0123456789") == "" {
+		base = elems[len(elems)-2]
+	}
+	base = strings.TrimPrefix(base, "go-")
+	if i := strings.IndexFunc(base, func(r rune) bool {
+		return !('a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9' || r == '_')
+	}); i >= 0 {
+		base = base[:i]
+	}
+	return base
+}
+
 // addImport loads a package and adds it to the import set.
 func (s *fileScope) addImport(path string) {
 	pkg, err := s.imp.Import(path)
@@ -187,6 +333,31 @@ func (s *fileScope) addImport(path string) {
 	s.rebuildImports()
 }
 
+// requireImport is like addImport, but returns an error if the package
+// can't be loaded. It is used for packages which may not be available.
+func (s *fileScope) requireImport(path string) error {
+	if _, err := s.imp.Import(path); err != nil {
+		return fmt.Errorf("can't import %q: %v", path, err)
+	}
+	s.addImport(path)
+	return nil
+}
+
+// importType loads a package and returns the named type declared in it.
+// The package is added to the import set.
+func (s *fileScope) importType(path, name string) types.Type {
+	pkg, err := s.imp.Import(path)
+	if err != nil {
+		panic(fmt.Errorf("can't import %q: %v", path, err))
+	}
+	obj := pkg.Scope().Lookup(name)
+	if obj == nil {
+		panic(fmt.Errorf("BUG: missing type %s.%s", path, name))
+	}
+	s.addReferences(obj.Type())
+	return obj.Type()
+}
+
 // addReferences marks all names referenced by typ as used.
 func (s *fileScope) addReferences(typ types.Type) {
 	walkNamedTypes(typ, func(nt *types.Named) {
@@ -207,7 +378,7 @@ func (s *fileScope) insertImport(pkg *types.Package) {
 	i := sort.Search(len(s.imports), func(i int) bool {
 		return s.imports[i].Path() >= pkg.Path()
 	})
-	if i < len(s.imports) && s.imports[i] == pkg {
+	if i < len(s.imports) && s.imports[i].Path() == pkg.Path() {
 		return
 	}
 	s.imports = append(s.imports[:i], append([]*types.Package{pkg}, s.imports[i:]...)...)
@@ -0,0 +1,290 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"io"
+	"strings"
+	"text/template"
+
+	. "github.com/garslo/gogen"
+)
+
+// unionKey is the key of the type name in the encoding of union values.
+const unionKey = "type"
+
+// unionTemplate generates the type holding the values of union fields in the
+// intermediate type. The value is encoded as an object with the type name added
+// to the keys of the value.
+var unionTemplate = template.Must(template.New("union").Parse(`
+// {{.Union}} holds the value of a field of union type {{.Type}}.
+type {{.Union}} struct{ v {{.Type}} }
+
+// MarshalJSON encodes the value with its type name in the "{{.Key}}" key.
+func ({{.U}} {{.Union}}) MarshalJSON() ([]byte, error) {
+	var {{.Name}} string
+	switch {{.U}}.v.(type) {
+	{{- range .Members}}
+	case {{.Type}}:
+		{{$.Name}} = {{printf "%q" .Name}}
+	{{- end}}
+	default:
+		return nil, {{.Fmt}}.Errorf("type %T is not in union {{.Type}}", {{.U}}.v)
+	}
+	{{.Value}}, {{.Err}} := {{.JSON}}.Marshal({{.U}}.v)
+	if {{.Err}} != nil {
+		return nil, {{.Err}}
+	}
+	if len({{.Value}}) < 2 || {{.Value}}[0] != '{' {
+		return nil, {{.Fmt}}.Errorf("value of union {{.Type}} has type %T, which isn't encoded as an object", {{.U}}.v)
+	}
+	{{.Enc}} := {{.Fmt}}.Appendf(nil, "{%q:%q", "{{.Key}}", {{.Name}})
+	if len({{.Value}}) > 2 {
+		{{.Enc}} = append({{.Enc}}, ',')
+	}
+	return append({{.Enc}}, {{.Value}}[1:]...), nil
+}
+
+// UnmarshalJSON decodes the value as the type named by the "{{.Key}}" key.
+func ({{.U}} *{{.Union}}) UnmarshalJSON({{.Input}} []byte) error {
+	var {{.Dec}} struct {
+		Type *string ` + "`json:\"{{.Key}}\"`" + `
+	}
+	if {{.Err}} := {{.JSON}}.Unmarshal({{.Input}}, &{{.Dec}}); {{.Err}} != nil {
+		return {{.Err}}
+	}
+	if {{.Dec}}.Type == nil {
+		return {{.Errors}}.New("missing key '{{.Key}}' of union {{.Type}}")
+	}
+	switch *{{.Dec}}.Type {
+	{{- range .Members}}
+	case {{printf "%q" .Name}}:
+		{{$.V}} := new({{.Elem}})
+		if {{$.Err}} := {{$.JSON}}.Unmarshal({{$.Input}}, {{$.V}}); {{$.Err}} != nil {
+			return {{$.Err}}
+		}
+		{{$.U}}.v = {{if not .Ptr}}*{{end}}{{$.V}}
+	{{- end}}
+	default:
+		return {{.Fmt}}.Errorf("unknown type %q of union {{.Type}}", *{{.Dec}}.Type)
+	}
+	return nil
+}
+`))
+
+// unionData is the input of unionTemplate.
+type unionData struct {
+	Type, Union, Key string
+	Members          []unionMemberData
+
+	// package and variable names
+	Errors, Fmt, JSON        string
+	U, Name, Value, Err, Enc string
+	Input, Dec, V            string
+}
+
+type unionMemberData struct {
+	Name, Type, Elem string
+	Ptr              bool
+}
+
+// unionType is an interface whose values have one of the member types.
+type unionType struct {
+	iface   *types.Named
+	members []unionMember
+}
+
+type unionMember struct {
+	name string // encoded type name
+	typ  types.Type
+}
+
+// parseUnions parses the unions of the -union flag. Unions are separated by
+// semicolons and written as Iface=T1,T2, where the interface and the member types
+// are declared in pkg. Members may be pointer types like *T1.
+func parseUnions(s string, pkg *types.Package) ([]*unionType, error) {
+	var unions []*unionType
+	for _, spec := range strings.Split(s, ";") {
+		if strings.TrimSpace(spec) == "" {
+			continue
+		}
+		name, list, ok := strings.Cut(spec, "=")
+		name = strings.TrimSpace(name)
+		if !ok || len(splitList(list)) == 0 {
+			return nil, fmt.Errorf("invalid union %q, expected Iface=T1,T2", spec)
+		}
+		iface, ok := lookupNamed(pkg, name)
+		if !ok || !types.IsInterface(iface) {
+			return nil, fmt.Errorf("union %s is not an interface type in %q", name, pkg.Path())
+		}
+		for _, u := range unions {
+			if u.iface == iface {
+				return nil, fmt.Errorf("duplicate union %s", name)
+			}
+		}
+		u := &unionType{iface: iface}
+		for _, elem := range splitList(list) {
+			typ, err := u.lookupMember(pkg, elem)
+			if err != nil {
+				return nil, err
+			}
+			m := unionMember{name: strings.TrimPrefix(elem, "*"), typ: typ}
+			for _, other := range u.members {
+				if other.name == m.name {
+					return nil, fmt.Errorf("duplicate type %s in union %s", m.name, name)
+				}
+			}
+			u.members = append(u.members, m)
+		}
+		unions = append(unions, u)
+	}
+	return unions, nil
+}
+
+// lookupMember finds a member type of the union, which must implement the interface.
+func (u *unionType) lookupMember(pkg *types.Package, name string) (types.Type, error) {
+	named, ok := lookupNamed(pkg, strings.TrimPrefix(name, "*"))
+	if !ok || types.IsInterface(named) {
+		return nil, fmt.Errorf("union %s: %s is not a concrete type in %q", u.iface.Obj().Name(), name, pkg.Path())
+	}
+	var typ types.Type = named
+	if strings.HasPrefix(name, "*") {
+		typ = types.NewPointer(named)
+	}
+	if !types.Implements(typ, u.iface.Underlying().(*types.Interface)) {
+		return nil, fmt.Errorf("union %s: %s does not implement %s", u.iface.Obj().Name(), name, u.iface.Obj().Name())
+	}
+	return typ, nil
+}
+
+func lookupNamed(pkg *types.Package, name string) (*types.Named, bool) {
+	obj, ok := pkg.Scope().Lookup(name).(*types.TypeName)
+	if !ok {
+		return nil, false
+	}
+	named, ok := obj.Type().(*types.Named)
+	return named, ok && named.TypeParams().Len() == 0
+}
+
+// loadUnionFields prepares the fields whose type is one of the union interfaces.
+func (mtyp *marshalerType) loadUnionFields(unions []*unionType) error {
+	for _, f := range mtyp.Fields {
+		if f.function != nil || f.dynamic {
+			continue
+		}
+		var union *unionType
+		for _, u := range unions {
+			if types.Identical(f.origTyp, u.iface) {
+				union = u
+			}
+		}
+		if union == nil {
+			continue
+		}
+		for _, format := range mtyp.formats {
+			if format != "json" {
+				return fmt.Errorf("%v: union field %s is not supported in format %s", mtyp.fs.Position(f.pos), f.name, format)
+			}
+		}
+		name := types.NewTypeName(token.NoPos, mtyp.orig.Obj().Pkg(), union.typeName(), nil)
+		f.typ = types.NewPointer(types.NewNamed(name, types.NewStruct(nil, nil), nil))
+		f.union = union
+		f.wellKnown = nil
+		mtyp.scope.addImport("fmt")
+	}
+	return nil
+}
+
+func (u *unionType) typeName() string {
+	return uncapitalize(u.iface.Obj().Name()) + "Union"
+}
+
+// genUnions writes the types holding the values of the union fields of mtyps.
+// Each union is written once, in the order of first use.
+func genUnions(w io.Writer, mtyps []*marshalerType) error {
+	seen := make(map[*unionType]bool)
+	for _, mtyp := range mtyps {
+		for _, f := range mtyp.Fields {
+			if f.union == nil || seen[f.union] {
+				continue
+			}
+			seen[f.union] = true
+			if err := genUnion(w, mtyp, f.union); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func genUnion(w io.Writer, mtyp *marshalerType, u *unionType) error {
+	scope := newFuncScope(mtyp.scope)
+	data := unionData{
+		Type:   types.TypeString(u.iface, mtyp.scope.qualify),
+		Union:  u.typeName(),
+		Key:    unionKey,
+		Errors: mtyp.scope.packageName("errors"),
+		Fmt:    mtyp.scope.packageName("fmt"),
+		JSON:   mtyp.scope.packageName("encoding/json"),
+		U:      scope.newIdent("u"),
+		Name:   scope.newIdent("name"),
+		Value:  scope.newIdent("value"),
+		Err:    scope.newIdent("err"),
+		Enc:    scope.newIdent("enc"),
+		Input:  scope.newIdent("input"),
+		Dec:    scope.newIdent("dec"),
+		V:      scope.newIdent("v"),
+	}
+	for _, m := range u.members {
+		md := unionMemberData{Name: m.name, Type: types.TypeString(m.typ, mtyp.scope.qualify)}
+		md.Elem = md.Type
+		if ptr, ok := m.typ.(*types.Pointer); ok {
+			md.Ptr = true
+			md.Elem = types.TypeString(ptr.Elem(), mtyp.scope.qualify)
+		}
+		data.Members = append(data.Members, md)
+	}
+	if err := mtyp.template(unionTemplate).Execute(w, data); err != nil {
+		return err
+	}
+	fmt.Fprintln(w)
+	return nil
+}
+
+// unionJSONSchema returns the JSON Schema of union values, which is one of the
+// schemas of the member types with the type name added.
+func (c *jsonSchemaConverter) unionJSONSchema(u *unionType) (*jsonSchema, error) {
+	schema := new(jsonSchema)
+	for _, m := range u.members {
+		typ := m.typ
+		if ptr, ok := typ.(*types.Pointer); ok {
+			typ = ptr.Elem()
+		}
+		value, err := c.schema(typ)
+		if err != nil {
+			return nil, fmt.Errorf("union %s: %v", u.iface.Obj().Name(), err)
+		}
+		name := &jsonSchema{
+			Type:       "object",
+			Properties: jsonSchemaProps{{unionKey, &jsonSchema{Const: m.name}}},
+			Required:   []string{unionKey},
+		}
+		schema.AnyOf = append(schema.AnyOf, &jsonSchema{AllOf: []*jsonSchema{name, value}})
+	}
+	return schema, nil
+}
+
+// marshalUnion stores the value of a union field in the intermediate value.
+// Nil values are left out.
+func (m *marshalMethod) marshalUnion(f *marshalerField, from, to Expression) []Statement {
+	wrap := Name(fmt.Sprintf("&%s{%s}", f.union.typeName(), printExpr(m.mtyp, from)))
+	return []Statement{If{
+		Condition: NotEqual{Lhs: from, Rhs: NIL},
+		Body:      []Statement{Assign{Lhs: to, Rhs: wrap}},
+	}}
+}
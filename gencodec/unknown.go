@@ -0,0 +1,246 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+
+	. "github.com/garslo/gogen"
+)
+
+// With -keep-unknown, the JSON and YAML methods keep the keys of the input which
+// don't belong to a field in a map[string]json.RawMessage field with the
+// gencodec:"unknown" option, and write them back when marshaling. JSON keys are
+// matched case-insensitively like encoding/json does, YAML keys exactly.
+
+// loadUnknownField removes the field with the unknown option from the marshaled
+// fields and keeps it as the destination of unknown keys.
+func (mtyp *marshalerType) loadUnknownField() error {
+	for i, f := range mtyp.Fields {
+		if f.function != nil || !f.hasOption("unknown") {
+			continue
+		}
+		if !mtyp.isRawMessageMap(f.origTyp) {
+			return fmt.Errorf("%v: field %s with the unknown option must have type map[string]json.RawMessage", mtyp.fs.Position(f.pos), f.name)
+		}
+		if mtyp.unknown != nil {
+			return fmt.Errorf("%v: more than one field with the unknown option in %s", mtyp.fs.Position(f.pos), mtyp.name)
+		}
+		mtyp.unknown = f
+		mtyp.Fields = append(mtyp.Fields[:i:i], mtyp.Fields[i+1:]...)
+		break
+	}
+	if mtyp.unknown == nil {
+		return fmt.Errorf(`type %s has no field for unknown keys, add one like: Unknown map[string]json.RawMessage `+"`"+`gencodec:"unknown"`+"`", mtyp.name)
+	}
+	for _, path := range []string{"encoding/json", "sort", "strings"} {
+		mtyp.scope.addImport(path)
+	}
+	return nil
+}
+
+// charLit is a rune literal.
+func charLit(c rune) Expression {
+	return Thunk{Expr: &ast.BasicLit{Kind: token.CHAR, Value: strconv.QuoteRune(c)}}
+}
+
+// isRawMessageMap reports whether typ is map[string]json.RawMessage. Types are
+// compared by name because RawMessage may be an alias of a type in another package,
+// loaded separately by the importer.
+func (mtyp *marshalerType) isRawMessageMap(typ types.Type) bool {
+	m, ok := typ.Underlying().(*types.Map)
+	if !ok || !isString(m.Key()) {
+		return false
+	}
+	json, err := mtyp.scope.imp.Import("encoding/json")
+	if err != nil {
+		return false
+	}
+	want, ok1 := types.Unalias(json.Scope().Lookup("RawMessage").Type()).(*types.Named)
+	elem, ok2 := types.Unalias(m.Elem()).(*types.Named)
+	return ok1 && ok2 && elem.Obj().Pkg() != nil &&
+		elem.Obj().Pkg().Path() == want.Obj().Pkg().Path() && elem.Obj().Name() == want.Obj().Name()
+}
+
+// knownKeys returns the keys of the fields in format. JSON keys are lowercased.
+func (m *marshalMethod) knownKeys(format string) []Expression {
+	var (
+		keys []Expression
+		seen = make(map[string]bool)
+	)
+	for _, f := range m.mtyp.Fields {
+		k, ok := f.key(format)
+		if format == "json" {
+			k = strings.ToLower(k)
+		}
+		if ok && !seen[k] {
+			seen[k] = true
+			keys = append(keys, stringLit{k})
+		}
+	}
+	return keys
+}
+
+// skipKnown continues the enclosing loop if key belongs to a field.
+func (m *marshalMethod) skipKnown(key Expression, format string) []Statement {
+	known := m.knownKeys(format)
+	if len(known) == 0 {
+		return nil
+	}
+	if format == "json" {
+		strs := Name(m.scope.parent.packageName("strings"))
+		key = CallFunction{Func: Dotted{Receiver: strs, Name: "ToLower"}, Params: []Expression{key}}
+	}
+	return []Statement{switchCase{Tag: key, Values: known, Body: []Statement{Thunk{Stmt: &ast.BranchStmt{Tok: token.CONTINUE}}}}}
+}
+
+// collectUnknownJSON stores the unknown keys of the JSON input in the unknown field.
+func (m *marshalMethod) collectUnknownJSON(input, recv Var) []Statement {
+	var (
+		json    = Name(m.scope.parent.packageName("encoding/json"))
+		raw     = Name(m.scope.newIdent("raw"))
+		k       = Name(m.scope.newIdent("k"))
+		v       = Name(m.scope.newIdent("v"))
+		unknown = m.mtyp.unknown.access(recv)
+		typ     = typeString(m.mtyp.unknown.origTyp, m.mtyp.scope.qualify)
+	)
+	body := m.skipKnown(k, "json")
+	body = append(body,
+		If{
+			Condition: Equals{Lhs: unknown, Rhs: NIL},
+			Body:      []Statement{Assign{Lhs: unknown, Rhs: CallFunction{Func: Name("make"), Params: []Expression{Name(typ)}}}},
+		},
+		Assign{Lhs: Index{Value: unknown, Index: k}, Rhs: v},
+	)
+	return []Statement{
+		Declare{Name: raw.Name, TypeName: "map[string]" + json.Name + ".RawMessage"},
+		errCheck(CallFunction{Func: Dotted{Receiver: json, Name: "Unmarshal"}, Params: []Expression{input, AddressOf{Value: raw}}}),
+		Assign{Lhs: unknown, Rhs: NIL},
+		Range{Key: k, Value: v, RangeValue: raw, Body: body},
+	}
+}
+
+// returnWithUnknownJSON returns the JSON encoding of enc with the unknown keys
+// appended, in sorted order. Keys belonging to a field are skipped.
+func (m *marshalMethod) returnWithUnknownJSON(enc, recv Var) []Statement {
+	var (
+		json    = Name(m.scope.parent.packageName("encoding/json"))
+		sort    = Name(m.scope.parent.packageName("sort"))
+		data    = Name(m.scope.newIdent("data"))
+		keys    = Name(m.scope.newIdent("keys"))
+		k       = Name(m.scope.newIdent("k"))
+		kjson   = Name(m.scope.newIdent("kjson"))
+		vjson   = Name(m.scope.newIdent("vjson"))
+		err     = Name("err")
+		unknown = m.mtyp.unknown.access(recv)
+		length  = func(e Expression) Expression { return CallFunction{Func: Name("len"), Params: []Expression{e}} }
+		appendf = func(e ...Expression) Expression { return CallFunction{Func: Name("append"), Params: e} }
+		concat  = func(x, y Expression) Expression {
+			return Thunk{Expr: &ast.CallExpr{Fun: ast.NewIdent("append"), Args: []ast.Expr{x.Expression(), y.Expression()}, Ellipsis: 1}}
+		}
+		marshal = func(e Expression) Expression {
+			return CallFunction{Func: Dotted{Receiver: json, Name: "Marshal"}, Params: []Expression{e}}
+		}
+		fail = If{Condition: NotEqual{Lhs: err, Rhs: NIL}, Body: []Statement{Return{Values: []Expression{NIL, err}}}}
+	)
+	collect := Range{Key: k, RangeValue: unknown, Body: append(
+		m.skipKnown(k, "json"),
+		Assign{Lhs: keys, Rhs: appendf(keys, k)},
+	)}.Statement().(*ast.RangeStmt)
+	collect.Value = nil // range over the keys only
+	return []Statement{
+		declareMulti{Lhs: []Expression{data, err}, Rhs: marshal(AddressOf{Value: enc})},
+		If{
+			Condition: binaryExpr{Op: token.LOR, X: NotEqual{Lhs: err, Rhs: NIL}, Y: Equals{Lhs: length(unknown), Rhs: Int(0)}},
+			Body:      []Statement{Return{Values: []Expression{data, err}}},
+		},
+		DeclareAndAssign{Lhs: keys, Rhs: CallFunction{Func: Name("make"), Params: []Expression{Name("[]string"), Int(0), length(unknown)}}},
+		Thunk{Stmt: collect},
+		CallFunction{Func: Dotted{Receiver: sort, Name: "Strings"}, Params: []Expression{keys}},
+		Assign{Lhs: data, Rhs: Thunk{Expr: &ast.SliceExpr{X: data.Expression(), High: binaryExpr{Op: token.SUB, X: length(data), Y: Int(1)}.Expression()}}},
+		Range{Value: k, RangeValue: keys, Body: []Statement{
+			If{Condition: GreaterThan{Lhs: length(data), Rhs: Int(1)}, Body: []Statement{Assign{Lhs: data, Rhs: appendf(data, charLit(','))}}},
+			declareMulti{Lhs: []Expression{kjson, err}, Rhs: marshal(k)},
+			fail,
+			declareMulti{Lhs: []Expression{vjson, err}, Rhs: marshal(Index{Value: unknown, Index: k})},
+			fail,
+			Assign{Lhs: data, Rhs: concat(data, kjson)},
+			Assign{Lhs: data, Rhs: appendf(data, charLit(':'))},
+			Assign{Lhs: data, Rhs: concat(data, vjson)},
+		}},
+		Return{Values: []Expression{appendf(data, charLit('}')), NIL}},
+	}
+}
+
+// unknownYAMLField is the field of the YAML intermediate types holding the
+// unknown keys. It is named like the unknown field.
+func (m *marshalMethod) unknownYAMLField() Field {
+	return Field{Name: m.mtyp.unknown.name, TypeName: "map[string]interface{}", Tag: `yaml:",inline"`}
+}
+
+// collectUnknownYAML converts the unknown keys decoded from YAML to JSON.
+func (m *marshalMethod) collectUnknownYAML(dec, recv Var) []Statement {
+	var (
+		json    = Name(m.scope.parent.packageName("encoding/json"))
+		k       = Name(m.scope.newIdent("k"))
+		v       = Name(m.scope.newIdent("v"))
+		raw     = Name(m.scope.newIdent("raw"))
+		err     = Name("err")
+		unknown = m.mtyp.unknown.access(recv)
+		from    = Dotted{Receiver: dec, Name: m.mtyp.unknown.name}
+		typ     = typeString(m.mtyp.unknown.origTyp, m.mtyp.scope.qualify)
+	)
+	return []Statement{
+		Assign{Lhs: unknown, Rhs: NIL},
+		If{
+			Condition: GreaterThan{Lhs: CallFunction{Func: Name("len"), Params: []Expression{from}}, Rhs: Int(0)},
+			Body: []Statement{
+				Assign{Lhs: unknown, Rhs: CallFunction{Func: Name("make"), Params: []Expression{Name(typ)}}},
+				Range{Key: k, Value: v, RangeValue: from, Body: []Statement{
+					declareMulti{Lhs: []Expression{raw, err}, Rhs: CallFunction{Func: Dotted{Receiver: json, Name: "Marshal"}, Params: []Expression{v}}},
+					If{Condition: NotEqual{Lhs: err, Rhs: NIL}, Body: []Statement{Return{Values: []Expression{err}}}},
+					Assign{Lhs: Index{Value: unknown, Index: k}, Rhs: raw},
+				}},
+			},
+		},
+	}
+}
+
+// convertUnknownYAML converts the unknown keys to YAML values of the intermediate
+// value enc. Keys belonging to a field are skipped.
+func (m *marshalMethod) convertUnknownYAML(enc, recv Var) []Statement {
+	var (
+		json    = Name(m.scope.parent.packageName("encoding/json"))
+		k       = Name(m.scope.newIdent("k"))
+		v       = Name(m.scope.newIdent("v"))
+		val     = Name(m.scope.newIdent("val"))
+		unknown = m.mtyp.unknown.access(recv)
+		to      = Dotted{Receiver: enc, Name: m.mtyp.unknown.name}
+	)
+	body := m.skipKnown(k, "yaml")
+	body = append(body,
+		Declare{Name: val.Name, TypeName: "interface{}"},
+		If{
+			Init:      DeclareAndAssign{Lhs: Name("err"), Rhs: CallFunction{Func: Dotted{Receiver: json, Name: "Unmarshal"}, Params: []Expression{v, AddressOf{Value: val}}}},
+			Condition: NotEqual{Lhs: Name("err"), Rhs: NIL},
+			Body:      []Statement{Return{Values: []Expression{NIL, Name("err")}}},
+		},
+		Assign{Lhs: Index{Value: to, Index: k}, Rhs: val},
+	)
+	return []Statement{
+		If{
+			Condition: GreaterThan{Lhs: CallFunction{Func: Name("len"), Params: []Expression{unknown}}, Rhs: Int(0)},
+			Body: []Statement{
+				Assign{Lhs: to, Rhs: CallFunction{Func: Name("make"), Params: []Expression{Name("map[string]interface{}")}}},
+				Range{Key: k, Value: v, RangeValue: unknown, Body: body},
+			},
+		},
+	}
+}
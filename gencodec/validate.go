@@ -0,0 +1,49 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"fmt"
+	"go/types"
+
+	. "github.com/garslo/gogen"
+)
+
+// defaultValidateMethod is the validation method called by the unmarshaling methods
+// if the type has it.
+const defaultValidateMethod = "ValidateAfterDecode"
+
+// loadValidateMethod looks up the method which validates the type after decoding. If
+// name is empty, the default method is used when the type has one. A method named
+// explicitly must exist.
+func (mtyp *marshalerType) loadValidateMethod(name string) error {
+	explicit := name != ""
+	if !explicit {
+		name = defaultValidateMethod
+	}
+	obj, _, _ := types.LookupFieldOrMethod(types.NewPointer(mtyp.orig), false, mtyp.orig.Obj().Pkg(), name)
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		if explicit {
+			return fmt.Errorf("type %s has no validation method %s", mtyp.name, name)
+		}
+		return nil
+	}
+	sig := fn.Type().(*types.Signature)
+	if sig.Params().Len() != 0 || sig.Results().Len() != 1 || !types.Identical(sig.Results().At(0).Type(), types.Universe.Lookup("error").Type()) {
+		return fmt.Errorf("validation method %s of %s must have signature func() error", name, mtyp.name)
+	}
+	mtyp.validate = name
+	return nil
+}
+
+// unmarshalReturn ends an unmarshaling method into recv. It returns the error of the
+// validation method if the type has one.
+func (m *marshalMethod) unmarshalReturn(recv Var) Statement {
+	if m.mtyp.validate == "" {
+		return Return{Values: []Expression{NIL}}
+	}
+	return Return{Values: []Expression{CallFunction{Func: Dotted{Receiver: recv, Name: m.mtyp.validate}}}}
+}
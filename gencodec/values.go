@@ -0,0 +1,240 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"fmt"
+	"go/types"
+	"io"
+	"reflect"
+	"text/template"
+
+	. "github.com/garslo/gogen"
+)
+
+// The values helper encodes the fields with a url or form struct tag as url.Values.
+// The url tag takes precedence if a field has both. Slice fields have a value for each
+// element, other fields a single value.
+
+// valuesTemplate generates DecodeValues. Each field is parsed by its own function,
+// which decode calls if the key is present.
+var valuesTemplate = template.Must(template.New("values").Parse(`
+// DecodeValues sets the fields of {{.Recv}} with a url or form tag from {{.Vals}}, such as
+// the query parameters of a request. Absent keys of required fields are an error. Fields
+// of absent optional keys get their default value or are left unchanged. Slice fields
+// take all values of their key, other fields the first one.
+func ({{.Recv}} *{{.RecvType}}) DecodeValues({{.Vals}} {{.URL}}.Values) error {
+	{{.Decode}} := func({{.Key}} string, {{.Parse}} func([]string) error) (bool, error) {
+		{{.VS}} := {{.Vals}}[{{.Key}}]
+		if len({{.VS}}) == 0 {
+			return false, nil
+		}
+		if {{.Err}} := {{.Parse}}({{.VS}}); {{.Err}} != nil {
+			return true, {{.Fmt}}.Errorf("invalid value of %s for {{.Type}}: %v", {{.Key}}, {{.Err}})
+		}
+		return true, nil
+	}
+	{{- range .Fields}}
+	if {{if .Absent}}{{$.Ok}}{{else}}_{{end}}, {{$.Err}} := {{$.Decode}}({{printf "%q" .Key}}, func({{$.VS}} []string) error {
+		{{.Parse}}
+		return nil
+	}); {{$.Err}} != nil {
+		return {{$.Err}}
+	}{{if .Absent}} else if !{{$.Ok}} {
+		{{.Absent}}
+	}{{end}}
+	{{- end}}
+	{{.Return}}
+}
+`))
+
+type valuesData struct {
+	Type, RecvType string
+	Fields         []valuesField
+	Return         string
+
+	// package and variable names
+	Fmt, URL                string
+	Recv, Vals, Decode, Key string
+	Parse, VS, Ok, Err      string
+}
+
+type valuesField struct {
+	Key    string
+	Parse  string // statements assigning the field from the values
+	Absent string // statements run if the key is absent, empty if none
+}
+
+// valuesFields returns the fields with a url or form tag and the format of their key.
+func (mtyp *marshalerType) valuesFields() (fields []*marshalerField, formats []string) {
+	for _, f := range mtyp.Fields {
+		if f.function != nil {
+			continue
+		}
+		for _, format := range []string{"url", "form"} {
+			if _, ok := reflect.StructTag(f.tag).Lookup(format); !ok {
+				continue
+			}
+			if f.inFormat(format) {
+				fields = append(fields, f)
+				formats = append(formats, format)
+			}
+			break
+		}
+	}
+	return fields, formats
+}
+
+// genValues writes the EncodeValues and DecodeValues methods of mtyp.
+func genValues(w io.Writer, mtyp *marshalerType) error {
+	fields, formats := mtyp.valuesFields()
+	if len(fields) == 0 {
+		return fmt.Errorf("type %s has no fields with a url or form tag", mtyp.name)
+	}
+	enc, err := genEncodeValues(mtyp, fields, formats)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "// EncodeValues returns the fields of %s with a url or form tag as URL values.\n", enc.Receiver.Name)
+	fmt.Fprintf(w, "// Nil pointers and fields with omitempty holding their zero value are left out.\n")
+	writeFunction(w, mtyp.fs, enc)
+	return genDecodeValues(w, mtyp, fields, formats)
+}
+
+// genEncodeValues generates the EncodeValues method.
+func genEncodeValues(mtyp *marshalerType, fields []*marshalerField, formats []string) (Function, error) {
+	var (
+		m    = newMarshalMethod(mtyp, false)
+		recv = m.receiver()
+		url  = m.scope.parent.packageName("net/url")
+		vals = Name(m.scope.newIdent("vals"))
+	)
+	fn := Function{
+		Receiver:    recv,
+		Name:        "EncodeValues",
+		ReturnTypes: Types{{TypeName: url + ".Values"}},
+		Body: []Statement{
+			DeclareAndAssign{Lhs: vals, Rhs: CallFunction{Func: Name("make"), Params: []Expression{Name(url + ".Values")}}},
+		},
+	}
+	for i, f := range fields {
+		key, _ := f.key(formats[i])
+		add := func(str Expression) Statement {
+			return CallFunction{Func: Dotted{Receiver: vals, Name: "Add"}, Params: []Expression{stringLit{key}, str}}
+		}
+		if slice := underlyingSlice(f.origTyp); slice != nil && !isTextUnmarshaler(f.origTyp) {
+			elem := Name(m.scope.newIdent("v"))
+			str, err := m.stringValue(f, elem, slice.Elem())
+			if err != nil {
+				return fn, fmt.Errorf("%v: field %s: %v", mtyp.fs.Position(f.pos), f.name, err)
+			}
+			fn.Body = append(fn.Body, Range{
+				Key:        Name("_"),
+				Value:      elem,
+				RangeValue: f.access(Name(recv.Name)),
+				Body:       []Statement{add(str)},
+			})
+			continue
+		}
+		str, cond, err := m.fieldString(f, Name(recv.Name))
+		if err != nil {
+			return fn, err
+		}
+		if cond == nil && f.hasTagOption(formats[i], "omitempty") {
+			zero, err := promptZero(m, f.access(Name(recv.Name)), f.origTyp)
+			if err != nil {
+				return fn, fmt.Errorf("%v: field %s: %v", mtyp.fs.Position(f.pos), f.name, err)
+			}
+			cond = negate(zero)
+		}
+		if cond == nil {
+			fn.Body = append(fn.Body, add(str))
+			continue
+		}
+		fn.Body = append(fn.Body, If{Condition: cond, Body: []Statement{add(str)}})
+	}
+	fn.Body = append(fn.Body, Return{Values: []Expression{vals}})
+	return fn, nil
+}
+
+// genDecodeValues writes the DecodeValues method.
+func genDecodeValues(w io.Writer, mtyp *marshalerType, fields []*marshalerField, formats []string) error {
+	var (
+		m    = newMarshalMethod(mtyp, true)
+		recv = m.receiver().Name
+	)
+	data := valuesData{
+		Type:     mtyp.name,
+		RecvType: mtyp.recvType(),
+		Fmt:      mtyp.scope.packageName("fmt"),
+		URL:      mtyp.scope.packageName("net/url"),
+		Recv:     recv,
+		Vals:     m.scope.newIdent("vals"),
+		Decode:   m.scope.newIdent("decode"),
+		Key:      m.scope.newIdent("key"),
+		Parse:    m.scope.newIdent("parse"),
+		VS:       m.scope.newIdent("vs"),
+		Ok:       m.scope.newIdent("ok"),
+		Err:      m.scope.newIdent("err"),
+		Return:   printStatements(mtyp, []Statement{m.unmarshalReturn(Name(recv))}),
+	}
+	for i, f := range fields {
+		key, _ := f.key(formats[i])
+		// Each field is parsed in its own function, which has a fresh scope.
+		fm := newMarshalMethod(mtyp, true)
+		fm.receiver()
+		fm.scope.used[data.VS] = true
+		parse, err := parseValues(fm, f.access(Name(recv)), f.origTyp, Name(data.VS))
+		if err != nil {
+			return fmt.Errorf("%v: field %s: %v", mtyp.fs.Position(f.pos), f.name, err)
+		}
+		vf := valuesField{Key: key, Parse: printStatements(mtyp, parse)}
+		switch {
+		case f.isRequired(formats[i]):
+			vf.Absent = printStatements(mtyp, []Statement{Return{Values: []Expression{m.missingFieldError(f, formats[i])}}})
+		case f.defValue != nil:
+			vf.Absent = printStatements(mtyp, []Statement{Assign{Lhs: f.access(Name(recv)), Rhs: f.defValue}})
+		}
+		data.Fields = append(data.Fields, vf)
+	}
+	return mtyp.template(valuesTemplate).Execute(w, data)
+}
+
+// parseValues returns statements which parse the values vs and assign them to value.
+// Slices are replaced by a new slice of all values, other types take the first value.
+func parseValues(m *marshalMethod, value Expression, typ types.Type, vs Expression) ([]Statement, error) {
+	slice := underlyingSlice(typ)
+	if slice == nil || isTextUnmarshaler(typ) {
+		return parseString(m, value, typ, Index{Value: vs, Index: Int(0)})
+	}
+	var (
+		list = Name(m.scope.newIdent("list"))
+		i    = Name(m.scope.newIdent("i"))
+		s    = Name(m.scope.newIdent("s"))
+	)
+	parse, err := parseString(m, Index{Value: list, Index: i}, slice.Elem(), s)
+	if err != nil {
+		return nil, err
+	}
+	return []Statement{
+		DeclareAndAssign{Lhs: list, Rhs: CallFunction{
+			Func:   Name("make"),
+			Params: []Expression{Name(types.TypeString(typ, m.mtyp.scope.qualify)), CallFunction{Func: Name("len"), Params: []Expression{vs}}},
+		}},
+		Range{Key: i, Value: s, RangeValue: vs, Body: parse},
+		Assign{Lhs: value, Rhs: list},
+	}, nil
+}
+
+// negate returns the negation of the condition returned by promptZero.
+func negate(cond Expression) Expression {
+	switch cond := cond.(type) {
+	case Not:
+		return cond.Value
+	case Equals:
+		return NotEqual{Lhs: cond.Lhs, Rhs: cond.Rhs}
+	}
+	return Not{Value: cond}
+}
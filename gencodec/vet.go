@@ -0,0 +1,183 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"go/types"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// gencodecOptions are the known options of the gencodec struct tag.
+var gencodecOptions = map[string]bool{
+	"required":       true,
+	"optional":       true,
+	"nobuiltin":      true,
+	"omitzero":       true,
+	"precision":      true,
+	"scale":          true,
+	"currency":       true,
+	"currencyobject": true,
+	"flatten":        true,
+	"value":          true,
+	"discriminator":  true,
+	"dynamic":        true,
+	"export":         true,
+	"conv":           true,
+	"hook":           true,
+	"unknown":        true,
+}
+
+// Vet checks the struct tags of all struct types in the package in dir.
+// It returns the problems found, prefixed by their position.
+func Vet(dir string) ([]string, error) {
+	cfg := Config{Dir: dir, FileSet: token.NewFileSet()}
+	pkg, err := loadPackage(&cfg)
+	if err != nil {
+		return nil, err
+	}
+	return vetPackage(cfg.FileSet, pkg), nil
+}
+
+// vetPackage checks the named struct types of pkg.
+func vetPackage(fs *token.FileSet, pkg *types.Package) (problems []string) {
+	scope := pkg.Scope()
+	for _, name := range scope.Names() {
+		tn, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || tn.IsAlias() {
+			continue
+		}
+		if styp, ok := tn.Type().Underlying().(*types.Struct); ok {
+			problems = append(problems, vetStruct(fs, name, styp)...)
+		}
+	}
+	return problems
+}
+
+// vetStruct checks the struct tags of the fields of styp.
+func vetStruct(fs *token.FileSet, name string, styp *types.Struct) (problems []string) {
+	report := func(pos token.Pos, format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf("%v: %s", fs.Position(pos), fmt.Sprintf(format, args...)))
+	}
+	var fields []*marshalerField
+	for i := 0; i < styp.NumFields(); i++ {
+		f := styp.Field(i)
+		mf := &marshalerField{name: f.Name(), tag: styp.Tag(i), origTyp: f.Type(), pos: f.Pos()}
+		if err := checkStructTag(mf.tag); err != nil {
+			report(f.Pos(), "field %s has invalid struct tag: %v", f.Name(), err)
+			continue
+		}
+		opts := strings.Split(reflect.StructTag(mf.tag).Get("gencodec"), ",")
+		for j := 0; j < len(opts); j++ {
+			k, _, _ := strings.Cut(strings.TrimSpace(opts[j]), "=")
+			switch {
+			case k == "conv" || k == "hook":
+				j++ // the second function of conv=to,from and hook=append,parse
+			case k != "" && !gencodecOptions[k]:
+				report(f.Pos(), "field %s has unknown gencodec option %q", f.Name(), k)
+			}
+		}
+		if !f.Exported() || f.Anonymous() {
+			continue
+		}
+		fields = append(fields, mf)
+
+		jsonKey, _, _ := strings.Cut(mf.formatTag("json"), ",")
+		yamlKey, _, _ := strings.Cut(mf.formatTag("yaml"), ",")
+		if mf.hasKey("json") && mf.hasKey("yaml") && jsonKey != yamlKey {
+			report(f.Pos(), "field %s has json key %q but yaml key %q", f.Name(), jsonKey, yamlKey)
+		}
+		if mf.hasTagOption("json", "omitempty") && !mf.hasOption("required") && (isNumber(f.Type()) || isBool(f.Type())) {
+			report(f.Pos(), "optional field %s has omitempty, absence can't be distinguished from the zero value", f.Name())
+		}
+		if mf.hasTagOption("json", "omitempty") && mf.hasOption("required") {
+			report(f.Pos(), "required field %s has omitempty, it is treated as optional", f.Name())
+		}
+	}
+
+	// Check for duplicate keys in all formats used by the struct.
+	for _, format := range AllFormats {
+		if format != "json" && !anyHasTag(fields, format) {
+			continue
+		}
+		seen := make(map[string]*marshalerField)
+		for _, mf := range fields {
+			key, ok := mf.key(format)
+			if !ok {
+				continue
+			}
+			if other := seen[key]; other != nil {
+				report(mf.pos, "fields %s and %s of %s have the same %s key %q", other.name, mf.name, name, format, key)
+			}
+			seen[key] = mf
+		}
+	}
+	return problems
+}
+
+func anyHasTag(fields []*marshalerField, format string) bool {
+	for _, mf := range fields {
+		if _, ok := reflect.StructTag(mf.tag).Lookup(format); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func hasTagOption(opts, name string) bool {
+	for _, o := range strings.Split(opts, ",") {
+		if o == name {
+			return true
+		}
+	}
+	return false
+}
+
+// checkStructTag verifies that tag is a space-separated list of key:"value"
+// pairs, the conventional format parsed by reflect.StructTag.
+func checkStructTag(tag string) error {
+	for tag != "" {
+		tag = strings.TrimLeft(tag, " ")
+		if tag == "" {
+			break
+		}
+		i := 0
+		for i < len(tag) && tag[i] > ' ' && tag[i] != ':' && tag[i] != '"' && tag[i] != 0x7f {
+			i++
+		}
+		switch {
+		case i == 0:
+			return errors.New("bad syntax for struct tag key")
+		case i+1 >= len(tag) || tag[i] != ':':
+			return fmt.Errorf("missing colon after key %q", tag[:i])
+		case tag[i+1] != '"':
+			return fmt.Errorf("value of key %q is not quoted", tag[:i])
+		}
+		key := tag[:i]
+		tag = tag[i+1:]
+		i = 1
+		for i < len(tag) && tag[i] != '"' {
+			if tag[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(tag) {
+			return fmt.Errorf("unterminated value of key %q", key)
+		}
+		if _, err := strconv.Unquote(tag[:i+1]); err != nil {
+			return fmt.Errorf("invalid value of key %q", key)
+		}
+		tag = tag[i+1:]
+		if tag != "" && tag[0] != ' ' {
+			return errors.New(`key:"value" pairs not separated by spaces`)
+		}
+	}
+	return nil
+}
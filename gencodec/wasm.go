@@ -0,0 +1,117 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+
+	"golang.org/x/tools/imports"
+)
+
+// wasmTemplate generates the JavaScript bindings of the JSON methods. The file is
+// only built for js/wasm. Results are returned as {value} or {error} objects
+// because Go functions called from JavaScript can't throw.
+var wasmTemplate = template.Must(template.New("wasm").Parse(`// Code generated by github.com/fjl/gencodec. DO NOT EDIT.
+
+//go:build js && wasm
+
+package {{.Package}}
+
+import (
+	"errors"
+	"syscall/js"
+)
+
+// JSCodecs returns a JavaScript object holding functions which decode and encode
+// {{range $i, $t := .Types}}{{if $i}}, {{end}}{{$t}}{{end}} with the generated JSON methods. Input is validated like on the server.
+// Register the object with
+//
+//	js.Global().Set("{{.Package}}", {{.Package}}.JSCodecs())
+//
+// For type T, unmarshalT(text) decodes a JSON string and returns the decoded object,
+// marshalT(object) checks an object and returns its JSON encoding. The result of both
+// is {value: result} on success and {error: message} if the value is invalid.
+func JSCodecs() js.Value {
+	type codec interface {
+		MarshalJSON() ([]byte, error)
+		UnmarshalJSON([]byte) error
+	}
+	JSON := js.Global().Get("JSON")
+	result := func(value any, err error) any {
+		if err != nil {
+			return map[string]any{"error": err.Error()}
+		}
+		return map[string]any{"value": value}
+	}
+	// roundTrip decodes input into a new value and encodes it again.
+	roundTrip := func(newValue func() codec, input string) ([]byte, error) {
+		v := newValue()
+		if err := v.UnmarshalJSON([]byte(input)); err != nil {
+			return nil, err
+		}
+		return v.MarshalJSON()
+	}
+	unmarshal := func(newValue func() codec) js.Func {
+		return js.FuncOf(func(this js.Value, args []js.Value) any {
+			if len(args) != 1 || args[0].Type() != js.TypeString {
+				return result(nil, errors.New("expected one JSON string argument"))
+			}
+			enc, err := roundTrip(newValue, args[0].String())
+			if err != nil {
+				return result(nil, err)
+			}
+			return result(JSON.Call("parse", string(enc)), nil)
+		})
+	}
+	marshal := func(newValue func() codec) js.Func {
+		return js.FuncOf(func(this js.Value, args []js.Value) any {
+			if len(args) != 1 {
+				return result(nil, errors.New("expected one argument"))
+			}
+			enc, err := roundTrip(newValue, JSON.Call("stringify", args[0]).String())
+			return result(string(enc), err)
+		})
+	}
+	codecs := js.Global().Get("Object").New()
+	{{- range .Types}}
+	new{{.}} := func() codec { return new({{.}}) }
+	codecs.Set("unmarshal{{.}}", unmarshal(new{{.}}))
+	codecs.Set("marshal{{.}}", marshal(new{{.}}))
+	{{- end}}
+	return codecs
+}
+`))
+
+type wasmData struct {
+	Package string
+	Types   []string
+}
+
+// genWASM returns a file with the JavaScript bindings of the JSON methods of mtyps,
+// for programs compiled to WebAssembly.
+func genWASM(mtyps []*marshalerType) ([]byte, error) {
+	data := wasmData{Package: mtyps[0].orig.Obj().Pkg().Name()}
+	for _, mtyp := range mtyps {
+		switch {
+		case mtyp.orig.TypeParams().Len() > 0:
+			return nil, fmt.Errorf("WASM bindings can't be generated for generic type %s", mtyp.name)
+		case !mtyp.hasFormat("json"):
+			return nil, fmt.Errorf("WASM bindings of %s require the json format", mtyp.name)
+		}
+		data.Types = append(data.Types, mtyp.name)
+	}
+	w := new(bytes.Buffer)
+	if err := wasmTemplate.Execute(w, data); err != nil {
+		return nil, err
+	}
+	opt := &imports.Options{Comments: true, TabIndent: true, TabWidth: 8, FormatOnly: true}
+	code, err := imports.Process("", w.Bytes(), opt)
+	if err != nil {
+		return nil, fmt.Errorf("can't gofmt generated WASM bindings: %v", err)
+	}
+	return code, nil
+}
@@ -0,0 +1,377 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"errors"
+	"fmt"
+	"go/types"
+	"strconv"
+	"strings"
+
+	. "github.com/garslo/gogen"
+)
+
+// wellKnownType describes the built-in marshaling representation of a type.
+// Fields of well-known types are marshaled as the representation type without
+// requiring an override.
+type wellKnownType struct {
+	ptrOnly bool     // only fields of pointer type use the representation
+	imports []string // packages used by the generated conversions
+	repr    func(s *fileScope) types.Type
+
+	// present reports whether a non-pointer value is set. If nil,
+	// non-pointer values are always considered present.
+	present func(v Expression) Expression
+	// emptyZero is set if the zero value marshals as the empty string, which
+	// decodes to the zero value, like in the text encoding of the type. Values
+	// which are not present are only left out with the gencodec:"omitzero" option.
+	emptyZero bool
+	// validate returns the conditions under which a value exceeds the
+	// precision and scale limits of the field. They are checked when
+	// decoding and before encoding.
+	validate func(m *marshalMethod, wk *wellKnownField, v Expression) []limitCheck
+	// zero is the name of the zero value constant in the package of the type.
+	// If set, the gencodec:"omitzero" option treats the zero value as absent.
+	zero string
+	// encode converts a value of the original type to the representation.
+	encode func(wk *wellKnownField, v Expression) Expression
+	// decode converts the representation value in to the original type,
+	// assigning it to out. Errors name the field.
+	decode func(m *marshalMethod, wk *wellKnownField, in, out Expression, field string) []Statement
+}
+
+// wellKnownField is a field of a well-known type.
+type wellKnownField struct {
+	*wellKnownType
+	named  *types.Named
+	ptr    bool // field is a pointer to named
+	limits decimalLimits
+}
+
+// decimalLimits are the precision and scale options of a decimal field.
+// Like SQL NUMERIC(precision, scale), the value may have at most scale
+// fractional digits and at most precision digits in total. The scale
+// defaults to zero, so a precision alone limits the field to integers.
+type decimalLimits struct {
+	precision, scale       int
+	hasPrecision, hasScale bool
+}
+
+// limitCheck is a condition that is true when a decoded value is invalid.
+type limitCheck struct {
+	cond Expression
+	desc string
+}
+
+var wellKnownTypes = map[string]*wellKnownType{
+	"google.golang.org/protobuf/types/known/timestamppb.Timestamp": {
+		ptrOnly: true,
+		repr:    func(s *fileScope) types.Type { return s.importType("time", "Time") },
+		encode:  methodCall("AsTime"),
+		decode:  decodeCall("New"),
+	},
+	"google.golang.org/protobuf/types/known/durationpb.Duration": {
+		ptrOnly: true,
+		imports: []string{"time"},
+		repr:    reprBasic(types.String),
+		encode: func(wk *wellKnownField, v Expression) Expression {
+			return CallFunction{Func: Dotted{Receiver: methodCall("AsDuration")(wk, v), Name: "String"}}
+		},
+		decode: func(m *marshalMethod, wk *wellKnownField, in, out Expression, field string) []Statement {
+			d := Name(m.scope.newIdent("d"))
+			parse := Dotted{Receiver: Name(m.scope.parent.packageName("time")), Name: "ParseDuration"}
+			s := m.parseField(d, CallFunction{Func: parse, Params: []Expression{in}}, field)
+			return append(s, Assign{Lhs: out, Rhs: wk.call(m, "New", d)})
+		},
+	},
+	"net.IP": {
+		repr:      reprBasic(types.String),
+		present:   func(v Expression) Expression { return NotEqual{Lhs: v, Rhs: NIL} },
+		emptyZero: true,
+		encode:    methodCall("String"),
+		decode: func(m *marshalMethod, wk *wellKnownField, in, out Expression, field string) []Statement {
+			ip := Name(m.scope.newIdent("ip"))
+			fmt := Name(m.scope.parent.packageName("fmt"))
+			errorf := CallFunction{
+				Func:   Dotted{Receiver: fmt, Name: "Errorf"},
+				Params: []Expression{stringLit{"invalid IP address %q for " + field}, in},
+			}
+			return []Statement{
+				DeclareAndAssign{Lhs: ip, Rhs: wk.call(m, "ParseIP", in)},
+				If{
+					Condition: Equals{Lhs: ip, Rhs: NIL},
+					Body:      []Statement{Return{Values: []Expression{errorf}}},
+				},
+				Assign{Lhs: out, Rhs: wk.adapt(ip, false)},
+			}
+		},
+	},
+	"net/netip.Addr": {
+		repr:      reprBasic(types.String),
+		present:   func(v Expression) Expression { return methodCall("IsValid")(nil, v) },
+		emptyZero: true,
+		encode:    methodCall("String"),
+		decode:    decodeParse("ParseAddr", "addr", false),
+	},
+	"net/url.URL": {
+		repr:   reprBasic(types.String),
+		encode: methodCall("String"),
+		decode: decodeParse("Parse", "u", true),
+	},
+	"regexp.Regexp": {
+		ptrOnly: true,
+		repr:    reprBasic(types.String),
+		encode:  methodCall("String"),
+		decode:  decodeParse("Compile", "re", true),
+	},
+	"net/mail.Address": {
+		repr: reprBasic(types.String),
+		present: func(v Expression) Expression {
+			return NotEqual{Lhs: Dotted{Receiver: v, Name: "Address"}, Rhs: stringLit{""}}
+		},
+		encode: methodCall("String"),
+		decode: decodeParse("ParseAddress", "addr", true),
+	},
+	"github.com/google/uuid.UUID":    uuidType("Parse"),
+	"github.com/satori/go.uuid.UUID": uuidType("FromString"),
+	"github.com/gofrs/uuid.UUID":     uuidType("FromString"),
+	"github.com/gofrs/uuid/v5.UUID":  uuidType("FromString"),
+	"github.com/shopspring/decimal.Decimal": {
+		repr:     reprBasic(types.String),
+		encode:   methodCall("String"),
+		decode:   decodeParse("NewFromString", "d", false),
+		validate: validateDecimal,
+	},
+	"math/big.Rat": {
+		ptrOnly: true,
+		repr:    reprBasic(types.String),
+		encode: func(wk *wellKnownField, v Expression) Expression {
+			if wk.limits.hasScale {
+				return CallFunction{Func: Dotted{Receiver: v, Name: "FloatString"}, Params: []Expression{Int(wk.limits.scale)}}
+			}
+			return CallFunction{Func: Dotted{Receiver: v, Name: "RatString"}}
+		},
+		decode: func(m *marshalMethod, wk *wellKnownField, in, out Expression, field string) []Statement {
+			r, ok := Name(m.scope.newIdent("r")), Name(m.scope.newIdent("ok"))
+			fmt := Name(m.scope.parent.packageName("fmt"))
+			errorf := CallFunction{
+				Func:   Dotted{Receiver: fmt, Name: "Errorf"},
+				Params: []Expression{stringLit{"invalid rational number %q for " + field}, in},
+			}
+			return []Statement{
+				declareMulti{Lhs: []Expression{r, ok}, Rhs: CallFunction{
+					Func:   Dotted{Receiver: wk.newValue(m), Name: "SetString"},
+					Params: []Expression{in},
+				}},
+				If{Condition: Not{Value: ok}, Body: []Statement{Return{Values: []Expression{errorf}}}},
+				Assign{Lhs: out, Rhs: r},
+			}
+		},
+		validate: validateRat,
+	},
+	"google.golang.org/protobuf/types/known/wrapperspb.DoubleValue": wrapperType(types.Float64, "Double"),
+	"google.golang.org/protobuf/types/known/wrapperspb.FloatValue":  wrapperType(types.Float32, "Float"),
+	"google.golang.org/protobuf/types/known/wrapperspb.Int64Value":  wrapperType(types.Int64, "Int64"),
+	"google.golang.org/protobuf/types/known/wrapperspb.UInt64Value": wrapperType(types.Uint64, "UInt64"),
+	"google.golang.org/protobuf/types/known/wrapperspb.Int32Value":  wrapperType(types.Int32, "Int32"),
+	"google.golang.org/protobuf/types/known/wrapperspb.UInt32Value": wrapperType(types.Uint32, "UInt32"),
+	"google.golang.org/protobuf/types/known/wrapperspb.BoolValue":   wrapperType(types.Bool, "Bool"),
+	"google.golang.org/protobuf/types/known/wrapperspb.StringValue": wrapperType(types.String, "String"),
+	"google.golang.org/protobuf/types/known/wrapperspb.BytesValue": {
+		ptrOnly: true,
+		repr:    func(*fileScope) types.Type { return types.NewSlice(types.Universe.Lookup("byte").Type()) },
+		encode:  fieldAccess("Value"),
+		decode:  decodeCall("Bytes"),
+	},
+}
+
+// wrapperType creates the representation of a protobuf wrapper message,
+// which is marshaled as the unwrapped scalar value.
+func wrapperType(kind types.BasicKind, constructor string) *wellKnownType {
+	return &wellKnownType{
+		ptrOnly: true,
+		repr:    reprBasic(kind),
+		encode:  fieldAccess("Value"),
+		decode:  decodeCall(constructor),
+	}
+}
+
+// uuidType creates the representation of a UUID type, which is marshaled
+// in canonical string form.
+func uuidType(parse string) *wellKnownType {
+	return &wellKnownType{
+		repr:   reprBasic(types.String),
+		zero:   "Nil",
+		encode: methodCall("String"),
+		decode: decodeParse(parse, "id", false),
+	}
+}
+
+func reprBasic(kind types.BasicKind) func(*fileScope) types.Type {
+	return func(*fileScope) types.Type { return types.Typ[kind] }
+}
+
+func methodCall(name string) func(*wellKnownField, Expression) Expression {
+	return func(wk *wellKnownField, v Expression) Expression {
+		return CallFunction{Func: Dotted{Receiver: v, Name: name}}
+	}
+}
+
+func fieldAccess(name string) func(*wellKnownField, Expression) Expression {
+	return func(wk *wellKnownField, v Expression) Expression {
+		return Dotted{Receiver: v, Name: name}
+	}
+}
+
+// decodeCall returns a decoder that calls an infallible constructor
+// function in the package of the well-known type.
+func decodeCall(name string) func(*marshalMethod, *wellKnownField, Expression, Expression, string) []Statement {
+	return func(m *marshalMethod, wk *wellKnownField, in, out Expression, field string) []Statement {
+		return []Statement{Assign{Lhs: out, Rhs: wk.call(m, name, in)}}
+	}
+}
+
+// decodeParse returns a decoder that calls a parsing function in the package of
+// the well-known type. The function returns the parsed value and an error.
+func decodeParse(name, varName string, returnsPtr bool) func(*marshalMethod, *wellKnownField, Expression, Expression, string) []Statement {
+	return func(m *marshalMethod, wk *wellKnownField, in, out Expression, field string) []Statement {
+		v := Name(m.scope.newIdent(varName))
+		s := m.parseField(v, wk.call(m, name, in), field)
+		return append(s, Assign{Lhs: out, Rhs: wk.adapt(v, returnsPtr)})
+	}
+}
+
+// validateDecimal checks the limits of a shopspring/decimal value.
+func validateDecimal(m *marshalMethod, wk *wellKnownField, v Expression) (checks []limitCheck) {
+	pkg := Name(m.mtyp.scope.qualify(wk.named.Obj().Pkg()))
+	if wk.limits.hasScale || wk.limits.hasPrecision {
+		trunc := CallFunction{Func: Dotted{Receiver: v, Name: "Truncate"}, Params: []Expression{Int(wk.limits.scale)}}
+		checks = append(checks, limitCheck{
+			cond: Not{Value: CallFunction{Func: Dotted{Receiver: v, Name: "Equal"}, Params: []Expression{trunc}}},
+			desc: fmt.Sprintf("scale %d", wk.limits.scale),
+		})
+	}
+	if wk.limits.hasPrecision {
+		abs := CallFunction{Func: Dotted{Receiver: v, Name: "Abs"}}
+		max := CallFunction{Func: Dotted{Receiver: pkg, Name: "New"}, Params: []Expression{Int(1), Int(wk.limits.intDigits())}}
+		checks = append(checks, limitCheck{
+			cond: GreaterThanOrEqual{Lhs: CallFunction{Func: Dotted{Receiver: abs, Name: "Cmp"}, Params: []Expression{max}}, Rhs: Int(0)},
+			desc: fmt.Sprintf("precision %d", wk.limits.precision),
+		})
+	}
+	return checks
+}
+
+// validateRat checks the limits of a big.Rat value.
+func validateRat(m *marshalMethod, wk *wellKnownField, v Expression) (checks []limitCheck) {
+	pkg := Name(m.mtyp.scope.qualify(wk.named.Obj().Pkg()))
+	pow10 := func(n int) Expression {
+		return CallFunction{Func: Dotted{Receiver: pkg, Name: "NewRat"}, Params: []Expression{Name("1" + strings.Repeat("0", n)), Int(1)}}
+	}
+	if wk.limits.hasScale || wk.limits.hasPrecision {
+		scaled := v
+		if wk.limits.scale > 0 {
+			scaled = CallFunction{Func: Dotted{Receiver: wk.newValue(m), Name: "Mul"}, Params: []Expression{v, pow10(wk.limits.scale)}}
+		}
+		checks = append(checks, limitCheck{
+			cond: Not{Value: CallFunction{Func: Dotted{Receiver: scaled, Name: "IsInt"}}},
+			desc: fmt.Sprintf("scale %d", wk.limits.scale),
+		})
+	}
+	if wk.limits.hasPrecision {
+		abs := CallFunction{Func: Dotted{Receiver: wk.newValue(m), Name: "Abs"}, Params: []Expression{v}}
+		cmp := CallFunction{Func: Dotted{Receiver: abs, Name: "Cmp"}, Params: []Expression{pow10(wk.limits.intDigits())}}
+		checks = append(checks, limitCheck{
+			cond: GreaterThanOrEqual{Lhs: cmp, Rhs: Int(0)},
+			desc: fmt.Sprintf("precision %d", wk.limits.precision),
+		})
+	}
+	return checks
+}
+
+// intDigits returns the number of digits allowed before the decimal point.
+func (l decimalLimits) intDigits() int {
+	return l.precision - l.scale
+}
+
+// parseLimits reads the precision and scale options of a field.
+func (wk *wellKnownField) parseLimits(mf *marshalerField) error {
+	var err error
+	if v, ok := mf.option("precision"); ok {
+		if wk.limits.precision, err = strconv.Atoi(v); err != nil || wk.limits.precision <= 0 {
+			return fmt.Errorf("invalid precision %q", v)
+		}
+		wk.limits.hasPrecision = true
+	}
+	if v, ok := mf.option("scale"); ok {
+		if wk.limits.scale, err = strconv.Atoi(v); err != nil || wk.limits.scale < 0 {
+			return fmt.Errorf("invalid scale %q", v)
+		}
+		wk.limits.hasScale = true
+	}
+	if wk.limits.hasPrecision && wk.limits.scale > wk.limits.precision {
+		return fmt.Errorf("scale %d exceeds precision %d", wk.limits.scale, wk.limits.precision)
+	}
+	// big.NewRat takes int64 arguments.
+	if wk.named.Obj().Pkg().Path() == "math/big" && (wk.limits.scale > 18 || wk.limits.intDigits() > 18) {
+		return errors.New("precision and scale of big.Rat fields are limited to 18 digits")
+	}
+	return nil
+}
+
+// zeroValue creates the zero value of the field type.
+func (wk *wellKnownField) zeroValue(m *marshalMethod) Expression {
+	if wk.ptr {
+		return wk.newValue(m)
+	}
+	if _, ok := wk.named.Underlying().(*types.Struct); ok {
+		return Name(types.TypeString(wk.named, m.mtyp.scope.qualify) + "{}")
+	}
+	return NIL
+}
+
+// newValue creates an expression that allocates a new value of the well-known type.
+func (wk *wellKnownField) newValue(m *marshalMethod) Expression {
+	name := types.TypeString(wk.named, m.mtyp.scope.qualify)
+	return CallFunction{Func: Name("new"), Params: []Expression{Name(name)}}
+}
+
+// lookupWellKnown returns the well-known type description for a field type.
+func lookupWellKnown(typ types.Type) *wellKnownField {
+	wk := new(wellKnownField)
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ, wk.ptr = ptr.Elem(), true
+	}
+	named, ok := typ.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return nil
+	}
+	wk.named = named
+	wk.wellKnownType = wellKnownTypes[named.Obj().Pkg().Path()+"."+named.Obj().Name()]
+	if wk.wellKnownType == nil || (wk.ptrOnly && !wk.ptr) {
+		return nil
+	}
+	return wk
+}
+
+// adapt converts v, a value or pointer of the well-known type,
+// to the field type.
+func (wk *wellKnownField) adapt(v Expression, isPtr bool) Expression {
+	switch {
+	case isPtr && !wk.ptr:
+		return Star{Value: v}
+	case !isPtr && wk.ptr:
+		return AddressOf{Value: v}
+	default:
+		return v
+	}
+}
+
+// call creates a call of the named function in the package of the well-known type.
+func (wk *wellKnownField) call(m *marshalMethod, name string, args ...Expression) Expression {
+	pkg := Name(m.mtyp.scope.qualify(wk.named.Obj().Pkg()))
+	return CallFunction{Func: Dotted{Receiver: pkg, Name: name}, Params: args}
+}
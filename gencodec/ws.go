@@ -0,0 +1,132 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"text/template"
+)
+
+// wsMessageFields are the fields of the WebSocket message envelope.
+const wsMessageFields = `struct {
+		Kind string          ` + "`" + `json:"kind"` + "`" + `
+		Seq  uint64          ` + "`" + `json:"seq"` + "`" + `
+		Data {{.JSON}}.RawMessage ` + "`" + `json:"data"` + "`" + `
+	}`
+
+var wsTemplate = template.Must(template.New("ws").Parse(`
+// EncodeWSMessage encodes {{.Recv}} as a WebSocket message of kind {{printf "%q" .Type}}
+// with the given sequence number.
+func ({{.Recv}} {{.Type}}) EncodeWSMessage({{.Seq}} uint64) ([]byte, error) {
+	{{.Data}}, {{.Err}} := {{.Recv}}.MarshalJSON()
+	if {{.Err}} != nil {
+		return nil, {{.Err}}
+	}
+	{{.Msg}} := ` + wsMessageFields + `{ {{- printf "%q" .Type}}, {{.Seq}}, {{.Data}}}
+	return {{.JSON}}.Marshal(&{{.Msg}})
+}
+
+// DecodeWSMessage decodes {{.Recv}} from a WebSocket message of kind {{printf "%q" .Type}}
+// and returns the sequence number of the message.
+func ({{.Recv}} *{{.Type}}) DecodeWSMessage({{.Input}} []byte) (uint64, error) {
+	var {{.Msg}} ` + wsMessageFields + `
+	if {{.Err}} := {{.JSON}}.Unmarshal({{.Input}}, &{{.Msg}}); {{.Err}} != nil {
+		return 0, {{.Err}}
+	}
+	if {{.Msg}}.Kind != {{printf "%q" .Type}} {
+		return 0, {{.Fmt}}.Errorf("wrong message kind %q for {{.Type}}", {{.Msg}}.Kind)
+	}
+	return {{.Msg}}.Seq, {{.Recv}}.UnmarshalJSON({{.Msg}}.Data)
+}
+`))
+
+var wsDecoderTemplate = template.Must(template.New("wsdecoder").Parse(`
+// DecodeWSMessage decodes a WebSocket message of any of the types {{range $i, $t := .Types}}{{if $i}}, {{end}}{{$t}}{{end}}.
+// It returns a pointer to the decoded value and the sequence number of the message.
+func DecodeWSMessage({{.Input}} []byte) (interface{}, uint64, error) {
+	var {{.Msg}} struct {
+		Kind string ` + "`" + `json:"kind"` + "`" + `
+	}
+	if {{.Err}} := {{.JSON}}.Unmarshal({{.Input}}, &{{.Msg}}); {{.Err}} != nil {
+		return nil, 0, {{.Err}}
+	}
+	switch {{.Msg}}.Kind {
+	{{- range .Types}}
+	case {{printf "%q" .}}:
+		{{$.Value}} := new({{.}})
+		{{$.Seq}}, {{$.Err}} := {{$.Value}}.DecodeWSMessage({{$.Input}})
+		return {{$.Value}}, {{$.Seq}}, {{$.Err}}
+	{{- end}}
+	default:
+		return nil, 0, {{.Fmt}}.Errorf("unknown message kind %q", {{.Msg}}.Kind)
+	}
+}
+`))
+
+type wsData struct {
+	Type  string
+	Types []string
+
+	// package and variable names
+	JSON, Fmt                               string
+	Recv, Seq, Data, Err, Msg, Input, Value string
+}
+
+// genWSMessage writes the EncodeWSMessage and DecodeWSMessage methods of mtyp.
+// Messages carry the type name as their kind, a sequence number and the JSON
+// encoding of the value.
+func genWSMessage(w io.Writer, mtyp *marshalerType) error {
+	if err := checkWSMessage(mtyp); err != nil {
+		return err
+	}
+	var (
+		scope = newFuncScope(mtyp.scope)
+		recv  = newMarshalMethod(mtyp, false).receiver().Name
+	)
+	scope.used[recv] = true
+	data := wsData{
+		Type:  mtyp.name,
+		JSON:  mtyp.scope.packageName("encoding/json"),
+		Fmt:   mtyp.scope.packageName("fmt"),
+		Recv:  recv,
+		Seq:   scope.newIdent("seq"),
+		Data:  scope.newIdent("data"),
+		Err:   scope.newIdent("err"),
+		Msg:   scope.newIdent("msg"),
+		Input: scope.newIdent("input"),
+	}
+	return mtyp.template(wsTemplate).Execute(w, data)
+}
+
+// genWSDecoder writes the DecodeWSMessage function, which decodes messages
+// of all types of the invocation.
+func genWSDecoder(w io.Writer, mtyps []*marshalerType) error {
+	scope := newFuncScope(mtyps[0].scope)
+	data := wsData{
+		JSON:  mtyps[0].scope.packageName("encoding/json"),
+		Fmt:   mtyps[0].scope.packageName("fmt"),
+		Seq:   scope.newIdent("seq"),
+		Err:   scope.newIdent("err"),
+		Msg:   scope.newIdent("msg"),
+		Input: scope.newIdent("input"),
+		Value: scope.newIdent("v"),
+	}
+	for _, mtyp := range mtyps {
+		data.Types = append(data.Types, mtyp.name)
+	}
+	return mtyps[0].template(wsDecoderTemplate).Execute(w, data)
+}
+
+func checkWSMessage(mtyp *marshalerType) error {
+	if !mtyp.hasFormat("json") {
+		return errors.New("WebSocket messages require the json format")
+	}
+	if mtyp.orig.TypeParams().Len() > 0 {
+		return fmt.Errorf("generic type %s can't be a WebSocket message", mtyp.name)
+	}
+	return nil
+}
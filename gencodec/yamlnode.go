@@ -0,0 +1,155 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gencodec
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"reflect"
+	"strings"
+
+	. "github.com/garslo/gogen"
+)
+
+// yamlPath is the package of yaml.Node, used by UnmarshalYAML with -yaml v3.
+const yamlPath = "gopkg.in/yaml.v3"
+
+// With -yaml v3, UnmarshalYAML takes the *yaml.Node of the value and decodes it by
+// its Decode method. The errors of missing and unknown fields then carry the line
+// of the value or key in the input.
+
+// yamlNodeParam returns the parameter of UnmarshalYAML and the statement decoding
+// the node into dec.
+func (m *marshalMethod) yamlNodeParam(dec Var) (Types, Statement) {
+	m.node = m.scope.newIdent("value")
+	yaml := m.scope.parent.packageName(yamlPath)
+	param := Types{{Name: m.node, TypeName: "*" + yaml + ".Node"}}
+	decode := errCheck(CallFunction{
+		Func:   Dotted{Receiver: Name(m.node), Name: "Decode"},
+		Params: []Expression{AddressOf{Value: dec}},
+	})
+	return param, decode
+}
+
+// checkUnknownYAMLNodeKeys returns an error for the first key of the mapping node
+// which doesn't belong to a field. Aliases are resolved first, and other kinds of
+// nodes have no keys to check. The keys of inline structs are known, and the merge
+// key is accepted because the decoder resolves it. Types with an inline map accept
+// all keys, so nothing is checked.
+func (m *marshalMethod) checkUnknownYAMLNodeKeys() []Statement {
+	known := []Expression{stringLit{"<<"}}
+	for _, f := range m.mtyp.Fields {
+		if !f.inFormat("yaml") {
+			continue
+		}
+		if !f.hasTagOption("yaml", "inline") {
+			k, _ := f.key("yaml")
+			known = append(known, stringLit{k})
+			continue
+		}
+		keys, all := yamlInlineKeys(f.typ)
+		if all {
+			return nil
+		}
+		for _, k := range keys {
+			known = append(known, stringLit{k})
+		}
+	}
+
+	var (
+		yaml    = Name(m.scope.parent.packageName(yamlPath))
+		mapping = Name(m.scope.newIdent("mapping"))
+		kind    = Dotted{Receiver: mapping, Name: "Kind"}
+		content = Dotted{Receiver: mapping, Name: "Content"}
+		i       = Name(m.scope.newIdent("i"))
+		key     = Name(m.scope.newIdent("key"))
+		value   = Dotted{Receiver: key, Name: "Value"}
+		line    = Dotted{Receiver: key, Name: "Line"}
+	)
+	var err Expression
+	if m.mtyp.typedErrors {
+		err = m.codecError("UnknownFieldError", value, line)
+	} else {
+		fmt := Name(m.scope.parent.packageName("fmt"))
+		err = CallFunction{
+			Func:   Dotted{Receiver: fmt, Name: "Errorf"},
+			Params: []Expression{stringLit{"line %d: unknown field '%s' for " + m.mtyp.name}, line, value},
+		}
+	}
+	// Keys and values alternate in the content of mapping nodes.
+	next := &ast.AssignStmt{Lhs: []ast.Expr{i.Expression()}, Tok: token.ADD_ASSIGN, Rhs: []ast.Expr{ast.NewIdent("2")}}
+	loop := For{
+		Init: DeclareAndAssign{Lhs: i, Rhs: Name("0")},
+		Condition: LessThan{
+			Lhs: binaryExpr{token.ADD, i, Name("1")},
+			Rhs: CallFunction{Func: Name("len"), Params: []Expression{content}},
+		},
+		Post: Thunk{Stmt: next},
+		Body: []Statement{
+			DeclareAndAssign{Lhs: key, Rhs: Index{Value: content, Index: i}},
+			switchCase{Tag: value, Values: known, Body: []Statement{Thunk{Stmt: &ast.BranchStmt{Tok: token.CONTINUE}}}},
+			Return{Values: []Expression{err}},
+		},
+	}
+	return []Statement{
+		DeclareAndAssign{Lhs: mapping, Rhs: Name(m.node)},
+		For{
+			Condition: Equals{Lhs: kind, Rhs: Dotted{Receiver: yaml, Name: "AliasNode"}},
+			Body:      []Statement{Assign{Lhs: mapping, Rhs: Dotted{Receiver: mapping, Name: "Alias"}}},
+		},
+		If{
+			Condition: Equals{Lhs: kind, Rhs: Dotted{Receiver: yaml, Name: "MappingNode"}},
+			Body:      []Statement{loop},
+		},
+	}
+}
+
+// yamlInlineKeys returns the keys which the YAML decoder assigns to the fields of
+// an inline struct, including the keys of structs inlined in it. It reports true
+// if the type is an inline map, which takes all keys.
+func yamlInlineKeys(typ types.Type) (keys []string, all bool) {
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+	styp, ok := typ.Underlying().(*types.Struct)
+	if !ok {
+		return nil, true
+	}
+	for i := 0; i < styp.NumFields(); i++ {
+		f := styp.Field(i)
+		if !f.Exported() {
+			continue
+		}
+		name, opts, _ := strings.Cut(reflect.StructTag(styp.Tag(i)).Get("yaml"), ",")
+		switch {
+		case name == "-":
+			continue
+		case hasTagOption(opts, "inline"):
+			sub, all := yamlInlineKeys(f.Type())
+			if all {
+				return nil, true
+			}
+			keys = append(keys, sub...)
+		case name == "":
+			keys = append(keys, strings.ToLower(f.Name()))
+		default:
+			keys = append(keys, name)
+		}
+	}
+	return keys, false
+}
+
+// yamlLineError returns the error of an absent required field, prefixed by the
+// line of the YAML value.
+func (m *marshalMethod) yamlLineError(f *marshalerField) Expression {
+	msg := fmt.Sprintf("line %%d: missing required field '%s' for %s", f.encodedName("yaml"), m.mtyp.name)
+	fmt := Name(m.scope.parent.packageName("fmt"))
+	return CallFunction{
+		Func:   Dotted{Receiver: fmt, Name: "Errorf"},
+		Params: []Expression{stringLit{msg}, Dotted{Receiver: Name(m.node), Name: "Line"}},
+	}
+}
@@ -1,14 +1,33 @@
 module github.com/fjl/gencodec
 
-go 1.18
+go 1.22.0
 
 require (
+	github.com/BurntSushi/toml v1.6.0
+	github.com/cespare/xxhash/v2 v2.3.0
+	github.com/fxamacker/cbor/v2 v2.7.0
 	github.com/garslo/gogen v0.0.0-20170306192744-1d203ffc1f61
+	github.com/hashicorp/hcl/v2 v2.23.0
 	github.com/kylelemons/godebug v0.0.0-20170224010052-a616ab194758
-	golang.org/x/tools v0.0.0-20191126055441-b0650ceb63d9
+	github.com/vmihailenco/msgpack/v5 v5.4.1
+	golang.org/x/tools v0.26.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	github.com/agext/levenshtein v1.2.1 // indirect
+	github.com/apparentlymart/go-textseg/v15 v15.0.0 // indirect
+	github.com/google/go-cmp v0.6.0 // indirect
+	github.com/mitchellh/go-wordwrap v1.0.1 // indirect
+	github.com/zclconf/go-cty v1.15.0 // indirect
+	golang.org/x/text v0.19.0 // indirect
 )
 
 require (
 	github.com/onsi/ginkgo v1.10.3 // indirect
 	github.com/onsi/gomega v1.7.1 // indirect
+	github.com/vmihailenco/tagparser/v2 v2.0.0 // indirect
+	github.com/x448/float16 v0.8.4 // indirect
+	golang.org/x/mod v0.21.0 // indirect
+	golang.org/x/sync v0.8.0 // indirect
 )
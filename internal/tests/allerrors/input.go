@@ -0,0 +1,14 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type X -all-errors -strict -formats json,yaml -out output.go
+
+package allerrors
+
+type X struct {
+	Name    string `json:"name" yaml:"name" gencodec:"required"`
+	Port    int    `json:"port" yaml:"port" gencodec:"required,conv=strconv.Itoa,strconv.Atoi"`
+	Retries int    `json:"retries" yaml:"retries" default:"3"`
+	Comment string `json:"comment" yaml:"comment"`
+}
@@ -0,0 +1,83 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package allerrors
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestAllErrorsJSON(t *testing.T) {
+	var x X
+	err := json.Unmarshal([]byte(`{"retries": 5, "comment": "c"}`), &x)
+	if err == nil {
+		t.Fatal("no error")
+	}
+	want := "missing required field 'name' for X\nmissing required field 'port' for X"
+	if err.Error() != want {
+		t.Fatalf("wrong error %q\nwant %q", err, want)
+	}
+	// The valid fields are decoded.
+	if x.Retries != 5 || x.Comment != "c" {
+		t.Fatalf("fields not decoded: %+v", x)
+	}
+}
+
+func TestAllErrorsTypeError(t *testing.T) {
+	var x X
+	err := json.Unmarshal([]byte(`{"zz": 1, "retries": "five", "name": 1, "comment": "c", "port": "80", "aa": 2}`), &x)
+	if err == nil {
+		t.Fatal("no error")
+	}
+	// The keys are decoded in sorted order, unknown keys are reported after them.
+	lines := strings.Split(err.Error(), "\n")
+	want := []string{
+		"json: cannot unmarshal number into Go struct field X.name of type string",
+		"json: cannot unmarshal string into Go struct field X.retries of type int",
+		"unknown field 'aa' for X",
+		"unknown field 'zz' for X",
+	}
+	if !reflect.DeepEqual(lines, want) {
+		t.Fatalf("wrong error %q\nwant %q", lines, want)
+	}
+	var typeErr *json.UnmarshalTypeError
+	if !errors.As(err, &typeErr) || typeErr.Field != "name" {
+		t.Fatalf("no type error for name in %q", err)
+	}
+	// The valid fields are decoded.
+	if x.Comment != "c" || x.Port != 80 {
+		t.Fatalf("fields not decoded: %+v", x)
+	}
+}
+
+func TestAllErrorsInvalid(t *testing.T) {
+	var x X
+	err := yaml.Unmarshal([]byte("port: abc\n"), &x)
+	if err == nil {
+		t.Fatal("no error")
+	}
+	lines := strings.Split(err.Error(), "\n")
+	if len(lines) != 2 || lines[0] != "missing required field 'name' for X" || !strings.Contains(lines[1], `"abc"`) {
+		t.Fatalf("wrong error %q", err)
+	}
+	if x.Retries != 3 {
+		t.Fatalf("default not set: %+v", x)
+	}
+}
+
+func TestAllErrorsValid(t *testing.T) {
+	var x X
+	if err := json.Unmarshal([]byte(`{"name": "n", "port": "80"}`), &x); err != nil {
+		t.Fatal(err)
+	}
+	if x != (X{Name: "n", Port: 80, Retries: 3}) {
+		t.Fatalf("wrong value %+v", x)
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type Record,Item -appender -out output.go
+
+package appender
+
+import (
+	"encoding/json"
+	"math/big"
+	"strconv"
+	"time"
+)
+
+type Level uint8
+
+type Record struct {
+	Name     string            `json:"name" gencodec:"required"`
+	Level    Level             `json:"level"`
+	Count    int64             `json:"count,string"`
+	Ratio    float64           `json:"ratio"`
+	Small    float32           `json:"small,omitempty"`
+	OK       bool              `json:"ok"`
+	Data     []byte            `json:"data"`
+	Ptr      *int              `json:"ptr"`
+	Tags     []string          `json:"tags,omitempty"`
+	Matrix   [2][2]uint16      `json:"matrix"`
+	Items    []*Item           `json:"items"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Extra    json.RawMessage   `json:"extra,omitempty"`
+	Big      *big.Int          `json:"big"`
+	Time     time.Time         `json:"time,omitzero"`
+	Any      interface{}       `json:"any"`
+	Inner    Inner             `json:"inner"`
+	Ignored  string            `json:"-"`
+	Unicode  string            `json:"☺"`
+	Untagged string
+	Seq      uint64 `json:"seq" gencodec:"hook=appendSeq,parseSeq"`
+}
+
+type Item struct {
+	ID    uint32  `json:"id"`
+	Price float64 `json:"price,omitempty"`
+}
+
+type Inner struct {
+	A int `json:"a"`
+}
+
+// appendSeq and parseSeq encode Seq like encoding/json.
+func appendSeq(dst []byte, v uint64) []byte {
+	return strconv.AppendUint(dst, v, 10)
+}
+
+func parseSeq(src []byte) (uint64, int, error) {
+	v, err := strconv.ParseUint(string(src), 10, 64)
+	return v, len(src), err
+}
@@ -0,0 +1,79 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package appender
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+)
+
+// plainRecord and plainItem have no methods, so encoding/json marshals them by reflection.
+type plainRecord Record
+
+type plainItem Item
+
+func TestMarshalJSONTo(t *testing.T) {
+	n := -7
+	tests := []Record{
+		{},
+		{Name: "<a href=\"x\">&</a>\n\t\x01\x7f", Count: -3, Ratio: 1e21, Small: 1e-7, OK: true},
+		{Name: "invalid \xff utf-8    ☺", Ratio: 0.000001, Small: 3.4e38, Data: []byte{}, Tags: []string{}},
+		{
+			Level:  200,
+			Ratio:  -123.456,
+			Data:   []byte{1, 2, 3},
+			Ptr:    &n,
+			Tags:   []string{"a", "b"},
+			Matrix: [2][2]uint16{{1, 2}, {3, 65535}},
+			Items:  []*Item{{ID: 1, Price: 2.5}, nil, {}},
+			Labels: map[string]string{"z": "1", "a": "2"},
+			Extra:  json.RawMessage(`{"x": [1, 2]}`),
+			Big:    big.NewInt(1 << 62),
+			Time:   time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+			Any:    map[string]interface{}{"k": []int{1}},
+			Inner:  Inner{A: 4},
+			Seq:    math.MaxUint64,
+		},
+	}
+	for _, r := range tests {
+		out, err := r.MarshalJSON()
+		if err != nil {
+			t.Fatal(err)
+		}
+		want, err := json.Marshal(plainRecord(r))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(out) != string(want) {
+			t.Errorf("wrong output\ngot  %s\nwant %s", out, want)
+		}
+	}
+}
+
+func TestMarshalJSONToAppends(t *testing.T) {
+	item := Item{ID: 5}
+	out, err := item.MarshalJSONTo([]byte("prefix:"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `prefix:{"id":5}`; string(out) != want {
+		t.Errorf("got %s, want %s", out, want)
+	}
+	want, _ := json.Marshal(plainItem(item))
+	if out, _ := item.MarshalJSON(); string(out) != string(want) {
+		t.Errorf("got %s, want %s", out, want)
+	}
+}
+
+func TestMarshalJSONToUnsupportedFloat(t *testing.T) {
+	for _, r := range []Record{{Ratio: math.NaN()}, {Small: float32(math.Inf(-1))}} {
+		if _, err := r.MarshalJSON(); err == nil {
+			t.Errorf("no error for %v %v", r.Ratio, r.Small)
+		}
+	}
+}
@@ -0,0 +1,16 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type X -omitempty -formats json,yaml -out output.go
+
+package autoomitempty
+
+type X struct {
+	Required int      `json:"required" gencodec:"required"`
+	Optional int      `json:"optional"`
+	Untagged string
+	Tags     []string `json:"tags,omitempty"`
+	Skipped  int      `json:"-"`
+	Dropped  int      `json:"dropped,omitempty" gencodec:"required"` // omitempty makes it optional
+}
@@ -0,0 +1,27 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package autoomitempty
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestOmitEmpty(t *testing.T) {
+	enc, err := json.Marshal(X{Required: 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(enc) != `{"required":0}` {
+		t.Fatalf("wrong encoding %s", enc)
+	}
+	var dec X
+	if err := json.Unmarshal(enc, &dec); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(`{}`), &dec); err == nil {
+		t.Fatal("no error for missing required field")
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type Header,Packet,Frame,Record -helpers binary -out output.go
+
+package binary
+
+type Kind uint8
+
+type Header struct {
+	Magic   [2]byte `wire:"bytes"`
+	Kind    Kind    `wire:"u8"`
+	Flags   bool    `wire:"bool"`
+	Length  int     `wire:"u16be"`
+	Seq     uint64  `wire:"u32le"`
+	Offset  int32   `wire:"i16le"`
+	Delta   int8    `wire:"i32be"`
+	Ratio   float64 `wire:"f32be"`
+	Total   uint    `wire:"u64be"`
+	Comment string  `json:"comment"`
+}
+
+// Packet has groups of bit fields sharing bytes.
+type Packet struct {
+	Version  uint8  `bits:"4"`
+	Priority Kind   `bits:"3"`
+	Urgent   bool   `bits:"1"`
+	Length   uint16 `wire:"u16be"`
+	Channel  uint16 `bits:"12"`
+	Window   uint8  `bits:"9"`
+	Ack      bool   `bits:"1"`
+	Retries  uint   `bits:"2"`
+}
+
+// Frame ends with a CRC-32 of the bytes before it.
+type Frame struct {
+	Kind    Kind    `wire:"u8"`
+	Payload [4]byte `wire:"bytes"`
+	CRC     uint32  `wire:"u32be" checksum:"crc32"`
+}
+
+// Record ends with an xxHash of the bytes before it.
+type Record struct {
+	ID   uint64 `wire:"u64le"`
+	Hash uint64 `wire:"u64le" checksum:"xxhash"`
+}
@@ -0,0 +1,143 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package binary
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"hash/crc32"
+	"math"
+	"testing"
+
+	"github.com/fjl/gencodec/codecerr"
+)
+
+func TestBinaryLayout(t *testing.T) {
+	h := Header{
+		Magic:   [2]byte{0xca, 0xfe},
+		Kind:    7,
+		Flags:   true,
+		Length:  0x0102,
+		Seq:     0x01020304,
+		Offset:  -2,
+		Delta:   -1,
+		Ratio:   1.5,
+		Total:   1 << 40,
+		Comment: "not encoded",
+	}
+	out, err := h.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _ := hex.DecodeString("cafe" + "07" + "01" + "0102" + "04030201" + "feff" + "ffffffff" + "3fc00000" + "0000010000000000")
+	if !bytes.Equal(out, want) {
+		t.Fatalf("wrong encoding %x\nwant %x", out, want)
+	}
+	var dec Header
+	if err := dec.UnmarshalBinary(out); err != nil {
+		t.Fatal(err)
+	}
+	h.Comment = ""
+	if dec != h {
+		t.Fatalf("wrong result %+v", dec)
+	}
+}
+
+func TestBinaryRange(t *testing.T) {
+	for _, h := range []Header{{Length: -1}, {Length: 1 << 16}, {Seq: 1 << 32}, {Offset: math.MaxInt16 + 1}} {
+		if _, err := h.MarshalBinary(); err == nil {
+			t.Errorf("no error for %+v", h)
+		}
+	}
+}
+
+func TestBinaryInvalid(t *testing.T) {
+	var h Header
+	if err := h.UnmarshalBinary(make([]byte, 27)); err == nil {
+		t.Error("no error for short input")
+	}
+	data := make([]byte, 28)
+	data[3] = 2
+	if err := h.UnmarshalBinary(data); err == nil {
+		t.Error("no error for invalid bool")
+	}
+	data[3] = 0
+	data[12] = 1 // Delta exceeds int8
+	if err := h.UnmarshalBinary(data); err == nil || err.Error() != "value 16777216 of field Delta of Header out of range" {
+		t.Errorf("wrong error %v", err)
+	}
+}
+
+func TestBitFields(t *testing.T) {
+	p := Packet{Version: 4, Priority: 5, Urgent: true, Length: 0x0102, Channel: 0xabc, Window: 0x1f, Ack: true, Retries: 2}
+	out, err := p.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _ := hex.DecodeString("4b" + "0102" + "abc0fe")
+	if !bytes.Equal(out, want) {
+		t.Fatalf("wrong encoding %x\nwant %x", out, want)
+	}
+	var dec Packet
+	if err := dec.UnmarshalBinary(out); err != nil {
+		t.Fatal(err)
+	}
+	if dec != p {
+		t.Fatalf("wrong result %+v", dec)
+	}
+
+	for _, p := range []Packet{{Version: 16}, {Priority: 8}, {Channel: 1 << 12}, {Retries: 4}} {
+		if _, err := p.MarshalBinary(); err == nil {
+			t.Errorf("no error for %+v", p)
+		}
+	}
+	data, _ := hex.DecodeString("00" + "0000" + "000800")
+	if err := dec.UnmarshalBinary(data); err == nil || err.Error() != "value 256 of field Window of Packet out of range" {
+		t.Errorf("wrong error %v", err)
+	}
+}
+
+func TestChecksum(t *testing.T) {
+	f := Frame{Kind: 1, Payload: [4]byte{'a', 'b', 'c', 'd'}}
+	out, err := f.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sum := crc32.ChecksumIEEE(out[:5]); binary.BigEndian.Uint32(out[5:]) != sum {
+		t.Fatalf("wrong checksum in %x, want %x", out, sum)
+	}
+	var dec Frame
+	if err := dec.UnmarshalBinary(out); err != nil {
+		t.Fatal(err)
+	}
+	if dec.CRC != crc32.ChecksumIEEE(out[:5]) || dec.Payload != f.Payload {
+		t.Fatalf("wrong result %+v", dec)
+	}
+
+	out[2] ^= 1
+	var cerr *codecerr.ChecksumError
+	if err := dec.UnmarshalBinary(out); !errors.As(err, &cerr) {
+		t.Fatalf("wrong error %v", err)
+	}
+	if cerr.Type != "Frame" || cerr.Algorithm != "crc32" || cerr.Computed == cerr.Sum {
+		t.Errorf("wrong error fields %+v", cerr)
+	}
+
+	r := Record{ID: 42}
+	out, err = r.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rdec Record
+	if err := rdec.UnmarshalBinary(out); err != nil || rdec.ID != 42 {
+		t.Fatalf("wrong result %+v (err %v)", rdec, err)
+	}
+	out[15] ^= 1
+	if err := rdec.UnmarshalBinary(out); !errors.As(err, &cerr) || cerr.Algorithm != "xxhash" {
+		t.Fatalf("wrong error %v", err)
+	}
+}
@@ -0,0 +1,20 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type X -field-override Xo -formats json,cbor -out output.go
+
+package cbor
+
+type replacedInt int
+
+type X struct {
+	Required int    `cbor:"req" gencodec:"required"`
+	JSONKey  string `json:"jsonKey" gencodec:"required"`
+	Replaced int    `cbor:"1,keyasint"`
+	Skipped  int    `json:"-"`
+}
+
+type Xo struct {
+	Replaced replacedInt
+}
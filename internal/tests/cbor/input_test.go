@@ -0,0 +1,47 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package cbor
+
+import (
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+func TestRoundTrip(t *testing.T) {
+	x := X{Required: 1, JSONKey: "a", Replaced: 2, Skipped: 3}
+	enc, err := cbor.Marshal(&x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var m map[interface{}]interface{}
+	if err := cbor.Unmarshal(enc, &m); err != nil {
+		t.Fatal(err)
+	}
+	if len(m) != 3 || m["req"] != uint64(1) || m["jsonKey"] != "a" || m[uint64(1)] != uint64(2) {
+		t.Fatalf("wrong encoding %v", m)
+	}
+
+	var dec X
+	if err := cbor.Unmarshal(enc, &dec); err != nil {
+		t.Fatal(err)
+	}
+	x.Skipped = 0
+	if dec != x {
+		t.Fatalf("got %+v, want %+v", dec, x)
+	}
+}
+
+func TestMissingRequired(t *testing.T) {
+	enc, err := cbor.Marshal(map[string]interface{}{"req": 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var dec X
+	err = cbor.Unmarshal(enc, &dec)
+	if err == nil || err.Error() != "missing required field 'jsonKey' for X" {
+		t.Fatalf("wrong error %v", err)
+	}
+}
@@ -0,0 +1,17 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type Order,Item -cgo cexport/main.go -out output.go
+
+package cgo
+
+type Order struct {
+	ID    uint64 `json:"id" gencodec:"required"`
+	Items []Item `json:"items"`
+}
+
+type Item struct {
+	SKU      string `json:"sku" gencodec:"required"`
+	Quantity int    `json:"qty"`
+}
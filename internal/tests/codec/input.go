@@ -0,0 +1,14 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type X -codec -out output.go
+
+package codec
+
+type X struct {
+	UserID   int    `json:"user_id" gencodec:"required"`
+	UserName string `json:"user_name,omitempty"`
+	Email    string
+	Internal string `json:"-"`
+}
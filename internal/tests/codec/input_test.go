@@ -0,0 +1,131 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package codec
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+// camelCase converts snake_case keys to camelCase.
+func camelCase(key string) string {
+	parts := strings.Split(key, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+func TestCodecDefault(t *testing.T) {
+	x := X{UserID: 1, UserName: "fjl", Email: "fjl@example.com"}
+	output, err := XCodec.Marshal(&x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"user_id":1,"user_name":"fjl","Email":"fjl@example.com"}`
+	if string(output) != want {
+		t.Fatalf("got %#q, want %#q", output, want)
+	}
+}
+
+func TestCodecNameMapping(t *testing.T) {
+	codec := XCodec
+	codec.NameMapping = camelCase
+
+	x := X{UserID: 1, UserName: "fjl", Email: "fjl@example.com"}
+	output, err := codec.Marshal(&x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"userId":1,"userName":"fjl","Email":"fjl@example.com"}`
+	if string(output) != want {
+		t.Fatalf("got %#q, want %#q", output, want)
+	}
+
+	var dec X
+	if err := codec.Unmarshal(output, &dec); err != nil {
+		t.Fatal(err)
+	}
+	if dec != x {
+		t.Fatalf("got %+v, want %+v", dec, x)
+	}
+
+	err = codec.Unmarshal([]byte(`{"userName":"fjl"}`), &dec)
+	if err == nil || err.Error() != "missing required field 'user_id' for X" {
+		t.Fatalf("wrong error %v", err)
+	}
+}
+
+func TestCodecStrict(t *testing.T) {
+	codec := XCodec
+	codec.Strict = true
+
+	var dec X
+	if err := codec.Unmarshal([]byte(`{"user_id":1,"Email":"a"}`), &dec); err != nil {
+		t.Fatal(err)
+	}
+	err := codec.Unmarshal([]byte(`{"user_id":1,"email":"a"}`), &dec)
+	if err == nil || err.Error() != "unknown field 'email' for X" {
+		t.Fatalf("wrong error %v", err)
+	}
+	err = codec.Unmarshal([]byte(`{"user_id":1,"Internal":"a"}`), &dec)
+	if err == nil || err.Error() != "unknown field 'Internal' for X" {
+		t.Fatalf("wrong error %v", err)
+	}
+}
+
+func TestCodecRedact(t *testing.T) {
+	codec := XCodec
+	codec.Redact = map[string]bool{"Email": true}
+
+	x := X{UserID: 1, Email: "fjl@example.com"}
+	output, err := codec.Marshal(&x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"user_id":1,"Email":"[REDACTED]"}`
+	if string(output) != want {
+		t.Fatalf("got %#q, want %#q", output, want)
+	}
+}
+
+type decodeStats struct {
+	typ    string
+	size   int
+	calls  int
+	errors int
+}
+
+func (s *decodeStats) OnDecode(typ string, size int, elapsed time.Duration, err error) {
+	s.typ = typ
+	s.size += size
+	s.calls++
+	if err != nil {
+		s.errors++
+	}
+}
+
+func TestCodecMetrics(t *testing.T) {
+	stats := new(decodeStats)
+	XMetrics = stats
+	defer func() { XMetrics = nil }()
+
+	// Both json.Unmarshal and the codec decode through UnmarshalJSON, which reports each
+	// call once.
+	var dec X
+	json.Unmarshal([]byte(`{"user_id":1}`), &dec)
+	json.Unmarshal([]byte(`{}`), &dec)
+	codec := XCodec
+	codec.Strict = true
+	codec.Unmarshal([]byte(`{"user_id":2}`), &dec)
+	want := decodeStats{typ: "codec.X", size: 28, calls: 3, errors: 1}
+	if *stats != want {
+		t.Fatalf("got %+v, want %+v", *stats, want)
+	}
+}
@@ -0,0 +1,15 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package compat
+
+type X struct {
+	ID       int    `json:"id" gencodec:"required"`
+	Name     string `json:"name"`
+	Removed  string `json:"removed" gencodec:"required"`
+	Optional string `json:"optional"`
+	Count    int    `json:"count"`
+	Email    string `json:"email"`
+	Dropped  int    `json:"dropped"`
+}
@@ -0,0 +1,15 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package compat
+
+type X struct {
+	ID       int    `json:"id" gencodec:"required"`
+	Name     string `json:"name"`
+	Optional string `json:"optional" gencodec:"required"`
+	Count    int64  `json:"count"`
+	Email    string `json:"mail"`
+	Added    int    `json:"added" gencodec:"required"`
+	Extra    int    `json:"extra"`
+}
@@ -0,0 +1,52 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type X -field-override Xo -formats json,yaml -out output.go
+
+package conv
+
+import (
+	"errors"
+	"fmt"
+)
+
+type Level int
+
+const (
+	Debug Level = iota
+	Info
+	Error
+)
+
+var levelNames = []string{"debug", "info", "error"}
+
+func levelName(l Level) string { return levelNames[l] }
+
+func parseLevel(s string) (Level, error) {
+	for i, name := range levelNames {
+		if name == s {
+			return Level(i), nil
+		}
+	}
+	return 0, errors.New("unknown level " + s)
+}
+
+type RGB [3]byte
+
+func rgbHex(c RGB) string { return fmt.Sprintf("#%02x%02x%02x", c[0], c[1], c[2]) }
+
+func parseRGB(s string) (c RGB, err error) {
+	_, err = fmt.Sscanf(s, "#%02x%02x%02x", &c[0], &c[1], &c[2])
+	return c, err
+}
+
+type X struct {
+	Level Level `gencodec:"conv=levelName,parseLevel"`
+	Port  int   `gencodec:"conv=strconv.Itoa,strconv.Atoi"`
+	Color RGB
+}
+
+type Xo struct {
+	Color string `gencodec:"conv=rgbHex,parseRGB"`
+}
@@ -0,0 +1,55 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package conv
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestConvRoundTrip(t *testing.T) {
+	x := X{Level: Error, Port: 8080, Color: RGB{0xff, 0x80, 0x01}}
+	enc, err := json.Marshal(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"Level":"error","Port":"8080","Color":"#ff8001"}`
+	if string(enc) != want {
+		t.Fatalf("got %s, want %s", enc, want)
+	}
+	var dec X
+	if err := json.Unmarshal(enc, &dec); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(dec, x) {
+		t.Fatalf("JSON round trip mismatch\ngot  %+v\nwant %+v", dec, x)
+	}
+
+	yamlEnc, err := yaml.Marshal(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dec = X{}
+	if err := yaml.Unmarshal(yamlEnc, &dec); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(dec, x) {
+		t.Fatalf("YAML round trip mismatch\ngot  %+v\nwant %+v", dec, x)
+	}
+}
+
+func TestConvError(t *testing.T) {
+	var x X
+	err := json.Unmarshal([]byte(`{"Level":"trace"}`), &x)
+	if err == nil || err.Error() != "unknown level trace" {
+		t.Fatalf("wrong error: %v", err)
+	}
+	if err := json.Unmarshal([]byte(`{"Port":"http"}`), &x); err == nil {
+		t.Fatal("no error for invalid port")
+	}
+}
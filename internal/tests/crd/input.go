@@ -0,0 +1,44 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type WidgetSpec,WidgetStatus -helpers deepcopy -crd schema.yaml -out output.go
+
+package crd
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+)
+
+type WidgetSpec struct {
+	Replicas int32                  `json:"replicas" gencodec:"required"`
+	Image    string                 `json:"image" gencodec:"required"`
+	Labels   map[string]string      `json:"labels,omitempty" crd:"mapType=granular"`
+	Ports    []Port                 `json:"ports,omitempty" crd:"listType=map,listMapKey=name"`
+	Endpoint *url.URL               `json:"endpoint,omitempty"`
+	Schedule *Schedule              `json:"schedule,omitempty"`
+	Size     json.RawMessage        `json:"size,omitempty" crd:"intOrString"`
+	Template map[string]interface{} `json:"template,omitempty" crd:"preserveUnknownFields"`
+	Matrix   [2][]float64           `json:"matrix"`
+	Status   *WidgetStatus          `json:"-"`
+}
+
+type Port struct {
+	Name     string  `json:"name" gencodec:"required"`
+	Port     uint16  `json:"port" gencodec:"required"`
+	Protocol *string `json:"protocol,omitempty"`
+}
+
+type Schedule struct {
+	Start time.Time         `json:"start"`
+	Every time.Duration     `json:"every"`
+	Skip  map[string][]bool `json:"skip"`
+}
+
+type WidgetStatus struct {
+	Ready      bool                 `json:"ready"`
+	Conditions []map[string]*string `json:"conditions"`
+	Spec       *WidgetSpec          `json:"spec,omitempty"`
+}
@@ -0,0 +1,43 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package crd
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDeepCopy(t *testing.T) {
+	proto := "TCP"
+	spec := &WidgetSpec{
+		Replicas: 2,
+		Labels:   map[string]string{"app": "w"},
+		Ports:    []Port{{Name: "http", Port: 80, Protocol: &proto}},
+		Endpoint: &url.URL{Scheme: "https", Host: "example.com", User: url.User("u")},
+		Schedule: &Schedule{Start: time.Unix(0, 0), Skip: map[string][]bool{"a": {true}}},
+		Matrix:   [2][]float64{{1}, {2, 3}},
+		Status:   &WidgetStatus{Conditions: []map[string]*string{{"ok": &proto}}},
+	}
+	cpy := spec.DeepCopy()
+	if !reflect.DeepEqual(spec, cpy) {
+		t.Fatalf("copy not equal:\n%+v\n%+v", spec, cpy)
+	}
+
+	cpy.Labels["app"] = "x"
+	*cpy.Ports[0].Protocol = "UDP"
+	cpy.Endpoint.Host = "example.org"
+	cpy.Schedule.Skip["a"][0] = false
+	cpy.Matrix[1][0] = 4
+	*cpy.Status.Conditions[0]["ok"] = "no"
+	if spec.Labels["app"] != "w" || proto != "TCP" || spec.Endpoint.Host != "example.com" ||
+		!spec.Schedule.Skip["a"][0] || spec.Matrix[1][0] != 2 {
+		t.Errorf("modifying the copy changed the original: %+v", spec)
+	}
+	if (*WidgetSpec)(nil).DeepCopy() != nil {
+		t.Error("copy of nil isn't nil")
+	}
+}
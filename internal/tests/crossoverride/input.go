@@ -0,0 +1,12 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type X -field-override github.com/fjl/gencodec/internal/tests/crossoverride/marshalutil.XMarshaling -out output.go
+
+package crossoverride
+
+type X struct {
+	Number uint64 `json:"number"`
+	Name   string `json:"name"`
+}
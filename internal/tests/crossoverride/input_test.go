@@ -0,0 +1,27 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package crossoverride
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestOverride(t *testing.T) {
+	enc, err := json.Marshal(X{Number: 255, Name: "x"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(enc) != `{"number":"0xff","name":"x"}` {
+		t.Fatalf("wrong encoding %s", enc)
+	}
+	var dec X
+	if err := json.Unmarshal(enc, &dec); err != nil {
+		t.Fatal(err)
+	}
+	if dec.Number != 255 {
+		t.Fatalf("wrong decoded number %d", dec.Number)
+	}
+}
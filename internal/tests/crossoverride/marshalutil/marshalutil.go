@@ -0,0 +1,28 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+// Package marshalutil holds the field override type of package crossoverride.
+package marshalutil
+
+import "strconv"
+
+// Hex is a uint64 which marshals as a hex string.
+type Hex uint64
+
+func (h Hex) MarshalText() ([]byte, error) {
+	return []byte("0x" + strconv.FormatUint(uint64(h), 16)), nil
+}
+
+func (h *Hex) UnmarshalText(input []byte) error {
+	if len(input) < 2 || string(input[:2]) != "0x" {
+		return strconv.ErrSyntax
+	}
+	v, err := strconv.ParseUint(string(input[2:]), 16, 64)
+	*h = Hex(v)
+	return err
+}
+
+type XMarshaling struct {
+	Number Hex
+}
@@ -0,0 +1,19 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type Server -formats json,yaml -out output.go
+
+package defaults
+
+type Mode string
+
+type Server struct {
+	Host    string  `json:"host" yaml:"host" default:"localhost"`
+	Port    uint16  `json:"port" yaml:"port" default:"8080"`
+	Mode    Mode    `json:"mode" yaml:"mode" default:"release"`
+	Verbose bool    `json:"verbose" yaml:"verbose" default:"true"`
+	Ratio   float64 `json:"ratio" yaml:"ratio" default:"0.5"`
+	Name    string  `json:"name" yaml:"name" gencodec:"required"`
+	Comment string  `json:"comment" yaml:"comment"`
+}
@@ -0,0 +1,35 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package defaults
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestDefaults(t *testing.T) {
+	want := Server{Host: "localhost", Port: 8080, Mode: "release", Verbose: true, Ratio: 0.5, Name: "a"}
+
+	var s Server
+	if err := json.Unmarshal([]byte(`{"name":"a"}`), &s); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(s, want) {
+		t.Errorf("got %+v, want %+v", s, want)
+	}
+}
+
+func TestDefaultsOverwritten(t *testing.T) {
+	var s Server
+	input := `{"name":"a","host":"example.com","port":0,"verbose":false}`
+	if err := json.Unmarshal([]byte(input), &s); err != nil {
+		t.Fatal(err)
+	}
+	want := Server{Host: "example.com", Port: 0, Mode: "release", Verbose: false, Ratio: 0.5, Name: "a"}
+	if !reflect.DeepEqual(s, want) {
+		t.Errorf("got %+v, want %+v", s, want)
+	}
+}
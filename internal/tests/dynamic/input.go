@@ -0,0 +1,21 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type Event -out output.go
+
+package dynamic
+
+import "fmt"
+
+// Payload is implemented by types which are only known at runtime, like the
+// types of plugins.
+type Payload interface {
+	Kind() string
+}
+
+type Event struct {
+	ID      string       `json:"id" gencodec:"required"`
+	Payload Payload      `json:"payload" gencodec:"required,dynamic"`
+	Extra   fmt.Stringer `json:"extra,omitempty" gencodec:"dynamic"`
+}
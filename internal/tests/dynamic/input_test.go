@@ -0,0 +1,91 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package dynamic
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+type click struct {
+	X, Y int
+}
+
+func (click) Kind() string { return "click" }
+
+type label string
+
+func (l label) String() string { return string(l) }
+
+func init() {
+	RegisterEventCodec("click", click{},
+		func(v any) ([]byte, error) { return json.Marshal(v) },
+		func(input []byte) (any, error) {
+			var c click
+			err := json.Unmarshal(input, &c)
+			return c, err
+		},
+	)
+	RegisterEventCodec("label", label(""),
+		func(v any) ([]byte, error) { return json.Marshal(string(v.(label))) },
+		func(input []byte) (any, error) {
+			var s string
+			err := json.Unmarshal(input, &s)
+			return label(s), err
+		},
+	)
+}
+
+func TestDynamicJSON(t *testing.T) {
+	for _, test := range []struct {
+		input string
+		want  Event
+	}{
+		{
+			input: `{"id":"a","payload":{"type":"click","value":{"X":1,"Y":2}}}`,
+			want:  Event{ID: "a", Payload: click{1, 2}},
+		},
+		{
+			input: `{"id":"b","payload":{"type":"click","value":{"X":3,"Y":4}},"extra":{"type":"label","value":"x"}}`,
+			want:  Event{ID: "b", Payload: click{3, 4}, Extra: label("x")},
+		},
+	} {
+		var x Event
+		if err := json.Unmarshal([]byte(test.input), &x); err != nil {
+			t.Fatalf("%s: %v", test.input, err)
+		}
+		if !reflect.DeepEqual(x, test.want) {
+			t.Fatalf("%s: got %+v, want %+v", test.input, x, test.want)
+		}
+		out, err := json.Marshal(x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(out) != test.input {
+			t.Fatalf("got %#q, want %#q", string(out), test.input)
+		}
+	}
+}
+
+func TestDynamicErrors(t *testing.T) {
+	for _, input := range []string{
+		`{"id":"a"}`,
+		`{"id":"a","payload":{"type":"scroll","value":{}}}`,
+		`{"id":"a","payload":{"type":"label","value":"x"}}`,
+	} {
+		var x Event
+		if err := json.Unmarshal([]byte(input), &x); err == nil {
+			t.Errorf("expected error for %s", input)
+		}
+	}
+	if _, err := json.Marshal(Event{Payload: unregistered{}}); err == nil {
+		t.Error("expected error for unregistered type")
+	}
+}
+
+type unregistered struct{}
+
+func (unregistered) Kind() string { return "unregistered" }
@@ -0,0 +1,31 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type X -field-override Xo -formats json,yaml -out output.go
+
+package elemconv
+
+type Hash [4]byte
+
+type Bytes []byte
+
+type replacedInt int
+
+type X struct {
+	Hash   Hash
+	Hashes []Hash
+	ByName map[string]Hash
+	Nested [][]int
+	Matrix map[string][]int
+	Fixed  [2][]int
+}
+
+type Xo struct {
+	Hash   Bytes
+	Hashes []Bytes
+	ByName map[string]Bytes
+	Nested [][]replacedInt
+	Matrix map[string][]replacedInt
+	Fixed  [2][]replacedInt
+}
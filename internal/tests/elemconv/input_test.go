@@ -0,0 +1,81 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package elemconv
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestElemConvRoundTrip(t *testing.T) {
+	x := X{
+		Hash:   Hash{1, 2, 3, 4},
+		Hashes: []Hash{{5, 6, 7, 8}},
+		ByName: map[string]Hash{"a": {9}},
+		Nested: [][]int{{1, 2}, {}},
+		Matrix: map[string][]int{"m": {3}},
+		Fixed:  [2][]int{{4}, {}},
+	}
+	enc, err := json.Marshal(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var dec X
+	if err := json.Unmarshal(enc, &dec); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(dec, x) {
+		t.Fatalf("JSON round trip mismatch\ngot  %+v\nwant %+v", dec, x)
+	}
+
+	yamlEnc, err := yaml.Marshal(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dec = X{}
+	if err := yaml.Unmarshal(yamlEnc, &dec); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(dec, x) {
+		t.Fatalf("YAML round trip mismatch\ngot  %+v\nwant %+v", dec, x)
+	}
+}
+
+func TestElemConvNil(t *testing.T) {
+	x := X{Nested: [][]int{nil}, Matrix: map[string][]int{"m": nil}}
+	enc, err := json.Marshal(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"Hash":"AAAAAA==","Hashes":null,"ByName":null,"Nested":[null],"Matrix":{"m":null},"Fixed":[null,null]}`
+	if string(enc) != want {
+		t.Fatalf("got %s, want %s", enc, want)
+	}
+	var dec X
+	if err := json.Unmarshal(enc, &dec); err != nil {
+		t.Fatal(err)
+	}
+	if dec.Nested[0] != nil || dec.Matrix["m"] != nil {
+		t.Fatalf("nil elements not preserved: %+v", dec)
+	}
+}
+
+func TestElemConvLength(t *testing.T) {
+	inputs := []string{
+		`{"Hash":"AQID"}`,
+		`{"Hashes":["AQIDBAU="]}`,
+		`{"ByName":{"a":""}}`,
+	}
+	for _, input := range inputs {
+		var x X
+		err := json.Unmarshal([]byte(input), &x)
+		if err == nil || err.Error() != "Hash value must have length 4" {
+			t.Errorf("wrong error for %s: %v", input, err)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type X -field-override Xo -formats json,yaml -out output.go
+
+package embed
+
+type replacedInt int
+
+type X struct {
+	Base
+	meta
+	Named Base `json:"named"`
+	ID    string
+}
+
+type Base struct {
+	ID      int    // shadowed by X.ID
+	Version int    `json:"version" gencodec:"required"`
+	Owner   string // conflicts with meta.Owner
+}
+
+type meta struct {
+	Owner   string
+	Created int64 `json:"created"`
+}
+
+type Xo struct {
+	Created replacedInt
+}
@@ -0,0 +1,44 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package embed
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPromotedFields(t *testing.T) {
+	x := X{
+		Base:  Base{ID: 1, Version: 2, Owner: "a"},
+		meta:  meta{Owner: "b", Created: 3},
+		Named: Base{Version: 4},
+		ID:    "id",
+	}
+	output, err := json.Marshal(&x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"version":2,"created":3,"named":{"ID":0,"version":4,"Owner":""},"ID":"id"}`
+	if string(output) != want {
+		t.Fatalf("got %#q, want %#q", output, want)
+	}
+
+	var dec X
+	if err := json.Unmarshal(output, &dec); err != nil {
+		t.Fatal(err)
+	}
+	x.Base.ID, x.Base.Owner, x.meta.Owner = 0, "", ""
+	if dec != x {
+		t.Fatalf("got %+v, want %+v", dec, x)
+	}
+}
+
+func TestPromotedRequiredField(t *testing.T) {
+	var dec X
+	err := json.Unmarshal([]byte(`{"created":3}`), &dec)
+	if err == nil || err.Error() != "missing required field 'version' for X" {
+		t.Fatalf("wrong error %v", err)
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type Color,Size,Shirt -formats json,yaml -out output.go
+
+package enum
+
+type Color int
+
+const (
+	Red Color = iota + 1
+	Green
+	Blue
+
+	// DefaultColor is accepted when decoding, Red is encoded.
+	DefaultColor = Red
+)
+
+type Size uint8
+
+const (
+	Small Size = iota
+	Medium
+	Large
+	_
+	XLarge
+)
+
+type Shirt struct {
+	Color Color  `json:"color" yaml:"color" gencodec:"required"`
+	Size  Size   `json:"size" yaml:"size"`
+	Sizes []Size `json:"sizes,omitempty" yaml:"sizes,omitempty"`
+}
@@ -0,0 +1,75 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package enum
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestEnumJSON(t *testing.T) {
+	s := Shirt{Color: Blue, Size: XLarge, Sizes: []Size{Small, Large}}
+	enc, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"color":"Blue","size":"XLarge","sizes":["Small","Large"]}`
+	if string(enc) != want {
+		t.Fatalf("got %s, want %s", enc, want)
+	}
+	var dec Shirt
+	if err := json.Unmarshal(enc, &dec); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(dec, s) {
+		t.Fatalf("got %+v, want %+v", dec, s)
+	}
+}
+
+func TestEnumYAML(t *testing.T) {
+	s := Shirt{Color: Green, Size: Medium}
+	enc, err := yaml.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "color: Green\nsize: Medium\n"; string(enc) != want {
+		t.Fatalf("got %q, want %q", enc, want)
+	}
+	var dec Shirt
+	if err := yaml.Unmarshal(enc, &dec); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(dec, s) {
+		t.Fatalf("got %+v, want %+v", dec, s)
+	}
+}
+
+func TestEnumAlias(t *testing.T) {
+	var c Color
+	if err := json.Unmarshal([]byte(`"DefaultColor"`), &c); err != nil {
+		t.Fatal(err)
+	}
+	if c != Red {
+		t.Fatalf("got %v, want Red", c)
+	}
+	if enc, _ := json.Marshal(DefaultColor); string(enc) != `"Red"` {
+		t.Fatalf("DefaultColor encoded as %s", enc)
+	}
+}
+
+func TestEnumErrors(t *testing.T) {
+	if _, err := json.Marshal(Color(0)); err == nil {
+		t.Error("no error for invalid value")
+	}
+	for _, input := range []string{`"Purple"`, `1`, `null`, `"red"`} {
+		var c Color
+		if err := json.Unmarshal([]byte(input), &c); err == nil {
+			t.Errorf("no error for %s", input)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type Config -formats json,env -out output.go
+
+package env
+
+import "net/netip"
+
+type Mode string
+
+type Config struct {
+	Host    string     `json:"host" env:"APP_HOST" gencodec:"required"`
+	Port    uint16     `json:"port" env:"APP_PORT" gencodec:"required"`
+	Mode    Mode       `json:"mode" env:"APP_MODE" default:"dev"`
+	Debug   bool       `json:"debug" env:"APP_DEBUG"`
+	Ratio   *float64   `json:"ratio" env:"APP_RATIO"`
+	Addr    netip.Addr `json:"addr" env:"APP_ADDR"`
+	Secret  string     `json:"-" env:"APP_SECRET"`
+	Comment string     `json:"comment"`
+}
@@ -0,0 +1,77 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package env
+
+import (
+	"net/netip"
+	"strings"
+	"testing"
+)
+
+func lookupIn(env map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		v, ok := env[key]
+		return v, ok
+	}
+}
+
+func TestUnmarshalEnv(t *testing.T) {
+	env := map[string]string{
+		"APP_HOST":   "localhost",
+		"APP_PORT":   "8080",
+		"APP_DEBUG":  "true",
+		"APP_RATIO":  "0.5",
+		"APP_ADDR":   "10.0.0.1",
+		"APP_SECRET": "s3cret",
+		"Comment":    "ignored",
+	}
+	cfg := Config{Comment: "kept"}
+	if err := cfg.UnmarshalEnv(lookupIn(env)); err != nil {
+		t.Fatal(err)
+	}
+	switch {
+	case cfg.Host != "localhost", cfg.Port != 8080, !cfg.Debug, cfg.Secret != "s3cret":
+		t.Errorf("wrong basic fields: %+v", cfg)
+	case cfg.Mode != "dev":
+		t.Errorf("default not applied, mode %q", cfg.Mode)
+	case cfg.Ratio == nil || *cfg.Ratio != 0.5:
+		t.Errorf("wrong ratio %v", cfg.Ratio)
+	case cfg.Addr != netip.MustParseAddr("10.0.0.1"):
+		t.Errorf("wrong addr %v", cfg.Addr)
+	case cfg.Comment != "kept":
+		t.Errorf("untagged field changed: %q", cfg.Comment)
+	}
+}
+
+func TestUnmarshalEnvErrors(t *testing.T) {
+	tests := []struct {
+		env map[string]string
+		err string
+	}{
+		{
+			env: map[string]string{"APP_PORT": "8080"},
+			err: "missing required field 'APP_HOST' for Config",
+		},
+		{
+			env: map[string]string{"APP_HOST": "localhost"},
+			err: "missing required field 'APP_PORT' for Config",
+		},
+		{
+			env: map[string]string{"APP_HOST": "localhost", "APP_PORT": "99999"},
+			err: "invalid environment variable APP_PORT for Config: ",
+		},
+		{
+			env: map[string]string{"APP_HOST": "localhost", "APP_PORT": "1", "APP_ADDR": "nope"},
+			err: "invalid environment variable APP_ADDR for Config: ",
+		},
+	}
+	for _, test := range tests {
+		var cfg Config
+		err := cfg.UnmarshalEnv(lookupIn(test.env))
+		if err == nil || !strings.HasPrefix(err.Error(), test.err) {
+			t.Errorf("env %v: wrong error %v, want %q", test.env, err, test.err)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package fieldinfo
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFields(t *testing.T) {
+	fields := XFields()
+	if len(fields) != 3 {
+		t.Fatalf("wrong number of fields: %d", len(fields))
+	}
+	want := XFieldInfo{Name: "Name", Key: "name", Type: "string", Tag: `json:"name,omitempty" db:"name"`}
+	if fields[1] != want {
+		t.Errorf("wrong field info %+v", fields[1])
+	}
+	if !fields[0].Required || fields[2].Type != "*big.Int" {
+		t.Errorf("wrong field info %+v", fields)
+	}
+	// The tags of the original struct are reported.
+	f, _ := reflect.TypeOf(X{}).FieldByName(fields[1].Name)
+	if string(f.Tag) != fields[1].Tag {
+		t.Errorf("tag mismatch: %q != %q", f.Tag, fields[1].Tag)
+	}
+}
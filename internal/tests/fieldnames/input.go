@@ -0,0 +1,18 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type X -helpers fieldnames -out output.go
+
+package fieldnames
+
+type Inner struct {
+	A int `json:"a"`
+}
+
+type X struct {
+	GasLimit uint64 `json:"gasLimit" gencodec:"required"`
+	Plain    string
+	Skipped  int   `json:"-"`
+	Inner    Inner `gencodec:"flatten" prefix:"inner_"`
+}
@@ -0,0 +1,29 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package fieldnames
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestFieldNames(t *testing.T) {
+	enc, err := json.Marshal(X{GasLimit: 1, Plain: "a", Inner: Inner{A: 2}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(enc, &m); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{XFieldGasLimit, XFieldPlain, XFieldInnerA} {
+		if _, ok := m[key]; !ok {
+			t.Errorf("key %q missing in %s", key, enc)
+		}
+	}
+	if len(m) != 3 {
+		t.Errorf("wrong number of keys in %s", enc)
+	}
+}
@@ -0,0 +1,22 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type X -field-override Xo -helpers fingerprint -out output.go
+
+package fingerprint
+
+import "math/big"
+
+type replacedInt int
+
+type X struct {
+	ID      int      `json:"id" gencodec:"required"`
+	Name    string   `json:"name"`
+	Balance *big.Int `json:"balance"`
+	Cache   []byte   `json:"-"`
+}
+
+type Xo struct {
+	ID replacedInt
+}
@@ -0,0 +1,26 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type Config -helpers flags -out output.go
+
+package flags
+
+import (
+	"net/netip"
+	"time"
+)
+
+type Mode string
+
+type Config struct {
+	Host    string        `json:"host" flag:"host" usage:"listening host"`
+	Port    int           `json:"port" flag:"port" usage:"listening port" default:"8080"`
+	Mode    Mode          `json:"mode" flag:"mode" default:"dev"`
+	Verbose bool          `json:"verbose" flag:"v"`
+	Workers uint16        `json:"workers" flag:"workers" default:"4"`
+	Ratio   *float64      `json:"ratio" flag:"ratio"`
+	Timeout time.Duration `json:"timeout" flag:"timeout"`
+	Addr    netip.Addr    `json:"addr" flag:"addr"`
+	Comment string        `json:"comment"`
+}
@@ -0,0 +1,59 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package flags
+
+import (
+	"flag"
+	"io"
+	"net/netip"
+	"testing"
+	"time"
+)
+
+func TestRegisterFlags(t *testing.T) {
+	// Values decoded from a config file are the defaults of the flags.
+	var cfg Config
+	if err := cfg.UnmarshalJSON([]byte(`{"host":"example.com","timeout":5000000000}`)); err != nil {
+		t.Fatal(err)
+	}
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	cfg.RegisterFlags(fs)
+	if cfg.Port != 8080 || cfg.Mode != "dev" || cfg.Workers != 4 {
+		t.Errorf("defaults not applied: %+v", cfg)
+	}
+	if f := fs.Lookup("host"); f.DefValue != "example.com" || f.Usage != "listening host" {
+		t.Errorf("wrong host flag %+v", f)
+	}
+
+	args := []string{"-port", "9000", "-mode", "prod", "-v", "-workers", "16", "-ratio", "0.5", "-timeout", "1m", "-addr", "10.0.0.1"}
+	if err := fs.Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	switch {
+	case cfg.Host != "example.com", cfg.Port != 9000, cfg.Mode != "prod", !cfg.Verbose, cfg.Workers != 16:
+		t.Errorf("wrong basic fields: %+v", cfg)
+	case cfg.Ratio == nil || *cfg.Ratio != 0.5:
+		t.Errorf("wrong ratio %v", cfg.Ratio)
+	case cfg.Timeout != time.Minute:
+		t.Errorf("wrong timeout %v", cfg.Timeout)
+	case cfg.Addr != netip.MustParseAddr("10.0.0.1"):
+		t.Errorf("wrong addr %v", cfg.Addr)
+	}
+	if fs.Lookup("comment") != nil {
+		t.Error("flag defined for untagged field")
+	}
+}
+
+func TestRegisterFlagsInvalid(t *testing.T) {
+	for _, args := range [][]string{{"-workers", "70000"}, {"-addr", "x"}, {"-ratio", "y"}} {
+		var cfg Config
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		cfg.RegisterFlags(fs)
+		if err := fs.Parse(args); err == nil {
+			t.Errorf("no error for %v", args)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type X -field-override Xo -formats json,yaml,toml -out output.go
+
+package flatten
+
+type replacedInt int
+
+type X struct {
+	A     int
+	Inner Inner `gencodec:"flatten"`
+}
+
+type Inner struct {
+	B    string `json:"b" gencodec:"required"`
+	C    int
+	Deep struct {
+		D uint `json:"d"`
+	} `gencodec:"flatten"`
+}
+
+type Xo struct {
+	C replacedInt
+}
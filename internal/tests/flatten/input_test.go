@@ -0,0 +1,32 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package flatten
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestFlattenJSON(t *testing.T) {
+	input := `{"A":1,"b":"x","C":2,"d":3}`
+	var x X
+	if err := json.Unmarshal([]byte(input), &x); err != nil {
+		t.Fatal("unexpected error", err)
+	}
+	if x.A != 1 || x.Inner.B != "x" || x.Inner.C != 2 || x.Inner.Deep.D != 3 {
+		t.Fatalf("wrong result %+v", x)
+	}
+	out, err := json.Marshal(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != input {
+		t.Fatalf("got %#q, want %#q", string(out), input)
+	}
+
+	if err := json.Unmarshal([]byte(`{"A":1}`), &x); err == nil {
+		t.Fatal("expected error for missing flattened field")
+	}
+}
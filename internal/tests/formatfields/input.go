@@ -0,0 +1,13 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type X -formats json,yaml -out output.go
+
+package formatfields
+
+type X struct {
+	Name   string `json:"name" yaml:"name" gencodec:"required"`
+	Secret string `json:"secret" yaml:"-"`
+	Note   string `json:"-" yaml:"note" gencodec:"required"`
+}
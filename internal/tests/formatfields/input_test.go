@@ -0,0 +1,52 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package formatfields
+
+import (
+	"encoding/json"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestFormatFieldsJSON(t *testing.T) {
+	x := X{Name: "a", Secret: "s", Note: "x"}
+	out, err := json.Marshal(&x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"name":"a","secret":"s"}`; string(out) != want {
+		t.Fatalf("wrong output %s\nwant %s", out, want)
+	}
+	// Note is required in YAML only.
+	var dec X
+	if err := json.Unmarshal(out, &dec); err != nil {
+		t.Fatal(err)
+	}
+	if dec.Secret != "s" || dec.Note != "" {
+		t.Fatalf("wrong result %+v", dec)
+	}
+}
+
+func TestFormatFieldsYAML(t *testing.T) {
+	x := X{Name: "a", Secret: "s", Note: "x"}
+	out, err := yaml.Marshal(&x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "name: a\nnote: x\n"; string(out) != want {
+		t.Fatalf("wrong output %q\nwant %q", out, want)
+	}
+	var dec X
+	if err := yaml.Unmarshal([]byte("name: a\nsecret: s\n"), &dec); err == nil {
+		t.Fatal("no error for missing note")
+	}
+	if err := yaml.Unmarshal(out, &dec); err != nil {
+		t.Fatal(err)
+	}
+	if dec.Secret != "" || dec.Note != "x" {
+		t.Fatalf("wrong result %+v", dec)
+	}
+}
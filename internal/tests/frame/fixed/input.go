@@ -0,0 +1,12 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type Message -helpers frame32 -out output.go
+
+package fixed
+
+type Message struct {
+	Seq  uint64 `json:"seq" gencodec:"required"`
+	Body string `json:"body"`
+}
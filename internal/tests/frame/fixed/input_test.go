@@ -0,0 +1,42 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package fixed
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"testing"
+)
+
+func TestFrame(t *testing.T) {
+	var buf bytes.Buffer
+	want := Message{Seq: 7, Body: "hi"}
+	if err := want.WriteFrame(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if size := binary.BigEndian.Uint32(buf.Bytes()); int(size) != buf.Len()-4 {
+		t.Fatalf("wrong prefix %d for frame of %d bytes", size, buf.Len())
+	}
+	var m Message
+	if err := m.ReadFrame(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if m != want {
+		t.Errorf("got %+v, want %+v", m, want)
+	}
+	if err := m.ReadFrame(&buf); err != io.EOF {
+		t.Errorf("wrong error at end of input: %v", err)
+	}
+	if err := m.ReadFrame(bytes.NewReader([]byte{0, 0})); err != io.ErrUnexpectedEOF {
+		t.Errorf("wrong error for truncated prefix: %v", err)
+	}
+
+	MessageMaxFrameSize = 4
+	defer func() { MessageMaxFrameSize = 16 << 20 }()
+	if err := want.WriteFrame(&buf); err == nil {
+		t.Error("no error for oversized frame")
+	}
+}
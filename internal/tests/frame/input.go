@@ -0,0 +1,12 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type Message -helpers frame -out output.go
+
+package frame
+
+type Message struct {
+	Seq  uint64 `json:"seq" gencodec:"required"`
+	Body string `json:"body"`
+}
@@ -0,0 +1,63 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package frame
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestFrame(t *testing.T) {
+	var buf bytes.Buffer
+	msgs := []Message{{Seq: 1, Body: "hello"}, {Seq: 2, Body: strings.Repeat("x", 300)}}
+	for _, m := range msgs {
+		if err := m.WriteFrame(&buf); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The second body needs a two-byte prefix.
+	if buf.Bytes()[0] != byte(len(`{"seq":1,"body":"hello"}`)) {
+		t.Fatalf("wrong prefix %x", buf.Bytes()[0])
+	}
+	for i, want := range msgs {
+		var m Message
+		if err := m.ReadFrame(&buf); err != nil {
+			t.Fatalf("frame %d: %v", i, err)
+		}
+		if m != want {
+			t.Errorf("frame %d: got %+v, want %+v", i, m, want)
+		}
+	}
+	var m Message
+	if err := m.ReadFrame(&buf); err != io.EOF {
+		t.Errorf("wrong error at end of input: %v", err)
+	}
+}
+
+func TestFrameErrors(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (&Message{Seq: 1}).WriteFrame(&buf); err != nil {
+		t.Fatal(err)
+	}
+	frame := buf.Bytes()
+	var m Message
+	if err := m.ReadFrame(bytes.NewReader(frame[:len(frame)-1])); err != io.ErrUnexpectedEOF {
+		t.Errorf("wrong error for truncated frame: %v", err)
+	}
+	if err := m.ReadFrame(bytes.NewReader([]byte{0x80})); err != io.ErrUnexpectedEOF {
+		t.Errorf("wrong error for truncated prefix: %v", err)
+	}
+	if err := m.ReadFrame(bytes.NewReader([]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x7f})); err == nil {
+		t.Error("no error for overflowing prefix")
+	}
+	if err := m.ReadFrame(bytes.NewReader([]byte{0x80, 0x80, 0x80, 0x10})); err == nil {
+		t.Error("no error for oversized frame")
+	}
+	if err := m.ReadFrame(bytes.NewReader([]byte{2, '{', '}'})); err == nil {
+		t.Error("no error for missing required field")
+	}
+}
@@ -0,0 +1,17 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type Server -formats json,yaml -gen-fuzz output_test.go -gen-fuzz-corpus testdata/fuzz -fuzz-fixtures testdata/*.json -out output.go
+
+package fuzz
+
+type Server struct {
+	Name    string            `json:"name" yaml:"name" gencodec:"required"`
+	Port    uint16            `json:"port" yaml:"port" gencodec:"required"`
+	Hosts   []string          `json:"hosts" yaml:"hosts" gencodec:"required"`
+	Labels  map[string]string `json:"labels" yaml:"labels"`
+	Timeout *float64          `json:"timeout" yaml:"timeout"`
+	Mode    string            `json:"mode" yaml:"mode" default:"active"`
+	TLS     bool              `json:"tls" yaml:"tls"`
+}
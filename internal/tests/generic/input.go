@@ -0,0 +1,26 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type Envelope -field-override envelopeMarshaling -formats json,yaml -out output.go
+
+package generic
+
+type Number interface {
+	~int | ~int64 | ~uint64
+}
+
+type seq uint64
+
+type Envelope[T any, N Number] struct {
+	Kind  string          `json:"kind" gencodec:"required"`
+	Seq   uint64          `json:"seq"`
+	Data  T               `json:"data" gencodec:"required"`
+	Items []T             `json:"items"`
+	Index map[string]N    `json:"index"`
+	Prev  *Envelope[T, N] `json:"prev"`
+}
+
+type envelopeMarshaling struct {
+	Seq seq
+}
@@ -0,0 +1,44 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package generic
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+type point struct {
+	X, Y int
+}
+
+func TestRoundTrip(t *testing.T) {
+	e := Envelope[point, int64]{
+		Kind:  "move",
+		Seq:   3,
+		Data:  point{1, 2},
+		Index: map[string]int64{"a": 1},
+		Prev:  &Envelope[point, int64]{Kind: "start", Data: point{0, 0}},
+	}
+	enc, err := json.Marshal(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var dec Envelope[point, int64]
+	if err := json.Unmarshal(enc, &dec); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(dec, e) {
+		t.Fatalf("decoded value mismatch\ngot:  %+v\nwant: %+v", dec, e)
+	}
+}
+
+func TestRequiredTypeParamField(t *testing.T) {
+	var dec Envelope[string, int]
+	err := json.Unmarshal([]byte(`{"kind":"x"}`), &dec)
+	if err == nil || err.Error() != "missing required field 'data' for Envelope" {
+		t.Fatalf("got error %v", err)
+	}
+}
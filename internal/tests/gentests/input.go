@@ -0,0 +1,30 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type Order,item -gen-tests output_test.go -test-fixtures testdata/fixtures -out output.go
+
+package gentests
+
+import (
+	"math/big"
+	"time"
+)
+
+type Order struct {
+	ID       uint64     `json:"id" gencodec:"required"`
+	Customer string     `json:"customer" gencodec:"required"`
+	Amount   *big.Int   `json:"amount" gencodec:"required"`
+	Items    []item     `json:"items" gencodec:"required"`
+	Status   string     `json:"status" default:"new"`
+	Express  bool       `json:"express"`
+	Note     *string    `json:"note"`
+	Placed   time.Time  `json:"placed"`
+	Shipped  *time.Time `json:"shipped,omitempty"`
+	Internal string     `json:"-"`
+}
+
+type item struct {
+	SKU   string  `json:"sku" gencodec:"required"`
+	Price float64 `json:"price"`
+}
@@ -0,0 +1,43 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type X -field-override Xo -graphql schema.graphql -out output.go
+
+package graphql
+
+import (
+	"math/big"
+	"time"
+)
+
+type hexBig big.Int
+
+func (h hexBig) MarshalText() ([]byte, error)   { return (*big.Int)(&h).MarshalText() }
+func (h *hexBig) UnmarshalText(b []byte) error { return (*big.Int)(h).UnmarshalText(b) }
+
+type Item struct {
+	Name string `json:"name"`
+}
+
+type Bounds struct {
+	Min int `json:"min"`
+}
+
+type X struct {
+	ID       int       `json:"id" gencodec:"required"`
+	UserID   string    `json:"user_id"`
+	Balance  *big.Int  `json:"balance"`
+	Note     *string   `json:"note"`
+	Tags     []string  `json:"tags"`
+	Items    []Item    `json:"items,omitempty"`
+	Created  time.Time `json:"created"`
+	Ratio    float64   `json:"ratio,omitempty"`
+	Data     []byte    `json:"data"`
+	Bounds   Bounds    `json:"bounds" gencodec:"flatten"`
+	Internal string    `json:"-"`
+}
+
+type Xo struct {
+	Balance *hexBig
+}
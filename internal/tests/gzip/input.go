@@ -0,0 +1,12 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type Snapshot -formats json,cbor -helpers gzip -out output.go
+
+package gzip
+
+type Snapshot struct {
+	Height uint64   `json:"height" gencodec:"required"`
+	Blocks []string `json:"blocks"`
+}
@@ -0,0 +1,66 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package gzip
+
+import (
+	"bytes"
+	"compress/gzip"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGzip(t *testing.T) {
+	s := Snapshot{Height: 10, Blocks: []string{strings.Repeat("ab", 1000)}}
+	for _, codec := range []struct {
+		name      string
+		marshal   func() ([]byte, error)
+		unmarshal func(*Snapshot, []byte) error
+	}{
+		{"JSON", s.MarshalJSONGzip, (*Snapshot).UnmarshalJSONGzip},
+		{"CBOR", s.MarshalCBORGzip, (*Snapshot).UnmarshalCBORGzip},
+	} {
+		data, err := codec.marshal()
+		if err != nil {
+			t.Fatalf("%s: %v", codec.name, err)
+		}
+		if len(data) > 200 {
+			t.Errorf("%s: output of %d bytes is not compressed", codec.name, len(data))
+		}
+		var dec Snapshot
+		if err := codec.unmarshal(&dec, data); err != nil {
+			t.Fatalf("%s: %v", codec.name, err)
+		}
+		if !reflect.DeepEqual(dec, s) {
+			t.Errorf("%s: wrong result %+v", codec.name, dec)
+		}
+	}
+}
+
+func TestGzipErrors(t *testing.T) {
+	var dec Snapshot
+	if err := dec.UnmarshalJSONGzip([]byte(`{"height":1}`)); err == nil {
+		t.Error("no error for uncompressed input")
+	}
+	if err := dec.UnmarshalJSONGzip(compress(`{}`)); err == nil || err.Error() != "missing required field 'height' for Snapshot" {
+		t.Errorf("wrong error %v", err)
+	}
+
+	// Input decompressing to more than the limit is rejected.
+	SnapshotMaxDecompressedSize = 1024
+	defer func() { SnapshotMaxDecompressedSize = 64 << 20 }()
+	bomb := compress(`{"height":1,"blocks":["` + strings.Repeat("a", 1<<20) + `"]}`)
+	if err := dec.UnmarshalJSONGzip(bomb); err == nil || err.Error() != "decompressed Snapshot input exceeds 1024 bytes" {
+		t.Errorf("wrong error %v", err)
+	}
+}
+
+func compress(s string) []byte {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	zw.Write([]byte(s))
+	zw.Close()
+	return buf.Bytes()
+}
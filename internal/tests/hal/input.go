@@ -0,0 +1,22 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type X -helpers hal -out output.go
+
+package hal
+
+import "strconv"
+
+type link struct {
+	Href string `json:"href"`
+}
+
+type X struct {
+	ID   int `gencodec:"required"`
+	Name string
+}
+
+func (x *X) HALLinks() map[string]link {
+	return map[string]link{"self": {Href: "/x/" + strconv.Itoa(x.ID)}}
+}
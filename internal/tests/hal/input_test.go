@@ -0,0 +1,18 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package hal
+
+import "testing"
+
+func TestMarshalHAL(t *testing.T) {
+	enc, err := X{ID: 5, Name: "five"}.MarshalHAL()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"ID":5,"Name":"five","_links":{"self":{"href":"/x/5"}}}`
+	if string(enc) != want {
+		t.Fatalf("wrong encoding\ngot:  %s\nwant: %s", enc, want)
+	}
+}
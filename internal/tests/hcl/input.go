@@ -0,0 +1,27 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type Config,Listener,TLS -formats hcl -out output.go
+
+package hcl
+
+type Config struct {
+	Name      string     `hcl:"name" gencodec:"required"`
+	Workers   int        `hcl:"workers,optional" default:"4"`
+	Tags      []string   `hcl:"tags,optional"`
+	Debug     *bool      `hcl:"debug"`
+	Listeners []Listener `hcl:"listener,block" gencodec:"required"`
+	TLS       *TLS       `hcl:"tls,block"`
+	Comment   string
+}
+
+type Listener struct {
+	Name string `hcl:"name,label"`
+	Port uint16 `hcl:"port" gencodec:"required"`
+}
+
+type TLS struct {
+	Cert string `hcl:"cert" gencodec:"required"`
+	Key  string `hcl:"key" gencodec:"required"`
+}
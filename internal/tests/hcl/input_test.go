@@ -0,0 +1,109 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package hcl
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+func decode(t *testing.T, src string) (Config, hcl.Diagnostics) {
+	t.Helper()
+	file, diags := hclsyntax.ParseConfig([]byte(src), "test.hcl", hcl.Pos{Line: 1, Column: 1})
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	cfg := Config{Comment: "kept"}
+	diags = cfg.DecodeHCL(file.Body, nil)
+	return cfg, diags
+}
+
+func TestDecodeHCL(t *testing.T) {
+	cfg, diags := decode(t, `
+name = "server"
+tags = ["a", "b"]
+debug = true
+
+listener "http" {
+  port = 80
+}
+listener "https" {
+  port = 443
+}
+tls {
+  cert = "cert.pem"
+  key  = "key.pem"
+}
+`)
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	switch {
+	case cfg.Name != "server", len(cfg.Tags) != 2, cfg.Debug == nil || !*cfg.Debug:
+		t.Errorf("wrong attributes: %+v", cfg)
+	case cfg.Workers != 4:
+		t.Errorf("default not applied, workers %d", cfg.Workers)
+	case len(cfg.Listeners) != 2 || cfg.Listeners[0] != Listener{"http", 80} || cfg.Listeners[1] != Listener{"https", 443}:
+		t.Errorf("wrong listeners %+v", cfg.Listeners)
+	case cfg.TLS == nil || *cfg.TLS != TLS{Cert: "cert.pem", Key: "key.pem"}:
+		t.Errorf("wrong tls block %+v", cfg.TLS)
+	case cfg.Comment != "kept":
+		t.Errorf("untagged field changed: %q", cfg.Comment)
+	}
+}
+
+func TestDecodeHCLErrors(t *testing.T) {
+	tests := []struct {
+		src, err string
+	}{
+		{
+			src: `listener "http" { port = 80 }`,
+			err: `Missing required argument; The argument "name" is required`,
+		},
+		{
+			src: `name = "server"`,
+			err: "Missing listener block",
+		},
+		{
+			src: `name = "server"
+listener "http" {}`,
+			err: `Missing required argument; The argument "port" is required`,
+		},
+		{
+			src: `name = "server"
+listener "http" { port = 80 }
+tls {
+  cert = "a"
+  key  = "b"
+}
+tls {
+  cert = "c"
+  key  = "d"
+}`,
+			err: "Duplicate tls block",
+		},
+		{
+			src: `name = "server"
+workers = "many"
+listener "http" { port = 80 }`,
+			err: "Unsuitable value type",
+		},
+		{
+			src: `name = "server"
+unknown = 1
+listener "http" { port = 80 }`,
+			err: "Unsupported argument",
+		},
+	}
+	for _, test := range tests {
+		_, diags := decode(t, test.src)
+		if !diags.HasErrors() || !strings.Contains(diags.Error(), test.err) {
+			t.Errorf("source %q: wrong diagnostics %v, want %q", test.src, diags, test.err)
+		}
+	}
+}
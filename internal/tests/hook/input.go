@@ -0,0 +1,53 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type X -formats json,yaml -helpers jsonstream -out output.go
+
+package hook
+
+import (
+	"encoding/hex"
+	"errors"
+)
+
+type Hash [4]byte
+
+type X struct {
+	Hash  Hash   `json:"hash" yaml:"hash" gencodec:"required,hook=appendHash,parseHash"`
+	Alt   *Hash  `json:"alt" yaml:"alt" gencodec:"hook=appendAlt,parseAlt"`
+	Label string `json:"label" yaml:"label"`
+}
+
+// appendHash writes h as a hex string.
+func appendHash(dst []byte, h Hash) []byte {
+	dst = append(dst, '"')
+	dst = hex.AppendEncode(dst, h[:])
+	return append(dst, '"')
+}
+
+// parseHash reads a hex string. It doesn't handle escapes.
+func parseHash(src []byte) (h Hash, n int, err error) {
+	if len(src) < 2+2*len(h) || src[0] != '"' || src[1+2*len(h)] != '"' {
+		return h, 0, errors.New("invalid hash")
+	}
+	if _, err := hex.Decode(h[:], src[1:1+2*len(h)]); err != nil {
+		return h, 0, err
+	}
+	return h, 2 + 2*len(h), nil
+}
+
+func appendAlt(dst []byte, h *Hash) []byte {
+	if h == nil {
+		return append(dst, "null"...)
+	}
+	return appendHash(dst, *h)
+}
+
+func parseAlt(src []byte) (*Hash, int, error) {
+	if string(src) == "null" {
+		return nil, 4, nil
+	}
+	h, n, err := parseHash(src)
+	return &h, n, err
+}
@@ -0,0 +1,75 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package hook
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestHookJSON(t *testing.T) {
+	x := X{Hash: Hash{1, 2, 3, 4}, Label: "l"}
+	out, err := json.Marshal(&x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"hash":"01020304","alt":null,"label":"l"}`; string(out) != want {
+		t.Fatalf("wrong output %s\nwant %s", out, want)
+	}
+	var dec X
+	if err := json.Unmarshal([]byte(`{"hash": "01020304", "alt": "0a0b0c0d", "label": "l"}`), &dec); err != nil {
+		t.Fatal(err)
+	}
+	want := X{Hash: Hash{1, 2, 3, 4}, Alt: &Hash{10, 11, 12, 13}, Label: "l"}
+	if !reflect.DeepEqual(dec, want) {
+		t.Fatalf("wrong result %+v", dec)
+	}
+}
+
+func TestHookErrors(t *testing.T) {
+	tests := map[string]string{
+		`{}`:                     "missing required field 'hash' for X",
+		`{"hash": "0102"}`:       "invalid hash",
+		`{"hash": 1}`:            "invalid hash",
+		`{"hash": "0102030405"}`: "invalid hash",
+	}
+	for input, want := range tests {
+		var x X
+		if err := json.Unmarshal([]byte(input), &x); err == nil || err.Error() != want {
+			t.Errorf("%s: wrong error %v, want %q", input, err, want)
+		}
+	}
+}
+
+func TestHookDecodeJSON(t *testing.T) {
+	var x X
+	d := json.NewDecoder(strings.NewReader(`{"alt": null, "hash": "ffffffff"}`))
+	if err := x.DecodeJSON(d); err != nil {
+		t.Fatal(err)
+	}
+	if x.Hash != (Hash{255, 255, 255, 255}) || x.Alt != nil {
+		t.Fatalf("wrong result %+v", x)
+	}
+}
+
+// YAML is not affected by the hook option.
+func TestHookYAML(t *testing.T) {
+	x := X{Hash: Hash{1, 2, 3, 4}}
+	out, err := yaml.Marshal(&x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var dec X
+	if err := yaml.Unmarshal(out, &dec); err != nil {
+		t.Fatal(err)
+	}
+	if dec.Hash != x.Hash {
+		t.Fatalf("wrong result %+v\n%s", dec, out)
+	}
+}
@@ -0,0 +1,15 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type X -formats json,yaml -intermediate-suffix Wire,yaml=YAML -out output.go
+
+package intersuffix
+
+// XWire already exists, the intermediate type in the methods shadows it.
+type XWire struct{}
+
+type X struct {
+	Count int    `json:"count" yaml:"count" gencodec:"required"`
+	Name  string `json:"name" yaml:"name"`
+}
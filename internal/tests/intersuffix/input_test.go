@@ -0,0 +1,19 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package intersuffix
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestInterSuffixError(t *testing.T) {
+	var x X
+	err := json.Unmarshal([]byte(`{"count":"1"}`), &x)
+	if err == nil || !strings.Contains(err.Error(), "XWire.count") {
+		t.Fatalf("error %v doesn't name the intermediate type", err)
+	}
+}
@@ -0,0 +1,20 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type Article -helpers jsonapi -out output.go
+
+package jsonapi
+
+type personID string
+
+type Article struct {
+	ID       uint64    `jsonapi:"primary,articles"`
+	Title    string    `jsonapi:"attr,title" gencodec:"required"`
+	Summary  string    `jsonapi:"attr,summary,omitempty"`
+	Views    *int      `jsonapi:"attr,views"`
+	Author   personID  `jsonapi:"relation,author,people" gencodec:"required"`
+	Editor   *personID `jsonapi:"relation,editor,people"`
+	Comments []uint64  `jsonapi:"relation,comments,comments"`
+	Internal string
+}
@@ -0,0 +1,64 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package jsonapi
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRoundTrip(t *testing.T) {
+	editor := personID("bob")
+	views := 7
+	a := Article{
+		ID:       42,
+		Title:    "Hello",
+		Views:    &views,
+		Author:   "alice",
+		Editor:   &editor,
+		Comments: []uint64{1, 2},
+	}
+	enc, err := a.MarshalJSONAPI()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"data":{"type":"articles","id":"42","attributes":{"title":"Hello","views":7},"relationships":{"author":{"data":{"type":"people","id":"alice"}},"comments":{"data":[{"type":"comments","id":"1"},{"type":"comments","id":"2"}]},"editor":{"data":{"type":"people","id":"bob"}}}}}`
+	if string(enc) != want {
+		t.Fatalf("wrong encoding\ngot:  %s\nwant: %s", enc, want)
+	}
+	var dec Article
+	if err := dec.UnmarshalJSONAPI(enc); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(dec, a) {
+		t.Fatalf("decoded value mismatch\ngot:  %+v\nwant: %+v", dec, a)
+	}
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	tests := []struct {
+		input, err string
+	}{
+		{
+			input: `{"data":{"type":"people","id":"1"}}`,
+			err:   `wrong resource type "people" for Article`,
+		},
+		{
+			input: `{"data":{"type":"articles","id":"1","attributes":{}}}`,
+			err:   "missing required field 'title' for Article",
+		},
+		{
+			input: `{"data":{"type":"articles","id":"1","attributes":{"title":"x"}}}`,
+			err:   "missing required relationship 'author' for Article",
+		},
+	}
+	for _, test := range tests {
+		var a Article
+		err := a.UnmarshalJSONAPI([]byte(test.input))
+		if err == nil || err.Error() != test.err {
+			t.Errorf("input %s: got error %v, want %q", test.input, err, test.err)
+		}
+	}
+}
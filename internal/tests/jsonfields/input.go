@@ -0,0 +1,27 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type Transaction -helpers jsonfields -out output.go
+
+package jsonfields
+
+import (
+	"math/big"
+	"net/url"
+)
+
+type Transaction struct {
+	Nonce    uint64   `json:"nonce" gencodec:"required"`
+	GasLimit uint64   `json:"gas" gencodec:"required"`
+	Kind     string   `json:"kind" default:"legacy"`
+	To       *url.URL `json:"to"`
+	Value    *big.Int `json:"value"`
+	Data     []byte   `json:"input"`
+	Meta     Meta     `json:"meta"`
+	Local    string   `json:"-"`
+}
+
+type Meta struct {
+	Source string `json:"source"`
+}
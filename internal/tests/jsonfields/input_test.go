@@ -0,0 +1,53 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package jsonfields
+
+import (
+	"testing"
+)
+
+func TestDecodeJSONFields(t *testing.T) {
+	// The input is cut off after the requested fields, which isn't noticed
+	// because reading stops when they have been found.
+	input := `{"nonce": 3, "input": "AAEC", "meta": {"source": [1, {"x": 2}]}, "gas": 21000, "to": "https://example.com`
+	tx := Transaction{Local: "x"}
+	if err := tx.DecodeJSONFields([]byte(input), "gas", "nonce"); err != nil {
+		t.Fatal(err)
+	}
+	if tx.Nonce != 3 || tx.GasLimit != 21000 || tx.Data != nil || tx.Meta.Source != "" || tx.Local != "x" {
+		t.Errorf("wrong result %+v", tx)
+	}
+}
+
+func TestDecodeJSONFieldsDefault(t *testing.T) {
+	var tx Transaction
+	if err := tx.DecodeJSONFields([]byte(`{"to": "https://example.com/a"}`), "kind", "to"); err != nil {
+		t.Fatal(err)
+	}
+	if tx.Kind != "legacy" || tx.To.Path != "/a" {
+		t.Errorf("wrong result %+v", tx)
+	}
+}
+
+func TestDecodeJSONFieldsErrors(t *testing.T) {
+	tests := []struct {
+		input  string
+		fields []string
+		err    string
+	}{
+		{`{}`, []string{"gas", "local"}, "unknown field 'local' for Transaction"},
+		{`{"nonce": 1}`, []string{"gas"}, "missing required field 'gas' for Transaction"},
+		{`{"gas": null}`, []string{"gas"}, "missing required field 'gas' for Transaction"},
+		{`[]`, []string{"gas"}, "expected JSON object for Transaction"},
+		{`{"gas": "1"}`, []string{"gas"}, "json: cannot unmarshal string into Go value of type uint64"},
+	}
+	for _, test := range tests {
+		var tx Transaction
+		err := tx.DecodeJSONFields([]byte(test.input), test.fields...)
+		if err == nil || err.Error() != test.err {
+			t.Errorf("input %s, fields %v: got error %v, want %q", test.input, test.fields, err, test.err)
+		}
+	}
+}
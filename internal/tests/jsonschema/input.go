@@ -0,0 +1,65 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type Order,Item -field-override orderMarshaling,itemMarshaling -strict -jsonschema schema.json -out output.go
+
+package jsonschema
+
+import (
+	"encoding/json"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Order struct {
+	ID       uint64            `json:"id" gencodec:"required"`
+	Customer string            `json:"customer" gencodec:"required"`
+	Total    *big.Int          `json:"total" gencodec:"required"`
+	Items    []Item            `json:"items" gencodec:"required"`
+	Ship     Address           `json:"shipTo"`
+	Category *Category         `json:"category"`
+	Placed   time.Time         `json:"placed"`
+	Tags     map[string]string `json:"tags"`
+	Digest   [4]byte           `json:"digest"`
+	Sig      []byte            `json:"sig"`
+	Meta     json.RawMessage   `json:"meta"`
+	Weight   float64           `json:"weight"`
+	Internal string            `json:"-"`
+}
+
+type orderMarshaling struct {
+	Weight grams
+}
+
+// grams is encoded as a string with unit.
+type grams float64
+
+func (g grams) MarshalText() ([]byte, error) {
+	return []byte(strconv.FormatFloat(float64(g), 'f', -1, 64) + "g"), nil
+}
+
+func (g *grams) UnmarshalText(input []byte) error {
+	v, err := strconv.ParseFloat(strings.TrimSuffix(string(input), "g"), 64)
+	*g = grams(v)
+	return err
+}
+
+type Item struct {
+	SKU      string `json:"sku" gencodec:"required"`
+	Quantity int    `json:"qty"`
+}
+
+type itemMarshaling struct{}
+
+type Address struct {
+	Street string `json:"street" gencodec:"required"`
+	City   string `json:"city"`
+}
+
+type Category struct {
+	Name   string    `json:"name"`
+	Parent *Category `json:"parent,omitempty"`
+}
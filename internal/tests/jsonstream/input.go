@@ -0,0 +1,22 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type Event -helpers jsonstream -out output.go
+
+package jsonstream
+
+import "net/url"
+
+type Event struct {
+	ID     uint64   `json:"id" gencodec:"required"`
+	Kind   string   `json:"kind" default:"info"`
+	Source *url.URL `json:"source"`
+	Tags   []string `json:"tags"`
+	Inner  Inner    `json:"inner"`
+	Secret string   `json:"-"`
+}
+
+type Inner struct {
+	A int `json:"a"`
+}
@@ -0,0 +1,53 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package jsonstream
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDecodeJSON(t *testing.T) {
+	input := `{"id": 1, "source": "https://example.com/x", "unknown": {"x": [1, 2]}, "tags": ["a", "b"], "inner": {"a": 2}}
+{"id": 2, "kind": "warn", "tags": null}`
+	d := json.NewDecoder(strings.NewReader(input))
+
+	var ev Event
+	if err := ev.DecodeJSON(d); err != nil {
+		t.Fatal(err)
+	}
+	var want Event
+	if err := json.Unmarshal([]byte(strings.Split(input, "\n")[0]), &want); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(ev, want) {
+		t.Errorf("got %+v, want %+v", ev, want)
+	}
+
+	var ev2 Event
+	if err := ev2.DecodeJSON(d); err != nil {
+		t.Fatal(err)
+	}
+	if want := (Event{ID: 2, Kind: "warn"}); !reflect.DeepEqual(ev2, want) {
+		t.Errorf("got %+v, want %+v", ev2, want)
+	}
+}
+
+func TestDecodeJSONErrors(t *testing.T) {
+	tests := []struct{ input, err string }{
+		{`{"kind": "x"}`, "missing required field 'id' for Event"},
+		{`[1]`, "expected JSON object for Event"},
+		{`{"id": "1"}`, "json: cannot unmarshal string into Go value of type uint64"},
+	}
+	for _, test := range tests {
+		var ev Event
+		err := ev.DecodeJSON(json.NewDecoder(strings.NewReader(test.input)))
+		if err == nil || err.Error() != test.err {
+			t.Errorf("input %s: wrong error %v", test.input, err)
+		}
+	}
+}
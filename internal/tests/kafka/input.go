@@ -0,0 +1,12 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type Event -helpers kafka -out output.go
+
+package kafka
+
+type Event struct {
+	ID   string `json:"id" gencodec:"required"`
+	Size int    `json:"size"`
+}
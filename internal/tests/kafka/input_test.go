@@ -0,0 +1,58 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package kafka
+
+import (
+	"reflect"
+	"testing"
+)
+
+// encoder is the Encoder interface of sarama.
+type encoder interface {
+	Encode() ([]byte, error)
+	Length() int
+}
+
+var _ encoder = (*EventKafkaEncoder)(nil)
+
+func TestEncoder(t *testing.T) {
+	ev := &Event{ID: "a", Size: 3}
+	enc := &EventKafkaEncoder{Value: ev}
+	data, err := enc.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"id":"a","size":3}` || enc.Length() != len(data) {
+		t.Fatalf("wrong encoding %q, length %d", data, enc.Length())
+	}
+	dec, _, err := DecodeEventKafka(data, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(dec, ev) {
+		t.Fatalf("decoded value mismatch: %+v", dec)
+	}
+}
+
+func TestFramed(t *testing.T) {
+	ev := &Event{ID: "b"}
+	data, err := (&EventKafkaEncoder{Value: ev, Framed: true, SchemaID: 258}).Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "\x00\x00\x00\x01\x02" + `{"id":"b","size":0}`; string(data) != want {
+		t.Fatalf("wrong encoding %q", data)
+	}
+	dec, id, err := DecodeEventKafka(data, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != 258 || !reflect.DeepEqual(dec, ev) {
+		t.Fatalf("wrong decoded value %+v, schema ID %d", dec, id)
+	}
+	if _, _, err := DecodeEventKafka([]byte(`{"id":"b"}`), true); err == nil {
+		t.Fatal("no error for missing header")
+	}
+}
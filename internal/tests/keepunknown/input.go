@@ -0,0 +1,16 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type X -keep-unknown -formats json,yaml -out output.go
+
+package keepunknown
+
+import "encoding/json"
+
+type X struct {
+	Name    string                     `json:"name" yaml:"name"`
+	Count   int                        `json:"count" yaml:"count"`
+	Renamed string                     `json:"renamed" yaml:"other"`
+	Extra   map[string]json.RawMessage `gencodec:"unknown"`
+}
@@ -0,0 +1,79 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package keepunknown
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestKeepUnknownJSON(t *testing.T) {
+	input := `{"name":"a","Count":2,"renamed":"r","zz":[1,2],"extra":{"b":true}}`
+	var x X
+	if err := json.Unmarshal([]byte(input), &x); err != nil {
+		t.Fatal(err)
+	}
+	want := X{Name: "a", Count: 2, Renamed: "r", Extra: map[string]json.RawMessage{
+		"zz":    json.RawMessage(`[1,2]`),
+		"extra": json.RawMessage(`{"b":true}`),
+	}}
+	if !reflect.DeepEqual(x, want) {
+		t.Fatalf("decoded %+v\nwant %+v", x, want)
+	}
+	enc, err := json.Marshal(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp := `{"name":"a","count":2,"renamed":"r","extra":{"b":true},"zz":[1,2]}`; string(enc) != exp {
+		t.Fatalf("encoded %s\nwant %s", enc, exp)
+	}
+}
+
+func TestKeepUnknownJSONSkipsFieldKeys(t *testing.T) {
+	x := X{Name: "a", Extra: map[string]json.RawMessage{"NAME": json.RawMessage(`"b"`), "k": json.RawMessage(`1`)}}
+	enc, err := json.Marshal(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp := `{"name":"a","count":0,"renamed":"","k":1}`; string(enc) != exp {
+		t.Fatalf("encoded %s\nwant %s", enc, exp)
+	}
+	// Without unknown keys, the map is reset.
+	if err := json.Unmarshal([]byte(`{"name":"c"}`), &x); err != nil {
+		t.Fatal(err)
+	}
+	if x.Extra != nil {
+		t.Fatalf("unknown keys not reset: %v", x.Extra)
+	}
+}
+
+func TestKeepUnknownYAML(t *testing.T) {
+	input := "name: a\nother: r\nlist: [1, 2]\nnested:\n  b: true\n"
+	var x X
+	if err := yaml.Unmarshal([]byte(input), &x); err != nil {
+		t.Fatal(err)
+	}
+	want := X{Name: "a", Renamed: "r", Extra: map[string]json.RawMessage{
+		"list":   json.RawMessage(`[1,2]`),
+		"nested": json.RawMessage(`{"b":true}`),
+	}}
+	if !reflect.DeepEqual(x, want) {
+		t.Fatalf("decoded %+v\nwant %+v", x, want)
+	}
+	enc, err := yaml.Marshal(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var dec X
+	if err := yaml.Unmarshal(enc, &dec); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(dec, want) {
+		t.Fatalf("round trip mismatch\n%s\ngot  %+v\nwant %+v", enc, dec, want)
+	}
+}
@@ -0,0 +1,31 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type X -helpers labels -out output.go
+
+package labels
+
+import "net/netip"
+
+type Method string
+
+type Status int
+
+func (s Status) String() string {
+	if s < 400 {
+		return "ok"
+	}
+	return "error"
+}
+
+type X struct {
+	Method  Method     `json:"method" label:""`
+	Path    string     `label:"path"`
+	Status  Status     `label:"status"`
+	Retries *uint8     `label:"retries"`
+	Cached  bool       `label:"cached"`
+	Latency float64    `label:"-"`
+	Peer    netip.Addr `label:"peer"`
+	Body    []byte
+}
@@ -0,0 +1,47 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package labels
+
+import (
+	"net/netip"
+	"reflect"
+	"testing"
+)
+
+func TestMetricLabels(t *testing.T) {
+	retries := uint8(2)
+	x := X{
+		Method:  "GET",
+		Path:    "/",
+		Status:  404,
+		Retries: &retries,
+		Peer:    netip.MustParseAddr("127.0.0.1"),
+	}
+	want := map[string]string{
+		"method":  "GET",
+		"path":    "/",
+		"status":  "error",
+		"retries": "2",
+		"cached":  "false",
+		"peer":    "127.0.0.1",
+	}
+	if labels := x.MetricLabels(); !reflect.DeepEqual(labels, want) {
+		t.Fatalf("got %v, want %v", labels, want)
+	}
+}
+
+func TestMetricLabelsAbsent(t *testing.T) {
+	want := map[string]string{
+		"method":  "",
+		"path":    "",
+		"status":  "ok",
+		"retries": "",
+		"cached":  "false",
+		"peer":    "",
+	}
+	if labels := new(X).MetricLabels(); !reflect.DeepEqual(labels, want) {
+		t.Fatalf("got %v, want %v", labels, want)
+	}
+}
@@ -0,0 +1,16 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type X -formats json,yaml -out output.go
+
+package money
+
+type currencyCode string
+
+type X struct {
+	Price    float64 `json:"price" gencodec:"required,currency=Currency"`
+	Currency currencyCode
+	Fee      uint32 `gencodec:"currency=FeeCur,currencyobject"`
+	FeeCur   string
+}
@@ -0,0 +1,38 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package money
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMoneyJSON(t *testing.T) {
+	input := `{"price":"12.5 USD","Fee":{"amount":3,"currency":"EUR"}}`
+	var x X
+	if err := json.Unmarshal([]byte(input), &x); err != nil {
+		t.Fatal("unexpected error", err)
+	}
+	want := X{Price: 12.5, Currency: "USD", Fee: 3, FeeCur: "EUR"}
+	if x != want {
+		t.Fatalf("got %+v, want %+v", x, want)
+	}
+	out, err := json.Marshal(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != input {
+		t.Fatalf("got %#q, want %#q", string(out), input)
+	}
+}
+
+func TestMoneyInvalid(t *testing.T) {
+	for _, input := range []string{`{}`, `{"price":"12.5"}`, `{"price":"x USD"}`} {
+		var x X
+		if err := json.Unmarshal([]byte(input), &x); err == nil {
+			t.Errorf("expected error for %s", input)
+		}
+	}
+}
@@ -0,0 +1,20 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type X -field-override Xo -formats json,msgpack -out output.go
+
+package msgpack
+
+type replacedInt int
+
+type X struct {
+	Required int    `msgpack:"req" gencodec:"required"`
+	Optional string `msgpack:"opt,omitempty"`
+	Replaced int
+	Skipped  int `msgpack:"-"`
+}
+
+type Xo struct {
+	Replaced replacedInt
+}
@@ -0,0 +1,47 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package msgpack
+
+import (
+	"testing"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+func TestRoundTrip(t *testing.T) {
+	x := X{Required: 1, Optional: "a", Replaced: 2, Skipped: 3}
+	enc, err := msgpack.Marshal(&x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var m map[string]interface{}
+	if err := msgpack.Unmarshal(enc, &m); err != nil {
+		t.Fatal(err)
+	}
+	if len(m) != 3 || m["req"] != int8(1) || m["opt"] != "a" || m["Replaced"] != int8(2) {
+		t.Fatalf("wrong encoding %v", m)
+	}
+
+	var dec X
+	if err := msgpack.Unmarshal(enc, &dec); err != nil {
+		t.Fatal(err)
+	}
+	x.Skipped = 0
+	if dec != x {
+		t.Fatalf("got %+v, want %+v", dec, x)
+	}
+}
+
+func TestMissingRequired(t *testing.T) {
+	enc, err := msgpack.Marshal(map[string]interface{}{"opt": "a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var dec X
+	err = msgpack.Unmarshal(enc, &dec)
+	if err == nil || err.Error() != "missing required field 'req' for X" {
+		t.Fatalf("wrong error %v", err)
+	}
+}
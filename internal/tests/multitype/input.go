@@ -0,0 +1,24 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type Header,Body -field-override ,bodyMarshaling -formats json,yaml -out output.go
+
+package multitype
+
+import "math/big"
+
+type replacedInt int
+
+type Header struct {
+	Number *big.Int `json:"number" gencodec:"required"`
+}
+
+type Body struct {
+	Count int      `json:"count" gencodec:"required"`
+	Items []string `json:"items"`
+}
+
+type bodyMarshaling struct {
+	Count replacedInt
+}
@@ -0,0 +1,42 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package multitype
+
+import (
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+func TestRoundTrip(t *testing.T) {
+	type both struct {
+		H Header
+		B Body
+	}
+	x := both{Header{big.NewInt(1)}, Body{Count: 2, Items: []string{"a"}}}
+	enc, err := json.Marshal(&x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var dec both
+	if err := json.Unmarshal(enc, &dec); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(dec, x) {
+		t.Fatalf("got %+v, want %+v", dec, x)
+	}
+}
+
+func TestMissingRequired(t *testing.T) {
+	var h Header
+	if err := json.Unmarshal([]byte(`{}`), &h); err == nil || err.Error() != "missing required field 'number' for Header" {
+		t.Errorf("wrong error for Header: %v", err)
+	}
+	var b Body
+	if err := json.Unmarshal([]byte(`{}`), &b); err == nil || err.Error() != "missing required field 'count' for Body" {
+		t.Errorf("wrong error for Body: %v", err)
+	}
+}
@@ -0,0 +1,29 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type CreateUserRequest,User -openapi schema.yaml -out output.go
+
+package openapi
+
+import "time"
+
+type CreateUserRequest struct {
+	Name    string   `json:"name" gencodec:"required"`
+	EMail   string   `json:"email" gencodec:"required"`
+	Roles   []string `json:"roles"`
+	Profile *Profile `json:"profile"`
+}
+
+type User struct {
+	ID        uint64    `json:"id" gencodec:"required"`
+	Name      string    `json:"name" gencodec:"required"`
+	CreatedAt time.Time `json:"created_at" gencodec:"required"`
+	Profile   *Profile  `json:"profile,omitempty"`
+	Password  string    `json:"-"`
+}
+
+type Profile struct {
+	DisplayName string `json:"display_name"`
+	AvatarURL   string `json:"avatar_url"`
+}
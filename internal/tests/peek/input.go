@@ -0,0 +1,17 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type Envelope -helpers peek -out output.go
+
+package peek
+
+import "encoding/json"
+
+type Kind string
+
+type Envelope struct {
+	ID   uint64          `json:"id"`
+	Kind Kind            `json:"kind" gencodec:"discriminator,required"`
+	Data json.RawMessage `json:"data"`
+}
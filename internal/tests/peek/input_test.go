@@ -0,0 +1,58 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package peek
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPeekType(t *testing.T) {
+	tests := []struct {
+		input, kind, err string
+	}{
+		{input: `{"kind": "login"}`, kind: "login"},
+		{input: ` { "id" : 1 , "kind":"a\"bä" }`, kind: "a\"bä"},
+		{input: `{"data": {"kind": "inner", "x": [1, "}", {"y": null}]}, "id": 2e5, "kind": "outer", bad}`, kind: "outer"},
+		{input: `{"data": "{\"kind\": 1}", "kind": ""}`, kind: ""},
+		{input: `{}`, err: "missing required field 'kind' for Envelope"},
+		{input: `{"Kind": "x", "kind": null}`, err: "missing required field 'kind' for Envelope"},
+		{input: `{"kind": 1}`, err: "field 'kind' of Envelope is not a string"},
+		{input: `[{"kind": "x"}]`, err: "expected JSON object for Envelope"},
+		{input: `{"id": 1 "kind": "x"}`, err: "invalid JSON object for Envelope"},
+		{input: `{"data": {"a": 1}`, err: "invalid JSON object for Envelope"},
+		{input: `{"data": "abc`, err: "invalid JSON object for Envelope"},
+		{input: `{"kind": "abc`, err: "invalid JSON object for Envelope"},
+	}
+	for _, test := range tests {
+		kind, err := PeekEnvelopeType([]byte(test.input))
+		if test.err != "" {
+			if err == nil || err.Error() != test.err {
+				t.Errorf("input %s: got error %v, want %q", test.input, err, test.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("input %s: unexpected error %v", test.input, err)
+		} else if kind != test.kind {
+			t.Errorf("input %s: got %q, want %q", test.input, kind, test.kind)
+		}
+	}
+}
+
+func TestPeekTypeUnmarshal(t *testing.T) {
+	input := []byte(`{"id": 7, "data": {"user": "bob"}, "kind": "login"}`)
+	kind, err := PeekEnvelopeType(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var env Envelope
+	if err := json.Unmarshal(input, &env); err != nil {
+		t.Fatal(err)
+	}
+	if kind != string(env.Kind) {
+		t.Errorf("PeekEnvelopeType returned %q, UnmarshalJSON decoded %q", kind, env.Kind)
+	}
+}
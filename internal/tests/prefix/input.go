@@ -0,0 +1,18 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type Y -formats json,yaml -out output.go
+
+package prefix
+
+type Y struct {
+	Primary DB `gencodec:"flatten" prefix:"primary_"`
+	Replica DB `gencodec:"flatten" prefix:"replica_"`
+}
+
+type DB struct {
+	Host string `json:"host" gencodec:"required"`
+	Port int    `yaml:",omitempty"`
+	Skip int    `json:"-"`
+}
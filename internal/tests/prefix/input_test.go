@@ -0,0 +1,29 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package prefix
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPrefixJSON(t *testing.T) {
+	input := `{"primary_host":"a","primary_Port":1,"replica_host":"b","replica_Port":2}`
+	var y Y
+	if err := json.Unmarshal([]byte(input), &y); err != nil {
+		t.Fatal("unexpected error", err)
+	}
+	want := Y{Primary: DB{Host: "a", Port: 1}, Replica: DB{Host: "b", Port: 2}}
+	if y != want {
+		t.Fatalf("got %+v, want %+v", y, want)
+	}
+	out, err := json.Marshal(y)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != input {
+		t.Fatalf("got %#q, want %#q", string(out), input)
+	}
+}
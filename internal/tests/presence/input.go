@@ -0,0 +1,25 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type X -field-override Xo -presence-flags -formats json,yaml -out output.go
+
+package presence
+
+type Names []string
+
+type replacedInt int
+
+type X struct {
+	ID      uint64 `json:"id" gencodec:"required"`
+	Name    string `json:"name"`
+	Retries int    `json:"retries" default:"3"`
+	Count   int    `json:"count"`
+	Tags    Names  `json:"tags"`
+	List    []int  `json:"list"`
+	Next    *X     `json:"next"`
+}
+
+type Xo struct {
+	Count replacedInt
+}
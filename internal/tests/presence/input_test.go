@@ -0,0 +1,61 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package presence
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestPresenceRoundTrip(t *testing.T) {
+	x := X{ID: 1, Name: "a", Retries: 0, Count: 5, Tags: Names{"t"}, List: []int{1}, Next: &X{ID: 2, Retries: 3}}
+	enc, err := json.Marshal(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var dec X
+	if err := json.Unmarshal(enc, &dec); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(dec, x) {
+		t.Fatalf("round trip mismatch\ngot  %+v\nwant %+v", dec, x)
+	}
+}
+
+func TestPresenceAbsent(t *testing.T) {
+	tests := []struct {
+		input string
+		want  X
+	}{
+		// Absent and null keys leave the fields unchanged or set the default.
+		{`{"id": 1}`, X{ID: 1, Name: "old", Retries: 3, Count: 7}},
+		{`{"id": 1, "name": null, "count": null, "retries": null}`, X{ID: 1, Name: "old", Retries: 3, Count: 7}},
+		// Present zero values are assigned.
+		{`{"id": 1, "name": "", "count": 0, "retries": 0}`, X{ID: 1, Retries: 0}},
+		// Keys are matched like encoding/json does.
+		{`{"ID": 1, "Name": "new"}`, X{ID: 1, Name: "new", Retries: 3, Count: 7}},
+	}
+	for _, test := range tests {
+		x := X{Name: "old", Count: 7}
+		if err := json.Unmarshal([]byte(test.input), &x); err != nil {
+			t.Errorf("%s: %v", test.input, err)
+			continue
+		}
+		if !reflect.DeepEqual(x, test.want) {
+			t.Errorf("%s: got %+v, want %+v", test.input, x, test.want)
+		}
+	}
+}
+
+func TestPresenceRequired(t *testing.T) {
+	for _, input := range []string{`{}`, `{"id": null}`} {
+		var x X
+		err := json.Unmarshal([]byte(input), &x)
+		if err == nil || err.Error() != "missing required field 'id' for X" {
+			t.Errorf("%s: wrong error %v", input, err)
+		}
+	}
+}
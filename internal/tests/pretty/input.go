@@ -0,0 +1,26 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type Block -helpers pretty -out output.go
+
+package pretty
+
+import (
+	"encoding/json"
+	"math/big"
+)
+
+type Block struct {
+	Number     uint64          `json:"number"`
+	Hash       [32]byte        `json:"hash"`
+	Bloom      [64]byte        `json:"logsBloom"`
+	Extra      []byte          `json:"extraData"`
+	Miner      string          `json:"miner"`
+	Difficulty *big.Int        `json:"difficulty"`
+	Parent     *uint64         `json:"parent"`
+	Note       *string         `json:"note"`
+	Uncles     []string        `json:"uncles"`
+	Data       json.RawMessage `json:"data"`
+	Cache      int             `json:"-"`
+}
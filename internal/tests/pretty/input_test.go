@@ -0,0 +1,58 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package pretty
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestPrettyString(t *testing.T) {
+	parent := uint64(99)
+	b := Block{
+		Number:     100,
+		Hash:       [32]byte{0x01, 0x02, 31: 0xff},
+		Extra:      bytes.Repeat([]byte{0xab}, 40),
+		Miner:      "alice",
+		Difficulty: big.NewInt(131072),
+		Parent:     &parent,
+		Uncles:     []string{"u1", "u2"},
+		Data:       json.RawMessage(`{"k":"v"}`),
+		Cache:      5,
+	}
+	want := `Block
+  number:     100
+  hash:       0x01020000000000000000000000000000000000000000000000000000000000ff
+  logsBloom:  0x0000000000000000000000000000000000000000000000000000000000000000... (64 bytes)
+  extraData:  0xabababababababababababababababababababababababababababababababab... (40 bytes)
+  miner:      "alice"
+  difficulty: 131072
+  parent:     99
+  note:       <nil>
+  uncles:     [u1 u2]
+  data:       {"k":"v"}
+`
+	if got := b.PrettyString(); got != want {
+		t.Errorf("wrong output:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestPrettyStringTruncate(t *testing.T) {
+	note := strings.Repeat("x", 100)
+	b := Block{Note: &note, Miner: strings.Repeat("m", 64)}
+	out := b.PrettyString()
+	if want := `  note:       "` + strings.Repeat("x", 64) + `"... (100 bytes)` + "\n"; !strings.Contains(out, want) {
+		t.Errorf("long note not truncated:\n%s", out)
+	}
+	if want := `  miner:      "` + strings.Repeat("m", 64) + `"` + "\n"; !strings.Contains(out, want) {
+		t.Errorf("miner of maximum length truncated:\n%s", out)
+	}
+	if want := "  difficulty: <nil>\n"; !strings.Contains(out, want) {
+		t.Errorf("nil big.Int not shown as <nil>:\n%s", out)
+	}
+}
@@ -0,0 +1,28 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type Config -helpers prompt -out output.go
+
+package prompt
+
+import (
+	"math/big"
+	"net/netip"
+	"time"
+)
+
+type Mode string
+
+type Config struct {
+	Name    string     `json:"name" gencodec:"required"`
+	Port    uint16     `json:"port" gencodec:"required"`
+	Mode    Mode       `json:"mode" gencodec:"required"`
+	Debug   bool       `json:"debug" gencodec:"required"`
+	Ratio   *float64   `json:"ratio" gencodec:"required"`
+	Addr    netip.Addr `json:"addr" gencodec:"required"`
+	Stake   *big.Int   `json:"stake" gencodec:"required"`
+	Started time.Time  `json:"started" gencodec:"required"`
+	Comment string     `json:"comment"`
+	Peers   []string   `json:"peers"`
+}
@@ -0,0 +1,83 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package prompt
+
+import (
+	"math/big"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPromptFill(t *testing.T) {
+	input := strings.Join([]string{
+		"node-1",
+		"",
+		"99999", // out of range
+		"8080",
+		" fast ",
+		"yes", // invalid bool
+		"true",
+		"0.25",
+		"::1",
+		"1000000000000000000000",
+		"2024-05-01T10:00:00Z",
+	}, "\n")
+	var cfg Config
+	var out strings.Builder
+	if err := cfg.PromptFill(strings.NewReader(input), &out); err != nil {
+		t.Fatal(err)
+	}
+	stake, _ := new(big.Int).SetString("1000000000000000000000", 10)
+	switch {
+	case cfg.Name != "node-1", cfg.Port != 8080, cfg.Mode != "fast", !cfg.Debug:
+		t.Errorf("wrong basic fields: %+v", cfg)
+	case cfg.Ratio == nil || *cfg.Ratio != 0.25:
+		t.Errorf("wrong ratio %v", cfg.Ratio)
+	case cfg.Addr != netip.MustParseAddr("::1"):
+		t.Errorf("wrong addr %v", cfg.Addr)
+	case cfg.Stake == nil || cfg.Stake.Cmp(stake) != 0:
+		t.Errorf("wrong stake %v", cfg.Stake)
+	case !cfg.Started.Equal(time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)):
+		t.Errorf("wrong start time %v", cfg.Started)
+	}
+	for _, want := range []string{"name: port: port: invalid port:", "invalid debug:", "started: "} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output doesn't contain %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestPromptFillSkipsSetFields(t *testing.T) {
+	ratio := 1.0
+	cfg := Config{
+		Name:    "x",
+		Port:    1,
+		Mode:    "m",
+		Debug:   true,
+		Ratio:   &ratio,
+		Stake:   big.NewInt(1),
+		Started: time.Unix(1, 0),
+	}
+	var out strings.Builder
+	if err := cfg.PromptFill(strings.NewReader("10.0.0.1\n"), &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != "addr: " {
+		t.Errorf("wrong prompts %q", out.String())
+	}
+	if cfg.Addr != netip.MustParseAddr("10.0.0.1") {
+		t.Errorf("wrong addr %v", cfg.Addr)
+	}
+}
+
+func TestPromptFillEOF(t *testing.T) {
+	var cfg Config
+	err := cfg.PromptFill(strings.NewReader("node-1\n"), new(strings.Builder))
+	if err == nil || err.Error() != "missing required field 'port' for Config" {
+		t.Fatalf("wrong error %v", err)
+	}
+}
@@ -0,0 +1,14 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type X -required-by-default -out output.go
+
+package reqdefault
+
+type X struct {
+	Name    string `json:"name"`
+	Port    int    `json:"port" gencodec:"omitzero"`
+	Comment string `json:"comment" gencodec:"optional"`
+	Secret  string `json:"-"`
+}
@@ -0,0 +1,21 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package reqdefault
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestRequiredByDefault(t *testing.T) {
+	var x X
+	if err := json.Unmarshal([]byte(`{"name":"a","port":1}`), &x); err != nil {
+		t.Fatal(err)
+	}
+	err := json.Unmarshal([]byte(`{"name":"a","comment":"c"}`), &x)
+	if err == nil || err.Error() != "missing required field 'port' for X" {
+		t.Fatalf("wrong error %v", err)
+	}
+}
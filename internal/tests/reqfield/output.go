@@ -10,20 +10,17 @@ import (
 // MarshalJSON marshals as JSON.
 func (x X) MarshalJSON() ([]byte, error) {
 	type X struct {
-		Required    int `gencodec:"required"`
-		NotRequired int `gencodec:"required" json:"-"`
+		Required int `gencodec:"required"`
 	}
 	var enc X
 	enc.Required = x.Required
-	enc.NotRequired = x.NotRequired
 	return json.Marshal(&enc)
 }
 
 // UnmarshalJSON unmarshals from JSON.
 func (x *X) UnmarshalJSON(input []byte) error {
 	type X struct {
-		Required    *int `gencodec:"required"`
-		NotRequired *int `gencodec:"required" json:"-"`
+		Required *int `gencodec:"required"`
 	}
 	var dec X
 	if err := json.Unmarshal(input, &dec); err != nil {
@@ -33,8 +30,5 @@ func (x *X) UnmarshalJSON(input []byte) error {
 		return errors.New("missing required field 'required' for X")
 	}
 	x.Required = *dec.Required
-	if dec.NotRequired != nil {
-		x.NotRequired = *dec.NotRequired
-	}
 	return nil
 }
@@ -0,0 +1,19 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type Server -formats json,yaml -schema schema.json -out output.go
+
+package schema
+
+import "net/url"
+
+type Server struct {
+	Host     string   `json:"host" yaml:"host"`
+	Port     uint16   `json:"port" yaml:"port"`
+	Mode     string   `json:"mode" yaml:"mode" default:"dev"`
+	Weight   float64  `json:"weight" yaml:"weight"`
+	Workers  int      `json:"workers" yaml:"workers"`
+	Tags     []string `json:"tags" yaml:"tags"`
+	Endpoint *url.URL `json:"endpoint" yaml:"endpoint"`
+}
@@ -0,0 +1,44 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSchemaChecks(t *testing.T) {
+	tests := []struct {
+		input, err string
+	}{
+		{`{"port": 80}`, "missing required field 'host' for Server"},
+		{`{"host": "", "port": 80}`, "field 'host' of Server must be at least 1 characters long"},
+		{`{"host": "Example.com", "port": 80}`, "field 'host' of Server must match ^[a-z0-9.-]+$"},
+		{`{"host": "a", "port": 0}`, "field 'port' of Server must be >= 1"},
+		{`{"host": "a", "port": 80, "mode": "test"}`, `field 'mode' of Server must be one of "dev", "prod"`},
+		{`{"host": "a", "port": 80, "weight": 0}`, "field 'weight' of Server must be > 0"},
+		{`{"host": "a", "port": 80, "weight": 1.5}`, "field 'weight' of Server must be <= 1"},
+		{`{"host": "a", "port": 80, "tags": ["a", "b", "c", "d"]}`, "field 'tags' of Server must have at most 3 items"},
+		{`{"host": "a", "port": 80, "endpoint": "http://a"}`, "field 'endpoint' of Server must match ^https://"},
+	}
+	for _, test := range tests {
+		var s Server
+		err := json.Unmarshal([]byte(test.input), &s)
+		if err == nil || err.Error() != test.err {
+			t.Errorf("input %s: got error %v, want %q", test.input, err, test.err)
+		}
+	}
+}
+
+func TestSchemaValid(t *testing.T) {
+	var s Server
+	input := `{"host": "example.com", "port": 443, "weight": 0.5, "tags": ["a"], "endpoint": "https://example.com"}`
+	if err := json.Unmarshal([]byte(input), &s); err != nil {
+		t.Fatal(err)
+	}
+	if s.Mode != "dev" || s.Port != 443 || s.Endpoint.Host != "example.com" {
+		t.Errorf("wrong result %+v", s)
+	}
+}
@@ -0,0 +1,17 @@
+package schema
+
+import (
+	"list"
+	"strings"
+)
+
+// Server has the same constraints as in schema.json.
+#Server: {
+	host:      string & =~"^[a-z0-9.-]+$" & strings.MinRunes(1) & strings.MaxRunes(253)
+	port:      int & >=1 & <=65535
+	mode?:     *"dev" | "prod"
+	weight?:   number & >0 & <=1
+	workers?:  int & >=1
+	tags?:     [...string] & list.MaxItems(3)
+	endpoint?: string & =~"^https://"
+}
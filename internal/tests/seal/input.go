@@ -0,0 +1,16 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type Secret,Other -helpers seal -out output.go
+
+package seal
+
+type Secret struct {
+	Name  string `json:"name" gencodec:"required"`
+	Token string `json:"token"`
+}
+
+type Other struct {
+	Name string `json:"name"`
+}
@@ -0,0 +1,69 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package seal
+
+import (
+	"bytes"
+	"testing"
+)
+
+var key = bytes.Repeat([]byte{7}, 32)
+
+func TestSeal(t *testing.T) {
+	s := Secret{Name: "db", Token: "hunter2"}
+	sealed, err := s.SealJSON(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sealed[0] != 1 {
+		t.Errorf("wrong version byte %d", sealed[0])
+	}
+	if bytes.Contains(sealed, []byte("hunter2")) {
+		t.Error("sealed value contains plaintext")
+	}
+	again, _ := s.SealJSON(key)
+	if bytes.Equal(sealed, again) {
+		t.Error("nonce is reused")
+	}
+	var dec Secret
+	if err := dec.OpenJSON(key, sealed); err != nil {
+		t.Fatal(err)
+	}
+	if dec != s {
+		t.Errorf("wrong result %+v", dec)
+	}
+}
+
+func TestSealErrors(t *testing.T) {
+	sealed, err := Secret{Name: "db"}.SealJSON(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var dec Secret
+	if err := dec.OpenJSON(bytes.Repeat([]byte{8}, 32), sealed); err == nil {
+		t.Error("no error for wrong key")
+	}
+	if err := dec.OpenJSON(key[:5], sealed); err == nil {
+		t.Error("no error for invalid key size")
+	}
+	if err := dec.OpenJSON(key, sealed[:20]); err == nil || err.Error() != "sealed Secret too short" {
+		t.Errorf("wrong error for short input: %v", err)
+	}
+	modified := bytes.Clone(sealed)
+	modified[len(modified)-1] ^= 1
+	if err := dec.OpenJSON(key, modified); err == nil {
+		t.Error("no error for modified value")
+	}
+	modified = bytes.Clone(sealed)
+	modified[0] = 2
+	if err := dec.OpenJSON(key, modified); err == nil || err.Error() != "unknown version 2 of sealed Secret" {
+		t.Errorf("wrong error for unknown version: %v", err)
+	}
+	// The type name is authenticated.
+	var other Other
+	if err := other.OpenJSON(key, sealed); err == nil {
+		t.Error("no error for value of other type")
+	}
+}
@@ -0,0 +1,11 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type X -helpers sse -out output.go
+
+package sse
+
+type X struct {
+	Msg string `json:"msg" gencodec:"required"`
+}
@@ -0,0 +1,37 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package sse
+
+import (
+	"bytes"
+	"testing"
+)
+
+type flushBuffer struct {
+	bytes.Buffer
+	flushed int
+}
+
+func (b *flushBuffer) Flush() { b.flushed++ }
+
+func TestWriteSSE(t *testing.T) {
+	var buf flushBuffer
+	if err := (X{Msg: "hi"}).WriteSSE(&buf, "update"); err != nil {
+		t.Fatal(err)
+	}
+	if err := (X{Msg: "again"}).WriteSSE(&buf, ""); err != nil {
+		t.Fatal(err)
+	}
+	want := "event: update\ndata: {\"msg\":\"hi\"}\n\ndata: {\"msg\":\"again\"}\n\n"
+	if buf.String() != want {
+		t.Fatalf("wrong output %q", buf.String())
+	}
+	if buf.flushed != 2 {
+		t.Fatalf("flushed %d times, want 2", buf.flushed)
+	}
+	if err := (X{}).WriteSSE(&buf, "a\nb"); err == nil {
+		t.Fatal("no error for event name with newline")
+	}
+}
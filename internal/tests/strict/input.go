@@ -0,0 +1,13 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type X -formats json,yaml -strict -out output.go
+
+package strict
+
+type X struct {
+	Name    string `json:"name" yaml:"name"`
+	Port    int    `json:"port" yaml:"port"`
+	Ignored string `json:"-" yaml:"-"`
+}
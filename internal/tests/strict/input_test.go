@@ -0,0 +1,47 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package strict
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestStrictJSON(t *testing.T) {
+	var x X
+	if err := json.Unmarshal([]byte(`{"name":"a","port":1}`), &x); err != nil {
+		t.Fatal(err)
+	}
+	err := json.Unmarshal([]byte(`{"name":"a","Ignored":"b"}`), &x)
+	if err == nil || err.Error() != `json: unknown field "Ignored"` {
+		t.Fatalf("wrong error %v", err)
+	}
+	err = x.UnmarshalJSON([]byte(`{"name":"a"} {}`))
+	if err == nil || err.Error() != "unexpected data after JSON value for X" {
+		t.Fatalf("wrong error %v", err)
+	}
+}
+
+func TestStrictYAML(t *testing.T) {
+	// The unmarshal function of the YAML package is emulated using JSON.
+	unmarshal := func(input string) func(interface{}) error {
+		return func(v interface{}) error { return json.Unmarshal([]byte(input), v) }
+	}
+	var x X
+	if err := x.UnmarshalYAML(unmarshal(`{"name":"a","port":1}`)); err != nil {
+		t.Fatal(err)
+	}
+	err := x.UnmarshalYAML(unmarshal(`{"name":"a","host":"b"}`))
+	if err == nil || err.Error() != "unknown field 'host' for X" {
+		t.Fatalf("wrong error %v", err)
+	}
+	// With several unknown keys, the first one in sorted order is reported.
+	for i := 0; i < 20; i++ {
+		err = x.UnmarshalYAML(unmarshal(`{"name":"a","zone":"z","host":"b"}`))
+		if err == nil || err.Error() != "unknown field 'host' for X" {
+			t.Fatalf("wrong error %v", err)
+		}
+	}
+}
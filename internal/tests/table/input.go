@@ -0,0 +1,22 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type Pod -helpers table -out output.go
+
+package table
+
+import (
+	"math/big"
+	"time"
+)
+
+type Pod struct {
+	Name     string     `json:"name" table:""`
+	Ready    bool       `json:"ready" table:"READY"`
+	Restarts uint32     `json:"restarts" table:""`
+	Node     *string    `json:"node" table:"NODE"`
+	Quota    *big.Int   `json:"quota" table:"QUOTA"`
+	Started  *time.Time `json:"started" table:"STARTED"`
+	Image    string     `json:"image"`
+}
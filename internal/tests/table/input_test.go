@@ -0,0 +1,36 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package table
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestTable(t *testing.T) {
+	node := "worker-1"
+	pods := []Pod{
+		{Name: "web-7f9c", Ready: true, Restarts: 2, Node: &node, Quota: big.NewInt(512), Image: "nginx"},
+		{Name: "db-0", Restarts: 14},
+	}
+	var out strings.Builder
+	if err := WritePodTable(&out, pods); err != nil {
+		t.Fatal(err)
+	}
+	want := "NAME      READY  RESTARTS  NODE      QUOTA  STARTED\n" +
+		"web-7f9c  true   2         worker-1  512    \n" +
+		"db-0      false  14                         \n"
+	if out.String() != want {
+		t.Errorf("wrong table:\n%s\nwant:\n%s", out.String(), want)
+	}
+}
+
+func TestTableRow(t *testing.T) {
+	p := Pod{Name: "x", Restarts: 1}
+	if got, want := p.TableRow(), "x\tfalse\t1\t\t\t"; got != want {
+		t.Errorf("got row %q, want %q", got, want)
+	}
+}
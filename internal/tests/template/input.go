@@ -0,0 +1,24 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type X -helpers sse -template-dir templates -out output.go
+
+package template
+
+import "net/url"
+
+type X struct {
+	Name  string   `json:"name" gencodec:"required"`
+	Count int      `json:"count"`
+	Link  *url.URL `json:"link"`
+}
+
+// MissingFieldError is returned by the UnmarshalJSON method of the custom template.
+type MissingFieldError struct {
+	Type, Field string
+}
+
+func (e *MissingFieldError) Error() string {
+	return "missing field " + e.Field + " of " + e.Type
+}
@@ -0,0 +1,34 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package template
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestMissingFieldError(t *testing.T) {
+	var x X
+	err := json.Unmarshal([]byte(`{"count": 1}`), &x)
+	var mfe *MissingFieldError
+	if !errors.As(err, &mfe) {
+		t.Fatalf("got error %v, want *MissingFieldError", err)
+	}
+	if mfe.Type != "X" || mfe.Field != "name" {
+		t.Errorf("wrong error %+v", mfe)
+	}
+}
+
+func TestWriteSSE(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (X{Name: "a"}).WriteSSE(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if want := "data: {\"name\":\"a\",\"count\":0,\"link\":null}\n\n"; buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+}
@@ -0,0 +1,20 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type X -field-override Xo -formats bson -out output.go
+
+package bson
+
+type replacedInt int
+
+type X struct {
+	Required int    `bson:"req" gencodec:"required"`
+	Optional string `bson:"opt,omitempty"`
+	Replaced int
+	Skipped  int `bson:"-"`
+}
+
+type Xo struct {
+	Replaced replacedInt
+}
@@ -0,0 +1,6 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+// Package buildtags defines its type only with the special build tag.
+package buildtags
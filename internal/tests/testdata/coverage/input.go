@@ -0,0 +1,45 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package coverage
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+type Covered struct {
+	A int
+}
+
+type Plain struct {
+	B string
+}
+
+type Custom struct {
+	C string
+}
+
+func (c Custom) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.C)
+}
+
+func encode(w io.Writer, c *Covered, p Plain, ps []*Plain, cs Custom) {
+	json.Marshal(c)
+	json.Marshal(p)
+	json.MarshalIndent(ps, "", "  ")
+	json.Marshal(cs)
+	json.Marshal(time.Time{})
+	json.NewEncoder(w).Encode(map[string]Plain{})
+}
+
+func decode(r io.Reader, input []byte) {
+	var c Covered
+	json.Unmarshal(input, &c)
+	var p Plain
+	json.Unmarshal(input, &p)
+	var cs Custom
+	json.NewDecoder(r).Decode(&cs)
+}
@@ -0,0 +1,7 @@
+module github.com/fjl/gencodec/internal/tests/testdata/decimal
+
+go 1.22.0
+
+require github.com/shopspring/decimal v1.4.0
+
+replace github.com/shopspring/decimal => ./stub
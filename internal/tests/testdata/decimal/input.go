@@ -0,0 +1,17 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type X -formats json,yaml -out output.go
+
+package decimal
+
+import "github.com/shopspring/decimal"
+
+type X struct {
+	Price    decimal.Decimal  `json:"price" gencodec:"required"`
+	Discount *decimal.Decimal `json:"discount"`
+	Amount   decimal.Decimal  `json:"amount" gencodec:"precision=5,scale=2"`
+	Rate     *decimal.Decimal `json:"rate" gencodec:"scale=4"`
+	Total    decimal.Decimal  `json:"total" gencodec:"precision=10"`
+}
@@ -0,0 +1,67 @@
+// Package decimal is a stub of github.com/shopspring/decimal.
+package decimal
+
+import (
+	"errors"
+	"math/big"
+)
+
+type Decimal struct {
+	r big.Rat
+}
+
+// New returns value * 10^exp.
+func New(value int64, exp int32) Decimal {
+	var d Decimal
+	d.r.SetInt64(value)
+	p := pow10(exp)
+	if exp < 0 {
+		d.r.Quo(&d.r, p)
+	} else {
+		d.r.Mul(&d.r, p)
+	}
+	return d
+}
+
+func NewFromString(s string) (Decimal, error) {
+	var d Decimal
+	if _, ok := d.r.SetString(s); !ok {
+		return d, errors.New("can't convert " + s + " to decimal")
+	}
+	return d, nil
+}
+
+func (d Decimal) String() string {
+	return d.r.RatString()
+}
+
+func (d Decimal) Abs() Decimal {
+	var a Decimal
+	a.r.Abs(&d.r)
+	return a
+}
+
+func (d Decimal) Cmp(d2 Decimal) int {
+	return d.r.Cmp(&d2.r)
+}
+
+func (d Decimal) Equal(d2 Decimal) bool {
+	return d.Cmp(d2) == 0
+}
+
+// Truncate removes the digits after the given number of decimal places.
+func (d Decimal) Truncate(precision int32) Decimal {
+	p := pow10(precision)
+	var t Decimal
+	t.r.Mul(&d.r, p)
+	t.r.SetInt(new(big.Int).Quo(t.r.Num(), t.r.Denom()))
+	t.r.Quo(&t.r, p)
+	return t
+}
+
+func pow10(exp int32) *big.Rat {
+	if exp < 0 {
+		exp = -exp
+	}
+	return new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exp)), nil))
+}
@@ -0,0 +1,4 @@
+// Stub of the decimal module with the functions used by the golden test.
+module github.com/shopspring/decimal
+
+go 1.22.0
@@ -0,0 +1,14 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package embedptr
+
+type Inner struct {
+	B int
+}
+
+type X struct {
+	A int
+	*Inner
+}
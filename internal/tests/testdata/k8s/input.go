@@ -0,0 +1,26 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type Widget,WidgetList -helpers deepcopy,deepcopyobject -out output.go
+
+package k8s
+
+// TypeMeta stands in for metav1.TypeMeta.
+type TypeMeta struct {
+	Kind       string `json:"kind,omitempty"`
+	APIVersion string `json:"apiVersion,omitempty"`
+}
+
+func (tm *TypeMeta) GetObjectKind() *TypeMeta { return tm }
+
+type Widget struct {
+	TypeMeta
+	Name   string            `json:"name"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+type WidgetList struct {
+	TypeMeta
+	Items []Widget `json:"items"`
+}
@@ -0,0 +1,13 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type X -helpers lambda -out output.go
+
+package lambda
+
+type X struct {
+	User  string `json:"user" gencodec:"required"`
+	Limit int    `json:"limit"`
+	Debug bool   `json:"-"`
+}
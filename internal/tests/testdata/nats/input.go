@@ -0,0 +1,12 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type X -helpers nats -out output.go
+
+package nats
+
+type X struct {
+	Subject string `json:"subject" gencodec:"required"`
+	Count   int    `json:"count"`
+}
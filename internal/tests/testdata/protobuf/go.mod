@@ -0,0 +1,7 @@
+module github.com/fjl/gencodec/internal/tests/testdata/protobuf
+
+go 1.22.0
+
+require google.golang.org/protobuf v1.36.0
+
+replace google.golang.org/protobuf => ./stub
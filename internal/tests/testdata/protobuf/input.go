@@ -0,0 +1,29 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type X -formats json,yaml -out output.go
+
+package protobuf
+
+import (
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type X struct {
+	Created *timestamppb.Timestamp `json:"created" gencodec:"required"`
+	Timeout *durationpb.Duration   `json:"timeout"`
+	Score   *wrapperspb.DoubleValue
+	Ratio   *wrapperspb.FloatValue
+	Big     *wrapperspb.Int64Value
+	Ubig    *wrapperspb.UInt64Value
+	Small   *wrapperspb.Int32Value
+	Usmall  *wrapperspb.UInt32Value
+	Enabled *wrapperspb.BoolValue
+	Name    *wrapperspb.StringValue
+	Data    *wrapperspb.BytesValue
+	// Messages which aren't pointers have no built-in representation.
+	Raw timestamppb.Timestamp
+}
@@ -0,0 +1,4 @@
+// Stub of the protobuf module with the well-known types used by the golden test.
+module google.golang.org/protobuf
+
+go 1.22.0
@@ -0,0 +1,17 @@
+// Package durationpb is a stub of the protobuf Duration message.
+package durationpb
+
+import "time"
+
+type Duration struct {
+	Seconds int64
+	Nanos   int32
+}
+
+func New(d time.Duration) *Duration {
+	return &Duration{Seconds: int64(d / time.Second), Nanos: int32(d % time.Second)}
+}
+
+func (x *Duration) AsDuration() time.Duration {
+	return time.Duration(x.Seconds)*time.Second + time.Duration(x.Nanos)
+}
@@ -0,0 +1,56 @@
+// Package wrapperspb is a stub of the protobuf wrapper messages.
+package wrapperspb
+
+type DoubleValue struct {
+	Value float64
+}
+
+func Double(v float64) *DoubleValue { return &DoubleValue{Value: v} }
+
+type FloatValue struct {
+	Value float32
+}
+
+func Float(v float32) *FloatValue { return &FloatValue{Value: v} }
+
+type Int64Value struct {
+	Value int64
+}
+
+func Int64(v int64) *Int64Value { return &Int64Value{Value: v} }
+
+type UInt64Value struct {
+	Value uint64
+}
+
+func UInt64(v uint64) *UInt64Value { return &UInt64Value{Value: v} }
+
+type Int32Value struct {
+	Value int32
+}
+
+func Int32(v int32) *Int32Value { return &Int32Value{Value: v} }
+
+type UInt32Value struct {
+	Value uint32
+}
+
+func UInt32(v uint32) *UInt32Value { return &UInt32Value{Value: v} }
+
+type BoolValue struct {
+	Value bool
+}
+
+func Bool(v bool) *BoolValue { return &BoolValue{Value: v} }
+
+type StringValue struct {
+	Value string
+}
+
+func String(v string) *StringValue { return &StringValue{Value: v} }
+
+type BytesValue struct {
+	Value []byte
+}
+
+func Bytes(v []byte) *BytesValue { return &BytesValue{Value: v} }
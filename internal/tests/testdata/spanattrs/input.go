@@ -0,0 +1,26 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type X -helpers spanattrs -out output.go
+
+package spanattrs
+
+import "time"
+
+type mode string
+
+type X struct {
+	UserID  string        `json:"user_id" otel:"user.id"`
+	Method  string        `otel:""`
+	Status  int           `json:"status" otel:""`
+	Size    int32         `otel:"size"`
+	Ratio   float32       `otel:"ratio"`
+	Cached  bool          `otel:"cached"`
+	Mode    mode          `otel:"mode"`
+	Retries *int          `otel:"retries"`
+	Tags    []string      `otel:"tags"`
+	Elapsed time.Duration `otel:"elapsed"`
+	Secret  string        `otel:"-"`
+	Body    []byte
+}
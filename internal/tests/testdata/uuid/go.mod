@@ -0,0 +1,17 @@
+module github.com/fjl/gencodec/internal/tests/testdata/uuid
+
+go 1.22.0
+
+require (
+	github.com/gofrs/uuid v4.4.0+incompatible
+	github.com/gofrs/uuid/v5 v5.3.0
+	github.com/google/uuid v1.6.0
+	github.com/satori/go.uuid v1.2.0
+)
+
+replace (
+	github.com/gofrs/uuid => ./stub/gofrs
+	github.com/gofrs/uuid/v5 => ./stub/gofrsv5
+	github.com/google/uuid => ./stub/google
+	github.com/satori/go.uuid => ./stub/satori
+)
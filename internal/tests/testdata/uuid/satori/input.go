@@ -0,0 +1,15 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type X -formats json,yaml -out output.go
+
+package satori
+
+import "github.com/satori/go.uuid"
+
+type X struct {
+	ID     uuid.UUID  `json:"id" gencodec:"required"`
+	Parent *uuid.UUID `json:"parent"`
+	Owner  uuid.UUID  `json:"owner" gencodec:"omitzero"`
+}
@@ -0,0 +1,4 @@
+// Stub of the UUID module used by the golden tests.
+module github.com/gofrs/uuid/v5
+
+go 1.22.0
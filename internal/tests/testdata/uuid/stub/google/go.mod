@@ -0,0 +1,4 @@
+// Stub of the UUID module used by the golden tests.
+module github.com/google/uuid
+
+go 1.22.0
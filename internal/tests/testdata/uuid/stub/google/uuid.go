@@ -0,0 +1,27 @@
+// Package uuid is a stub of github.com/google/uuid.
+package uuid
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+type UUID [16]byte
+
+var Nil UUID
+
+func Parse(s string) (UUID, error) {
+	var u UUID
+	b, err := hex.DecodeString(strings.ReplaceAll(s, "-", ""))
+	if err != nil || len(b) != len(u) {
+		return u, errors.New("invalid UUID")
+	}
+	copy(u[:], b)
+	return u, nil
+}
+
+func (u UUID) String() string {
+	s := hex.EncodeToString(u[:])
+	return s[:8] + "-" + s[8:12] + "-" + s[12:16] + "-" + s[16:20] + "-" + s[20:]
+}
@@ -0,0 +1,17 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package vet
+
+type X struct {
+	Valid    string `json:"valid" yaml:"valid"`
+	BadTag   string `json:"badTag`
+	Unknown  string `gencodec:"requried"`
+	Dup1     string `json:"dup"`
+	Dup2     string `json:"dup"`
+	Mismatch string `json:"a" yaml:"b"`
+	Count    int    `json:"count,omitempty"`
+	Ptr      *int   `json:"ptr,omitempty"`
+	Req      int    `json:"req,omitempty" gencodec:"required"`
+}
@@ -0,0 +1,14 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type X -helpers text -out output.go
+
+package text
+
+type count uint16
+
+type X struct {
+	Count count `gencodec:"value"`
+	Note  string
+}
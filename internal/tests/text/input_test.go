@@ -0,0 +1,35 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package text
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMapKey(t *testing.T) {
+	m := map[X]int{{Count: 1}: 1, {Count: 65535}: 2}
+	enc, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(enc) != `{"1":1,"65535":2}` {
+		t.Fatalf("wrong encoding %s", enc)
+	}
+	var dec map[X]int
+	if err := json.Unmarshal(enc, &dec); err != nil {
+		t.Fatal(err)
+	}
+	if len(dec) != 2 || dec[X{Count: 1}] != 1 || dec[X{Count: 65535}] != 2 {
+		t.Fatalf("wrong decoded map %v", dec)
+	}
+}
+
+func TestUnmarshalTextError(t *testing.T) {
+	var x X
+	if err := x.UnmarshalText([]byte("65536")); err == nil {
+		t.Fatal("no error for out of range value")
+	}
+}
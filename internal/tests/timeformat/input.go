@@ -0,0 +1,19 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type X -formats json,yaml -out output.go
+
+package timeformat
+
+import "time"
+
+type X struct {
+	Default time.Time
+	RFC3339 time.Time  `timeformat:"rfc3339"`
+	Unix    *time.Time `timeformat:"unix"`
+	Milli   time.Time  `timeformat:"unixmilli" gencodec:"required"`
+	Micro   time.Time  `timeformat:"unixmicro"`
+	Nano    *time.Time `timeformat:"unixnano"`
+	Day     *time.Time `timeformat:"2006-01-02"`
+}
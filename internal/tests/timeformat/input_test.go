@@ -0,0 +1,64 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package timeformat
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestTimeFormatJSON(t *testing.T) {
+	input := `{"Default":"2024-05-06T07:08:09Z","RFC3339":"2024-05-06T07:08:09.5+02:00","Unix":1715000000,"Milli":1715000000123,"Micro":1715000000123456,"Nano":1715000000123456789,"Day":"2024-05-06"}`
+	var x X
+	if err := json.Unmarshal([]byte(input), &x); err != nil {
+		t.Fatal("unexpected error", err)
+	}
+	if want := time.UnixMilli(1715000000123); !x.Milli.Equal(want) {
+		t.Errorf("wrong Milli %v, want %v", x.Milli, want)
+	}
+	if want := time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC); !x.Day.Equal(want) {
+		t.Errorf("wrong Day %v, want %v", x.Day, want)
+	}
+	out, err := json.Marshal(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != input {
+		t.Fatalf("got %#q, want %#q", string(out), input)
+	}
+}
+
+func TestTimeFormatYAML(t *testing.T) {
+	input := "default: 2024-05-06T07:08:09Z\nrfc3339: \"2024-05-06T07:08:09Z\"\nunix: null\nmilli: 1715000000123\nmicro: 1715000000123456\nnano: null\nday: \"2024-05-06\"\n"
+	var x X
+	if err := yaml.Unmarshal([]byte(input), &x); err != nil {
+		t.Fatal("unexpected error", err)
+	}
+	out, err := yaml.Marshal(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != input {
+		t.Fatalf("got %q, want %q", string(out), input)
+	}
+}
+
+func TestTimeFormatInvalid(t *testing.T) {
+	inputs := []string{
+		`{"Milli":0,"RFC3339":"2024-05-06"}`,
+		`{"Milli":0,"Day":"06.05.2024"}`,
+		`{"Milli":"2024-05-06T07:08:09Z"}`,
+		`{}`,
+	}
+	for _, input := range inputs {
+		var x X
+		if err := json.Unmarshal([]byte(input), &x); err == nil {
+			t.Errorf("no error for %s", input)
+		}
+	}
+}
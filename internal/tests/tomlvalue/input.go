@@ -0,0 +1,18 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type X,Server -formats toml -toml burntsushi -strict -out output.go
+
+package tomlvalue
+
+type X struct {
+	Name   string   `toml:"name" gencodec:"required"`
+	Port   int      `toml:"port" default:"8080"`
+	Tags   []string `toml:"tags"`
+	Server *Server  `toml:"server"`
+}
+
+type Server struct {
+	Host string `toml:"host" gencodec:"required"`
+}
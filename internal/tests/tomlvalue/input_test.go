@@ -0,0 +1,97 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package tomlvalue
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+)
+
+func TestDecode(t *testing.T) {
+	input := `
+name = "node"
+tags = ["a", "b"]
+
+[server]
+host = "localhost"
+`
+	var x X
+	if _, err := toml.Decode(input, &x); err != nil {
+		t.Fatal(err)
+	}
+	want := X{Name: "node", Port: 8080, Tags: []string{"a", "b"}, Server: &Server{Host: "localhost"}}
+	if !reflect.DeepEqual(x, want) {
+		t.Errorf("wrong value %+v, want %+v", x, want)
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	tests := []struct {
+		input, err string
+	}{
+		{
+			input: `port = 1`,
+			err:   "missing required field 'name' for X",
+		},
+		{
+			input: "name = \"node\"\n[server]\n",
+			err:   "missing required field 'host' for Server",
+		},
+		{
+			// The keys are checked in sorted order.
+			input: "name = \"node\"\nzzz = 1\naaa = 2",
+			err:   "unknown field 'aaa' for X",
+		},
+		{
+			input: "name = \"node\"\n[server]\nhost = \"localhost\"\nextra = true",
+			err:   "unknown field 'extra' for Server",
+		},
+		{
+			input: `name = 1`,
+			err:   "incompatible types",
+		},
+	}
+	for _, test := range tests {
+		var x X
+		_, err := toml.Decode(test.input, &x)
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("input %q: wrong error %v, want %q", test.input, err, test.err)
+		}
+	}
+}
+
+func TestEncodeRoundTrip(t *testing.T) {
+	x := X{Name: "node", Port: 9000, Tags: []string{"a"}, Server: &Server{Host: "localhost"}}
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(x); err != nil {
+		t.Fatal(err)
+	}
+	want := "name = \"node\"\nport = 9000\ntags = [\"a\"]\n\n[server]\n  host = \"localhost\"\n"
+	if buf.String() != want {
+		t.Errorf("wrong encoding %q, want %q", buf.String(), want)
+	}
+	var dec X
+	if _, err := toml.Decode(buf.String(), &dec); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(dec, x) {
+		t.Errorf("wrong value %+v, want %+v", dec, x)
+	}
+}
+
+func TestEncodeServer(t *testing.T) {
+	// Nested in X, Server is a table. On its own, it is the whole document.
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(&Server{Host: "localhost"}); err != nil {
+		t.Fatal(err)
+	}
+	if want := "host = \"localhost\"\n"; buf.String() != want {
+		t.Errorf("wrong encoding %q, want %q", buf.String(), want)
+	}
+}
@@ -0,0 +1,13 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type X -typed-errors -strict -formats json,yaml -out output.go
+
+package typederrors
+
+type X struct {
+	Name  string `json:"name" yaml:"name" gencodec:"required"`
+	Count int    `json:"count" yaml:"count"`
+	Label string `gencodec:"required"`
+}
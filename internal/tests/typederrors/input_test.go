@@ -0,0 +1,87 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package typederrors
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/fjl/gencodec/codecerr"
+	"gopkg.in/yaml.v3"
+)
+
+func TestMissingFieldError(t *testing.T) {
+	var x X
+	err := json.Unmarshal([]byte(`{"count": 1, "Label": "l"}`), &x)
+	var missing *codecerr.MissingFieldError
+	if !errors.As(err, &missing) {
+		t.Fatalf("wrong error %#v", err)
+	}
+	if *missing != (codecerr.MissingFieldError{Type: "X", Field: "name"}) {
+		t.Fatalf("wrong error value %+v", missing)
+	}
+	if want := "missing required field 'name' for X"; err.Error() != want {
+		t.Fatalf("wrong message %q", err)
+	}
+}
+
+// The field of a missing untagged field is its key in the format.
+func TestMissingUntaggedFieldError(t *testing.T) {
+	tests := []struct {
+		unmarshal func([]byte, interface{}) error
+		input     string
+		field     string
+	}{
+		{json.Unmarshal, `{"name": "n"}`, "Label"},
+		{yaml.Unmarshal, "name: n\n", "label"},
+	}
+	for _, test := range tests {
+		var x X
+		err := test.unmarshal([]byte(test.input), &x)
+		var missing *codecerr.MissingFieldError
+		if !errors.As(err, &missing) {
+			t.Fatalf("wrong error %#v", err)
+		}
+		if *missing != (codecerr.MissingFieldError{Type: "X", Field: test.field}) {
+			t.Errorf("wrong error value %+v, want field %q", missing, test.field)
+		}
+	}
+}
+
+func TestUnknownFieldError(t *testing.T) {
+	tests := []struct {
+		unmarshal func([]byte, interface{}) error
+		input     string
+	}{
+		{json.Unmarshal, `{"name": "n", "Label": "l", "Other": 1}`},
+		{yaml.Unmarshal, "name: n\nlabel: l\nOther: 1\n"},
+	}
+	for _, test := range tests {
+		var x X
+		err := test.unmarshal([]byte(test.input), &x)
+		var unknown *codecerr.UnknownFieldError
+		if !errors.As(err, &unknown) {
+			t.Fatalf("wrong error %#v", err)
+		}
+		if *unknown != (codecerr.UnknownFieldError{Type: "X", Field: "Other"}) {
+			t.Fatalf("wrong error value %+v", unknown)
+		}
+	}
+	// With several unknown keys, the first one in sorted order is reported.
+	for i := 0; i < 20; i++ {
+		var x X
+		err := json.Unmarshal([]byte(`{"name": "n", "Label": "l", "Zone": 1, "Other": 2}`), &x)
+		var unknown *codecerr.UnknownFieldError
+		if !errors.As(err, &unknown) || unknown.Field != "Other" {
+			t.Fatalf("wrong error %v", err)
+		}
+	}
+	// Keys of fields are matched case-insensitively in JSON.
+	var x X
+	if err := json.Unmarshal([]byte(`{"Name": "n", "COUNT": 2, "LABEL": "l"}`), &x); err != nil {
+		t.Fatal(err)
+	}
+}
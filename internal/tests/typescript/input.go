@@ -0,0 +1,55 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type Order,Item -field-override orderMarshaling,itemMarshaling -ts types.d.ts -out output.go
+
+package typescript
+
+import (
+	"math/big"
+	"time"
+)
+
+type Order struct {
+	ID       uint64            `json:"id" gencodec:"required"`
+	Total    *big.Int          `json:"total" gencodec:"required"`
+	Nonce    uint64            `json:"nonce"`
+	Items    []Item            `json:"items" gencodec:"required"`
+	Ship     *Address          `json:"ship-to"`
+	Placed   time.Time         `json:"placed"`
+	Tags     map[string]string `json:"tags,omitempty"`
+	Notes    []*string         `json:"notes"`
+	Internal string            `json:"-"`
+}
+
+type orderMarshaling struct {
+	Total *hexBig
+	Nonce hexUint
+}
+
+// hexBig and hexUint are encoded as hex strings.
+type hexBig big.Int
+
+func (h hexBig) MarshalText() ([]byte, error)   { return (*big.Int)(&h).MarshalText() }
+func (h *hexBig) UnmarshalText(b []byte) error { return (*big.Int)(h).UnmarshalText(b) }
+
+type hexUint uint64
+
+func (h hexUint) MarshalText() ([]byte, error)   { return nil, nil }
+func (h *hexUint) UnmarshalText(b []byte) error { return nil }
+
+type Item struct {
+	SKU      string `json:"sku" gencodec:"required"`
+	Quantity int    `json:"qty"`
+}
+
+type itemMarshaling struct{}
+
+type Address struct {
+	Street string `json:"street" gencodec:"required"`
+	City   string `json:"city,omitempty"`
+	Geo    struct {
+		Lat, Lon float64
+	} `json:"geo"`
+}
@@ -0,0 +1,24 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type X,Y -formats json,yaml -include-unexported -out output.go
+
+package unexported
+
+import "time"
+
+// X is stored with its internal state.
+type X struct {
+	ID      string    `json:"id" yaml:"id" gencodec:"required"`
+	counter int       `gencodec:"required"`
+	updated time.Time `yaml:"updatedAt"`
+	cache   []byte    `json:"-" yaml:"-"`
+	_       int
+}
+
+// Y has a single unexported field, which is included by the export option.
+type Y struct {
+	Name   string
+	secret string `yaml:"s,omitempty" gencodec:"export"`
+}
@@ -0,0 +1,50 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package unexported
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestUnexportedJSON(t *testing.T) {
+	x := X{ID: "a", counter: 3, updated: time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC), cache: []byte{1}}
+	enc, err := json.Marshal(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"id":"a","counter":3,"updated":"2020-01-02T00:00:00Z"}`
+	if string(enc) != want {
+		t.Fatalf("got %s, want %s", enc, want)
+	}
+	var dec X
+	if err := json.Unmarshal(enc, &dec); err != nil {
+		t.Fatal(err)
+	}
+	if dec.ID != x.ID || dec.counter != x.counter || !dec.updated.Equal(x.updated) || dec.cache != nil {
+		t.Fatalf("got %+v, want %+v without cache", dec, x)
+	}
+	if err := json.Unmarshal([]byte(`{"id":"a"}`), &dec); err == nil {
+		t.Fatal("expected error for missing counter")
+	}
+}
+
+func TestUnexportedYAML(t *testing.T) {
+	y := Y{Name: "n", secret: "s"}
+	enc, err := yaml.Marshal(y)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var dec Y
+	if err := yaml.Unmarshal(enc, &dec); err != nil {
+		t.Fatal(err)
+	}
+	if dec != y {
+		t.Fatalf("got %+v, want %+v", dec, y)
+	}
+}
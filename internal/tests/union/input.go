@@ -0,0 +1,32 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type Drawing -union Shape=Circle,*Polygon,Empty -out output.go
+
+package union
+
+type Shape interface {
+	Area() float64
+}
+
+type Circle struct {
+	Radius float64 `json:"radius"`
+}
+
+func (c Circle) Area() float64 { return 3 * c.Radius * c.Radius }
+
+type Polygon struct {
+	Points [][2]float64 `json:"points"`
+}
+
+func (p *Polygon) Area() float64 { return 0 }
+
+type Empty struct{}
+
+func (Empty) Area() float64 { return 0 }
+
+type Drawing struct {
+	Background Shape `json:"background" gencodec:"required"`
+	Foreground Shape `json:"foreground,omitempty"`
+}
@@ -0,0 +1,65 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package union
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestUnionRoundTrip(t *testing.T) {
+	tests := []struct {
+		value Drawing
+		want  string
+	}{
+		{
+			Drawing{Background: Circle{Radius: 2}},
+			`{"background":{"type":"Circle","radius":2}}`,
+		},
+		{
+			Drawing{Background: &Polygon{Points: [][2]float64{{0, 1}}}, Foreground: Empty{}},
+			`{"background":{"type":"Polygon","points":[[0,1]]},"foreground":{"type":"Empty"}}`,
+		},
+	}
+	for _, test := range tests {
+		enc, err := json.Marshal(test.value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(enc) != test.want {
+			t.Errorf("got %s, want %s", enc, test.want)
+		}
+		var dec Drawing
+		if err := json.Unmarshal(enc, &dec); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(dec, test.value) {
+			t.Errorf("decoded %+v, want %+v", dec, test.value)
+		}
+	}
+}
+
+type square struct{}
+
+func (square) Area() float64 { return 1 }
+
+func TestUnionErrors(t *testing.T) {
+	if _, err := json.Marshal(Drawing{Background: square{}}); err == nil {
+		t.Error("no error for type outside of the union")
+	}
+	inputs := []string{
+		`{"background":{"radius":2}}`,
+		`{"background":{"type":"Square"}}`,
+		`{"background":{"type":"Polygon","points":1}}`,
+		`{}`,
+	}
+	for _, input := range inputs {
+		var dec Drawing
+		if err := json.Unmarshal([]byte(input), &dec); err == nil {
+			t.Errorf("no error for %s", input)
+		}
+	}
+}
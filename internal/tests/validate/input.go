@@ -0,0 +1,26 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type X,Y -formats json,yaml -out output.go
+
+package validate
+
+import "errors"
+
+type X struct {
+	Min int `json:"min" yaml:"min"`
+	Max int `json:"max" yaml:"max"`
+}
+
+func (x *X) ValidateAfterDecode() error {
+	if x.Min > x.Max {
+		return errors.New("min exceeds max")
+	}
+	return nil
+}
+
+// Y has no validation method.
+type Y struct {
+	Name string `json:"name" yaml:"name"`
+}
@@ -0,0 +1,25 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package validate
+
+import (
+	"encoding/json"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestValidateAfterDecode(t *testing.T) {
+	var x X
+	if err := json.Unmarshal([]byte(`{"min": 1, "max": 2}`), &x); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(`{"min": 3, "max": 2}`), &x); err == nil || err.Error() != "min exceeds max" {
+		t.Fatalf("wrong error %v", err)
+	}
+	if err := yaml.Unmarshal([]byte("min: 3\nmax: 2\n"), &x); err == nil || err.Error() != "min exceeds max" {
+		t.Fatalf("wrong error %v", err)
+	}
+}
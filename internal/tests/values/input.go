@@ -0,0 +1,21 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type Query -helpers values -out output.go
+
+package values
+
+import "net/netip"
+
+type Query struct {
+	Term   string       `json:"term" url:"q" gencodec:"required"`
+	Page   int          `json:"page" url:"page" default:"1"`
+	Limit  *uint8       `json:"limit" url:"limit"`
+	Exact  bool         `json:"exact" form:"exact,omitempty"`
+	Tags   []string     `json:"tags" url:"tag"`
+	IDs    []int64      `json:"ids" form:"id"`
+	Client netip.Addr   `json:"client" url:"client,omitempty"`
+	Peers  []netip.Addr `json:"peers" url:"peer"`
+	Debug  string       `json:"debug"`
+}
@@ -0,0 +1,73 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package values
+
+import (
+	"net/netip"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestValues(t *testing.T) {
+	limit := uint8(20)
+	q := Query{
+		Term:   "go",
+		Page:   3,
+		Limit:  &limit,
+		Exact:  true,
+		Tags:   []string{"a", "b"},
+		IDs:    []int64{7, -1},
+		Client: netip.MustParseAddr("10.0.0.1"),
+		Peers:  []netip.Addr{netip.MustParseAddr("::1")},
+		Debug:  "not encoded",
+	}
+	vals := q.EncodeValues()
+	want := "client=10.0.0.1&exact=true&id=7&id=-1&limit=20&page=3&peer=%3A%3A1&q=go&tag=a&tag=b"
+	if enc := vals.Encode(); enc != want {
+		t.Fatalf("wrong encoding\n got %s\nwant %s", enc, want)
+	}
+	var dec Query
+	if err := dec.DecodeValues(vals); err != nil {
+		t.Fatal(err)
+	}
+	q.Debug = ""
+	if !reflect.DeepEqual(dec, q) {
+		t.Fatalf("wrong result %+v", dec)
+	}
+}
+
+func TestValuesOptional(t *testing.T) {
+	vals := Query{Term: "go"}.EncodeValues()
+	if enc := vals.Encode(); enc != "page=0&q=go" {
+		t.Errorf("wrong encoding %s", enc)
+	}
+	var dec Query
+	if err := dec.DecodeValues(url.Values{"q": {"x", "y"}}); err != nil {
+		t.Fatal(err)
+	}
+	if dec.Term != "x" || dec.Page != 1 || dec.Limit != nil || dec.Tags != nil {
+		t.Errorf("wrong result %+v", dec)
+	}
+}
+
+func TestValuesErrors(t *testing.T) {
+	tests := []struct {
+		query, err string
+	}{
+		{"page=2", "missing required field 'q' for Query"},
+		{"q=go&page=two", `invalid value of page for Query: strconv.ParseInt: parsing "two": invalid syntax`},
+		{"q=go&limit=300", `invalid value of limit for Query: strconv.ParseUint: parsing "300": value out of range`},
+		{"q=go&id=1&id=x", `invalid value of id for Query: strconv.ParseInt: parsing "x": invalid syntax`},
+		{"q=go&client=nope", `invalid value of client for Query: ParseAddr("nope"): unable to parse IP`},
+	}
+	for _, test := range tests {
+		vals, _ := url.ParseQuery(test.query)
+		var dec Query
+		if err := dec.DecodeValues(vals); err == nil || err.Error() != test.err {
+			t.Errorf("query %s: wrong error %v, want %q", test.query, err, test.err)
+		}
+	}
+}
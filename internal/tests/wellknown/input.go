@@ -0,0 +1,32 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type X -formats json,yaml -out output.go
+
+package wellknown
+
+import (
+	"math/big"
+	"net"
+	"net/mail"
+	"net/netip"
+	"net/url"
+	"regexp"
+)
+
+type X struct {
+	IP      net.IP
+	Addr    netip.Addr
+	AddrPtr *netip.Addr
+	OptIP   net.IP     `gencodec:"omitzero"`
+	OptAddr netip.Addr `gencodec:"omitzero"`
+	URL     *url.URL   `gencodec:"required"`
+	URLVal  url.URL
+	Re      *regexp.Regexp
+	Mail    mail.Address
+	RawIP   net.IP `gencodec:"nobuiltin"`
+	Rat     *big.Rat
+	Amount  *big.Rat `gencodec:"precision=5,scale=2"`
+	Units   *big.Rat `gencodec:"precision=3"`
+}
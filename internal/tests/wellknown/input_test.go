@@ -0,0 +1,141 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package wellknown
+
+import (
+	"encoding/json"
+	"math/big"
+	"net"
+	"net/netip"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestWellKnownJSON(t *testing.T) {
+	input := `{"IP":"10.0.0.1","Addr":"::1","AddrPtr":"127.0.0.1","OptIP":"10.0.0.3","OptAddr":"::2","URL":"https://example.com/x","URLVal":"/path","Re":"^a+$","Mail":"\u003ca@example.com\u003e","RawIP":"10.0.0.2","Rat":"1/3","Amount":"-123.40","Units":"12"}`
+	var x X
+	if err := json.Unmarshal([]byte(input), &x); err != nil {
+		t.Fatal("unexpected error", err)
+	}
+	out, err := json.Marshal(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != input {
+		t.Fatalf("got %#q, want %#q", string(out), input)
+	}
+}
+
+func TestWellKnownNull(t *testing.T) {
+	want := `{"IP":"","Addr":"","AddrPtr":null,"OptIP":null,"OptAddr":null,"URL":null,"URLVal":"","Re":null,"Mail":null,"RawIP":"","Rat":null,"Amount":null,"Units":null}`
+	out, err := json.Marshal(X{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != want {
+		t.Fatalf("got %#q, want %#q", string(out), want)
+	}
+}
+
+// TestWellKnownEmpty checks that zero IP addresses marshal as the empty string, like
+// their MarshalText methods, and that the empty string decodes to the zero value.
+func TestWellKnownEmpty(t *testing.T) {
+	addr := netip.MustParseAddr("::1")
+	x := X{IP: net.IPv4(10, 0, 0, 1), Addr: addr, AddrPtr: &addr, OptIP: net.IPv4(10, 0, 0, 2), OptAddr: addr}
+	input := `{"IP":"","Addr":"","AddrPtr":"","OptIP":"","OptAddr":"","URL":"x"}`
+	if err := json.Unmarshal([]byte(input), &x); err != nil {
+		t.Fatal("unexpected error", err)
+	}
+	if x.IP != nil || x.Addr.IsValid() || x.AddrPtr == nil || x.AddrPtr.IsValid() || x.OptIP != nil || x.OptAddr.IsValid() {
+		t.Fatalf("empty strings not decoded as zero values: %+v", x)
+	}
+
+	// The output of encoding/json for the same fields decodes as well.
+	type plain struct {
+		IP      net.IP
+		Addr    netip.Addr
+		AddrPtr *netip.Addr
+		URL     string
+	}
+	out, err := json.Marshal(plain{AddrPtr: new(netip.Addr), URL: "x"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(out, &x); err != nil {
+		t.Fatalf("can't decode %s: %v", out, err)
+	}
+	enc, err := json.Marshal(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"IP":"","Addr":"","AddrPtr":"","OptIP":null,"OptAddr":null,"URL":"x","URLVal":"","Re":null,"Mail":null,"RawIP":"","Rat":null,"Amount":null,"Units":null}`
+	if string(enc) != want {
+		t.Fatalf("got %#q, want %#q", string(enc), want)
+	}
+}
+
+func TestWellKnownInvalid(t *testing.T) {
+	inputs := []string{
+		`{"URL":"x","IP":"foo"}`,
+		`{"URL":"x","Addr":"1.2.3"}`,
+		`{"URL":"x","Re":"("}`,
+		`{"URL":"x","Mail":"@"}`,
+		`{"URL":":"}`,
+		`{"URL":"x","Rat":"1/0"}`,
+		`{"URL":"x","Amount":"1.001"}`,
+		`{"URL":"x","Amount":"1000"}`,
+		`{"URL":"x","Units":"1.5"}`,
+		`{"URL":"x","Units":"1000"}`,
+	}
+	for _, input := range inputs {
+		var x X
+		if err := json.Unmarshal([]byte(input), &x); err == nil {
+			t.Errorf("expected error for %s", input)
+		}
+	}
+}
+
+func TestWellKnownErrorField(t *testing.T) {
+	tests := []struct{ input, want string }{
+		{`{"URL":"x","IP":"foo"}`, `invalid IP address "foo" for field 'IP' of X`},
+		{`{"URL":"x","Addr":"1.2.3"}`, `invalid value for field 'Addr' of X: ParseAddr("1.2.3"): IPv4 address too short`},
+	}
+	for _, test := range tests {
+		var x X
+		err := json.Unmarshal([]byte(test.input), &x)
+		if err == nil || err.Error() != test.want {
+			t.Errorf("wrong error for %s: %v\nwant %s", test.input, err, test.want)
+		}
+	}
+}
+
+func TestWellKnownLimitsEncode(t *testing.T) {
+	u, _ := url.Parse("x")
+	tests := []struct {
+		x    X
+		want string
+	}{
+		// The value is not rounded to the scale.
+		{X{URL: u, Amount: big.NewRat(1005, 1000)}, "field 'Amount' of X exceeds scale 2"},
+		{X{URL: u, Amount: big.NewRat(1000, 1)}, "field 'Amount' of X exceeds precision 5"},
+		// Without a scale, the value must be an integer.
+		{X{URL: u, Units: big.NewRat(3, 2)}, "field 'Units' of X exceeds scale 0"},
+		{X{URL: u, Units: big.NewRat(1000, 1)}, "field 'Units' of X exceeds precision 3"},
+	}
+	for _, test := range tests {
+		_, err := json.Marshal(test.x)
+		if err == nil || !strings.Contains(err.Error(), test.want) {
+			t.Errorf("wrong error %v, want %q", err, test.want)
+		}
+	}
+	out, err := json.Marshal(X{URL: u, Amount: big.NewRat(-1234, 10), Units: big.NewRat(999, 1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(out), `"Amount":"-123.40","Units":"999"`) {
+		t.Errorf("wrong output %s", out)
+	}
+}
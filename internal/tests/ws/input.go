@@ -0,0 +1,16 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type Join,Chat -helpers ws -out output.go
+
+package ws
+
+type Join struct {
+	User string `json:"user" gencodec:"required"`
+}
+
+type Chat struct {
+	User string `json:"user" gencodec:"required"`
+	Text string `json:"text"`
+}
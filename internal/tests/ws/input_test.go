@@ -0,0 +1,44 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package ws
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRoundTrip(t *testing.T) {
+	enc, err := Chat{User: "bob", Text: "hi"}.EncodeWSMessage(7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"kind":"Chat","seq":7,"data":{"user":"bob","text":"hi"}}`; string(enc) != want {
+		t.Fatalf("wrong encoding %s", enc)
+	}
+	msg, seq, err := DecodeWSMessage(enc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if seq != 7 || !reflect.DeepEqual(msg, &Chat{User: "bob", Text: "hi"}) {
+		t.Fatalf("wrong decoded message %#v, seq %d", msg, seq)
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	enc, err := Join{User: "bob"}.EncodeWSMessage(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var c Chat
+	if _, err := c.DecodeWSMessage(enc); err == nil || err.Error() != `wrong message kind "Join" for Chat` {
+		t.Fatalf("got error %v", err)
+	}
+	if _, _, err := DecodeWSMessage([]byte(`{"kind":"Leave"}`)); err == nil || err.Error() != `unknown message kind "Leave"` {
+		t.Fatalf("got error %v", err)
+	}
+	if _, _, err := DecodeWSMessage([]byte(`{"kind":"Join","seq":2,"data":{}}`)); err == nil {
+		t.Fatal("no error for missing required field")
+	}
+}
@@ -0,0 +1,21 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type X -field-override Xo -formats json,xml -out output.go
+
+package xml
+
+type replacedInt int
+
+type X struct {
+	ID       string `xml:"id,attr" gencodec:"required"`
+	Required int    `xml:"req" gencodec:"required"`
+	Optional string `xml:"opt,omitempty"`
+	Replaced int
+	Skipped  int `xml:"-"`
+}
+
+type Xo struct {
+	Replaced replacedInt
+}
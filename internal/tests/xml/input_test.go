@@ -0,0 +1,47 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package xml
+
+import (
+	"encoding/xml"
+	"testing"
+)
+
+func TestRoundTrip(t *testing.T) {
+	x := X{ID: "a", Required: 1, Optional: "b", Replaced: 2, Skipped: 3}
+	enc, err := xml.Marshal(&x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `<X id="a"><req>1</req><opt>b</opt><Replaced>2</Replaced></X>`
+	if string(enc) != want {
+		t.Fatalf("wrong encoding %s", enc)
+	}
+
+	var dec X
+	if err := xml.Unmarshal(enc, &dec); err != nil {
+		t.Fatal(err)
+	}
+	x.Skipped = 0
+	if dec != x {
+		t.Fatalf("got %+v, want %+v", dec, x)
+	}
+}
+
+func TestMissingRequired(t *testing.T) {
+	tests := []struct {
+		input, err string
+	}{
+		{`<X><req>1</req></X>`, "missing required field 'id' for X"},
+		{`<X id="a"><opt>b</opt></X>`, "missing required field 'req' for X"},
+	}
+	for _, test := range tests {
+		var dec X
+		err := xml.Unmarshal([]byte(test.input), &dec)
+		if err == nil || err.Error() != test.err {
+			t.Errorf("input %s: wrong error %v", test.input, err)
+		}
+	}
+}
@@ -0,0 +1,17 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type X -formats json,yaml -strict -out output.go
+
+package yamlinline
+
+type X struct {
+	Name string `json:"name" yaml:"name" gencodec:"required"`
+	Meta Meta   `json:"meta" yaml:",inline"`
+}
+
+type Meta struct {
+	Owner string `json:"owner" yaml:"owner" gencodec:"required"`
+	Port  int    `json:"port" yaml:"port" default:"80"`
+}
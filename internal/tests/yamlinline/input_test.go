@@ -0,0 +1,53 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package yamlinline
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestInline(t *testing.T) {
+	var x X
+	if err := yaml.Unmarshal([]byte("name: a\nowner: b\n"), &x); err != nil {
+		t.Fatal(err)
+	}
+	want := X{Name: "a", Meta: Meta{Owner: "b", Port: 80}}
+	if !reflect.DeepEqual(x, want) {
+		t.Fatalf("wrong result %+v", x)
+	}
+	out, err := yaml.Marshal(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "name: a\nowner: b\nport: 80\n"; string(out) != want {
+		t.Fatalf("wrong YAML %q", out)
+	}
+
+	// JSON keeps the nested object.
+	js, err := json.Marshal(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"name":"a","meta":{"owner":"b","port":80}}`; string(js) != want {
+		t.Fatalf("wrong JSON %s", js)
+	}
+}
+
+func TestInlineErrors(t *testing.T) {
+	tests := map[string]string{
+		"name: a\n":                    "missing required field 'owner' for X",
+		"name: a\nmeta:\n  owner: b\n": "unknown field 'meta' for X",
+	}
+	for input, want := range tests {
+		var x X
+		if err := yaml.Unmarshal([]byte(input), &x); err == nil || err.Error() != want {
+			t.Errorf("%q: wrong error %v, want %q", input, err, want)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type X,Y -formats yaml -yaml v3 -strict -out output.go
+
+package yamlnode
+
+type X struct {
+	Name  string `yaml:"name" gencodec:"required"`
+	Count int    `yaml:"count" default:"1"`
+	Tags  []string
+	Meta  Meta `yaml:",inline"`
+}
+
+type Meta struct {
+	Owner string `yaml:"owner"`
+	Note  string
+	Audit `yaml:",inline"`
+	skip  int
+}
+
+type Audit struct {
+	Created string `yaml:"created"`
+	Ignored string `yaml:"-"`
+}
+
+// Y keeps unknown keys in an inline map, so it accepts all keys.
+type Y struct {
+	ID    int               `yaml:"id" gencodec:"required"`
+	Extra map[string]string `yaml:",inline"`
+}
@@ -0,0 +1,104 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package yamlnode
+
+import (
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestYAMLNode(t *testing.T) {
+	var x X
+	if err := yaml.Unmarshal([]byte("name: a\ntags: [b]\n"), &x); err != nil {
+		t.Fatal(err)
+	}
+	if want := (X{Name: "a", Count: 1, Tags: []string{"b"}}); !reflect.DeepEqual(x, want) {
+		t.Fatalf("wrong result %+v", x)
+	}
+}
+
+func TestYAMLNodeErrors(t *testing.T) {
+	tests := map[string]string{
+		"items:\n  - count: 2\n":                "line 2: missing required field 'name' for X",
+		"items:\n  - name: a\n    x: 1\n":       "line 3: unknown field 'x' for X",
+		"items:\n  - name: a\n    ignored: b\n": "line 3: unknown field 'ignored' for X",
+		"items:\n  - name: a\n    meta: {}\n":   "line 3: unknown field 'meta' for X",
+	}
+	for input, want := range tests {
+		var v struct{ Items []X }
+		if err := yaml.Unmarshal([]byte(input), &v); err == nil || err.Error() != want {
+			t.Errorf("%q: wrong error %v, want %q", input, err, want)
+		}
+	}
+}
+
+func TestYAMLNodeMerge(t *testing.T) {
+	input := "base: &base\n  count: 3\nitem:\n  <<: *base\n  name: a\n"
+	var v struct{ Item X }
+	if err := yaml.Unmarshal([]byte(input), &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.Item.Count != 3 || v.Item.Name != "a" {
+		t.Fatalf("wrong result %+v", v.Item)
+	}
+}
+
+func TestYAMLNodeInline(t *testing.T) {
+	input := "name: a\nowner: b\nnote: c\ncreated: d\n"
+	var x X
+	if err := yaml.Unmarshal([]byte(input), &x); err != nil {
+		t.Fatal(err)
+	}
+	want := X{Name: "a", Count: 1, Meta: Meta{Owner: "b", Note: "c", Audit: Audit{Created: "d"}}}
+	if !reflect.DeepEqual(x, want) {
+		t.Fatalf("wrong result %+v", x)
+	}
+	out, err := yaml.Marshal(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "name: a\ncount: 1\ntags: []\nowner: b\nnote: c\ncreated: d\n"; string(out) != want {
+		t.Fatalf("wrong output %q", out)
+	}
+
+	// Inline maps take the unknown keys.
+	var y Y
+	if err := yaml.Unmarshal([]byte("id: 1\nx: a\n"), &y); err != nil {
+		t.Fatal(err)
+	}
+	if want := (Y{ID: 1, Extra: map[string]string{"x": "a"}}); !reflect.DeepEqual(y, want) {
+		t.Fatalf("wrong result %+v", y)
+	}
+}
+
+// TestYAMLNodeAlias checks that the keys of aliased mappings are checked when the
+// alias node is passed to UnmarshalYAML.
+func TestYAMLNodeAlias(t *testing.T) {
+	input := "base: &base\n  name: a\n  x: 1\nitem: *base\n"
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(input), &doc); err != nil {
+		t.Fatal(err)
+	}
+	alias := doc.Content[0].Content[3]
+	if alias.Kind != yaml.AliasNode {
+		t.Fatalf("wrong node kind %v", alias.Kind)
+	}
+	var x X
+	err := x.UnmarshalYAML(alias)
+	if want := "line 3: unknown field 'x' for X"; err == nil || err.Error() != want {
+		t.Fatalf("wrong error %v, want %q", err, want)
+	}
+
+	// Nodes of other kinds fail to decode.
+	var seq yaml.Node
+	if err := yaml.Unmarshal([]byte("[name, a]"), &seq); err != nil {
+		t.Fatal(err)
+	}
+	if err := x.UnmarshalYAML(seq.Content[0]); err == nil {
+		t.Fatal("no error for sequence node")
+	}
+}
@@ -0,0 +1,12 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+//go:generate gencodec -type X -formats yaml -yaml v3 -strict -typed-errors -out output.go
+
+package typed
+
+type X struct {
+	Name  string `yaml:"name" gencodec:"required"`
+	Count int    `yaml:"count"`
+}
@@ -0,0 +1,37 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package typed
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fjl/gencodec/codecerr"
+	"gopkg.in/yaml.v3"
+)
+
+func TestTypedErrorLines(t *testing.T) {
+	var v struct{ Items []X }
+	err := yaml.Unmarshal([]byte("items:\n  - count: 1\n"), &v)
+	var missing *codecerr.MissingFieldError
+	if !errors.As(err, &missing) {
+		t.Fatalf("wrong error %#v", err)
+	}
+	if *missing != (codecerr.MissingFieldError{Type: "X", Field: "name", Line: 2}) {
+		t.Fatalf("wrong error value %+v", missing)
+	}
+	if want := "line 2: missing required field 'name' for X"; err.Error() != want {
+		t.Fatalf("wrong message %q", err)
+	}
+
+	err = yaml.Unmarshal([]byte("items:\n  - name: a\n    x: 1\n"), &v)
+	var unknown *codecerr.UnknownFieldError
+	if !errors.As(err, &unknown) {
+		t.Fatalf("wrong error %#v", err)
+	}
+	if *unknown != (codecerr.UnknownFieldError{Type: "X", Field: "x", Line: 3}) {
+		t.Fatalf("wrong error value %+v", unknown)
+	}
+}
@@ -11,10 +11,198 @@ features which the standard json package cannot offer.
 
 	gencodec -type MyType -formats json,yaml,toml -out mytype_json.go
 
+The -type flag also accepts a comma-separated list of types, whose methods are written to
+the same file. This is faster than running gencodec once for each type because the package
+is loaded only once. The -field-override flag then takes one override type for each type,
+which may be left empty.
+
+	gencodec -type Header,Body -field-override headerMarshaling, -out types_json.go
+
+The package is loaded by the go command, so imports are resolved through the enclosing
+module, workspace or vendor directory. Files with build constraints can be included using
+the -tags flag, which takes a comma-separated list of build tags.
+
+The -formats flag selects the generated methods. The YAML methods implement the Marshaler
+and Unmarshaler interfaces of gopkg.in/yaml.v2. The TOML methods MarshalTOML and
+UnmarshalTOML have the same signatures and are used by github.com/naoina/toml, see -toml
+for github.com/BurntSushi/toml. The msgpack format generates EncodeMsgpack and
+DecodeMsgpack methods for github.com/vmihailenco/msgpack/v5, taking keys from "msgpack"
+struct tags. The cbor format generates MarshalCBOR and UnmarshalCBOR methods for
+github.com/fxamacker/cbor/v2. Its keys are taken from "cbor" struct tags, or "json" tags
+if there is no cbor tag. The xml format generates MarshalXML and UnmarshalXML methods for
+encoding/xml. Options of "xml" struct tags such as attr and chardata work as usual, and
+unlike encoding/xml, the generated UnmarshalXML reports missing required elements and
+attributes.
+
+The bson format generates MarshalBSON and UnmarshalBSON methods, which implement the
+Marshaler and Unmarshaler interfaces of go.mongodb.org/mongo-driver/bson. Keys are taken
+from "bson" struct tags. Like the bson package, fields without a tag use the lowercased
+field name as the key. The input package's module must require the mongo driver.
+
+The env format generates only UnmarshalEnv(lookup func(string) (string, bool)) error,
+which reads the fields with an "env" struct tag from environment variables. The tag gives
+the name of the variable, and lookup is usually os.LookupEnv. Strings, numbers, booleans
+and types implementing encoding.TextUnmarshaler are parsed from the variable. An unset
+variable of a required field is an error, like a missing key in the other formats, and
+optional fields get their default value or are left unchanged.
+
+	type Config struct {
+		Host string `env:"APP_HOST" gencodec:"required"`
+		Port int    `env:"APP_PORT" default:"8080"`
+	}
+
+The hcl format generates only DecodeHCL(body hcl.Body, ctx *hcl.EvalContext) hcl.Diagnostics,
+which decodes the fields with an "hcl" struct tag from an HCL body, using the tag kinds of
+the gohcl package: attributes, blocks and block labels. Block fields must have a type which
+also has a DecodeHCL method. Missing required attributes and blocks are reported as
+diagnostics, and absent optional attributes get their default value.
+
+	type Config struct {
+		Name      string     `hcl:"name" gencodec:"required"`
+		Workers   int        `hcl:"workers,optional" default:"4"`
+		Listeners []Listener `hcl:"listener,block"`
+	}
+
+Required fields and field overrides work the same way in all formats.
+
 Struct Tags
 
 The gencodec:"required" tag can be used to generate a presence check for the field.
 The generated unmarshaling method returns an error if a required field is missing.
+Multiple gencodec options are separated by commas.
+
+Fields are optional unless they have the required option. When most fields of a type are
+required, the -required-by-default flag reverses this: all fields are required unless they
+have the gencodec:"optional" option.
+
+Fields whose struct tag for a format has the omitempty option are optional in that
+format, even if they have the required option, because their zero value is left out when
+marshaling. The -omitempty flag adds omitempty to the struct tags of all optional fields
+in the generated intermediate types, so their zero values are dropped on marshaling.
+
+The default tag sets the value of an optional field when it is absent from the input.
+Without it, the unmarshaling methods leave absent fields unchanged. Defaults are supported
+for fields of string, numeric and boolean types. Fields with a default are never required,
+not even with -required-by-default.
+
+With the -strict flag, UnmarshalJSON, UnmarshalYAML and UnmarshalTOML return an error
+naming a key of the input which doesn't belong to a field. If there are several, it is the
+first one of the input in UnmarshalJSON without -typed-errors and with -yaml v3, and the
+first one in sorted order otherwise, so that the error doesn't change between runs.
+UnmarshalJSON also rejects data following the JSON value.
+
+UnmarshalYAML has the signature of gopkg.in/yaml.v2 by default, which yaml.v3 also
+supports. With -yaml v3, it takes the *yaml.Node of the value instead and decodes it by
+Node.Decode. The errors of missing required fields and, with -strict, unknown keys then
+name the line of the value or key in the input, also in the Line field of the codecerr
+types. The module of the input package must require gopkg.in/yaml.v3.
+
+	func (x *T) UnmarshalYAML(value *yaml.Node) error
+
+Fields tagged yaml:",inline" are decoded by yaml.v3, which accepts inline pointers. In
+strict mode, the keys of inline structs belong to the type, and a type with an inline map
+accepts all keys. Because yaml.v3 passes the whole mapping to the UnmarshalYAML method of
+an inline struct, types generated with -yaml v3 can themselves be inlined in other types,
+though not in strict mode, which would reject the keys of the outer type.
+
+UnmarshalTOML has the signature of github.com/naoina/toml by default. With -toml burntsushi,
+it implements the Unmarshaler interface of github.com/BurntSushi/toml instead, which passes
+the decoded table. The table is encoded again and decoded into the intermediate type, and
+-strict rejects the first unknown key in sorted order. MarshalTOML implements the
+Marshaler interface and returns the intermediate value encoded as a TOML document.
+toml.Encoder writes this document as is, which is only valid at the top level, so
+fields holding a type with such a MarshalTOML method are decoded into a table before
+they are encoded. The module of the input package must require github.com/BurntSushi/toml.
+
+	func (x T) MarshalTOML() ([]byte, error)
+	func (x *T) UnmarshalTOML(input interface{}) error
+
+UnmarshalJSON decodes into an intermediate type whose fields are pointers, so that absent
+keys can be told apart from zero values. This allocates every present field of value type.
+With the -presence-flags flag, these fields are decoded as values and the present keys are
+found by decoding the input a second time into a struct of boolean flags, which doesn't
+allocate. The flag type is declared next to the methods. Fields of well-known types,
+amounts of money, dynamic and union fields and the other formats keep using pointers.
+
+The unmarshaling methods return the error of the first missing or invalid field. With the
+-all-errors flag, they decode all fields and return the errors of every field which
+failed, combined by errors.Join, so that all mistakes in a configuration file can be
+fixed at once. The fields which were decoded are assigned. UnmarshalJSON decodes each
+key of the input separately, in sorted order, so that it includes the
+*json.UnmarshalTypeError of every value of the wrong type and, with -strict, an error for
+every unknown key.
+
+If a type has the method
+
+	func (x *T) ValidateAfterDecode() error
+
+the unmarshaling methods call it after assigning the fields and return its error, so
+checks involving several fields run whenever the type is decoded. The -validate-method
+flag names a different method, which all generated types must have. Templates find the
+name of the method in .Validate.
+
+With the -typed-errors flag, missing required fields and, with -strict, unknown keys are
+reported by the error types of package github.com/fjl/gencodec/codecerr, which can be
+matched with errors.As. Both hold the name of the type and the key of the field. Their
+messages are the same as without the flag. The module of the input package must require
+github.com/fjl/gencodec.
+
+	var missing *codecerr.MissingFieldError
+	if errors.As(err, &missing) {
+		http.Error(w, "missing field "+missing.Field, http.StatusBadRequest)
+	}
+
+With the -keep-unknown flag, the JSON and YAML methods preserve keys which don't belong
+to a field. They are stored in a field of the type tagged gencodec:"unknown", which must
+have type map[string]json.RawMessage, and written back after the other keys, in sorted
+order, when marshaling. Keys of fields are skipped when writing them back.
+
+	type Config struct {
+		Name  string                     `json:"name"`
+		Extra map[string]json.RawMessage `gencodec:"unknown"`
+	}
+
+JSON keys are matched case-insensitively like encoding/json does. The flag can't be
+combined with -strict or -appender.
+
+With the -appender flag, the JSON encoding is written by a generated method
+
+	func (x T) MarshalJSONTo(buf []byte) ([]byte, error)
+
+which appends the object to buf without allocating the intermediate type or using
+reflection, and MarshalJSON calls it with a nil buffer. Strings, numbers, booleans, byte
+slices, pointers, slices and arrays of these, and types with MarshalJSONTo are encoded
+directly. Maps, interfaces, other structs and types implementing json.Marshaler or
+encoding.TextMarshaler are encoded by json.Marshal. The output is the same as that of
+encoding/json, including HTML escaping.
+
+The intermediate types declared in the generated methods are named like the original type.
+The name shows up in some errors of the encoding packages, for example in the field path of
+json.UnmarshalTypeError. The -intermediate-suffix flag appends a suffix to the names. It is
+either a plain suffix for all formats, or a list of format=suffix elements choosing the suffix
+of each format. A plain element in the list applies to the other formats.
+
+	gencodec -type T -intermediate-suffix Wire -out t_json.go
+	gencodec -type T -formats json,yaml -intermediate-suffix json=JSON,yaml=YAML -out t_codec.go
+
+Each format has its own intermediate types, which hold only the fields encoded by the
+format. A field tagged yaml:"-" is still encoded in JSON, and its required and default
+options apply only to the formats which include it.
+
+Unexported fields are skipped unless they have the gencodec:"export" option, or the
+-include-unexported flag is given. This is useful for internal persistence formats which
+store the state of a type. The fields of the intermediate type are exported, but the keys
+stay the same: an unexported field without a struct tag is encoded under its own name.
+Note that go vet reports json tags of unexported fields other than "-". Use the exported
+name when overriding the field type, for example Counter for counter. Unexported fields of
+embedded types of other packages can't be included.
+
+	type session struct {
+		ID      string `json:"id"`
+		counter int
+		created time.Time `yaml:"createdAt"`
+		cache   []byte    `json:"-" yaml:"-"`
+	}
 
 Other struct tags are carried over as is. The "json", "yaml", "toml" tags can be used to
 rename a field when marshaling.
@@ -25,6 +213,69 @@ Example:
 		Required string `gencodec:"required"`
 		Optional string
 		Renamed  string `json:"otherName"`
+		Port     int    `default:"8080"`
+	}
+
+Flattened Structs
+
+The gencodec:"flatten" option can be used on a field of struct type to marshal the fields
+of the nested struct at the parent level, like embedded structs in encoding/json or
+',squash' in mapstructure. On unmarshaling, the fields of the nested struct are assigned
+from the keys of the parent object. Struct tags of the nested fields, including
+gencodec:"required", apply as usual. Field overrides can refer to nested fields by name.
+
+	type foo struct {
+		ID   string
+		Meta meta `gencodec:"flatten"`
+	}
+
+	type meta struct {
+		Created int64 `json:"created" gencodec:"required"`
+	}
+
+The names of flattened fields must not clash with other fields of the type.
+
+The fields of embedded structs are promoted to the parent level like in encoding/json,
+unless the embedded field has a name in its json tag. If promoted fields have the same
+name or JSON key as another field, the least nested field is used. Among fields of the
+same depth, a field with a json tag name wins. Otherwise, all of them are left out.
+Embedded pointers to structs are not supported. They are ignored with a warning.
+
+The "prefix" struct tag of a flattened field is prepended to the keys of all nested fields
+in every generated format. Prefixed fields are named after their path in the intermediate
+type, i.e. Primary.Host becomes PrimaryHost. This name must be used when overriding them.
+
+	type config struct {
+		Primary db `gencodec:"flatten" prefix:"primary_"` // keys primary_host, primary_port
+		Replica db `gencodec:"flatten" prefix:"replica_"` // keys replica_host, replica_port
+	}
+
+	type db struct {
+		Host string `json:"host"`
+		Port int    `json:"port"`
+	}
+
+A field of struct type tagged yaml:",inline" is flattened in the YAML methods only, where
+the keys of the struct belong to the parent mapping like in the yaml package. The other
+formats encode the struct as a nested value. As with gencodec:"flatten", the required
+and default options of the nested fields apply, and their names must not clash with other
+fields. Inline maps and pointers are left to the yaml package.
+
+	type server struct {
+		Name string `json:"name" yaml:"name"`
+		Meta meta   `json:"meta" yaml:",inline"` // YAML keys name and owner
+	}
+
+Generic Types
+
+Methods can be generated for generic struct types. The generated methods have the type
+parameters of the type, and fields of type parameter type are handled like fields of any
+other type, including the required check. Field override types can't be generic. The
+codec object and GraphQL schemas are not available for generic types.
+
+	type Envelope[T any] struct {
+		Kind string `json:"kind"`
+		Data T      `json:"data" gencodec:"required"`
 	}
 
 Field Type Overrides
@@ -60,6 +311,824 @@ field is ignored.
 		Func string `json:"id"`    // adds the result of foo.Func() to the serialised object under the key id
 	}
 
+The override struct may be declared in another package, e.g. to keep marshaling helper
+types out of the API package. It is then given as pkg.T, where pkg is the import path of
+the package or the name of a package imported by the input package. The override type
+must be exported.
+
+	//go:generate gencodec -type foo -field-override example.com/app/internal/marshalutil.FooMarshaling -out foo_json.go
+
+Well-Known Types
+
+Fields of certain well-known types are marshaled using a built-in representation. No
+field override is required for them. Fields with an override use the override type instead.
+
+Pointers to the protobuf types from google.golang.org/protobuf/types/known are supported:
+timestamppb.Timestamp marshals as an RFC 3339 time string, durationpb.Duration as a duration
+string like "1m30s", and the wrappers in package wrapperspb marshal as the wrapped scalar value.
+
+The standard library types net.IP, netip.Addr, url.URL, *regexp.Regexp and mail.Address
+marshal as their string form. The generated unmarshaling methods parse the string and
+return an error naming the field if it is invalid. Nil values and the zero mail.Address
+are marshaled as null. Like their MarshalText methods, the zero net.IP and netip.Addr
+marshal as the empty string, which decodes to the zero value. With the gencodec:"omitzero"
+option, they marshal as null instead.
+
+UUID types from github.com/google/uuid, github.com/gofrs/uuid and github.com/satori/go.uuid
+marshal in canonical string form. When the gencodec:"omitzero" option is set on a UUID field,
+the nil UUID is treated as absent and marshals as null. Use it together with omitempty to
+leave out the key entirely.
+
+Decimal values of type github.com/shopspring/decimal.Decimal and *big.Rat marshal as
+decimal strings. The precision and scale options limit the accepted values like the SQL
+type NUMERIC(precision, scale): the value may have at most scale fractional digits and at
+most precision digits in total. Like in SQL, the scale defaults to zero, so a precision
+without a scale only accepts integers. Decoding fails if a value exceeds these limits, and
+so does marshaling, instead of rounding the value. *big.Rat fields with a scale marshal
+using exactly scale fractional digits, other *big.Rat fields marshal as a fraction like
+"1/3".
+
+	type payment struct {
+		Amount decimal.Decimal `gencodec:"required,precision=12,scale=2"`
+		Rate   *big.Rat        `gencodec:"scale=6"`
+	}
+
+The built-in representation can be disabled for a field using the gencodec:"nobuiltin" tag.
+The field is then marshaled by its own methods.
+
+	type foo struct {
+		Addr    netip.Addr
+		Pattern *regexp.Regexp `gencodec:"required"`
+		RawIP   net.IP         `gencodec:"nobuiltin"`
+	}
+
+Time Formats
+
+Fields of type time.Time or *time.Time marshal as RFC 3339 strings by default. The timeformat
+tag selects a different representation: "rfc3339" marshals in RFC 3339 format with fractional
+seconds, "unix", "unixmilli", "unixmicro" and "unixnano" marshal as an integer number of
+seconds, milliseconds, microseconds or nanoseconds since the Unix epoch, and any other value
+is a layout for time.Format and time.Parse.
+
+	type event struct {
+		Start   time.Time  `timeformat:"unix"`
+		Created *time.Time `timeformat:"unixmilli"`
+		Day     time.Time  `timeformat:"2006-01-02"`
+	}
+
+Decoding fails if a string doesn't match the layout. Times decoded from integers are in the
+local time zone, times decoded using a layout without a zone are in UTC.
+
+Amounts With Currency
+
+An amount field can be marshaled together with its currency using the currency=F option,
+where F is the name of a string field holding the currency code. The two fields are
+combined into a single value under the key of the amount field, for example "12.50 USD".
+The unmarshaling method splits the value and assigns both fields. With the currencyobject
+option, the value is an object with keys "amount" and "currency" instead.
+
+	type price struct {
+		Net      decimal.Decimal `gencodec:"required,currency=Currency"`
+		Gross    float64         `gencodec:"currency=Currency,currencyobject"`
+		Currency string
+	}
+
+The amount field must have a numeric or decimal type unless currencyobject is used.
+
+Dynamic Fields
+
+Fields of interface type with the dynamic option hold values whose types are not known
+when the code is generated, for example types provided by plugins. Their encoding is done
+by codecs registered at runtime. For type T, the generated function RegisterTCodec adds the
+codec of a type to the lookup table of T, under a name identifying the type in the encoding.
+Dynamic values are encoded as an object holding the name and the value created by the codec.
+
+	type Event struct {
+		Payload Payload `json:"payload" gencodec:"dynamic"`
+	}
+
+	// in the plugin:
+	func init() {
+		events.RegisterEventCodec("click", Click{}, marshalClick, unmarshalClick)
+	}
+
+The value {"type": "click", "value": ...} is decoded by unmarshalClick. Marshaling and
+unmarshaling fail for types and names without codec. Dynamic fields require the json format.
+
+Unions
+
+Fields of an interface type whose values have one of a known set of types can be declared
+as a union with the -union flag. The interface and its types are listed as Iface=T1,T2,
+several unions are separated by semicolons. A type is given as *T if its pointer implements
+the interface. Union values are encoded as objects, with the name of the type added under
+the "type" key. When unmarshaling, the key selects the type of the decoded value.
+
+	type Shape interface{ Area() float64 }
+
+	type Drawing struct {
+		Background Shape `json:"background"`
+	}
+
+	//go:generate gencodec -type Drawing -union Shape=Circle,*Polygon -out drawing_json.go
+
+A Circle value is encoded as {"type": "Circle", "radius": 1}. The types must be encoded as
+JSON objects which don't have a "type" key of their own. Union fields require the json format.
+
+Enums
+
+Named integer types given to -type are encoded by the names of their constants. gencodec
+generates MarshalText, UnmarshalText, MarshalJSON and UnmarshalJSON methods for them, which
+encode the name of the constant as a string and reject unknown names. YAML and TOML use the
+text methods. If several constants have the same value, the first one is encoded and all
+names are accepted when decoding. Values without a constant can't be encoded.
+
+	type Color int
+
+	const (
+		Red Color = iota + 1
+		Green
+	)
+
+	//go:generate gencodec -type Color,Shirt -out shirt_json.go
+
+Green is encoded as "Green". The JSON Schema of enum types lists the names of the constants.
+
+Codec Objects
+
+With the -codec flag, gencodec also generates a codec object for the type. For type T,
+the variable TCodec of type TCodecOptions marshals and unmarshals T as JSON using the
+generated methods. The options of the codec change the encoding at runtime. Since the
+codec is a plain value, copies of it can be configured differently for each call site.
+
+The NameMapping option translates the keys of the object, e.g. to serve clients which
+expect another naming convention from the same type. Unmarshal translates the keys of
+the input back using the known keys of the type.
+
+	codec := TCodec
+	codec.NameMapping = strings.ToUpper
+	output, err := codec.Marshal(&value)
+
+The Strict option makes Unmarshal reject unknown keys. The Redact option is a set of keys
+whose values are replaced by the string "[REDACTED]" when marshaling, e.g. for logging.
+The codec also declares the metrics hook TMetrics. If it is set, the generated UnmarshalJSON
+reports the input size, decoding time and error of every call to it, which can be used to
+record decoding statistics per type. Since the hook is called by UnmarshalJSON, values decoded
+by json.Unmarshal, by the codec or as part of another type are all reported, without changing
+the call sites.
+
+	TMetrics = decodeStats
+
+Helper Methods
+
+The -helpers flag selects additional methods to generate from the fields of the type.
+Multiple helpers are separated by commas. The following helpers are available:
+
+spanattrs generates the method SpanAttributes, which returns fields as OpenTelemetry
+attributes for annotating traces. Only fields with an "otel" struct tag are included. The
+tag is the attribute key, or empty to use the JSON key. Fields of string, boolean and
+numeric types and of slices thereof are supported, as well as types implementing
+fmt.Stringer. Nil pointers are left out.
+
+	type request struct {
+		UserID string `json:"user_id" otel:"user.id"`
+		Method string `otel:""` // key "Method"
+		Token  string
+	}
+
+labels generates the method MetricLabels, which returns fields with a "label" struct tag
+as a map of strings, e.g. for use as Prometheus labels. The tag is the label name, or
+empty to use the JSON key. Values are converted like the string forms of well-known
+types, numbers and booleans are formatted using package strconv and other types must
+implement fmt.Stringer. Labels of absent values are the empty string.
+
+flags generates the method RegisterFlags(fs *flag.FlagSet), which defines a flag for each
+field with a "flag" struct tag, so one struct holds both the configuration file and the
+command line. The tag is the flag name, and the "usage" tag its usage message. The flag
+starts with the default value of the field if it has one, or else its current value, so
+flags can override values decoded from a file before. Flags of types without a method of
+flag.FlagSet are parsed like in the prompt helper.
+
+	type Config struct {
+		Port int `json:"port" flag:"port" usage:"listening port" default:"8080"`
+	}
+
+table generates the methods TableHeader and TableRow, which return the fields with a
+"table" struct tag as cells separated by tabs, for use with package text/tabwriter. The
+tag is the column name, or empty to use the JSON key in upper case. Values are converted
+like in labels, and cells of absent values are empty. The function WriteTTable writes a
+slice of values as a table with aligned columns.
+
+	type Pod struct {
+		Name     string `json:"name" table:""`
+		Restarts uint32 `json:"restarts" table:""`
+		Node     string `json:"node" table:"NODE"`
+	}
+
+	WritePodTable(os.Stdout, pods)
+
+fingerprint generates the constant TSchemaFingerprint for type T, a hash of the names, JSON
+keys, types and requiredness of the marshaled fields. Both ends of a connection can
+compare their fingerprints to verify that they agree on the schema.
+
+fieldnames generates a constant for the JSON key of every marshaled field, named TFieldF
+for field F of type T. Query builders, validators and tests can use the constants instead
+of string literals, which may drift when a key is renamed.
+
+	const (
+		TxFieldGasLimit = "gasLimit"
+	)
+
+fieldinfo generates the function TFields, which describes the marshaled fields by their
+Go name, JSON key, Go type, requiredness and struct tag. Admin interfaces and export tools
+can use it to inspect the type without reflection. The element type TFieldInfo is an
+alias of an unnamed struct type, which is the same for all types.
+
+text generates MarshalText and UnmarshalText methods, which encode a single field of the
+type as text. Types with these methods can be used as map keys in JSON objects and as
+flag values. The field is the one with the gencodec:"value" option, or the only field of
+the type. It must be a string, number or boolean, or implement encoding.TextMarshaler and
+encoding.TextUnmarshaler.
+
+	type blockID struct {
+		Number uint64 `gencodec:"value"`
+		hash   []byte
+	}
+
+hal generates the method MarshalHAL, which encodes like MarshalJSON and adds a "_links"
+member for hypermedia APIs. The links are computed by the HALLinks method of the type,
+which must be written by hand. Its result can be of any type encodable as JSON.
+
+	func (o *Order) HALLinks() map[string]Link {
+		return map[string]Link{"self": {Href: "/orders/" + o.ID}}
+	}
+
+sse generates the method WriteSSE, which writes the JSON encoding of the value as a
+server-sent event. The event name is optional. Every line of the data gets its own "data:"
+field, and writers with a Flush method like http.ResponseWriter are flushed after each
+event. The helper requires the json format.
+
+ws generates EncodeWSMessage and DecodeWSMessage methods for WebSocket messages. A message
+is a JSON object holding the message kind, which is the type name, a sequence number and
+the JSON encoding of the value. The helper also generates the function DecodeWSMessage,
+which decodes messages of all types given to -type, so only one invocation of gencodec in a
+package should use it. The helper requires the json format.
+
+	{"kind":"Chat","seq":7,"data":{"user":"bob","text":"hi"}}
+
+kafka generates the type TKafkaEncoder for type T, which implements the Encoder interface
+of the sarama Kafka client, and the function DecodeTKafka. Messages are encoded using the
+first of the json, cbor and bson formats generated for the type. The Framed option selects
+the wire format of the Confluent schema registry, which prepends a zero byte and the
+schema ID to the message.
+
+	msg := &sarama.ProducerMessage{Topic: "events", Value: &EventKafkaEncoder{Value: ev}}
+
+gzip generates gzip-compressed variants of the methods of the json, cbor and bson formats,
+such as MarshalJSONGzip and UnmarshalJSONGzip. The unmarshaling methods stop decompressing
+when the input exceeds the variable TMaxDecompressedSize of type T, 64 MiB by default, and
+return an error instead of decoding it.
+
+seal generates methods for storing values in untrusted places, such as SealJSON(key) and
+OpenJSON(key, sealed) for the json format, and likewise for cbor and bson. SealJSON
+encrypts the JSON encoding with AES-GCM using a key of 16, 24 or 32 bytes. The result
+starts with a version byte followed by a random nonce, and the type name is authenticated
+along with the ciphertext. OpenJSON rejects values which were modified, sealed with
+another key or sealed as another type.
+
+values generates EncodeValues and DecodeValues, which convert the fields with a "url" or
+"form" struct tag to and from url.Values, for query strings and form bodies. The tag gives
+the key, and the url tag is used if a field has both. Slices have a value for each element,
+and other fields are encoded like the labels helper does and decoded from the first value.
+DecodeValues reports absent required fields and sets default values like the unmarshaling
+methods of the formats. Nil pointers are left out, as are zero values with omitempty.
+
+frame generates WriteFrame and ReadFrame methods for stream protocols. WriteFrame writes
+the encoding of the value preceded by its length as a uvarint, and ReadFrame reads one
+frame and decodes it without reading past its end. The frame32 helper generates the same
+methods with a big-endian uint32 length instead. Like the kafka helper, they use the first
+of the json, cbor and bson formats. ReadFrame rejects frames larger than the variable
+TMaxFrameSize of type T, which is 16 MiB by default.
+
+binary generates MarshalBinary and UnmarshalBinary, which encode the fields with a "wire"
+struct tag in a fixed layout, in the order of the struct. The tag gives the width and byte
+order of the field: u8 and i8, u16be, u16le, i16be, i16le and so on up to 64 bits, f32be,
+f32le, f64be and f64le for floats, bool for a single byte of 0 or 1, and bytes for byte
+arrays. Integer fields are checked against the range of their wire form when marshaling,
+and decoded values against the range of the field type. UnmarshalBinary requires input of
+exactly the encoded length.
+
+	type Header struct {
+		Magic  [2]byte `wire:"bytes"`
+		Length int     `wire:"u16be"`
+		Seq    uint32  `wire:"u32le"`
+	}
+
+Fields with a "bits" struct tag are packed into bytes shared with the adjacent bit fields.
+The tag gives the width in bits. Bit fields must be unsigned integers, or booleans of one
+bit, and each run of them must fill whole bytes, up to 64 bits. The first field takes the
+most significant bits, and the bytes of a run are in big-endian order. Values are checked
+against the width when marshaling, and against the field type when unmarshaling.
+
+	type Flags struct {
+		Version  uint8 `bits:"4"`
+		Priority uint8 `bits:"3"`
+		Urgent   bool  `bits:"1"`
+	}
+
+A "checksum" struct tag on the last field makes it the checksum of the bytes before it.
+MarshalBinary computes the checksum instead of encoding the field value, and
+UnmarshalBinary returns a *codecerr.ChecksumError when the input doesn't match it. The
+algorithms are crc32 (IEEE) and crc32c (Castagnoli) with a u32 wire form, and xxhash
+(github.com/cespare/xxhash/v2) with a u64 wire form.
+
+	type Frame struct {
+		Kind    uint8   `wire:"u8"`
+		Payload [4]byte `wire:"bytes"`
+		CRC     uint32  `wire:"u32be" checksum:"crc32"`
+	}
+
+nats generates methods for sending the type over NATS using github.com/nats-io/nats.go.
+NATSMsg creates a message holding the JSON encoding of the value, PublishTo publishes it to
+a JetStream subject and DecodeNATSMsg decodes a received message. Messages carry the schema
+fingerprint of the type (see the fingerprint helper) in the Schema-Fingerprint header, and
+DecodeNATSMsg rejects messages of a different schema. The helper requires the json format.
+
+lambda generates methods for AWS Lambda functions behind API Gateway, using the event types
+of github.com/aws/aws-lambda-go/events. DecodeAPIGatewayRequest decodes the value from the
+JSON body of a proxy request. It rejects unknown keys, reporting the first in sorted order,
+and returns a 400 response with the error message in a JSON object when decoding fails.
+APIGatewayResponse creates a response holding the JSON encoding of the value. The helper
+requires the json format.
+
+	func handler(req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		var in Input
+		if resp, err := in.DecodeAPIGatewayRequest(req); err != nil {
+			return resp, nil
+		}
+		return process(in).APIGatewayResponse(200)
+	}
+
+jsonstream generates a DecodeJSON method taking a *json.Decoder. It reads the object token
+by token and decodes each field directly into the receiver, which avoids holding large
+inputs in memory. Presence of required fields and fields with a default is tracked in a
+bitmask. Unlike UnmarshalJSON, keys are matched exactly. DecodeJSON can be called
+repeatedly to read a stream of objects.
+
+	d := json.NewDecoder(conn)
+	for {
+		var ev Event
+		if err := ev.DecodeJSON(d); err != nil {
+			return err
+		}
+		handle(ev)
+	}
+
+jsonfields generates a DecodeJSONFields method, which decodes only the fields with the
+given JSON keys. The values of other keys are skipped without decoding them, and reading
+stops as soon as all requested fields have been found. This is useful on hot paths which
+need a few fields of large documents. Requested fields which are missing get their default
+value, and required fields among them are checked as in UnmarshalJSON.
+
+	var tx Transaction
+	if err := tx.DecodeJSONFields(data, "gas", "to"); err != nil {
+		return err
+	}
+
+peek generates the function PeekTType for type T, which returns the value of the field
+with the gencodec:"discriminator" option from a JSON object without decoding the object.
+It skips other keys using a minimal scanner, so routers can choose the decoder of a message
+cheaply. The discriminator must be a string field.
+
+	type Envelope struct {
+		Kind string          `json:"kind" gencodec:"discriminator"`
+		Data json.RawMessage `json:"data"`
+	}
+
+	kind, err := PeekEnvelopeType(msg)
+
+pretty generates a PrettyString method, which renders the fields for debugging and command
+line output. Each field is written on its own line with its JSON key, and the values are
+aligned. Byte slices and arrays are shown in hex, and long strings and byte slices are
+truncated. Types with a String method are rendered by it.
+
+	Block
+	  number:    100
+	  hash:      0x01020000000000000000000000000000000000000000000000000000000000ff
+	  extraData: 0xabababababababababababababababababababababababababababababababab... (40 bytes)
+	  miner:     "alice"
+
+prompt generates a PromptFill method for bootstrap commands, which asks for the required
+fields that have their zero value. It writes the JSON key of each field as a prompt and
+reads the value from the next line of input, repeating the prompt if the line is empty or
+can't be parsed. Strings, numbers, booleans, types implementing encoding.TextUnmarshaler
+and pointers to them are supported.
+
+	var cfg Config
+	if err := cfg.PromptFill(os.Stdin, os.Stdout); err != nil {
+		return err
+	}
+
+deepcopy generates DeepCopyInto and DeepCopy methods in the style of the Kubernetes
+deepcopy-gen tool. Pointers, slices, maps and arrays are copied recursively, and fields of
+types with a DeepCopyInto method are copied by calling it. Interfaces, functions, channels
+and struct types of other packages which have unexported fields are copied by assignment.
+
+deepcopyobject generates the DeepCopyObject method of the runtime.Object interface of
+Kubernetes API types. It is used together with the deepcopy helper, and the type must have
+a GetObjectKind method, usually by embedding metav1.TypeMeta.
+
+	gencodec -type Widget,WidgetList -helpers deepcopy,deepcopyobject -out zz_generated.go
+
+jsonapi generates MarshalJSONAPI and UnmarshalJSONAPI methods, which encode the type as a
+JSON:API (https://jsonapi.org) resource document. The "jsonapi" struct tag assigns fields
+to parts of the resource: jsonapi:"primary,type" marks the ID field and sets the resource
+type, jsonapi:"attr,name" marks an attribute and jsonapi:"relation,name,type" a
+relationship to resources of the given type. Fields without the tag are left out. IDs must
+be strings or integers. Relationships are IDs, pointers to IDs for optional to-one
+relationships or slices of IDs for to-many relationships. Attributes are converted like
+fields of the JSON methods, so overrides and the gencodec:"required" option apply to them.
+Required relationships must be present in decoded documents.
+
+	type Article struct {
+		ID     uint64 `jsonapi:"primary,articles"`
+		Title  string `jsonapi:"attr,title" gencodec:"required"`
+		Author string `jsonapi:"relation,author,people"`
+	}
+
+GraphQL Schemas
+
+The -graphql flag writes a GraphQL object type for the type to the given file, for use
+with gqlgen. Field names are the JSON keys and field types follow the marshaling
+representation: types with a text encoding become String, and named struct types are
+referenced by name. Fields are non-null if they are required or always present in the
+JSON encoding. Keys which differ from the Go field name are bound using the @goField
+directive of gqlgen, which must be declared in the schema. Flattened fields and fields
+taken from functions are marked to get a resolver.
+
+	gencodec -type MyType -graphql mytype.graphql -out mytype_json.go
+
+Kubernetes CRD Schemas
+
+The -crd flag writes the OpenAPI v3 structural schema of each type to the given file, for
+use as the openAPIV3Schema of a CustomResourceDefinition version. Properties are the JSON
+keys and required lists follow the gencodec:"required" option. Types with a text encoding
+are strings, time.Time has the date-time format, and interfaces and json.RawMessage preserve
+unknown fields. Recursive types can't be expressed in structural schemas and are rejected.
+
+The crd struct tag sets the x-kubernetes-* extensions of a field. Its options are
+listType=atomic|set|map, listMapKey=key (repeated for multiple keys), mapType=atomic|granular,
+preserveUnknownFields, intOrString and embeddedResource.
+
+	type WidgetSpec struct {
+		Replicas int32             `json:"replicas" gencodec:"required"`
+		Labels   map[string]string `json:"labels,omitempty" crd:"mapType=granular"`
+		Ports    []Port            `json:"ports,omitempty" crd:"listType=map,listMapKey=name"`
+	}
+
+Operators usually pair the schema with the deepcopy helper:
+
+	gencodec -type WidgetSpec -helpers deepcopy -crd widget.yaml -out widget_json.go
+
+JSON Schemas
+
+The -jsonschema flag writes a JSON Schema (draft 2020-12) of the JSON encoding to the given
+file, so API consumers can validate payloads against the same definitions as the Go code.
+The types are defined in $defs, and the root schema refers to the type if there is only
+one. Like the other schemas, properties are the JSON keys and their types follow the
+marshaling representation, including field overrides. Required fields are listed in
+required, and optional fields may be null. Nested named struct types are defined in $defs
+as well and referenced using $ref, so recursive types are supported. With -strict, the
+schemas of the types don't allow additional properties.
+
+	gencodec -type Order,Item -jsonschema order.schema.json -out order_json.go
+
+OpenAPI Components
+
+The -openapi flag writes the components section of an OpenAPI 3.1 document as YAML, with
+the schemas of the types under components.schemas. They are the same as the definitions
+written by -jsonschema, with references pointing to #/components/schemas. Properties are
+named by the JSON keys, so renamed fields appear under their encoded name, and the required
+arrays list the required fields. The section can be merged into the API description by
+tools such as redocly join, or referenced from the paths of another document.
+
+	gencodec -type CreateOrderRequest,Order -openapi components.yaml -out api_json.go
+
+TypeScript Declarations
+
+The -ts flag writes TypeScript interfaces describing the JSON encoding of the types, for
+use by front-end code. Each type becomes an exported interface whose properties are the
+JSON keys. Fields which aren't required are optional properties, and their values may be
+null. Property types follow the intermediate type, so fields overridden by a type with a
+text encoding are strings and numbers are numbers. Nested struct types get interfaces of
+their own.
+
+	gencodec -type Order,Item -ts order.d.ts -out order_json.go
+
+Go Plugins
+
+The -plugin flag writes the main package of a Go plugin exposing the JSON codecs of the
+types to host applications which load data models at runtime. The file must be in a
+directory of its own. The plugin has the symbols Encoders and Decoders, maps from the type
+name to functions encoding a pointer to the type and decoding a new value of the type.
+
+	gencodec -type Order,Item -plugin plugin/main.go -out order_json.go
+	go build -buildmode=plugin -o order.so ./plugin
+
+The host looks up the symbol tables by their unnamed types:
+
+	p, err := plugin.Open("order.so")
+	sym, err := p.Lookup("Decoders")
+	decoders := *sym.(*map[string]func([]byte) (any, error))
+	order, err := decoders["Order"](input)
+
+C Exports
+
+The -cgo flag writes the main package of a C library exporting the JSON decoders of the
+types, so that components written in other languages can validate data with the same code.
+Like the plugin package, the file must be in a directory of its own. For type T, the library
+has the function
+
+	char* DecodeT(void* input, size_t size, void** output, size_t* output_size);
+
+which decodes the JSON input and stores the canonical encoding in a new buffer. On failure
+it returns the error message instead. The buffer and the message must be released with free.
+
+	gencodec -type Order,Item -cgo cexport/main.go -out order_json.go
+	go build -buildmode=c-shared -o liborder.so ./cexport
+
+WebAssembly Bindings
+
+The -wasm flag writes a file of the package which is only built for GOOS=js GOARCH=wasm.
+It has the function JSCodecs, returning a JavaScript object whose functions decode and
+encode the types using the generated JSON methods, so that browser code validates data the
+same way as the server. For type T, unmarshalT takes a JSON string and returns the decoded
+object. marshalT takes an object and returns its JSON encoding. Both return {value: ...}
+on success and {error: message} when the input is invalid, for example when a required
+field is missing.
+
+	gencodec -type Order,Item -wasm order_wasm.go -out order_json.go
+
+The WebAssembly program makes the functions available to JavaScript:
+
+	js.Global().Set("orders", orders.JSCodecs())
+
+Generated Tests
+
+The -gen-tests flag writes a test file for the JSON methods of the types. For each type, the
+test encodes a sample value and checks that decoding yields the same value. It then removes
+each required field from the encoding and checks that decoding fails with the missing field
+error, and removes each optional field and checks that it is left at its zero or default
+value. Fields of basic types get non-zero sample values, and pointers, slices and maps of
+required fields are allocated. Other fields keep their zero value. The file name must end
+in _test.go.
+
+	gencodec -type Order -gen-tests gen_order_json_test.go -out gen_order_json.go
+
+The -test-fixtures flag names a directory of JSON fixtures, with a subdirectory for each type.
+For type T, the test TestTFixtures decodes each file matching T/*.json in the directory, then
+removes each required key in turn and checks for the missing field error of the key. The
+fixtures are read when the tests run, so the directory is relative to the package directory.
+
+	gencodec -type Order -gen-tests gen_order_json_test.go -test-fixtures testdata/fixtures -out gen_order_json.go
+
+The -gen-fuzz flag writes fuzz targets for the unmarshal methods, one for each type and
+format. FuzzTUnmarshalJSON and FuzzTUnmarshalYAML decode arbitrary input as type T and
+check that decoding doesn't panic and that decoded values can be encoded and decoded
+again. The seed corpus holds the encoding of a sample value, built like in the round-trip
+tests, and an empty object. Run them with go test -fuzz.
+
+	gencodec -type Order -formats json,yaml -gen-fuzz gen_order_fuzz_test.go -out gen_order_json.go
+	go test -fuzz FuzzOrderUnmarshalJSON
+
+The -gen-fuzz-corpus flag writes more seed inputs of the fuzz targets to the given directory,
+which is usually testdata/fuzz. Each target gets an object with sample values of the required
+fields, and objects setting one field to a boundary value: the limits of integer and float
+types, empty strings, slices and maps, and null for optional fields. The files matching the
+glob pattern of -fuzz-fixtures are added as well, those ending in .json to the JSON targets
+and those ending in .yaml or .yml to the YAML targets. Plain go test runs the seed inputs.
+
+	gencodec -type Order -formats json,yaml -gen-fuzz-corpus testdata/fuzz -fuzz-fixtures 'testdata/*.json' -out gen_order_json.go
+
+Project Files
+
+A project file describes all generation targets of a module, so they can be regenerated in
+one run with the -config flag. The flag takes the file, or a directory in which
+gencodec.yaml, gencodec.yml or gencodec.toml is searched. If the directory has none, its
+parent directories are searched up to the module root. Each target has the keys of the
+command line flags. The package directory is relative to the project file, and other
+paths, such as the output file, are relative to the package directory. The output file is
+required.
+
+	targets:
+	  - dir: ./types
+	    type: Header,Body
+	    field-override: headerMarshaling,
+	    formats: [json, yaml]
+	    out: types_json.go
+	  - dir: ./api
+	    type: Request
+	    helpers: [sse]
+	    out: request_json.go
+
+In TOML files, the targets are written as an array of tables.
+
+	[[targets]]
+	dir = "./types"
+	type = "Header,Body"
+	out = "types_json.go"
+
+	gencodec -config .
+
+Generating All Packages
+
+The -all flag regenerates every type with a gencodec directive in the packages matched by
+-dir, which may end in /... to include all packages below the directory. A
+//gencodec:generate comment in the doc comment of a type declaration generates the type.
+The rest of the comment can hold additional flags. Unless it has the -out flag, the code is
+written to gen_<type>_json.go, with the type name in lower case.
+
+	//gencodec:generate -formats json,yaml -field-override headerMarshaling
+	type Header struct {
+		...
+	}
+
+Existing go:generate comments which run gencodec are executed as well, so packages don't
+need to be converted. The arguments of all directives are relative to their package
+directory.
+
+	gencodec -all -dir ./...
+
+With -config and -all, the targets are generated in the order of their dependencies: a
+target comes after the targets of the packages imported by its package and of the packages
+holding its field override types, so that their generated methods are visible. Field
+override types can create a dependency cycle between targets, which is reported as an
+error.
+
+The -state flag makes regeneration incremental. The state file records a hash of the
+inputs of each target: its configuration, the Go files of its package and the packages it
+depends on, its templates and schema, its output files and the gencodec executable. Targets
+whose hash is unchanged are skipped, so regenerating a whole repository only generates the
+targets affected by a change.
+
+	gencodec -all -dir ./... -state .gencodec-state.json
+
+The -stdout-archive flag writes the output files to stdout instead of creating them, so
+that build systems can capture all outputs of a target without a temporary directory. The
+files are written in txtar format, each starting with a line holding its name, like the
+name given to the flag, between "-- " and " --". A newline is added to files which don't
+end in one. The -out flag must name the file of the code. With -config and -all, the files
+of all targets are written to the same archive. The flag can't be combined with -state.
+
+	gencodec -type Config -jsonschema config.schema.json -out gen_config.go -stdout-archive
+
+Output files are replaced atomically: each file is written to a temporary file in its
+directory, which is then renamed, so that builds running at the same time never read a
+partial file. The permissions of existing files are kept. With the -protect flag, gencodec
+refuses to overwrite a file which doesn't start with the "Code generated by
+github.com/fjl/gencodec" header, which prevents clobbering a hand-written file with the
+same name. Go files whose header is a comment marking them as generated are recognized as
+well. The outputs which can't start with a comment have the marker elsewhere: JSON files,
+like the -dump-ir model, in a "$comment" key and the files of the fuzz seed corpus in a
+comment before the value.
+
+Compatibility Checks
+
+The compat subcommand compares the encoding of a type between two git revisions and reports
+changes which break decoding on either side: removed or added required fields, fields
+which became required, type changes and renamed keys. It exits with status 1 if there are
+breaking changes, which can be used to gate releases.
+
+	gencodec compat -dir ./types -old v1.2.0 -new HEAD -type MyType
+
+Without -new, the type in the working tree is used. Instead of git revisions, -old and -new
+can also be package directories.
+
+Checking Struct Tags
+
+The vet subcommand checks the struct tags of all struct types in a package. It reports
+invalid tag syntax, unknown gencodec options, fields with the same key, fields whose json
+and yaml keys differ and optional number or boolean fields with omitempty, where an absent
+field can't be distinguished from the zero value.
+
+	gencodec vet -dir ./types
+
+Migration Coverage
+
+The coverage subcommand helps moving a code base to generated methods step by step. It
+finds the calls of json.Marshal, json.Unmarshal and the json.Encoder and json.Decoder
+methods in the packages matched by -dir, and lists the struct types of the module encoded
+by them which have no MarshalJSON or UnmarshalJSON method.
+
+	gencodec coverage -dir ./...
+
+The check is also available as an analyzer for go/analysis drivers, gencodec.CoverageAnalyzer.
+
+Custom Templates
+
+The -template-dir flag names a directory of text/template files which replace built-in
+code. A file named after a format, like json.tmpl, replaces the methods of the format. Its
+input holds the pieces of the built-in methods: .Marshal.Decl and .Unmarshal.Decl declare
+the intermediate type and a variable of it named .Marshal.Var or .Unmarshal.Var, and
+.Marshal.Conversions and .Unmarshal.Conversions convert between the receiver .Recv and the
+variable, including the checks of required fields. For templates which handle fields one
+by one, .Fields lists the fields with their .Name in the intermediate type, .Key, .Type
+and .Required, as well as .Encode and .Decode, which convert a single field. .Decode
+expects the decoded field to be non-nil. The function pkg returns the name of an imported
+package, which is added to the imports of the generated file.
+
+	func ({{.Recv}} *{{.RecvType}}) UnmarshalJSON(input []byte) error {
+		{{.Unmarshal.Decl}}
+		if err := {{pkg "encoding/json"}}.Unmarshal(input, &{{.Unmarshal.Var}}); err != nil {
+			return err
+		}
+		{{- range .Fields}}
+		if {{$.Unmarshal.Var}}.{{.Name}} == nil {
+			{{- if .Required}}
+			return &apierr.MissingField{Key: {{printf "%q" .Key}}}
+			{{- end}}
+		} else {
+			{{.Decode}}
+		}
+		{{- end}}
+		return nil
+	}
+
+Other files replace the template of a helper or the codec object with the same name, such
+as sse.tmpl or codec.tmpl. They get the same input as the built-in template, which can be
+found in the source of package github.com/fjl/gencodec/gencodec.
+
+Package github.com/fjl/gencodec/gencodec/gencodectest helps to test templates. Its Golden
+function generates the targets of the project file in an example package and compares the
+results with the files checked in next to it. The files are updated by running the test
+with -gencodectest.update.
+
+Generated Go files start with the line
+
+	// Code generated by github.com/fjl/gencodec. DO NOT EDIT.
+
+The -header flag replaces it by a text/template, whose input has the fields .Version, the
+version of gencodec, .Args, the command line arguments, .Types, the generated types, and
+.FormatVersion, the version of the generated code. The result must match the regular
+expression ^// Code generated .* DO NOT EDIT\.$ which marks generated files for Go tools.
+
+	gencodec -type Config -header '// Code generated by gencodec {{.Version}} {{.Args}}. DO NOT EDIT.' -out gen_config.go
+
+The version of the generated code is increased when files generated by an older version
+of gencodec can't be used with the current one. With the -version-const flag, it is
+declared as a constant named gencodecFormat followed by the first type name, so tools can
+find files which need to be generated again.
+
+The -dump-ir flag writes the model of the types computed by gencodec to a JSON file before
+the code is rendered. It lists the imports of the generated file, and for each type its
+fields with their marshaling type, original type, tag and kind of conversion. The formats
+member holds the input of the format templates, so template authors can see the values of
+.Marshal, .Unmarshal and .Fields. The dump is also useful when reporting bugs.
+
+	gencodec -type Config -formats json,yaml -dump-ir config-ir.json -out gen_config.go
+
+Schema Validation
+
+The -schema flag names a JSON Schema or CUE file with constraints of the generated types.
+The constraints are compiled into the unmarshaling methods, which return an error when a
+decoded field violates them. JSON Schema files hold a definition for each type in $defs or
+definitions, or the schema of a single type at the root. Properties are matched to fields
+by their JSON key. The keywords type, minimum, maximum, exclusiveMinimum,
+exclusiveMaximum, multipleOf, minLength, maxLength, minItems, maxItems, pattern, enum,
+const and required are supported. Properties listed in required are treated like fields
+with the gencodec:"required" tag.
+
+	{"$defs": {"Server": {
+		"required": ["host"],
+		"properties": {
+			"host": {"type": "string", "pattern": "^[a-z0-9.-]+$"},
+			"port": {"type": "integer", "minimum": 1, "maximum": 65535}
+		}
+	}}}
+
+CUE files are read if the file name ends in .cue. Only definitions of structs are
+supported, whose fields are constrained by types, bounds, regular expressions, disjunctions
+of literals and the functions strings.MinRunes, strings.MaxRunes, list.MinItems and
+list.MaxItems. Fields without ? are required.
+
+	#Server: {
+		host:  string & =~"^[a-z0-9.-]+$"
+		port?: int & >=1 & <=65535
+	}
+
+Constraints are checked against the field types when the code is generated. A schema
+which no value of the field type can satisfy, such as a string pattern of an int field or
+a minimum above the range of a uint8, is an error, as is a default value violating the
+constraints. Bounds which always hold for the field type are left out of the generated
+code.
+
+Using gencodec as a Library
+
+The generator is available as package github.com/fjl/gencodec/gencodec, for use in build
+tools and tests. Its Config type has the same options as the command line flags, and
+Generate returns the generated code or an error.
+
+	code, err := gencodec.Generate(&gencodec.Config{Dir: "./types", Type: "MyType"})
+
 Relaxed Field Conversions
 
 Field types in the override struct must be trivially convertible to the original field
@@ -107,305 +1176,474 @@ The generated code is similar to this snippet:
 		...
 	}
 
+If the element types aren't convertible themselves, they are converted the same way, element
+by element. This works for nested slices and maps and for arrays. Arrays can be replaced by
+slices of the same element type: the unmarshaling method checks that the decoded slice has
+the length of the array and returns an error otherwise.
+
+	type Hash [32]byte
+
+	type Foo3 struct{ Hashes []Hash }
+
+	type foo3Marshaling struct{ Hashes []hexutil.Bytes }
+
+Conversion Functions
+
+When no conversion is possible, the gencodec:"conv=to,from" option names the functions
+converting the field to its marshaling type and back. The functions are declared in the
+package of the type, or qualified by package name like strconv.Itoa. The function
+converting back may return an error as the second result, which is returned by the
+unmarshaling method. The option is set on the field of the override struct, or on the
+original field. In the latter case, the result type of the first function becomes the
+marshaling type.
+
+	type Level int
+
+	func levelName(l Level) string { ... }
+
+	func parseLevel(s string) (Level, error) { ... }
+
+	type Foo4 struct {
+		Level Level `gencodec:"conv=levelName,parseLevel"`
+		Port  int   `gencodec:"conv=strconv.Itoa,strconv.Atoi"`
+	}
+
+JSON Hooks
+
+The gencodec:"hook=append,parse" option replaces the JSON encoding of a field with
+hand-written functions, like SIMD hex encoders or custom float formatting. The append
+function has signature func(dst []byte, v T) []byte and appends the JSON value of v to
+dst. The parse function has signature func(src []byte) (T, int, error) and decodes the
+JSON value in src, returning the number of bytes used, which must be all of them. It
+also receives null values.
+
+MarshalJSONTo calls the append function on its buffer directly. MarshalJSON and
+UnmarshalJSON hold the value as json.RawMessage in the intermediate type, as do
+DecodeJSON and DecodeJSONFields. Other formats encode the field as usual. The option
+can't be combined with other conversions or with the omitempty, omitzero and string
+options of the json tag.
+
+	func appendHash(dst []byte, h Hash) []byte { ... }
+
+	func parseHash(src []byte) (Hash, int, error) { ... }
+
+	type Foo5 struct {
+		Hash Hash `json:"hash" gencodec:"hook=appendHash,parseHash"`
+	}
+
 */
 package main
 
 import (
-	"bytes"
+	"errors"
 	"flag"
 	"fmt"
-	"go/importer"
-	"go/token"
-	"go/types"
 	"io"
-	"io/ioutil"
 	"os"
-	"reflect"
+	"path/filepath"
+	"sort"
 	"strings"
 
-	"github.com/garslo/gogen"
-	"golang.org/x/tools/go/packages"
-	"golang.org/x/tools/imports"
+	"github.com/fjl/gencodec/gencodec"
+	"golang.org/x/tools/txtar"
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "compat":
+			os.Exit(runCompat(os.Args[2:]))
+		case "coverage":
+			os.Exit(runCoverage(os.Args[2:]))
+		case "vet":
+			os.Exit(runVet(os.Args[2:]))
+		}
+	}
+
 	var (
-		pkgdir    = flag.String("dir", ".", "input package")
-		output    = flag.String("out", "-", "output file (default is stdout)")
-		typename  = flag.String("type", "", "type to generate methods for")
-		overrides = flag.String("field-override", "", "type to take field type replacements from")
-		formats   = flag.String("formats", "json", `marshaling formats (e.g. "json,yaml")`)
+		target  = targetFlags(flag.CommandLine)
+		project = flag.String("config", "", "project file listing all targets, or a directory to search for one")
+		all     = flag.Bool("all", false, "generate all types with gencodec directives in the packages matched by -dir")
+		state   = flag.String("state", "", "file recording the inputs of targets, unchanged targets are skipped (with -config or -all)")
+		archive = flag.Bool("stdout-archive", false, "write all output files to stdout in txtar format instead of creating them")
+		protect = flag.Bool("protect", false, "refuse to overwrite files which were not generated by gencodec")
 	)
 	flag.Parse()
 
-	formatList := strings.Split(*formats, ",")
-	for i := range formatList {
-		formatList[i] = strings.TrimSpace(formatList[i])
+	var out outputWriter = fileWriter{protect: *protect}
+	if *archive {
+		if *state != "" {
+			fatal("-stdout-archive can't be combined with -state")
+		}
+		out = archiveWriter{os.Stdout}
 	}
-	cfg := Config{Dir: *pkgdir, Type: *typename, FieldOverride: *overrides, Formats: formatList}
-	code, err := cfg.process()
-	if err != nil {
-		fatal(err)
+	var err error
+	switch t := target(); {
+	case *project != "":
+		err = generateProject(*project, *state, out)
+	case *all:
+		err = generateAll(t.Dir, *state, out)
+	default:
+		t.Args = os.Args[1:]
+		err = generate(&t, out)
 	}
-	if *output == "-" {
-		os.Stdout.Write(code)
-	} else if err := ioutil.WriteFile(*output, code, 0644); err != nil {
+	if err != nil {
 		fatal(err)
 	}
 }
 
-func fatal(args ...interface{}) {
-	fmt.Fprintln(os.Stderr, args...)
-	os.Exit(1)
+// targetFlags defines the flags of a generation target in fs. The returned
+// function creates the target from the parsed flags.
+func targetFlags(fs *flag.FlagSet) func() gencodec.Target {
+	var (
+		pkgdir    = fs.String("dir", ".", "input package")
+		output    = fs.String("out", "-", "output file (default is stdout)")
+		typename  = fs.String("type", "", `types to generate methods for (e.g. "Header,Body")`)
+		overrides = fs.String("field-override", "", "types to take field type replacements from, one for each type")
+		formats   = fs.String("formats", "json", `marshaling formats (e.g. "json,yaml"), env and hcl only decode`)
+		codec     = fs.Bool("codec", false, "generate codec object with runtime options and decode metrics hook")
+		helperSet = fs.String("helpers", "", `additional methods to generate (e.g. "spanattrs")`)
+		graphql   = fs.String("graphql", "", "output file of GraphQL schema")
+		crd       = fs.String("crd", "", "output file of Kubernetes CRD structural schema")
+		jschema   = fs.String("jsonschema", "", "output file of JSON Schema (draft 2020-12)")
+		openAPI   = fs.String("openapi", "", "output file of OpenAPI 3.1 component schemas")
+		ts        = fs.String("ts", "", "output file of TypeScript declarations")
+		plugin    = fs.String("plugin", "", "output file of Go plugin main package")
+		wasm      = fs.String("wasm", "", "output file of JavaScript bindings for js/wasm")
+		cgo       = fs.String("cgo", "", "output file of main package exporting the decoders to C")
+		tests     = fs.String("gen-tests", "", "output file of round-trip tests of the JSON methods")
+		testFix   = fs.String("test-fixtures", "", "directory of JSON fixtures used by the generated tests, relative to the package")
+		fuzz      = fs.String("gen-fuzz", "", "output file of fuzz targets of the JSON and YAML decoders")
+		corpus    = fs.String("gen-fuzz-corpus", "", "output directory of the seed corpus of the fuzz targets")
+		fixtures  = fs.String("fuzz-fixtures", "", "glob pattern of JSON and YAML files added to the seed corpus")
+		dumpIR    = fs.String("dump-ir", "", "output file of the model of the types as JSON, for debugging templates")
+		omitEmpty = fs.Bool("omitempty", false, "add omitempty to the struct tags of optional fields")
+		reqAll    = fs.Bool("required-by-default", false, `make fields required unless tagged gencodec:"optional"`)
+		buildTags = fs.String("tags", "", "build tags used when loading the package")
+		strict    = fs.Bool("strict", false, "reject unknown keys when unmarshaling")
+		presence  = fs.Bool("presence-flags", false, "track present JSON keys with flags instead of pointer fields")
+		keepUnk   = fs.Bool("keep-unknown", false, `keep unknown JSON and YAML keys in the field tagged gencodec:"unknown"`)
+		allErrs   = fs.Bool("all-errors", false, "report all missing and invalid fields when unmarshaling")
+		typedErrs = fs.Bool("typed-errors", false, "return the error types of package github.com/fjl/gencodec/codecerr")
+		validate  = fs.String("validate-method", "", "method called after unmarshaling (default ValidateAfterDecode, if the type has it)")
+		yamlAPI   = fs.String("yaml", "v2", "API of UnmarshalYAML: v2 or v3, which decodes a *yaml.Node")
+		tomlAPI   = fs.String("toml", "naoina", "API of UnmarshalTOML: naoina or burntsushi, which takes the decoded value")
+		appender  = fs.Bool("appender", false, "generate MarshalJSONTo, which appends JSON to a buffer")
+		suffix    = fs.String("intermediate-suffix", "", "suffix of intermediate type names, or format=suffix list")
+		unexp     = fs.Bool("include-unexported", false, "marshal unexported fields")
+		unions    = fs.String("union", "", "union interfaces as Iface=T1,T2, separated by semicolons")
+		tmplDir   = fs.String("template-dir", "", "directory of templates replacing built-in methods")
+		schema    = fs.String("schema", "", "JSON Schema or CUE file with constraints checked when unmarshaling")
+		header    = fs.String("header", "", "template of the first line of the generated Go file")
+		verConst  = fs.Bool("version-const", false, "declare a constant holding the version of the generated code")
+	)
+	return func() gencodec.Target {
+		return gencodec.Target{
+			Config: gencodec.Config{
+				Dir:           *pkgdir,
+				Type:          *typename,
+				FieldOverride: *overrides,
+				Formats:       splitList(*formats),
+				Codec:         *codec,
+				Helpers:       splitList(*helperSet),
+				OmitEmpty:     *omitEmpty,
+				RequireAll:    *reqAll,
+				BuildTags:     splitList(*buildTags),
+				Strict:        *strict,
+				Presence:      *presence,
+				KeepUnknown:   *keepUnk,
+				AllErrors:     *allErrs,
+				TypedErrors:   *typedErrs,
+				Validate:      *validate,
+				YAML:          *yamlAPI,
+				TOML:          *tomlAPI,
+				Appender:      *appender,
+				InterSuffix:   *suffix,
+				Unexported:    *unexp,
+				Unions:        *unions,
+				TemplateDir:   *tmplDir,
+				Schema:        *schema,
+				Header:        *header,
+				VersionConst:  *verConst,
+			},
+			Out:        *output,
+			GraphQL:    *graphql,
+			CRD:        *crd,
+			JSONSchema: *jschema,
+			OpenAPI:    *openAPI,
+			TypeScript: *ts,
+			Plugin:     *plugin,
+			WASM:       *wasm,
+			Cgo:        *cgo,
+			Tests:      *tests,
+			TestDir:    *testFix,
+			Fuzz:       *fuzz,
+			FuzzCorpus: *corpus,
+			Fixtures:   *fixtures,
+			DumpIR:     *dumpIR,
+		}
+	}
 }
 
-var AllFormats = []string{"json", "yaml", "toml"}
+// generateAll runs the gencodec directives of the packages matched by pattern.
+// The arguments of a directive are relative to its package directory, like
+// the arguments of go:generate commands.
+func generateAll(pattern, stateFile string, out outputWriter) error {
+	ds, err := gencodec.ScanDirectives(pattern)
+	if err != nil {
+		return err
+	}
+	if len(ds) == 0 {
+		return fmt.Errorf("no gencodec directives in %s", pattern)
+	}
+	targets := make([]gencodec.Target, len(ds))
+	for i, d := range ds {
+		fs := flag.NewFlagSet("gencodec", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		target := targetFlags(fs)
+		if err := fs.Parse(d.Args); err != nil {
+			return fmt.Errorf("%v: %v", d.Pos, err)
+		}
+		if fs.NArg() > 0 {
+			return fmt.Errorf("%v: unexpected argument %q", d.Pos, fs.Arg(0))
+		}
+		t := target()
+		t.Args = d.Args
+		if t.Out == "-" {
+			return fmt.Errorf("%v: directive has no output file", d.Pos)
+		}
+		for _, p := range []*string{&t.Dir, &t.Out, &t.GraphQL, &t.CRD, &t.JSONSchema, &t.OpenAPI, &t.TypeScript, &t.Plugin, &t.WASM, &t.Cgo, &t.Tests, &t.Fuzz, &t.FuzzCorpus, &t.Fixtures, &t.TemplateDir, &t.Schema, &t.DumpIR} {
+			if *p != "" && !filepath.IsAbs(*p) {
+				*p = filepath.Join(d.Dir, *p)
+			}
+		}
+		targets[i] = t
+	}
+	return generateTargets(targets, stateFile, out, func(i int) string { return ds[i].Pos.String() })
+}
 
-type Config struct {
-	Dir           string   // input package directory
-	Type          string   // type to generate methods for
-	FieldOverride string   // name of struct type for field overrides
-	Formats       []string // defaults to just "json", supported: "json", "yaml"
-	Importer      types.Importer
-	FileSet       *token.FileSet
+// generateProject runs all targets of a project file. If file is a
+// directory, the project file is searched in it and its parents.
+func generateProject(file, stateFile string, out outputWriter) error {
+	if info, err := os.Stat(file); err == nil && info.IsDir() {
+		if file, err = gencodec.FindProject(file); err != nil {
+			return err
+		}
+	}
+	targets, err := gencodec.LoadProject(file)
+	if err != nil {
+		return err
+	}
+	return generateTargets(targets, stateFile, out, func(i int) string { return targets[i].Out })
 }
 
-func (cfg *Config) process() (code []byte, err error) {
-	if cfg.FileSet == nil {
-		cfg.FileSet = token.NewFileSet()
+// generateTargets generates targets in dependency order. Errors are prefixed by
+// the location of the target. If stateFile is set, targets whose inputs haven't
+// changed since the state was recorded are skipped.
+func generateTargets(targets []gencodec.Target, stateFile string, out outputWriter, where func(i int) string) error {
+	order, err := gencodec.TargetOrder(targets)
+	if err != nil {
+		return err
 	}
-	if cfg.Importer == nil {
-		cfg.Importer = importer.Default()
+	var state *gencodec.State
+	if stateFile != "" {
+		if state, err = gencodec.LoadState(stateFile); err != nil {
+			return err
+		}
 	}
-	if cfg.Formats == nil {
-		cfg.Formats = []string{"json"}
+	generated := false
+	for _, i := range order {
+		if state != nil {
+			changed, err := state.Changed(&targets[i])
+			if err != nil {
+				return fmt.Errorf("%s: %v", where(i), err)
+			}
+			if !changed {
+				continue
+			}
+		}
+		if err := generate(&targets[i], out); err != nil {
+			return fmt.Errorf("%s: %v", where(i), err)
+		}
+		generated = true
+	}
+	if state == nil || !generated {
+		return nil
 	}
-	pkg, err := loadPackage(cfg)
+	// Generated files are inputs of other targets, so all hashes are
+	// recorded after generating.
+	for i := range targets {
+		if err := state.Record(&targets[i]); err != nil {
+			return fmt.Errorf("%s: %v", where(i), err)
+		}
+	}
+	return state.Save()
+}
+
+// generate writes the code of a target to its output file, and the schemas
+// to their files if they are given.
+func generate(t *gencodec.Target, out outputWriter) error {
+	t.Config.Warnf = warn
+	types, err := gencodec.Load(&t.Config)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	// The model is written first because it helps to debug errors of the code generation.
+	if t.DumpIR != "" {
+		ir, err := types.IR()
+		if err != nil {
+			return err
+		}
+		if err := out.WriteFile(t.DumpIR, ir); err != nil {
+			return err
+		}
 	}
-	typ, err := lookupStructType(pkg.Scope(), cfg.Type)
+	code, err := types.Code()
 	if err != nil {
-		return nil, fmt.Errorf("can't find %s in %q: %v", cfg.Type, pkg.Path(), err)
+		return err
 	}
-
-	// Construct the marshaling type.
-	mtyp := newMarshalerType(cfg.FileSet, cfg.Importer, typ)
-	if cfg.FieldOverride != "" {
-		otyp, err := lookupStructType(pkg.Scope(), cfg.FieldOverride)
+	schemas := []struct {
+		file string
+		gen  func() ([]byte, error)
+	}{
+		{t.GraphQL, types.GraphQLSchema},
+		{t.CRD, types.CRDSchema},
+		{t.JSONSchema, types.JSONSchema},
+		{t.OpenAPI, types.OpenAPISchemas},
+		{t.TypeScript, types.TypeScript},
+		{t.WASM, types.WASM},
+	}
+	for _, s := range schemas {
+		if s.file == "" {
+			continue
+		}
+		content, err := s.gen()
 		if err != nil {
-			return nil, fmt.Errorf("can't find field replacement type %s: %v", cfg.FieldOverride, err)
+			return err
 		}
-
-		err = mtyp.loadOverrides(otyp)
+		if err := out.WriteFile(s.file, content); err != nil {
+			return err
+		}
+	}
+	if t.Plugin != "" {
+		if err := writeMainPackage(t, out, t.Plugin, types.Plugin); err != nil {
+			return err
+		}
+	}
+	if t.Cgo != "" {
+		if err := writeMainPackage(t, out, t.Cgo, types.Cgo); err != nil {
+			return err
+		}
+	}
+	if t.Tests != "" {
+		if !strings.HasSuffix(t.Tests, "_test.go") {
+			return fmt.Errorf("test file name %s doesn't end in _test.go", t.Tests)
+		}
+		tests, err := types.Tests(t.TestDir)
 		if err != nil {
-			return nil, err
+			return err
+		}
+		if err := out.WriteFile(t.Tests, tests); err != nil {
+			return err
 		}
 	}
-
-	// Generate and format the output. Formatting uses goimports because it
-	// removes unused imports.
-	code, err = generate(mtyp, cfg)
-	if err != nil {
-		return nil, err
+	if t.Fuzz != "" {
+		if !strings.HasSuffix(t.Fuzz, "_test.go") {
+			return fmt.Errorf("test file name %s doesn't end in _test.go", t.Fuzz)
+		}
+		fuzz, err := types.FuzzTests()
+		if err != nil {
+			return err
+		}
+		if err := out.WriteFile(t.Fuzz, fuzz); err != nil {
+			return err
+		}
 	}
-	opt := &imports.Options{Comments: true, TabIndent: true, TabWidth: 8}
-	code, err = imports.Process("", code, opt)
-	if err != nil {
-		panic(fmt.Errorf("BUG: can't gofmt generated code: %v", err))
+	if t.FuzzCorpus != "" {
+		corpus, err := types.FuzzCorpus(t.Fixtures)
+		if err != nil {
+			return err
+		}
+		names := make([]string, 0, len(corpus))
+		for name := range corpus {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if err := out.WriteFile(filepath.Join(t.FuzzCorpus, filepath.FromSlash(name)), corpus[name]); err != nil {
+				return err
+			}
+		}
 	}
-	return code, nil
+	return out.WriteFile(t.Out, code)
 }
 
-func loadPackage(cfg *Config) (*types.Package, error) {
-	pcfg := &packages.Config{
-		Mode:  packages.NeedTypes | packages.NeedDeps | packages.NeedImports,
-		Tests: true,
-		Dir:   cfg.Dir,
+// writeMainPackage writes the file of a generated main package, which must be in
+// a directory of its own.
+func writeMainPackage(t *gencodec.Target, out outputWriter, file string, gen func() ([]byte, error)) error {
+	dir, _ := filepath.Abs(filepath.Dir(file))
+	if pkgdir, _ := filepath.Abs(t.Dir); dir == pkgdir {
+		return fmt.Errorf("main package file %s must not be in the directory of the types", file)
 	}
-	ps, err := packages.Load(pcfg, ".")
+	code, err := gen()
 	if err != nil {
-		return nil, err
-	}
-	if len(ps) == 0 {
-		return nil, fmt.Errorf("can't find go package in %s", cfg.Dir)
+		return err
 	}
-	return ps[0].Types, nil
+	return out.WriteFile(file, code)
 }
 
-func generate(mtyp *marshalerType, cfg *Config) ([]byte, error) {
-	w := new(bytes.Buffer)
-	fmt.Fprint(w, "// Code generated by github.com/fjl/gencodec. DO NOT EDIT.\n\n")
-	fmt.Fprintln(w, "package", mtyp.orig.Obj().Pkg().Name())
-	fmt.Fprintln(w)
-	mtyp.scope.writeImportDecl(w)
-	fmt.Fprintln(w)
-	if mtyp.override != nil {
-		writeUseOfOverride(w, mtyp.override, mtyp.scope.qualify)
-	}
-	for _, format := range cfg.Formats {
-		var genMarshal, genUnmarshal gogen.Function
-		switch format {
-		case "json":
-			genMarshal = genMarshalJSON(mtyp)
-			genUnmarshal = genUnmarshalJSON(mtyp)
-		case "yaml":
-			genMarshal = genMarshalYAML(mtyp)
-			genUnmarshal = genUnmarshalYAML(mtyp)
-		case "toml":
-			genMarshal = genMarshalTOML(mtyp)
-			genUnmarshal = genUnmarshalTOML(mtyp)
-		default:
-			return nil, fmt.Errorf("unknown format: %q", format)
-		}
-		fmt.Fprintf(w, "// %s marshals as %s.", genMarshal.Name, strings.ToUpper(format))
-		fmt.Fprintln(w)
-		writeFunction(w, mtyp.fs, genMarshal)
-		fmt.Fprintln(w)
-		fmt.Fprintf(w, "// %s unmarshals from %s.", genUnmarshal.Name, strings.ToUpper(format))
-		fmt.Fprintln(w)
-		writeFunction(w, mtyp.fs, genUnmarshal)
-		fmt.Fprintln(w)
-	}
-	return w.Bytes(), nil
+// outputWriter writes the generated files.
+type outputWriter interface {
+	WriteFile(name string, content []byte) error
 }
 
-func writeUseOfOverride(w io.Writer, n *types.Named, qf types.Qualifier) {
-	name := types.TypeString(types.NewPointer(n), qf)
-	fmt.Fprintf(w, "var _ = (%s)(nil)\n", name)
+// fileWriter writes files to disk using gencodec.WriteFile. The file name "-"
+// stands for stdout.
+type fileWriter struct {
+	protect bool // don't overwrite files which were not generated
 }
 
-// marshalerType represents the intermediate struct type used during marshaling.
-// This is the input data to all the Go code templates.
-type marshalerType struct {
-	name     string
-	Fields   []*marshalerField
-	fs       *token.FileSet
-	orig     *types.Named
-	override *types.Named
-	scope    *fileScope
-}
-
-// marshalerField represents a field of the intermediate marshaling type.
-type marshalerField struct {
-	name     string
-	typ      types.Type
-	origTyp  types.Type
-	tag      string
-	function *types.Func // map to a function instead of a field
-}
-
-func newMarshalerType(fs *token.FileSet, imp types.Importer, typ *types.Named) *marshalerType {
-	mtyp := &marshalerType{name: typ.Obj().Name(), fs: fs, orig: typ}
-	styp := typ.Underlying().(*types.Struct)
-	mtyp.scope = newFileScope(imp, typ.Obj().Pkg())
-	mtyp.scope.addReferences(styp)
-
-	// Add packages which are always needed.
-	mtyp.scope.addImport("encoding/json")
-	mtyp.scope.addImport("errors")
-
-	for i := 0; i < styp.NumFields(); i++ {
-		f := styp.Field(i)
-		if !f.Exported() {
-			continue
-		}
-		if f.Anonymous() {
-			fmt.Fprintf(os.Stderr, "Warning: ignoring embedded field %s\n", f.Name())
-			continue
-		}
-
-		mf := &marshalerField{
-			name:    f.Name(),
-			typ:     f.Type(),
-			origTyp: f.Type(),
-			tag:     styp.Tag(i),
-		}
-
-		mtyp.Fields = append(mtyp.Fields, mf)
+func (w fileWriter) WriteFile(name string, content []byte) error {
+	if name == "-" {
+		_, err := os.Stdout.Write(content)
+		return err
 	}
-
-	return mtyp
+	return gencodec.WriteFile(name, content, w.protect)
 }
 
-// findFunction returns a function with `name` that accepts no arguments
-// and returns a single value that is convertible to the given to type.
-func findFunction(typ *types.Named, name string, to types.Type) (*types.Func, types.Type) {
-	for i := 0; i < typ.NumMethods(); i++ {
-		fun := typ.Method(i)
-		if fun.Name() != name || !fun.Exported() {
-			continue
-		}
-		sign := fun.Type().(*types.Signature)
-		if sign.Params().Len() != 0 || sign.Results().Len() != 1 {
-			continue
-		}
-		if err := checkConvertible(sign.Results().At(0).Type(), to); err == nil {
-			return fun, sign.Results().At(0).Type()
-		}
-	}
-	return nil, nil
+// archiveWriter writes files to w in txtar format, as they are generated. Each
+// file starts with a line holding its name between "-- " and " --".
+type archiveWriter struct {
+	w io.Writer
 }
 
-// loadOverrides sets field types of the intermediate marshaling type from
-// matching fields of otyp.
-func (mtyp *marshalerType) loadOverrides(otyp *types.Named) error {
-	s := otyp.Underlying().(*types.Struct)
-	for i := 0; i < s.NumFields(); i++ {
-		of := s.Field(i)
-		if of.Anonymous() || !of.Exported() {
-			return fmt.Errorf("%v: field override type cannot have embedded or unexported fields", mtyp.fs.Position(of.Pos()))
-		}
-		f := mtyp.fieldByName(of.Name())
-		if f == nil {
-			// field not defined in original type, check if it maps to a suitable function and add it as an override
-			if fun, retType := findFunction(mtyp.orig, of.Name(), of.Type()); fun != nil {
-				f = &marshalerField{name: fun.Name(), origTyp: retType, typ: of.Type(), function: fun, tag: s.Tag(i)}
-				mtyp.Fields = append(mtyp.Fields, f)
-			} else {
-				return fmt.Errorf("%v: no matching field or function for %s in original type %s", mtyp.fs.Position(of.Pos()), of.Name(), mtyp.name)
-			}
-		}
-		if err := checkConvertible(of.Type(), f.origTyp); err != nil {
-			return fmt.Errorf("%v: invalid field override: %v", mtyp.fs.Position(of.Pos()), err)
-		}
-		f.typ = of.Type()
+func (a archiveWriter) WriteFile(name string, content []byte) error {
+	if name == "-" {
+		return errors.New("-stdout-archive needs the name of the output file in -out")
 	}
-	mtyp.scope.addReferences(s)
-	mtyp.override = otyp
-	return nil
+	file := txtar.File{Name: filepath.ToSlash(name), Data: content}
+	_, err := a.w.Write(txtar.Format(&txtar.Archive{Files: []txtar.File{file}}))
+	return err
 }
 
-func (mtyp *marshalerType) fieldByName(name string) *marshalerField {
-	for _, f := range mtyp.Fields {
-		if f.name == name {
-			return f
-		}
-	}
-	return nil
+// warn prints a warning of the generator.
+func warn(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, "Warning: "+format+"\n", args...)
 }
 
-// isRequired returns whether the field is required when decoding the given format.
-func (mf *marshalerField) isRequired(format string) bool {
-	rtag := reflect.StructTag(mf.tag)
-	req := rtag.Get("gencodec") == "required"
-	// Fields with json:"-" must be treated as optional. This also works
-	// for the other supported formats.
-	return req && !strings.HasPrefix(rtag.Get(format), "-")
+func fatal(args ...interface{}) {
+	fmt.Fprintln(os.Stderr, args...)
+	os.Exit(1)
 }
 
-// encodedName returns the alternative field name assigned by the format's struct tag.
-func (mf *marshalerField) encodedName(format string) string {
-	val := reflect.StructTag(mf.tag).Get(format)
-	if comma := strings.Index(val, ","); comma != -1 {
-		val = val[:comma]
+// splitList splits a comma-separated flag value.
+func splitList(s string) []string {
+	if s == "" {
+		return nil
 	}
-	if val == "" || val == "-" {
-		return uncapitalize(mf.name)
+	list := strings.Split(s, ",")
+	for i := range list {
+		list[i] = strings.TrimSpace(list[i])
 	}
-	return val
-}
-
-func uncapitalize(s string) string {
-	return strings.ToLower(s[:1]) + s[1:]
+	return list
 }
@@ -0,0 +1,35 @@
+// Copyright 2017 Felix Lange <fjl@twurst.com>.
+// Use of this source code is governed by the MIT license,
+// which can be found in the LICENSE file.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/fjl/gencodec/gencodec"
+)
+
+// runVet implements the vet subcommand, which checks the struct tags
+// of all struct types in a package.
+func runVet(args []string) int {
+	var (
+		fs     = flag.NewFlagSet("vet", flag.ExitOnError)
+		pkgdir = fs.String("dir", ".", "input package")
+	)
+	fs.Parse(args)
+
+	problems, err := gencodec.Vet(*pkgdir)
+	if err != nil {
+		fatal(err)
+	}
+	for _, p := range problems {
+		fmt.Fprintln(os.Stderr, p)
+	}
+	if len(problems) > 0 {
+		return 1
+	}
+	return 0
+}